@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/nlpodyssey/openai-agents-go/modelsettings"
 	"github.com/nlpodyssey/openai-agents-go/util/transforms"
@@ -46,8 +47,38 @@ type MCPConfig struct {
 	// This is a best-effort conversion, so some schemas may not be convertible.
 	// Defaults to false.
 	ConvertSchemasToStrict bool
+
+	// If true, MCP servers based on MCPServerWithClientSession cache their
+	// tools list when listing tools for this agent, as if they were created
+	// with CacheToolsList. The cache belongs to the server, so it is shared
+	// by all agents using it, while tool filters are still applied for each
+	// agent. Defaults to false.
+	CacheToolsList bool
+
+	// Optional time after which a cached tools list is fetched again from
+	// the server. The cache can always be invalidated explicitly by calling
+	// `InvalidateToolsCache()` on the server.
+	// Default (when zero or negative): no expiration.
+	CacheTTL time.Duration
+
+	// Optional maximum number of attempts to reconnect to MCP servers based
+	// on MCPServerWithClientSession, when a call made for this agent fails
+	// because the connection was lost, e.g. after a server restart. After
+	// reconnecting, the failed call is retried once. If all attempts fail, an
+	// MCPConnectionError is returned.
+	// Default (when zero or negative): no reconnection.
+	MaxReconnectAttempts int
 }
 
+// FinalOutputExtractor extracts the final output from the text of the last
+// assistant message. It returns false if the text contains no final output.
+type FinalOutputExtractor = func(text string) (string, bool)
+
+// ToolCallOrdering reorders the function tool calls parsed from a model
+// response, before they are executed. It must return the very same calls,
+// without dropping or duplicating any of them.
+type ToolCallOrdering = func(calls []ToolRunFunction) []ToolRunFunction
+
 // An Agent is an AI model configured with instructions, tools, guardrails, handoffs and more.
 //
 // We strongly recommend passing `Instructions`, which is the "system prompt" for the agent. In
@@ -103,6 +134,12 @@ type Agent struct {
 	// Optional configuration for MCP servers.
 	MCPConfig MCPConfig
 
+	// Optional policy deciding whether hosted MCP tool calls requiring approval
+	// are approved, when the HostedMCPTool has no OnApprovalRequest function.
+	// If not provided, the run is suspended instead, listing the approval
+	// requests in RunResult.Interruptions: see Runner.Resume.
+	MCPApprovalPolicy MCPApprovalPolicy
+
 	// A list of checks that run in parallel to the agent's execution, before generating a
 	// response. Runs only if the agent is the first agent in the chain.
 	InputGuardrails []InputGuardrail
@@ -114,6 +151,12 @@ type Agent struct {
 	// Optional output type describing the output. If not provided, the output will be a simple string.
 	OutputType OutputTypeInterface
 
+	// Optional function extracting the final output from the text of the last
+	// assistant message, e.g. the text following a "Final Answer:" marker.
+	// When it returns false, the full text is used as the final output.
+	// It only applies to plain text outputs.
+	FinalOutputExtractor FinalOutputExtractor
+
 	// Optional object that receives callbacks on various lifecycle events for this agent.
 	Hooks AgentHooks
 
@@ -134,10 +177,35 @@ type Agent struct {
 	// web search, etc. are always processed by the LLM.
 	ToolUseBehavior ToolUseBehavior
 
-	// Whether to reset the tool choice to the default value after a tool has been called.
-	// Defaults to true.
-	// This ensures that the agent doesn't enter an infinite loop of tool usage.
+	// Optional function normalizing the order of the function tool calls
+	// of each model response, e.g. to always handle an authorization check
+	// before the other tools, without relying on the model. When set, function
+	// tools are run one at a time in the returned order, instead of
+	// concurrently; their outputs are sent back to the model, and seen by
+	// ToolUseBehavior, in the same order.
+	// Returning calls which do not match the original ones is an error.
+	ToolCallOrdering ToolCallOrdering
+
+	// Whether to reset the tool choice to the agent's own ModelSettings.ToolChoice
+	// after a tool has been called. Defaults to true.
+	// This ensures that the agent doesn't enter an infinite loop of tool usage
+	// when a tool choice is forced from outside the agent, e.g. with
+	// RunConfig.ModelSettings. A tool choice configured by the agent itself,
+	// such as "required", is kept.
 	ResetToolChoice param.Opt[bool]
+
+	// Whether to send all the function tools (including MCP tools) to the
+	// model with a non-strict JSON schema, regardless of their own
+	// StrictJSONSchema setting, e.g. for models which don't support strict
+	// mode. It is the opposite of MCPConfig.ConvertSchemasToStrict.
+	// See also RunConfig.ForceNonStrictTools.
+	ForceNonStrictTools bool
+
+	// Whether to collapse identical function tool calls (same name and same
+	// arguments) produced by the model in the same turn into a single tool
+	// execution, to avoid duplicate side effects. Each of the original calls
+	// still gets its own output item, carrying the shared result.
+	DedupeToolCalls bool
 }
 
 type AgentAsToolParams struct {
@@ -154,6 +222,45 @@ type AgentAsToolParams struct {
 	// Optional static or dynamic flag reporting whether the tool is enabled.
 	// If omitted, the tool is enabled by default.
 	IsEnabled FunctionToolEnabler
+
+	// Optional maximum nesting depth of agents running as tools.
+	// The run of this agent as a tool is refused with a MaxAgentDepthError
+	// when it would be nested deeper than this value, counting from the
+	// outermost run (whose depth is 0).
+	// Default (when left zero): DefaultMaxAgentToolDepth.
+	MaxDepth uint64
+}
+
+// DefaultMaxAgentToolDepth is the default maximum nesting depth of agents
+// running as tools. See AgentAsToolParams.MaxDepth.
+const DefaultMaxAgentToolDepth = 10
+
+type agentToolDepthKey struct{}
+
+// ContextWithAgentToolDepth returns a copy of ctx carrying the given nesting
+// depth of agents running as tools.
+func ContextWithAgentToolDepth(ctx context.Context, depth uint64) context.Context {
+	return context.WithValue(ctx, agentToolDepthKey{}, depth)
+}
+
+// AgentToolDepthFromContext returns the nesting depth of agents running as
+// tools carried by ctx, or 0 if ctx is not inside an agent tool run.
+func AgentToolDepthFromContext(ctx context.Context) uint64 {
+	depth, _ := ctx.Value(agentToolDepthKey{}).(uint64)
+	return depth
+}
+
+type streamEventEmitterKey struct{}
+
+// contextWithStreamEventEmitter returns a copy of ctx carrying the function
+// which puts events in the stream of the current streamed run.
+func contextWithStreamEventEmitter(ctx context.Context, emit func(StreamEvent)) context.Context {
+	return context.WithValue(ctx, streamEventEmitterKey{}, emit)
+}
+
+func streamEventEmitterFromContext(ctx context.Context) func(StreamEvent) {
+	emit, _ := ctx.Value(streamEventEmitterKey{}).(func(StreamEvent))
+	return emit
 }
 
 // AsTool transforms this agent into a tool, callable by other agents.
@@ -164,6 +271,22 @@ type AgentAsToolParams struct {
 //  2. In handoffs, the new agent takes over the conversation. In this tool, the new agent is
 //     called as a tool, and the conversation is continued by the original agent.
 func (a *Agent) AsTool(params AgentAsToolParams) Tool {
+	return a.asTool(params, false)
+}
+
+// AsToolStreamed is like AsTool, but when the calling agent is run in
+// streaming mode (see Runner.RunStreamed), this agent is run in streaming
+// mode too, and its run item events are forwarded to the outer stream as
+// NestedAgentStreamEvent values. When the calling agent is not streamed, it
+// behaves exactly like AsTool.
+//
+// As with AsTool, the usage of the nested run is added to the usage of the
+// outer run.
+func (a *Agent) AsToolStreamed(params AgentAsToolParams) Tool {
+	return a.asTool(params, true)
+}
+
+func (a *Agent) asTool(params AgentAsToolParams, streamed bool) Tool {
 	name := params.ToolName
 	if name == "" {
 		name = transforms.TransformStringFunctionStyle(a.Name)
@@ -173,8 +296,25 @@ func (a *Agent) AsTool(params AgentAsToolParams) Tool {
 		Input string `json:"input"`
 	}
 
+	maxDepth := params.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultMaxAgentToolDepth
+	}
+
 	runAgent := func(ctx context.Context, args argsType) (string, error) {
-		output, err := DefaultRunner.Run(ctx, a, args.Input)
+		depth := AgentToolDepthFromContext(ctx) + 1
+		if depth > maxDepth {
+			return "", MaxAgentDepthErrorf("max agent tool depth (%d) exceeded running agent %s", maxDepth, a.Name)
+		}
+		ctx = ContextWithAgentToolDepth(ctx, depth)
+
+		var output *RunResult
+		var err error
+		if emit := streamEventEmitterFromContext(ctx); streamed && emit != nil {
+			output, err = a.runStreamedAsTool(ctx, name, args.Input, emit)
+		} else {
+			output, err = DefaultRunner.Run(ctx, a, args.Input)
+		}
 		if err != nil {
 			return "", fmt.Errorf("failed to run agent %s as tool: %w", a.Name, err)
 		}
@@ -187,9 +327,67 @@ func (a *Agent) AsTool(params AgentAsToolParams) Tool {
 
 	tool := NewFunctionTool(name, params.ToolDescription, runAgent)
 	tool.IsEnabled = params.IsEnabled
+
+	// Exceeding the max depth must stop the whole run, rather than being
+	// reported back to the LLM, which could just try again.
+	errorFn := ToolErrorFunction(func(ctx context.Context, err error) (any, error) {
+		if maxDepthErr := (MaxAgentDepthError{}); errors.As(err, &maxDepthErr) {
+			return nil, err
+		}
+		return DefaultToolErrorFunction(ctx, err)
+	})
+	tool.FailureErrorFunction = &errorFn
+
 	return tool
 }
 
+// runStreamedAsTool runs the agent in streaming mode, forwarding its events
+// to the outer stream through emit.
+func (a *Agent) runStreamedAsTool(
+	ctx context.Context,
+	toolName string,
+	input string,
+	emit func(StreamEvent),
+) (*RunResult, error) {
+	var toolCallID string
+	if toolData := ToolDataFromContext(ctx); toolData != nil {
+		toolCallID = toolData.ToolCallID
+	}
+
+	result, err := DefaultRunner.RunStreamed(ctx, a, input)
+	if err != nil {
+		return nil, err
+	}
+	err = result.StreamEvents(func(event StreamEvent) error {
+		switch event.(type) {
+		case RunItemStreamEvent, NestedAgentStreamEvent:
+			emit(NestedAgentStreamEvent{
+				ToolName:   toolName,
+				ToolCallID: toolCallID,
+				AgentName:  result.CurrentAgent().Name,
+				Event:      event,
+				Type:       "nested_agent_stream_event",
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunResult{
+		Input:                  result.Input(),
+		NewItems:               result.NewItems(),
+		RawResponses:           result.RawResponses(),
+		ShadowResponses:        result.ShadowResponses(),
+		FinalOutput:            result.FinalOutput(),
+		StoppedEarly:           result.StoppedEarly(),
+		InputGuardrailResults:  result.InputGuardrailResults(),
+		OutputGuardrailResults: result.OutputGuardrailResults(),
+		LastAgent:              result.LastAgent(),
+	}, nil
+}
+
 // GetSystemPrompt returns the system prompt for the agent.
 func (a *Agent) GetSystemPrompt(ctx context.Context) (param.Opt[string], error) {
 	if a.Instructions == nil {
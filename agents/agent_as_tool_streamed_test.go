@@ -0,0 +1,114 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentAsToolStreamedForwardsEvents(t *testing.T) {
+	innerModel := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("inner done")},
+	})
+	innerModel.SetHardcodedUsage(usage.Usage{Requests: 1, InputTokens: 7})
+	innerAgent := agents.New("inner").WithModelInstance(innerModel)
+
+	outerModel := agentstesting.NewFakeModel(false, nil)
+	outerModel.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("inner_tool", `{"input": "hi"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("outer done")}},
+	})
+	outerAgent := agents.New("outer").
+		WithModelInstance(outerModel).
+		WithTools(innerAgent.AsToolStreamed(agents.AgentAsToolParams{ToolName: "inner_tool"}))
+
+	u := usage.NewUsage()
+	ctx := usage.NewContext(t.Context(), u)
+
+	result, err := agents.RunStreamed(ctx, outerAgent, "hello")
+	require.NoError(t, err)
+
+	var events []agents.StreamEvent
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "outer done", result.FinalOutput())
+
+	var nested []agents.NestedAgentStreamEvent
+	nestedIndex, toolOutputIndex := -1, -1
+	for i, event := range events {
+		switch e := event.(type) {
+		case agents.NestedAgentStreamEvent:
+			nested = append(nested, e)
+			nestedIndex = i
+		case agents.RunItemStreamEvent:
+			if e.Name == agents.StreamEventToolOutput {
+				toolOutputIndex = i
+			}
+		}
+	}
+
+	require.Len(t, nested, 1)
+	assert.Equal(t, "inner_tool", nested[0].ToolName)
+	assert.Equal(t, "2", nested[0].ToolCallID)
+	assert.Equal(t, "inner", nested[0].AgentName)
+	assert.Equal(t, "nested_agent_stream_event", nested[0].Type)
+
+	innerEvent, ok := nested[0].Event.(agents.RunItemStreamEvent)
+	require.True(t, ok)
+	assert.Equal(t, agents.StreamEventMessageOutputCreated, innerEvent.Name)
+
+	// The nested events precede the output of the tool running the agent.
+	assert.Less(t, nestedIndex, toolOutputIndex)
+
+	// The usage of the inner agent is added to the shared tracker.
+	assert.Equal(t, uint64(7), u.InputTokens)
+}
+
+func TestAgentAsToolStreamedNotStreamed(t *testing.T) {
+	innerModel := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("inner done")},
+	})
+	innerAgent := agents.New("inner").WithModelInstance(innerModel)
+
+	outerModel := agentstesting.NewFakeModel(false, nil)
+	outerModel.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("inner_tool", `{"input": "hi"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("outer done")}},
+	})
+	outerAgent := agents.New("outer").
+		WithModelInstance(outerModel).
+		WithTools(innerAgent.AsToolStreamed(agents.AgentAsToolParams{ToolName: "inner_tool"}))
+
+	result, err := agents.Run(t.Context(), outerAgent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "outer done", result.FinalOutput)
+
+	invocations := result.ToolInvocations()
+	require.Len(t, invocations, 1)
+	assert.Equal(t, "inner done", invocations[0].Output)
+}
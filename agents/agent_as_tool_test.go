@@ -0,0 +1,98 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func agentToolCallTurn(toolName string) agentstesting.FakeModelTurnOutput {
+	return agentstesting.FakeModelTurnOutput{Value: []agents.TResponseOutputItem{
+		agentstesting.GetFunctionToolCall(toolName, `{"input": "go deeper"}`),
+	}}
+}
+
+func TestAgentAsToolNestingWithinMaxDepth(t *testing.T) {
+	innerModel := agentstesting.NewFakeModel(false, nil)
+	innerModel.SetNextOutput(agentstesting.FakeModelTurnOutput{Value: []agents.TResponseOutputItem{
+		agentstesting.GetTextMessage("inner_done"),
+	}})
+	inner := agents.New("inner").WithModelInstance(innerModel)
+
+	middleModel := agentstesting.NewFakeModel(false, nil)
+	middleModel.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		agentToolCallTurn("inner"),
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("middle_done")}},
+	})
+	middle := agents.New("middle").WithModelInstance(middleModel).
+		WithTools(inner.AsTool(agents.AgentAsToolParams{MaxDepth: 2}))
+
+	outerModel := agentstesting.NewFakeModel(false, nil)
+	outerModel.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		agentToolCallTurn("middle"),
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("outer_done")}},
+	})
+	outer := agents.New("outer").WithModelInstance(outerModel).
+		WithTools(middle.AsTool(agents.AgentAsToolParams{MaxDepth: 2}))
+
+	result, err := agents.Runner{}.Run(t.Context(), outer, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "outer_done", result.FinalOutput)
+}
+
+func TestAgentAsToolMaxDepthExceeded(t *testing.T) {
+	// A self-recursive agent: every run calls itself again as a tool.
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		agentToolCallTurn("recursive"),
+		agentToolCallTurn("recursive"),
+		agentToolCallTurn("recursive"),
+		agentToolCallTurn("recursive"),
+	})
+	agent := agents.New("recursive").WithModelInstance(model)
+	agent.WithTools(agent.AsTool(agents.AgentAsToolParams{MaxDepth: 2}))
+
+	_, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+	var maxDepthErr agents.MaxAgentDepthError
+	require.ErrorAs(t, err, &maxDepthErr)
+	assert.ErrorContains(t, err, "max agent tool depth (2) exceeded running agent recursive")
+}
+
+func TestAgentAsToolDefaultMaxDepth(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	outputs := make([]agentstesting.FakeModelTurnOutput, agents.DefaultMaxAgentToolDepth+1)
+	for i := range outputs {
+		outputs[i] = agentToolCallTurn("recursive")
+	}
+	model.AddMultipleTurnOutputs(outputs)
+	agent := agents.New("recursive").WithModelInstance(model)
+	agent.WithTools(agent.AsTool(agents.AgentAsToolParams{}))
+
+	_, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+	var maxDepthErr agents.MaxAgentDepthError
+	require.ErrorAs(t, err, &maxDepthErr)
+}
+
+func TestAgentToolDepthFromContext(t *testing.T) {
+	ctx := t.Context()
+	assert.Equal(t, uint64(0), agents.AgentToolDepthFromContext(ctx))
+	ctx = agents.ContextWithAgentToolDepth(ctx, 3)
+	assert.Equal(t, uint64(3), agents.AgentToolDepthFromContext(ctx))
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/packages/param"
+)
+
+// Names of the ToolUseBehavior values which can be part of an AgentDefinition.
+const (
+	ToolUseBehaviorRunLLMAgain     = "run_llm_again"
+	ToolUseBehaviorStopOnFirstTool = "stop_on_first_tool"
+	ToolUseBehaviorStopAtTools     = "stop_at_tools"
+)
+
+// AgentDefinition is the data-only definition of an Agent, which can be
+// persisted as JSON (see Agent.ExportJSON) and turned back into an Agent
+// with LoadAgent.
+//
+// Tools, handoffs, guardrails and output type hold live values, so they are
+// referenced by name, and resolved through an AgentRegistry.
+type AgentDefinition struct {
+	Name               string                      `json:"name"`
+	Instructions       string                      `json:"instructions,omitempty"`
+	HandoffDescription string                      `json:"handoff_description,omitempty"`
+	Model              string                      `json:"model,omitempty"`
+	ModelSettings      modelsettings.ModelSettings `json:"model_settings,omitzero"`
+	Tools              []string                    `json:"tools,omitempty"`
+	Handoffs           []string                    `json:"handoffs,omitempty"`
+	InputGuardrails    []string                    `json:"input_guardrails,omitempty"`
+	OutputGuardrails   []string                    `json:"output_guardrails,omitempty"`
+	OutputType         string                      `json:"output_type,omitempty"`
+	// One of the ToolUseBehavior* constants, or empty for the default behavior.
+	ToolUseBehavior string `json:"tool_use_behavior,omitempty"`
+	// The tool names for ToolUseBehaviorStopAtTools.
+	StopAtTools         []string        `json:"stop_at_tools,omitempty"`
+	ResetToolChoice     param.Opt[bool] `json:"reset_tool_choice,omitzero"`
+	ForceNonStrictTools bool            `json:"force_non_strict_tools,omitempty"`
+	DedupeToolCalls     bool            `json:"dedupe_tool_calls,omitempty"`
+}
+
+// AgentRegistry resolves the names referenced by an AgentDefinition.
+type AgentRegistry struct {
+	// Tools by Tool.ToolName.
+	Tools map[string]Tool
+
+	// Agents which can be used as handoffs (see Agent.AgentHandoffs), by Agent.Name.
+	Agents map[string]*Agent
+
+	// Handoff objects (see Agent.Handoffs), by Handoff.AgentName.
+	// They take precedence over Agents with the same name.
+	Handoffs map[string]Handoff
+
+	// Input guardrails by InputGuardrail.Name.
+	InputGuardrails map[string]InputGuardrail
+
+	// Output guardrails by OutputGuardrail.Name.
+	OutputGuardrails map[string]OutputGuardrail
+
+	// Output types by OutputTypeInterface.Name.
+	OutputTypes map[string]OutputTypeInterface
+}
+
+// Definition returns the data-only definition of the agent.
+//
+// An error is returned if the agent has settings which can't be represented
+// as data: dynamic instructions, a prompt, a Model instance (rather than a
+// model name), MCP servers, an MCP approval policy, a final output extractor,
+// hooks, a custom ToolUseBehavior, or request customization functions in the
+// model settings.
+func (a *Agent) Definition() (AgentDefinition, error) {
+	def := AgentDefinition{
+		Name:                a.Name,
+		HandoffDescription:  a.HandoffDescription,
+		ModelSettings:       a.ModelSettings,
+		ResetToolChoice:     a.ResetToolChoice,
+		ForceNonStrictTools: a.ForceNonStrictTools,
+		DedupeToolCalls:     a.DedupeToolCalls,
+	}
+
+	switch instructions := a.Instructions.(type) {
+	case nil:
+	case InstructionsStr:
+		def.Instructions = instructions.String()
+	default:
+		return AgentDefinition{}, UserErrorf("agent %q: dynamic instructions can't be serialized", a.Name)
+	}
+
+	if a.Model.Valid() {
+		modelName, ok := a.Model.Value.SafeModelName()
+		if !ok {
+			return AgentDefinition{}, UserErrorf("agent %q: a Model instance can't be serialized, use a model name instead", a.Name)
+		}
+		def.Model = modelName
+	}
+
+	switch {
+	case a.Prompt != nil:
+		return AgentDefinition{}, UserErrorf("agent %q: prompts can't be serialized", a.Name)
+	case len(a.MCPServers) > 0:
+		return AgentDefinition{}, UserErrorf("agent %q: MCP servers can't be serialized", a.Name)
+	case a.MCPApprovalPolicy != nil:
+		return AgentDefinition{}, UserErrorf("agent %q: MCP approval policies can't be serialized", a.Name)
+	case a.FinalOutputExtractor != nil:
+		return AgentDefinition{}, UserErrorf("agent %q: final output extractors can't be serialized", a.Name)
+	case a.ToolCallOrdering != nil:
+		return AgentDefinition{}, UserErrorf("agent %q: tool call orderings can't be serialized", a.Name)
+	case a.Hooks != nil:
+		return AgentDefinition{}, UserErrorf("agent %q: hooks can't be serialized", a.Name)
+	case a.ModelSettings.CustomizeResponsesRequest != nil || a.ModelSettings.CustomizeChatCompletionsRequest != nil:
+		return AgentDefinition{}, UserErrorf("agent %q: model settings request customization functions can't be serialized", a.Name)
+	}
+
+	for _, tool := range a.Tools {
+		def.Tools = append(def.Tools, tool.ToolName())
+	}
+	for _, handoff := range a.Handoffs {
+		def.Handoffs = append(def.Handoffs, handoff.AgentName)
+	}
+	for _, agent := range a.AgentHandoffs {
+		def.Handoffs = append(def.Handoffs, agent.Name)
+	}
+	for _, guardrail := range a.InputGuardrails {
+		def.InputGuardrails = append(def.InputGuardrails, guardrail.Name)
+	}
+	for _, guardrail := range a.OutputGuardrails {
+		def.OutputGuardrails = append(def.OutputGuardrails, guardrail.Name)
+	}
+	if a.OutputType != nil {
+		def.OutputType = a.OutputType.Name()
+	}
+
+	switch behavior := a.ToolUseBehavior.(type) {
+	case nil:
+	case runLLMAgain:
+		def.ToolUseBehavior = ToolUseBehaviorRunLLMAgain
+	case stopOnFirstTool:
+		def.ToolUseBehavior = ToolUseBehaviorStopOnFirstTool
+	case stopAtTools:
+		def.ToolUseBehavior = ToolUseBehaviorStopAtTools
+		def.StopAtTools = behavior.names
+	default:
+		return AgentDefinition{}, UserErrorf("agent %q: custom tool use behaviors can't be serialized", a.Name)
+	}
+
+	return def, nil
+}
+
+// ExportJSON returns the JSON encoding of the agent Definition.
+func (a *Agent) ExportJSON() ([]byte, error) {
+	def, err := a.Definition()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(def)
+}
+
+// LoadAgent reconstructs an Agent from the JSON encoding of its
+// AgentDefinition (see Agent.ExportJSON), resolving the names it references
+// through the registry. An error is returned for any unknown name.
+func LoadAgent(data []byte, registry AgentRegistry) (*Agent, error) {
+	var def AgentDefinition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent definition: %w", err)
+	}
+	return NewAgentFromDefinition(def, registry)
+}
+
+// NewAgentFromDefinition creates a new Agent from its definition, resolving
+// the names it references through the registry. An error is returned for
+// any unknown name.
+func NewAgentFromDefinition(def AgentDefinition, registry AgentRegistry) (*Agent, error) {
+	agent := New(def.Name).
+		WithHandoffDescription(def.HandoffDescription).
+		WithModelSettings(def.ModelSettings)
+	agent.ResetToolChoice = def.ResetToolChoice
+	agent.ForceNonStrictTools = def.ForceNonStrictTools
+	agent.DedupeToolCalls = def.DedupeToolCalls
+
+	if def.Instructions != "" {
+		agent.Instructions = InstructionsStr(def.Instructions)
+	}
+	if def.Model != "" {
+		agent.Model = param.NewOpt(NewAgentModelName(def.Model))
+	}
+
+	for _, name := range def.Tools {
+		tool, ok := registry.Tools[name]
+		if !ok {
+			return nil, UserErrorf("agent %q: unknown tool %q", def.Name, name)
+		}
+		agent.Tools = append(agent.Tools, tool)
+	}
+	for _, name := range def.Handoffs {
+		if handoff, ok := registry.Handoffs[name]; ok {
+			agent.Handoffs = append(agent.Handoffs, handoff)
+		} else if handoffAgent, ok := registry.Agents[name]; ok {
+			agent.AgentHandoffs = append(agent.AgentHandoffs, handoffAgent)
+		} else {
+			return nil, UserErrorf("agent %q: unknown handoff %q", def.Name, name)
+		}
+	}
+	for _, name := range def.InputGuardrails {
+		guardrail, ok := registry.InputGuardrails[name]
+		if !ok {
+			return nil, UserErrorf("agent %q: unknown input guardrail %q", def.Name, name)
+		}
+		agent.InputGuardrails = append(agent.InputGuardrails, guardrail)
+	}
+	for _, name := range def.OutputGuardrails {
+		guardrail, ok := registry.OutputGuardrails[name]
+		if !ok {
+			return nil, UserErrorf("agent %q: unknown output guardrail %q", def.Name, name)
+		}
+		agent.OutputGuardrails = append(agent.OutputGuardrails, guardrail)
+	}
+	if def.OutputType != "" {
+		outputType, ok := registry.OutputTypes[def.OutputType]
+		if !ok {
+			return nil, UserErrorf("agent %q: unknown output type %q", def.Name, def.OutputType)
+		}
+		agent.OutputType = outputType
+	}
+
+	switch def.ToolUseBehavior {
+	case "":
+	case ToolUseBehaviorRunLLMAgain:
+		agent.ToolUseBehavior = RunLLMAgain()
+	case ToolUseBehaviorStopOnFirstTool:
+		agent.ToolUseBehavior = StopOnFirstTool()
+	case ToolUseBehaviorStopAtTools:
+		agent.ToolUseBehavior = StopAtTools(def.StopAtTools...)
+	default:
+		return nil, UserErrorf("agent %q: unknown tool use behavior %q", def.Name, def.ToolUseBehavior)
+	}
+
+	return agent, nil
+}
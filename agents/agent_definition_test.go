@@ -0,0 +1,165 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type definitionOutput struct {
+	Answer string `json:"answer"`
+}
+
+func TestAgentDefinitionRoundTrip(t *testing.T) {
+	fooTool := agentstesting.GetFunctionTool("foo", "foo_result")
+	barTool := agentstesting.GetFunctionTool("bar", "bar_result")
+	billing := agents.New("billing")
+	refunds := agents.HandoffFromAgent(agents.HandoffFromAgentParams{
+		Agent:                   agents.New("refunds"),
+		ToolDescriptionOverride: "Handles refunds",
+	})
+	inputGuardrail := agents.InputGuardrail{Name: "input_check"}
+	outputGuardrail := agents.OutputGuardrail{Name: "output_check"}
+	outputType := agents.OutputType[definitionOutput]()
+
+	agent := agents.New("triage").
+		WithInstructions("Route the request.").
+		WithHandoffDescription("Triages requests").
+		WithModel("gpt-4.1").
+		WithModelSettings(modelsettings.ModelSettings{
+			Temperature: param.NewOpt(0.3),
+			ToolChoice:  modelsettings.ToolChoiceRequired,
+		}).
+		WithTools(fooTool, barTool).
+		WithHandoffs(refunds).
+		WithAgentHandoffs(billing).
+		WithInputGuardrails([]agents.InputGuardrail{inputGuardrail}).
+		WithOutputGuardrails([]agents.OutputGuardrail{outputGuardrail}).
+		WithOutputType(outputType).
+		WithToolUseBehavior(agents.StopAtTools("bar"))
+	agent.ResetToolChoice = param.NewOpt(false)
+
+	data, err := agent.ExportJSON()
+	require.NoError(t, err)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, "triage", raw["name"])
+	assert.Equal(t, []any{"foo", "bar"}, raw["tools"])
+	assert.Equal(t, []any{"refunds", "billing"}, raw["handoffs"])
+
+	loaded, err := agents.LoadAgent(data, agents.AgentRegistry{
+		Tools:            map[string]agents.Tool{"foo": fooTool, "bar": barTool},
+		Agents:           map[string]*agents.Agent{"billing": billing},
+		Handoffs:         map[string]agents.Handoff{"refunds": refunds},
+		InputGuardrails:  map[string]agents.InputGuardrail{"input_check": inputGuardrail},
+		OutputGuardrails: map[string]agents.OutputGuardrail{"output_check": outputGuardrail},
+		OutputTypes:      map[string]agents.OutputTypeInterface{outputType.Name(): outputType},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "triage", loaded.Name)
+	assert.Equal(t, agents.InstructionsStr("Route the request."), loaded.Instructions)
+	assert.Equal(t, "Triages requests", loaded.HandoffDescription)
+	assert.Equal(t, "gpt-4.1", loaded.Model.Value.ModelName())
+	assert.Equal(t, agent.ModelSettings, loaded.ModelSettings)
+	assert.Equal(t, param.NewOpt(false), loaded.ResetToolChoice)
+	require.Len(t, loaded.Tools, 2)
+	assert.Equal(t, "foo", loaded.Tools[0].ToolName())
+	assert.Equal(t, "bar", loaded.Tools[1].ToolName())
+	require.Len(t, loaded.Handoffs, 1)
+	assert.Equal(t, "Handles refunds", loaded.Handoffs[0].ToolDescription)
+	require.Len(t, loaded.AgentHandoffs, 1)
+	assert.Same(t, billing, loaded.AgentHandoffs[0])
+	assert.Equal(t, []string{"input_check"}, guardrailNames(loaded.InputGuardrails))
+	assert.Equal(t, "output_check", loaded.OutputGuardrails[0].Name)
+	assert.Equal(t, outputType, loaded.OutputType)
+	assert.Equal(t, agents.StopAtTools("bar"), loaded.ToolUseBehavior)
+
+	// Exporting the loaded agent gives the same definition.
+	reexported, err := loaded.ExportJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(reexported))
+}
+
+func guardrailNames(guardrails []agents.InputGuardrail) []string {
+	names := make([]string, len(guardrails))
+	for i, guardrail := range guardrails {
+		names[i] = guardrail.Name
+	}
+	return names
+}
+
+func TestAgentDefinitionMinimal(t *testing.T) {
+	data, err := agents.New("minimal").ExportJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"minimal"}`, string(data))
+
+	loaded, err := agents.LoadAgent(data, agents.AgentRegistry{})
+	require.NoError(t, err)
+	assert.Equal(t, agents.New("minimal"), loaded)
+}
+
+func TestAgentDefinitionUnserializable(t *testing.T) {
+	testCases := map[string]*agents.Agent{
+		"dynamic instructions": agents.New("a").WithInstructionsFunc(
+			func(context.Context, *agents.Agent) (string, error) { return "", nil }),
+		"model instance": agents.New("a").WithModelInstance(agentstesting.NewFakeModel(false, nil)),
+		"custom tool use behavior": agents.New("a").WithToolUseBehavior(agents.ToolsToFinalOutputFunction(
+			func(context.Context, []agents.FunctionToolResult) (agents.ToolsToFinalOutputResult, error) {
+				return agents.ToolsToFinalOutputResult{}, nil
+			})),
+		"final output extractor": agents.New("a").WithFinalOutputExtractor(
+			func(text string) (string, bool) { return text, true }),
+	}
+	for name, agent := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := agent.ExportJSON()
+			assert.ErrorAs(t, err, &agents.UserError{})
+		})
+	}
+}
+
+func TestLoadAgentUnknownReferences(t *testing.T) {
+	testCases := map[string]string{
+		"tool":              `{"name":"a","tools":["missing"]}`,
+		"handoff":           `{"name":"a","handoffs":["missing"]}`,
+		"input guardrail":   `{"name":"a","input_guardrails":["missing"]}`,
+		"output guardrail":  `{"name":"a","output_guardrails":["missing"]}`,
+		"output type":       `{"name":"a","output_type":"missing"}`,
+		"tool use behavior": `{"name":"a","tool_use_behavior":"missing"}`,
+	}
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := agents.LoadAgent([]byte(data), agents.AgentRegistry{})
+			assert.ErrorAs(t, err, &agents.UserError{})
+			assert.ErrorContains(t, err, `"missing"`)
+		})
+	}
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := agents.LoadAgent([]byte(`{`), agents.AgentRegistry{})
+		assert.Error(t, err)
+	})
+}
@@ -16,6 +16,7 @@ package agents_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
@@ -121,12 +122,12 @@ func TestNonStreamedAgentHooks(t *testing.T) {
 	_, err = agents.Runner{}.Run(t.Context(), agent3, "user_message")
 	require.NoError(t, err)
 
-	// Shouldn't have OnEnd because it's not the last agent
+	// Shouldn't have OnEnd because it's not the last agent, nor OnHandoff
+	// because it's handing off, rather than being handed off to
 	assert.Equal(t, map[string]int{
 		"OnStart":     1,
 		"OnToolStart": 1,
 		"OnToolEnd":   1,
-		"OnHandoff":   1,
 	}, hooks.Events)
 	hooks.Reset()
 
@@ -216,12 +217,12 @@ func TestStreamedAgentHooks(t *testing.T) {
 	err = output.StreamEvents(func(agents.StreamEvent) error { return nil })
 	require.NoError(t, err)
 
-	// Shouldn't have OnEnd because it's not the last agent
+	// Shouldn't have OnEnd because it's not the last agent, nor OnHandoff
+	// because it's handing off, rather than being handed off to
 	assert.Equal(t, map[string]int{
 		"OnStart":     1,
 		"OnToolStart": 1,
 		"OnToolEnd":   1,
-		"OnHandoff":   1,
 	}, hooks.Events)
 	hooks.Reset()
 
@@ -314,12 +315,12 @@ func TestStructuredOutputNonStreamedAgentHooks(t *testing.T) {
 	_, err = agents.Runner{}.Run(t.Context(), agent3, "user_message")
 	require.NoError(t, err)
 
-	// Shouldn't have OnEnd because it's not the last agent
+	// Shouldn't have OnEnd because it's not the last agent, nor OnHandoff
+	// because it's handing off, rather than being handed off to
 	assert.Equal(t, map[string]int{
 		"OnStart":     1,
 		"OnToolStart": 1,
 		"OnToolEnd":   1,
-		"OnHandoff":   1,
 	}, hooks.Events)
 	hooks.Reset()
 
@@ -414,12 +415,12 @@ func TestStructuredOutputStreamedAgentHooks(t *testing.T) {
 	err = output.StreamEvents(func(agents.StreamEvent) error { return nil })
 	require.NoError(t, err)
 
-	// Shouldn't have OnEnd because it's not the last agent
+	// Shouldn't have OnEnd because it's not the last agent, nor OnHandoff
+	// because it's handing off, rather than being handed off to
 	assert.Equal(t, map[string]int{
 		"OnStart":     1,
 		"OnToolStart": 1,
 		"OnToolEnd":   1,
-		"OnHandoff":   1,
 	}, hooks.Events)
 	hooks.Reset()
 
@@ -457,3 +458,47 @@ func TestStructuredOutputStreamedAgentHooks(t *testing.T) {
 		"OnEnd":       1, // Agent 3 is the last agent
 	}, hooks.Events)
 }
+
+type handoffRecordingAgentHooks struct {
+	agents.NoOpAgentHooks
+	agent  *agents.Agent
+	source *agents.Agent
+}
+
+func (h *handoffRecordingAgentHooks) OnHandoff(_ context.Context, agent, source *agents.Agent) error {
+	h.agent = agent
+	h.source = source
+	return nil
+}
+
+func TestAgentHooksOnHandoff(t *testing.T) {
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming %v", streaming), func(t *testing.T) {
+			sourceHooks := &handoffRecordingAgentHooks{}
+			targetHooks := &handoffRecordingAgentHooks{}
+
+			model := agentstesting.NewFakeModel(false, nil)
+			target := agents.New("target").WithModelInstance(model).WithHooks(targetHooks)
+			source := agents.New("source").WithModelInstance(model).WithHooks(sourceHooks).WithAgentHandoffs(target)
+
+			model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+				{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(target, "", "")}},
+				{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+			})
+
+			if streaming {
+				result, err := agents.RunStreamed(t.Context(), source, "user_message")
+				require.NoError(t, err)
+				require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+			} else {
+				_, err := agents.Run(t.Context(), source, "user_message")
+				require.NoError(t, err)
+			}
+
+			assert.Same(t, target, targetHooks.agent)
+			assert.Same(t, source, targetHooks.source)
+			assert.Nil(t, sourceHooks.agent)
+			assert.Nil(t, sourceHooks.source)
+		})
+	}
+}
@@ -15,11 +15,13 @@
 package agents
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/invopop/jsonschema"
 	"github.com/nlpodyssey/openai-agents-go/tracing"
@@ -52,6 +54,43 @@ type OutputTypeInterface interface {
 	ValidateJSON(ctx context.Context, jsonStr string) (any, error)
 }
 
+// OutputTypeStrictValidator is an optional interface which an
+// OutputTypeInterface can implement to check its own JSON schema against
+// the strict mode rules (see ValidateStrictOutputType).
+type OutputTypeStrictValidator interface {
+	// ValidateStrict returns a UserError if the output type is in strict
+	// mode, but its JSON schema would be rejected by the strict mode of the
+	// OpenAI API.
+	ValidateStrict() error
+}
+
+// ValidateStrictOutputType checks that the JSON schema of a strict output
+// type is strict-compatible, returning a UserError otherwise. Plain text and
+// non-strict output types are always valid.
+//
+// If the output type implements OutputTypeStrictValidator, its own
+// validation is used, otherwise its JSON schema is checked with
+// ValidateStrictJSONSchema.
+//
+// The runner calls it whenever an agent starts running, so that an invalid
+// schema is reported before any model request is made.
+func ValidateStrictOutputType(outputType OutputTypeInterface) error {
+	if outputType == nil || outputType.IsPlainText() || !outputType.IsStrictJSONSchema() {
+		return nil
+	}
+	if v, ok := outputType.(OutputTypeStrictValidator); ok {
+		return v.ValidateStrict()
+	}
+	schema, err := outputType.JSONSchema()
+	if err != nil {
+		return err
+	}
+	if err = ValidateStrictJSONSchema(schema); err != nil {
+		return UserErrorf("output type %s: %w", outputType.Name(), err)
+	}
+	return nil
+}
+
 type outputTypeImpl[T any] struct {
 	// Whether the output type is wrapped in a dictionary. This is generally done if the base
 	// output type cannot be represented as a JSON Schema object.
@@ -66,6 +105,9 @@ type outputTypeImpl[T any] struct {
 
 	isPlainText bool
 	name        string
+
+	// Optional transformation of the JSON field names, see OutputTypeOpts.
+	fieldNameTransform func(string) string
 }
 
 type wrappedOutputType[T any] struct {
@@ -84,6 +126,18 @@ func OutputType[T any]() OutputTypeInterface {
 
 type OutputTypeOpts struct {
 	StrictJSONSchema bool
+
+	// Whether to inline the definitions of nested types in the JSON schema,
+	// instead of referencing them from "$defs". Recursive types can't be
+	// inlined: if the output type is recursive, its schema keeps referencing
+	// the definitions from "$defs".
+	InlineDefs bool
+
+	// Optional function transforming the JSON name of each struct field
+	// (the name from the json tag, if any, or the field name) in the schema,
+	// e.g. to match model-specific naming quirks. The JSON produced by the
+	// model is mapped back to the original names when parsing the output.
+	FieldNameTransform func(string) string
 }
 
 var defaultOutputTypeOpts = OutputTypeOpts{
@@ -121,6 +175,7 @@ func SafeOutputType[T any](opts OutputTypeOpts) (OutputTypeInterface, error) {
 			Anonymous:                 true,
 			AllowAdditionalProperties: !opts.StrictJSONSchema,
 			ExpandedStruct:            true,
+			KeyNamer:                  opts.FieldNameTransform,
 		}
 
 		var valueToReflect any
@@ -130,6 +185,11 @@ func SafeOutputType[T any](opts OutputTypeOpts) (OutputTypeInterface, error) {
 			valueToReflect = zero
 		}
 
+		// Inlining the definitions of a recursive type would never end
+		rootType := reflect.TypeOf(valueToReflect)
+		isRecursive := isRecursiveType(rootType, nil)
+		reflector.DoNotReference = opts.InlineDefs && !isRecursive
+
 		schema = reflector.Reflect(valueToReflect)
 		b, err := json.Marshal(schema)
 		if err != nil {
@@ -139,6 +199,14 @@ func SafeOutputType[T any](opts OutputTypeOpts) (OutputTypeInterface, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to JSON-unmarshal JSON schema: %w", err)
 		}
+		if isRecursive {
+			// The root type is expanded, so it has no definition in "$defs":
+			// references to it must point to the root of the schema.
+			if rootType.Kind() == reflect.Pointer {
+				rootType = rootType.Elem()
+			}
+			replaceJSONSchemaRefs(outputSchema, "#/$defs/"+rootType.Name(), "#")
+		}
 
 		if opts.StrictJSONSchema {
 			outputSchema, err = EnsureStrictJSONSchema(outputSchema)
@@ -157,11 +225,12 @@ func SafeOutputType[T any](opts OutputTypeOpts) (OutputTypeInterface, error) {
 	}
 
 	return outputTypeImpl[T]{
-		isWrapped:        isWrapped,
-		outputSchema:     outputSchema,
-		strictJSONSchema: opts.StrictJSONSchema,
-		isPlainText:      isPlainText,
-		name:             fmt.Sprintf("%T", zero),
+		isWrapped:          isWrapped,
+		outputSchema:       outputSchema,
+		strictJSONSchema:   opts.StrictJSONSchema,
+		isPlainText:        isPlainText,
+		name:               fmt.Sprintf("%T", zero),
+		fieldNameTransform: opts.FieldNameTransform,
 	}, nil
 }
 
@@ -173,6 +242,54 @@ func isStruct[T any]() bool {
 	return (kind == reflect.Struct) || (kind == reflect.Ptr && val.Elem().Kind() == reflect.Struct)
 }
 
+// isRecursiveType reports whether typ refers to itself, or contains a type
+// which refers to itself, through its fields, elements or pointers.
+// The types being visited are tracked in visiting.
+func isRecursiveType(typ reflect.Type, visiting map[reflect.Type]bool) bool {
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return isRecursiveType(typ.Elem(), visiting)
+	case reflect.Struct:
+		if visiting[typ] {
+			return true
+		}
+		if visiting == nil {
+			visiting = make(map[reflect.Type]bool)
+		}
+		visiting[typ] = true
+		defer delete(visiting, typ)
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			if field.Tag.Get("json") == "-" || (!field.IsExported() && !field.Anonymous) {
+				continue
+			}
+			if isRecursiveType(field.Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// replaceJSONSchemaRefs replaces the "$ref" values equal to oldRef with
+// newRef, anywhere within the JSON schema.
+func replaceJSONSchemaRefs(schema any, oldRef, newRef string) {
+	switch v := schema.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if key == "$ref" && value == oldRef {
+				v[key] = newRef
+			} else {
+				replaceJSONSchemaRefs(value, oldRef, newRef)
+			}
+		}
+	case []any:
+		for _, value := range v {
+			replaceJSONSchemaRefs(value, oldRef, newRef)
+		}
+	}
+}
+
 func (t outputTypeImpl[T]) IsPlainText() bool        { return t.isPlainText }
 func (t outputTypeImpl[T]) Name() string             { return t.name }
 func (t outputTypeImpl[T]) IsStrictJSONSchema() bool { return t.strictJSONSchema }
@@ -195,33 +312,170 @@ func (t outputTypeImpl[T]) ValidateJSON(ctx context.Context, jsonStr string) (_
 		return nil, ModelBehaviorErrorf("failed to load and compile output JSON schema: %w", err)
 	}
 
-	err = ValidateJSON(ctx, schema, jsonStr)
-	if err != nil {
-		return nil, fmt.Errorf("output type validation error: %w", err)
-	}
-
 	defer func() {
 		if err != nil {
 			AttachErrorToCurrentSpan(ctx, tracing.SpanError{
 				Message: "Invalid JSON",
-				Data:    map[string]any{"details": err.Error()},
+				Data:    map[string]any{"details": err.Error(), "output_type": t.name},
 			})
 		}
 	}()
 
+	err = ValidateJSON(ctx, schema, jsonStr)
+	if err != nil {
+		return nil, NewOutputParsingError(t.name, jsonStr, err)
+	}
+
+	output, err := t.unmarshal(jsonStr)
+	if err != nil {
+		return nil, NewOutputParsingError(t.name, jsonStr, err)
+	}
+	return output, nil
+}
+
+// unmarshal parses the JSON value, unwrapping it if needed.
+func (t outputTypeImpl[T]) unmarshal(jsonStr string) (any, error) {
+	data := []byte(jsonStr)
 	if t.isWrapped {
 		var wrappedOutput wrappedOutputType[T]
-		err = json.Unmarshal([]byte(jsonStr), &wrappedOutput)
-		if err != nil {
-			return nil, ModelBehaviorErrorf("failed to unmarshal JSON output (wrapped): %w", err)
+		if err := t.unmarshalRestoringFieldNames(data, &wrappedOutput); err != nil {
+			return nil, err
 		}
 		return wrappedOutput.Response, nil
-	} else {
-		var output T
-		err = json.Unmarshal([]byte(jsonStr), &output)
-		if err != nil {
-			return nil, ModelBehaviorErrorf("failed to unmarshal JSON output: %w", err)
+	}
+	var output T
+	if err := t.unmarshalRestoringFieldNames(data, &output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// unmarshalRestoringFieldNames unmarshals the JSON value into v, first mapping
+// the field names transformed by the FieldNameTransform back to the original ones.
+func (t outputTypeImpl[T]) unmarshalRestoringFieldNames(data []byte, v any) error {
+	if t.fieldNameTransform != nil {
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+		var value any
+		if err := d.Decode(&value); err != nil {
+			return err
+		}
+		value = restoreFieldNames(value, reflect.TypeOf(v), t.fieldNameTransform)
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, v)
+}
+
+// restoreFieldNames renames the keys of the JSON objects within the decoded
+// JSON value, corresponding to structs of type typ, from the transformed
+// field names to the original ones.
+func restoreFieldNames(value any, typ reflect.Type, transform func(string) string) any {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		fields := make(map[string]reflect.StructField)
+		collectTransformedFields(typ, transform, fields)
+		result := make(map[string]any, len(obj))
+		for key, v := range obj {
+			if field, ok := fields[key]; ok {
+				result[jsonFieldName(field)] = restoreFieldNames(v, field.Type, transform)
+			} else {
+				result[key] = v
+			}
+		}
+		return result
+	case reflect.Slice, reflect.Array:
+		arr, ok := value.([]any)
+		if !ok {
+			return value
+		}
+		result := make([]any, len(arr))
+		for i, v := range arr {
+			result[i] = restoreFieldNames(v, typ.Elem(), transform)
+		}
+		return result
+	case reflect.Map:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		result := make(map[string]any, len(obj))
+		for key, v := range obj {
+			result[key] = restoreFieldNames(v, typ.Elem(), transform)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// collectTransformedFields maps the transformed JSON names of the fields of
+// a struct type to the fields, following the JSON marshaling rules for
+// embedded structs.
+func collectTransformedFields(typ reflect.Type, transform func(string) string, fields map[string]reflect.StructField) {
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && strings.Split(tag, ",")[0] == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectTransformedFields(embedded, transform, fields)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
 		}
-		return output, nil
+		fields[transform(jsonFieldName(field))] = field
+	}
+}
+
+// jsonFieldName returns the name of a struct field in JSON.
+func jsonFieldName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return field.Name
+}
+
+func (t outputTypeImpl[T]) ValidateStrict() error {
+	if t.isPlainText || !t.strictJSONSchema {
+		return nil
+	}
+	if err := ValidateStrictJSONSchema(t.outputSchema); err != nil {
+		return UserErrorf("output type %s: %w", t.name, err)
+	}
+	return nil
+}
+
+func (t outputTypeImpl[T]) ParsePartialJSON(jsonStr string) (any, error) {
+	if t.isPlainText {
+		return nil, NewUserError("output type is plain text, so JSON parsing is not available")
+	}
+
+	completeJSON, ok := CompletePartialJSON(jsonStr)
+	if !ok {
+		return nil, NewOutputParsingError(t.name, jsonStr, errors.New("no complete JSON value"))
+	}
+
+	output, err := t.unmarshal(completeJSON)
+	if err != nil {
+		return nil, NewOutputParsingError(t.name, jsonStr, err)
 	}
+	return output, nil
 }
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -87,6 +88,11 @@ func TestOutputType(t *testing.T) {
 		for _, val := range badValues {
 			_, err := ot.ValidateJSON(t.Context(), val)
 			require.ErrorAs(t, err, &agents.ModelBehaviorError{})
+
+			var parsingErr agents.OutputParsingError
+			require.ErrorAs(t, err, &parsingErr)
+			assert.Equal(t, val, parsingErr.RawText)
+			assert.Equal(t, ot.Name(), parsingErr.TypeName)
 		}
 	})
 
@@ -128,6 +134,167 @@ func TestOutputType(t *testing.T) {
 	})
 }
 
+type schemaOptionsAddress struct {
+	City string `json:"city"`
+}
+
+type schemaOptionsPerson struct {
+	Name    string                 `json:"name"`
+	Address schemaOptionsAddress   `json:"address"`
+	Others  []schemaOptionsAddress `json:"others"`
+}
+
+func TestOutputTypeInlineDefs(t *testing.T) {
+	type m = map[string]any
+
+	addressSchema := m{
+		"type":                 "object",
+		"required":             []any{"city"},
+		"additionalProperties": false,
+		"properties":           m{"city": m{"type": "string"}},
+	}
+
+	t.Run("referenced", func(t *testing.T) {
+		ot := agents.OutputTypeWithOpts[schemaOptionsPerson](agents.OutputTypeOpts{
+			StrictJSONSchema: true,
+		})
+		schema, err := ot.JSONSchema()
+		require.NoError(t, err)
+		assert.Equal(t, m{
+			"$schema":              "https://json-schema.org/draft/2020-12/schema",
+			"type":                 "object",
+			"required":             []any{"address", "name", "others"},
+			"additionalProperties": false,
+			"properties": m{
+				"name":    m{"type": "string"},
+				"address": m{"$ref": "#/$defs/schemaOptionsAddress"},
+				"others":  m{"type": "array", "items": m{"$ref": "#/$defs/schemaOptionsAddress"}},
+			},
+			"$defs": m{"schemaOptionsAddress": addressSchema},
+		}, schema)
+	})
+
+	t.Run("inlined", func(t *testing.T) {
+		ot := agents.OutputTypeWithOpts[schemaOptionsPerson](agents.OutputTypeOpts{
+			StrictJSONSchema: true,
+			InlineDefs:       true,
+		})
+		schema, err := ot.JSONSchema()
+		require.NoError(t, err)
+		assert.Equal(t, m{
+			"$schema":              "https://json-schema.org/draft/2020-12/schema",
+			"type":                 "object",
+			"required":             []any{"address", "name", "others"},
+			"additionalProperties": false,
+			"properties": m{
+				"name":    m{"type": "string"},
+				"address": addressSchema,
+				"others":  m{"type": "array", "items": addressSchema},
+			},
+		}, schema)
+
+		validated, err := ot.ValidateJSON(t.Context(), `{"name": "Joe", "address": {"city": "Rome"}, "others": []}`)
+		require.NoError(t, err)
+		assert.Equal(t, schemaOptionsPerson{
+			Name:    "Joe",
+			Address: schemaOptionsAddress{City: "Rome"},
+			Others:  []schemaOptionsAddress{},
+		}, validated)
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		ot, err := agents.SafeOutputType[schemaOptionsNode](agents.OutputTypeOpts{
+			StrictJSONSchema: true,
+			InlineDefs:       true,
+		})
+		require.NoError(t, err)
+		schema, err := ot.JSONSchema()
+		require.NoError(t, err)
+		assert.Equal(t, m{
+			"$schema":              "https://json-schema.org/draft/2020-12/schema",
+			"type":                 "object",
+			"required":             []any{"children", "name"},
+			"additionalProperties": false,
+			"properties": m{
+				"name":     m{"type": "string"},
+				"children": m{"type": "array", "items": m{"$ref": "#"}},
+			},
+		}, schema)
+		require.NoError(t, agents.ValidateStrictOutputType(ot))
+
+		validated, err := ot.ValidateJSON(t.Context(),
+			`{"name": "root", "children": [{"name": "leaf", "children": []}]}`)
+		require.NoError(t, err)
+		assert.Equal(t, schemaOptionsNode{
+			Name:     "root",
+			Children: []*schemaOptionsNode{{Name: "leaf", Children: []*schemaOptionsNode{}}},
+		}, validated)
+	})
+
+	t.Run("nested recursive", func(t *testing.T) {
+		ot, err := agents.SafeOutputType[[]schemaOptionsNode](agents.OutputTypeOpts{
+			StrictJSONSchema: true,
+			InlineDefs:       true,
+		})
+		require.NoError(t, err)
+		schema, err := ot.JSONSchema()
+		require.NoError(t, err)
+		assert.Equal(t, m{
+			"type":  "array",
+			"items": m{"$ref": "#/$defs/schemaOptionsNode"},
+		}, schema["properties"].(m)["response"])
+		assert.Contains(t, schema["$defs"], "schemaOptionsNode")
+
+		validated, err := ot.ValidateJSON(t.Context(), `{"response": [{"name": "leaf", "children": []}]}`)
+		require.NoError(t, err)
+		assert.Equal(t, []schemaOptionsNode{{Name: "leaf", Children: []*schemaOptionsNode{}}}, validated)
+	})
+}
+
+type schemaOptionsNode struct {
+	Name     string               `json:"name"`
+	Children []*schemaOptionsNode `json:"children"`
+}
+
+func TestOutputTypeFieldNameTransform(t *testing.T) {
+	type m = map[string]any
+	transform := func(name string) string { return "x_" + name }
+
+	t.Run("struct", func(t *testing.T) {
+		ot := agents.OutputTypeWithOpts[schemaOptionsPerson](agents.OutputTypeOpts{
+			StrictJSONSchema:   true,
+			InlineDefs:         true,
+			FieldNameTransform: transform,
+		})
+		schema, err := ot.JSONSchema()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []any{"x_name", "x_address", "x_others"}, schema["required"])
+		assert.Equal(t, []any{"x_city"}, schema["properties"].(m)["x_address"].(m)["required"])
+
+		validated, err := ot.ValidateJSON(t.Context(),
+			`{"x_name": "Joe", "x_address": {"x_city": "Rome"}, "x_others": [{"x_city": "Milan"}]}`)
+		require.NoError(t, err)
+		assert.Equal(t, schemaOptionsPerson{
+			Name:    "Joe",
+			Address: schemaOptionsAddress{City: "Rome"},
+			Others:  []schemaOptionsAddress{{City: "Milan"}},
+		}, validated)
+
+		_, err = ot.ValidateJSON(t.Context(), `{"name": "Joe", "address": {"city": "Rome"}, "others": []}`)
+		assert.Error(t, err)
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		ot := agents.OutputTypeWithOpts[[]schemaOptionsAddress](agents.OutputTypeOpts{
+			StrictJSONSchema:   true,
+			FieldNameTransform: transform,
+		})
+		validated, err := ot.ValidateJSON(t.Context(), `{"x_response": [{"x_city": "Rome"}]}`)
+		require.NoError(t, err)
+		assert.Equal(t, []schemaOptionsAddress{{City: "Rome"}}, validated)
+	})
+}
+
 var CustomOutputTypeJSONSchema = map[string]any{
 	"type": "object",
 	"properties": map[string]any{
@@ -161,3 +328,179 @@ func TestCustomOutputType(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, []string{"some", "output"}, validated)
 }
+
+type strictConformingOutput struct {
+	Name string `json:"name"`
+	Tags []struct {
+		Value string `json:"value"`
+	} `json:"tags"`
+}
+
+// laxOutputType claims to be strict, but its JSON schema (the one of a
+// struct with a single "foo" field) does not forbid additional properties.
+type laxOutputType struct {
+	CustomOutputType
+	strict bool
+}
+
+func (ot laxOutputType) IsStrictJSONSchema() bool { return ot.strict }
+
+func TestValidateStrictOutputType(t *testing.T) {
+	t.Run("conforming struct", func(t *testing.T) {
+		ot := agents.OutputType[strictConformingOutput]()
+		assert.NoError(t, agents.ValidateStrictOutputType(ot))
+
+		validator, ok := ot.(agents.OutputTypeStrictValidator)
+		require.True(t, ok)
+		assert.NoError(t, validator.ValidateStrict())
+	})
+
+	t.Run("plain text", func(t *testing.T) {
+		assert.NoError(t, agents.ValidateStrictOutputType(agents.OutputType[string]()))
+		assert.NoError(t, agents.ValidateStrictOutputType(nil))
+	})
+
+	t.Run("non-conforming struct", func(t *testing.T) {
+		err := agents.ValidateStrictOutputType(laxOutputType{strict: true})
+		assert.ErrorAs(t, err, &agents.UserError{})
+		assert.ErrorContains(t, err, "FooBarBaz")
+
+		// The schema is left unchanged.
+		assert.NotContains(t, CustomOutputTypeJSONSchema, "additionalProperties")
+	})
+
+	t.Run("non-strict output type is not validated", func(t *testing.T) {
+		assert.NoError(t, agents.ValidateStrictOutputType(laxOutputType{strict: false}))
+	})
+}
+
+func TestValidateStrictJSONSchema(t *testing.T) {
+	assert.NoError(t, agents.ValidateStrictJSONSchema(map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{"foo": map[string]any{"type": "string"}},
+		"required":             []any{"foo"},
+		"additionalProperties": false,
+	}))
+
+	err := agents.ValidateStrictJSONSchema(map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{"foo": map[string]any{"type": "string"}},
+		"required":             []any{},
+		"additionalProperties": false,
+	})
+	assert.ErrorAs(t, err, &agents.UserError{})
+
+	err = agents.ValidateStrictJSONSchema(map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{},
+		"additionalProperties": true,
+	})
+	assert.ErrorAs(t, err, &agents.UserError{})
+
+	t.Run("required properties in any order", func(t *testing.T) {
+		assert.NoError(t, agents.ValidateStrictJSONSchema(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name": map[string]any{"type": "string"},
+				"age":  map[string]any{"type": "integer"},
+			},
+			"required":             []string{"name", "age"},
+			"additionalProperties": false,
+		}))
+	})
+
+	t.Run("duplicated required property", func(t *testing.T) {
+		err := agents.ValidateStrictJSONSchema(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name": map[string]any{"type": "string"},
+				"age":  map[string]any{"type": "integer"},
+			},
+			"required":             []any{"name", "name"},
+			"additionalProperties": false,
+		})
+		assert.ErrorAs(t, err, &agents.UserError{})
+		assert.ErrorContains(t, err, "#: all properties must be listed as required")
+	})
+
+	t.Run("nested object without additionalProperties", func(t *testing.T) {
+		err := agents.ValidateStrictJSONSchema(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"address": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"city": map[string]any{"type": "string"}},
+					"required":   []any{"city"},
+				},
+			},
+			"required":             []any{"address"},
+			"additionalProperties": false,
+		})
+		assert.ErrorAs(t, err, &agents.UserError{})
+		assert.ErrorContains(t, err, "#/properties/address: additionalProperties must be false")
+	})
+
+	t.Run("nullable object without additionalProperties", func(t *testing.T) {
+		schema := map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"address": map[string]any{
+					"type":       []any{"object", "null"},
+					"properties": map[string]any{"city": map[string]any{"type": "string"}},
+					"required":   []any{"city"},
+				},
+			},
+			"required":             []any{"address"},
+			"additionalProperties": false,
+		}
+		err := agents.ValidateStrictJSONSchema(schema)
+		assert.ErrorAs(t, err, &agents.UserError{})
+		assert.ErrorContains(t, err, "#/properties/address: additionalProperties must be false")
+
+		// The schema fixed by EnsureStrictJSONSchema passes the validation.
+		strictSchema, err := agents.EnsureStrictJSONSchema(schema)
+		require.NoError(t, err)
+		assert.NoError(t, agents.ValidateStrictJSONSchema(strictSchema))
+	})
+
+	t.Run("$ref with sibling keys", func(t *testing.T) {
+		schema := map[string]any{
+			"type": "object",
+			"$defs": map[string]any{
+				"Name": map[string]any{"type": "string"},
+			},
+			"properties": map[string]any{
+				"name": map[string]any{"$ref": "#/$defs/Name", "description": "the name"},
+			},
+			"required":             []any{"name"},
+			"additionalProperties": false,
+		}
+		err := agents.ValidateStrictJSONSchema(schema)
+		assert.ErrorAs(t, err, &agents.UserError{})
+		assert.ErrorContains(t, err, "#/properties/name: $ref must not have sibling keys")
+
+		delete(schema["properties"].(map[string]any)["name"].(map[string]any), "description")
+		assert.NoError(t, agents.ValidateStrictJSONSchema(schema))
+	})
+}
+
+func TestRunRejectsNonStrictCompatibleOutputType(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage(`{"foo": "bar"}`),
+		},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithOutputType(laxOutputType{strict: true})
+
+	_, err := agents.Run(t.Context(), agent, "hello")
+	assert.ErrorAs(t, err, &agents.UserError{})
+	assert.Nil(t, model.LastTurnArgs.Input, "the model must not be called")
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	assert.ErrorAs(t, err, &agents.UserError{})
+	assert.Nil(t, model.LastTurnArgs.Input, "the model must not be called")
+}
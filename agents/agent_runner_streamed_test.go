@@ -462,6 +462,28 @@ func TestOutputGuardrailTripwireTriggeredCausesErrorStreamed(t *testing.T) {
 	assert.ErrorAs(t, err, &agents.OutputGuardrailTripwireTriggeredError{})
 }
 
+func TestGuardrailsBeforeModelStreamed(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithInputGuardrails([]agents.InputGuardrail{{
+			Name: "guardrail_function",
+			GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+				return agents.GuardrailFunctionOutput{TripwireTriggered: true}, nil
+			},
+		}})
+
+	result, err := agents.Runner{Config: agents.RunConfig{
+		GuardrailsBeforeModel: true,
+	}}.RunStreamed(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	assert.ErrorAs(t, err, &agents.InputGuardrailTripwireTriggeredError{})
+	assert.Nil(t, model.LastTurnArgs.Input, "model must not be called")
+}
+
 func TestRunInputGuardrailTripwireTriggeredCausesErrorStreamed(t *testing.T) {
 	guardrailFunction := func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
 		return agents.GuardrailFunctionOutput{
@@ -26,6 +26,7 @@ import (
 	"github.com/nlpodyssey/openai-agents-go/agentstesting"
 	"github.com/nlpodyssey/openai-agents-go/modelsettings"
 	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -243,6 +244,65 @@ func TestStructuredOutput(t *testing.T) {
 	assert.Same(t, agent1, result.LastAgent, "should have handed off to agent1")
 }
 
+func TestStructuredOutputParsingError(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithOutputType(agents.OutputType[AgentRunnerTestFoo]())
+
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{Value: []agents.TResponseOutputItem{
+		agentstesting.GetFinalOutputMessage(`{"bar": 123}`),
+	}})
+
+	_, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+
+	var parsingErr agents.OutputParsingError
+	require.ErrorAs(t, err, &parsingErr)
+	assert.Equal(t, `{"bar": 123}`, parsingErr.RawText)
+	assert.Equal(t, "agents_test.AgentRunnerTestFoo", parsingErr.TypeName)
+	assert.Error(t, parsingErr.ParseErr)
+	assert.ErrorAs(t, err, &agents.ModelBehaviorError{})
+}
+
+func TestGuardrailsBeforeModel(t *testing.T) {
+	newAgent := func(model *agentstesting.FakeModel, tripwireTriggered bool) *agents.Agent {
+		return agents.New("test").
+			WithModelInstance(model).
+			WithInputGuardrails([]agents.InputGuardrail{{
+				Name: "guardrail_function",
+				GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+					return agents.GuardrailFunctionOutput{TripwireTriggered: tripwireTriggered}, nil
+				},
+			}})
+	}
+
+	t.Run("tripwire triggered", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+
+		_, err := agents.Runner{Config: agents.RunConfig{
+			GuardrailsBeforeModel: true,
+		}}.Run(t.Context(), newAgent(model, true), "user_message")
+		assert.ErrorAs(t, err, &agents.InputGuardrailTripwireTriggeredError{})
+		assert.Nil(t, model.LastTurnArgs.Input, "model must not be called")
+	})
+
+	t.Run("guardrails pass", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+
+		result, err := agents.Runner{Config: agents.RunConfig{
+			GuardrailsBeforeModel: true,
+		}}.Run(t.Context(), newAgent(model, false), "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+		require.Len(t, result.InputGuardrailResults, 1)
+		assert.False(t, result.InputGuardrailResults[0].Output.TripwireTriggered)
+	})
+}
+
 func RemoveNewItems(_ context.Context, handoffInputData agents.HandoffInputData) (agents.HandoffInputData, error) {
 	return agents.HandoffInputData{
 		InputHistory:    handoffInputData.InputHistory,
@@ -287,6 +347,86 @@ func TestHandoffFilters(t *testing.T) {
 	assert.Len(t, result.ToInputList(), 2, "should only have 2 inputs: orig input and last message")
 }
 
+func TestHandoffWithRawInputAndInputFilter(t *testing.T) {
+	const rawArgs = `{"payload": {"opaque": [1, "two"]}}`
+
+	var receivedRawJSON string
+	filterCalled := false
+
+	model := agentstesting.NewFakeModel(false, nil)
+	agent1 := agents.New("agent_1").WithModelInstance(model)
+	agent2 := agents.New("agent_2").WithModelInstance(model).WithHandoffs(
+		agents.HandoffFromAgent(agents.HandoffFromAgentParams{
+			Agent: agent1,
+			OnHandoff: agents.OnHandoffWithRawInput(func(_ context.Context, rawJSON string) error {
+				receivedRawJSON = rawJSON
+				return nil
+			}),
+			InputFilter: func(ctx context.Context, data agents.HandoffInputData) (agents.HandoffInputData, error) {
+				filterCalled = true
+				return RemoveNewItems(ctx, data)
+			},
+		}),
+	)
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("1"),
+			agentstesting.GetHandoffToolCall(agent1, "", rawArgs),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("last"),
+		}},
+	})
+
+	result, err := agents.Runner{}.Run(t.Context(), agent2, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "last", result.FinalOutput)
+	assert.Same(t, agent1, result.LastAgent)
+
+	assert.Equal(t, rawArgs, receivedRawJSON)
+	assert.True(t, filterCalled)
+	assert.Len(t, result.ToInputList(), 2, "should only have 2 inputs: orig input and last message")
+}
+
+func TestHandoffAcknowledgementMessage(t *testing.T) {
+	const note = "You are now handling this because the user asked for a refund."
+
+	for _, inputFilter := range []agents.HandoffInputFilter{nil, RemoveNewItems} {
+		sourceModel := agentstesting.NewFakeModel(false, nil)
+		targetModel := agentstesting.NewFakeModel(false, nil)
+		agent1 := agents.New("agent_1").WithModelInstance(targetModel)
+		agent2 := agents.New("agent_2").WithModelInstance(sourceModel).WithHandoffs(
+			agents.HandoffFromAgent(agents.HandoffFromAgentParams{
+				Agent:                  agent1,
+				InputFilter:            inputFilter,
+				AcknowledgementMessage: note,
+			}),
+		)
+
+		sourceModel.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetHandoffToolCall(agent1, "", ""),
+			},
+		})
+		targetModel.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("done"),
+			},
+		})
+
+		result, err := agents.Runner{}.Run(t.Context(), agent2, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+
+		input := targetModel.LastTurnArgs.Input.(agents.InputItems)
+		lastItem := input[len(input)-1]
+		require.NotNil(t, lastItem.OfMessage)
+		assert.Equal(t, responses.EasyInputMessageRoleSystem, lastItem.OfMessage.Role)
+		assert.Equal(t, note, lastItem.OfMessage.Content.OfString.Value)
+	}
+}
+
 func TestInputFilterError(t *testing.T) {
 	model := agentstesting.NewFakeModel(false, nil)
 	agent1 := &agents.Agent{
@@ -831,3 +971,71 @@ func TestTestRunStreamedSeq(t *testing.T) {
 	assert.Greater(t, seen, 0)
 	assert.NoError(t, seq.Err)
 }
+
+func TestRunStreamedSeq2(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, nil)
+		agent := agents.New("test").WithModelInstance(model)
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("hi"),
+			},
+		})
+
+		seq, err := agents.Runner{}.RunStreamedSeq2(t.Context(), agent, "test")
+		require.NoError(t, err)
+
+		var seen int
+		for event, err := range seq {
+			require.NoError(t, err)
+			require.NotNil(t, event)
+			seen++
+		}
+		assert.Greater(t, seen, 0)
+	})
+
+	t.Run("terminal error", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, nil)
+		agent := agents.New("test").WithModelInstance(model)
+		testErr := errors.New("model failure")
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{Error: testErr})
+
+		seq, err := agents.Runner{}.RunStreamedSeq2(t.Context(), agent, "test")
+		require.NoError(t, err)
+
+		var errs []error
+		var eventsAfterError int
+		for event, err := range seq {
+			if err != nil {
+				assert.Nil(t, event)
+				errs = append(errs, err)
+			} else if len(errs) > 0 {
+				eventsAfterError++
+			}
+		}
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], testErr)
+		assert.Zero(t, eventsAfterError)
+	})
+
+	t.Run("early break", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, nil)
+		agent := agents.New("test").WithModelInstance(model)
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("hi"),
+			},
+		})
+
+		seq, err := agents.RunStreamedSeq2(t.Context(), agent, "test")
+		require.NoError(t, err)
+
+		var seen int
+		for _, err := range seq {
+			require.NoError(t, err)
+			seen++
+			break
+		}
+		assert.Equal(t, 1, seen)
+	})
+}
@@ -2,6 +2,7 @@ package agents_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/nlpodyssey/openai-agents-go/agentstesting"
 	"github.com/nlpodyssey/openai-agents-go/tracing"
 	"github.com/nlpodyssey/openai-agents-go/tracing/tracingtesting"
+	"github.com/openai/openai-go/v3/packages/param"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -588,3 +590,37 @@ func TestManualStreamingDisablingWorks(t *testing.T) {
 
 	tracingtesting.RequireNoTraces(t)
 }
+
+func TestOutputParsingErrorSpanHasRawText(t *testing.T) {
+	for _, includeSensitiveData := range []bool{true, false} {
+		t.Run(fmt.Sprintf("includeSensitiveData=%v", includeSensitiveData), func(t *testing.T) {
+			tracingtesting.Setup(t)
+			agents.ClearOpenaiSettings()
+
+			model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+				Value: []agents.TResponseOutputItem{agentstesting.GetFinalOutputMessage(`{"bar": 123}`)},
+			})
+			agent := agents.New("test_agent").
+				WithModelInstance(model).
+				WithOutputType(agents.OutputType[AgentRunnerTestFoo]())
+
+			runner := agents.Runner{Config: agents.RunConfig{
+				TraceIncludeSensitiveData: param.NewOpt(includeSensitiveData),
+			}}
+			_, err := runner.Run(t.Context(), agent, "user_message")
+			require.ErrorAs(t, err, &agents.OutputParsingError{})
+
+			spans := tracingtesting.FetchOrderedSpans(false)
+			require.NotEmpty(t, spans)
+			spanErr := spans[0].Error()
+			require.NotNil(t, spanErr)
+			assert.Equal(t, "Invalid JSON", spanErr.Message)
+			assert.Equal(t, "agents_test.AgentRunnerTestFoo", spanErr.Data["output_type"])
+			if includeSensitiveData {
+				assert.Equal(t, `{"bar": 123}`, spanErr.Data["raw_text"])
+			} else {
+				assert.NotContains(t, spanErr.Data, "raw_text")
+			}
+		})
+	}
+}
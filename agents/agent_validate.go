@@ -0,0 +1,103 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import "errors"
+
+// Validate checks the agent configuration for common mistakes, which would
+// otherwise surface as confusing errors while running. It reports:
+//   - an empty agent name;
+//   - nil tools, nil agents in AgentHandoffs, and handoffs without an
+//     OnInvokeHandoff function;
+//   - duplicate names among tools and handoffs;
+//   - a structured OutputType combined with a ToolUseBehavior which uses the
+//     tool outputs as final output (StopOnFirstTool or StopAtTools), as the
+//     tool output is not validated against the output type;
+//   - StopAtTools names which don't match any tool (only for agents without
+//     MCP servers);
+//   - a strict OutputType whose JSON schema is not strict-compatible (see
+//     ValidateStrictOutputType).
+//
+// All the problems found are returned as UserError values, joined into a
+// single error. MCP tools are not checked for duplicates, as they are only
+// known at run time.
+//
+// The runner validates each agent when it starts running, if
+// RunConfig.ValidateAgents is set.
+func (a *Agent) Validate() error {
+	var errs []error
+
+	if a.Name == "" {
+		errs = append(errs, NewUserError("agent name must not be empty"))
+	}
+
+	toolNames := make(map[string]struct{}, len(a.Tools)+len(a.Handoffs)+len(a.AgentHandoffs))
+	checkDuplicate := func(kind, name string) {
+		if _, ok := toolNames[name]; ok {
+			errs = append(errs, UserErrorf("agent %q: duplicate %s name %q", a.Name, kind, name))
+		}
+		toolNames[name] = struct{}{}
+	}
+
+	for i, tool := range a.Tools {
+		if tool == nil {
+			errs = append(errs, UserErrorf("agent %q: tool %d is nil", a.Name, i))
+			continue
+		}
+		checkDuplicate("tool", tool.ToolName())
+	}
+	for i, handoff := range a.Handoffs {
+		if handoff.OnInvokeHandoff == nil {
+			errs = append(errs, UserErrorf("agent %q: handoff %d (%q) has no OnInvokeHandoff function", a.Name, i, handoff.ToolName))
+		}
+		checkDuplicate("handoff", handoff.ToolName)
+	}
+	for i, agent := range a.AgentHandoffs {
+		if agent == nil {
+			errs = append(errs, UserErrorf("agent %q: handoff agent %d is nil", a.Name, i))
+			continue
+		}
+		checkDuplicate("handoff", DefaultHandoffToolName(agent))
+	}
+
+	var behaviorName string
+	switch behavior := a.ToolUseBehavior.(type) {
+	case stopOnFirstTool:
+		behaviorName = "StopOnFirstTool"
+	case stopAtTools:
+		behaviorName = "StopAtTools"
+		// MCP tools are only known at run time.
+		if len(a.MCPServers) == 0 {
+			for _, name := range behavior.names {
+				if _, ok := toolNames[name]; !ok {
+					errs = append(errs, UserErrorf("agent %q: StopAtTools refers to unknown tool %q", a.Name, name))
+				}
+			}
+		}
+	}
+	if behaviorName != "" && a.OutputType != nil && !a.OutputType.IsPlainText() {
+		errs = append(errs, UserErrorf(
+			"agent %q: ToolUseBehavior %s uses a tool output as final output, "+
+				"which conflicts with the structured output type %s",
+			a.Name, behaviorName, a.OutputType.Name(),
+		))
+	}
+
+	if err := ValidateStrictOutputType(a.OutputType); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
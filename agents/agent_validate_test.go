@@ -0,0 +1,155 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type agentValidateTestFoo struct {
+	Bar string `json:"bar"`
+}
+
+func TestAgentValidate(t *testing.T) {
+	t.Run("valid agent", func(t *testing.T) {
+		agent := agents.New("test").
+			WithTools(agentstesting.GetFunctionTool("foo", "result")).
+			WithAgentHandoffs(agents.New("other")).
+			WithToolUseBehavior(agents.StopAtTools("foo"))
+		assert.NoError(t, agent.Validate())
+	})
+
+	testCases := []struct {
+		name    string
+		agent   *agents.Agent
+		message string
+	}{
+		{
+			name:    "empty name",
+			agent:   agents.New(""),
+			message: "agent name must not be empty",
+		},
+		{
+			name: "duplicate tool names",
+			agent: agents.New("test").WithTools(
+				agentstesting.GetFunctionTool("foo", "a"),
+				agentstesting.GetFunctionTool("foo", "b"),
+			),
+			message: `duplicate tool name "foo"`,
+		},
+		{
+			name: "handoff conflicting with a tool",
+			agent: agents.New("test").
+				WithTools(agentstesting.GetFunctionTool("transfer_to_other", "a")).
+				WithAgentHandoffs(agents.New("other")),
+			message: `duplicate handoff name "transfer_to_other"`,
+		},
+		{
+			name:    "nil tool",
+			agent:   agents.New("test").WithTools(nil),
+			message: "tool 0 is nil",
+		},
+		{
+			name:    "nil handoff agent",
+			agent:   agents.New("test").WithAgentHandoffs(nil),
+			message: "handoff agent 0 is nil",
+		},
+		{
+			name:    "handoff without OnInvokeHandoff",
+			agent:   agents.New("test").WithHandoffs(agents.Handoff{ToolName: "transfer"}),
+			message: `handoff 0 ("transfer") has no OnInvokeHandoff function`,
+		},
+		{
+			name: "StopOnFirstTool with structured output type",
+			agent: agents.New("test").
+				WithToolUseBehavior(agents.StopOnFirstTool()).
+				WithOutputType(agents.OutputType[agentValidateTestFoo]()),
+			message: "ToolUseBehavior StopOnFirstTool uses a tool output as final output",
+		},
+		{
+			name: "StopAtTools with structured output type",
+			agent: agents.New("test").
+				WithTools(agentstesting.GetFunctionTool("foo", "a")).
+				WithToolUseBehavior(agents.StopAtTools("foo")).
+				WithOutputType(agents.OutputType[agentValidateTestFoo]()),
+			message: "ToolUseBehavior StopAtTools uses a tool output as final output",
+		},
+		{
+			name: "StopAtTools with unknown tool",
+			agent: agents.New("test").
+				WithTools(agentstesting.GetFunctionTool("foo", "a")).
+				WithToolUseBehavior(agents.StopAtTools("bar")),
+			message: `StopAtTools refers to unknown tool "bar"`,
+		},
+		{
+			name:    "non-strict-compatible output type",
+			agent:   agents.New("test").WithOutputType(laxOutputType{strict: true}),
+			message: "not strict-compatible",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.agent.Validate()
+			assert.ErrorAs(t, err, &agents.UserError{})
+			assert.ErrorContains(t, err, tc.message)
+		})
+	}
+
+	t.Run("multiple problems are joined", func(t *testing.T) {
+		agent := agents.New("").
+			WithTools(nil).
+			WithAgentHandoffs(nil)
+		err := agent.Validate()
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "agent name must not be empty")
+		assert.ErrorContains(t, err, "tool 0 is nil")
+		assert.ErrorContains(t, err, "handoff agent 0 is nil")
+	})
+}
+
+func TestRunConfigValidateAgents(t *testing.T) {
+	newAgent := func() (*agents.Agent, *agentstesting.FakeModel) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithTools(
+				agentstesting.GetFunctionTool("foo", "a"),
+				agentstesting.GetFunctionTool("foo", "b"),
+			)
+		return agent, model
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		agent, model := newAgent()
+		runner := agents.Runner{Config: agents.RunConfig{ValidateAgents: true}}
+		_, err := runner.Run(t.Context(), agent, "hello")
+		assert.ErrorContains(t, err, `duplicate tool name "foo"`)
+		assert.Nil(t, model.LastTurnArgs.Input, "the model must not be called")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		agent, _ := newAgent()
+		result, err := agents.Runner{}.Run(t.Context(), agent, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+	})
+}
@@ -122,6 +122,12 @@ func (a *Agent) WithMCPConfig(mcpConfig MCPConfig) *Agent {
 	return a
 }
 
+// WithMCPApprovalPolicy sets the policy for hosted MCP tool approval requests.
+func (a *Agent) WithMCPApprovalPolicy(p MCPApprovalPolicy) *Agent {
+	a.MCPApprovalPolicy = p
+	return a
+}
+
 // WithInputGuardrails sets the input guardrails.
 func (a *Agent) WithInputGuardrails(gr []InputGuardrail) *Agent {
 	a.InputGuardrails = gr
@@ -140,6 +146,12 @@ func (a *Agent) WithOutputType(outputType OutputTypeInterface) *Agent {
 	return a
 }
 
+// WithFinalOutputExtractor sets the function extracting the final output from the text.
+func (a *Agent) WithFinalOutputExtractor(fn FinalOutputExtractor) *Agent {
+	a.FinalOutputExtractor = fn
+	return a
+}
+
 // WithHooks sets the lifecycle hooks for the agent.
 func (a *Agent) WithHooks(hooks AgentHooks) *Agent {
 	a.Hooks = hooks
@@ -152,8 +164,28 @@ func (a *Agent) WithToolUseBehavior(b ToolUseBehavior) *Agent {
 	return a
 }
 
+// WithToolCallOrdering sets the function reordering the function tool calls.
+func (a *Agent) WithToolCallOrdering(fn ToolCallOrdering) *Agent {
+	a.ToolCallOrdering = fn
+	return a
+}
+
 // WithResetToolChoice sets whether tool choice is reset after use.
 func (a *Agent) WithResetToolChoice(v param.Opt[bool]) *Agent {
 	a.ResetToolChoice = v
 	return a
 }
+
+// WithForceNonStrictTools sets whether function tools are always sent to
+// the model with a non-strict JSON schema.
+func (a *Agent) WithForceNonStrictTools(v bool) *Agent {
+	a.ForceNonStrictTools = v
+	return a
+}
+
+// WithDedupeToolCalls sets whether identical function tool calls of the same
+// turn are executed only once.
+func (a *Agent) WithDedupeToolCalls(v bool) *Agent {
+	a.DedupeToolCalls = v
+	return a
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatCompletionsModelLogitBiasAndStop(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests, completedChatCompletionJSON)
+
+		model := agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+		_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input: agents.InputString("hi"),
+			ModelSettings: modelsettings.ModelSettings{
+				LogitBias: map[string]int64{"42": -100, "7": 5},
+				Stop:      []string{"END", "STOP"},
+			},
+			Tracing: agents.ModelTracingDisabled,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, map[string]any{"42": -100.0, "7": 5.0}, requests[0].Body["logit_bias"])
+		assert.Equal(t, []any{"END", "STOP"}, requests[0].Body["stop"])
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests, completedChatCompletionJSON)
+
+		model := agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+		_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input:   agents.InputString("hi"),
+			Tracing: agents.ModelTracingDisabled,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.NotContains(t, requests[0].Body, "logit_bias")
+		assert.NotContains(t, requests[0].Body, "stop")
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatCompletionsModelSeedAndSystemFingerprint(t *testing.T) {
+	const body = `{
+		"id": "chatcmpl-1",
+		"object": "chat.completion",
+		"created": 0,
+		"model": "gpt-4",
+		"system_fingerprint": "fp_123",
+		"choices": [{
+			"index": 0,
+			"finish_reason": "stop",
+			"message": {"role": "assistant", "content": "done"}
+		}]
+	}`
+
+	t.Run("set", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests, body)
+
+		model := agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+		response, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input: agents.InputString("hi"),
+			ModelSettings: modelsettings.ModelSettings{
+				Seed: param.NewOpt[int64](42),
+			},
+			Tracing: agents.ModelTracingDisabled,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, 42.0, requests[0].Body["seed"])
+		assert.Equal(t, "fp_123", response.SystemFingerprint)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests, completedChatCompletionJSON)
+
+		model := agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+		response, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input:   agents.InputString("hi"),
+			Tracing: agents.ModelTracingDisabled,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.NotContains(t, requests[0].Body, "seed")
+		assert.Empty(t, response.SystemFingerprint)
+	})
+}
+
+func TestChatCompletionsModelStreamedSystemFingerprint(t *testing.T) {
+	type m = map[string]any
+	chunk1 := m{
+		"id":                 "chunk-id",
+		"created":            1,
+		"model":              "fake",
+		"object":             "chat.completion.chunk",
+		"system_fingerprint": "fp_stream",
+		"choices":            []m{{"index": 0, "delta": m{"content": "do"}}},
+	}
+	chunk2 := m{
+		"id":                 "chunk-id",
+		"created":            1,
+		"model":              "fake",
+		"object":             "chat.completion.chunk",
+		"system_fingerprint": "fp_stream",
+		"choices":            []m{{"index": 0, "delta": m{"content": "ne"}}},
+		"usage":              m{"completion_tokens": 2, "prompt_tokens": 2, "total_tokens": 4},
+	}
+
+	client := makeOpenaiClientWithStreamResponse(t, chunk1, chunk2)
+	provider := agents.NewOpenAIProvider(agents.OpenAIProviderParams{
+		OpenaiClient: &client,
+		UseResponses: param.NewOpt(false),
+	})
+	model, err := provider.GetModel("gpt-4")
+	require.NoError(t, err)
+
+	agent := agents.New("test").WithModelInstance(model)
+	result, err := agents.RunStreamed(t.Context(), agent, "hi")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	require.NoError(t, err)
+
+	assert.Equal(t, "done", result.FinalOutput())
+	require.Len(t, result.RawResponses(), 1)
+	assert.Equal(t, "fp_stream", result.RawResponses()[0].SystemFingerprint)
+}
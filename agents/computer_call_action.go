@@ -0,0 +1,116 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"github.com/nlpodyssey/openai-agents-go/computer"
+	"github.com/openai/openai-go/v3/responses"
+)
+
+// ComputerCallAction is a structured representation of the action of a computer
+// tool call. It is one of the ComputerCallAction* types.
+type ComputerCallAction interface {
+	isComputerCallAction()
+}
+
+// ComputerCallActionClick is a click at the given coordinates.
+type ComputerCallActionClick struct {
+	X      int64
+	Y      int64
+	Button computer.Button
+}
+
+// ComputerCallActionDoubleClick is a double click at the given coordinates.
+type ComputerCallActionDoubleClick struct {
+	X int64
+	Y int64
+}
+
+// ComputerCallActionDrag is a drag along the given path.
+type ComputerCallActionDrag struct {
+	Path []computer.Position
+}
+
+// ComputerCallActionKeypress is the pressing of the given keys together.
+type ComputerCallActionKeypress struct {
+	Keys []string
+}
+
+// ComputerCallActionMove is a mouse move to the given coordinates.
+type ComputerCallActionMove struct {
+	X int64
+	Y int64
+}
+
+// ComputerCallActionScreenshot is a screenshot.
+type ComputerCallActionScreenshot struct{}
+
+// ComputerCallActionScroll is a scroll by ScrollX and ScrollY, with the mouse at
+// the given coordinates.
+type ComputerCallActionScroll struct {
+	X       int64
+	Y       int64
+	ScrollX int64
+	ScrollY int64
+}
+
+// ComputerCallActionType is the typing of the given text.
+type ComputerCallActionType struct {
+	Text string
+}
+
+// ComputerCallActionWait is a wait.
+type ComputerCallActionWait struct{}
+
+func (ComputerCallActionClick) isComputerCallAction()       {}
+func (ComputerCallActionDoubleClick) isComputerCallAction() {}
+func (ComputerCallActionDrag) isComputerCallAction()        {}
+func (ComputerCallActionKeypress) isComputerCallAction()    {}
+func (ComputerCallActionMove) isComputerCallAction()        {}
+func (ComputerCallActionScreenshot) isComputerCallAction()  {}
+func (ComputerCallActionScroll) isComputerCallAction()      {}
+func (ComputerCallActionType) isComputerCallAction()        {}
+func (ComputerCallActionWait) isComputerCallAction()        {}
+
+// NewComputerCallAction converts the action of a computer tool call into its
+// structured representation. It returns false if the action type is unknown.
+func NewComputerCallAction(action responses.ResponseComputerToolCallActionUnion) (ComputerCallAction, bool) {
+	switch action.Type {
+	case "click":
+		return ComputerCallActionClick{X: action.X, Y: action.Y, Button: computer.Button(action.Button)}, true
+	case "double_click":
+		return ComputerCallActionDoubleClick{X: action.X, Y: action.Y}, true
+	case "drag":
+		path := make([]computer.Position, len(action.Path))
+		for i, p := range action.Path {
+			path[i] = computer.Position{X: p.X, Y: p.Y}
+		}
+		return ComputerCallActionDrag{Path: path}, true
+	case "keypress":
+		return ComputerCallActionKeypress{Keys: action.Keys}, true
+	case "move":
+		return ComputerCallActionMove{X: action.X, Y: action.Y}, true
+	case "screenshot":
+		return ComputerCallActionScreenshot{}, true
+	case "scroll":
+		return ComputerCallActionScroll{X: action.X, Y: action.Y, ScrollX: action.ScrollX, ScrollY: action.ScrollY}, true
+	case "type":
+		return ComputerCallActionType{Text: action.Text}, true
+	case "wait":
+		return ComputerCallActionWait{}, true
+	default:
+		return nil, false
+	}
+}
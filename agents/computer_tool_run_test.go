@@ -0,0 +1,70 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/computer"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ computer.Computer = (*agentstesting.FakeComputer)(nil)
+
+func TestRunExecutesComputerActions(t *testing.T) {
+	comp := agentstesting.NewFakeComputer("c2NyZWVu")
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetComputerToolCall(responses.ResponseOutputItemUnionAction{
+				Type:   "click",
+				X:      10,
+				Y:      20,
+				Button: "left",
+			}),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agents.ComputerTool{Computer: comp})
+
+	result, err := agents.Run(t.Context(), agent, "click the button")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	assert.Equal(t, [][]any{
+		{"Click", int64(10), int64(20), computer.ButtonLeft},
+		{"Screenshot"},
+	}, comp.Calls())
+
+	require.Len(t, result.NewItems, 3)
+	outputItem, ok := result.NewItems[1].(agents.ToolCallOutputItem)
+	require.True(t, ok)
+	assert.Equal(t, "data:image/png;base64,c2NyZWVu", outputItem.Output)
+
+	// The screenshot is sent back to the model as a computer_call_output item.
+	inputItems := model.LastTurnArgs.Input.(agents.InputItems)
+	require.Len(t, inputItems, 3)
+	computerCallOutput := inputItems[2].OfComputerCallOutput
+	require.NotNil(t, computerCallOutput)
+	assert.Equal(t, "2", computerCallOutput.CallID)
+	assert.Equal(t, "data:image/png;base64,c2NyZWVu", computerCallOutput.Output.ImageURL.Value)
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dedupeToolArgs struct {
+	X int `json:"x"`
+}
+
+func runDedupeToolCalls(t *testing.T, dedupe bool) (*agents.RunResult, int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	tool := agents.NewFunctionTool("add_one", "", func(_ context.Context, args dedupeToolArgs) (int, error) {
+		calls.Add(1)
+		return args.X + 1, nil
+	})
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			functionToolCallWithID("add_one", "call_1", `{"x":1}`),
+			functionToolCallWithID("add_one", "call_2", `{"x":1}`),
+			functionToolCallWithID("add_one", "call_3", `{"x":2}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(tool).
+		WithDedupeToolCalls(dedupe)
+
+	result, err := agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	return result, calls.Load()
+}
+
+func TestDedupeToolCalls(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		result, calls := runDedupeToolCalls(t, true)
+
+		// The identical calls run the tool only once, but all get an output.
+		assert.Equal(t, int32(2), calls)
+		assert.Equal(t, map[string]any{"call_1": 2, "call_2": 2, "call_3": 3}, toolOutputs(result.NewItems))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		result, calls := runDedupeToolCalls(t, false)
+
+		assert.Equal(t, int32(3), calls)
+		assert.Equal(t, map[string]any{"call_1": 2, "call_2": 2, "call_3": 3}, toolOutputs(result.NewItems))
+	})
+}
+
+func dedupedApprovalRun(t *testing.T, executed *[]string) *agents.RunResult {
+	t.Helper()
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			functionToolCallWithID("send_email", "call_1", `{"to":"bob"}`),
+			functionToolCallWithID("send_email", "call_2", `{"to":"bob"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(sensitiveTool(executed)).
+		WithDedupeToolCalls(true)
+
+	result, err := agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+
+	// The identical calls need a single approval.
+	assert.Equal(t, []agents.ApprovalRequest{{
+		ToolName:         "send_email",
+		CallID:           "call_1",
+		Arguments:        `{"to":"bob"}`,
+		Reason:           "emails are sensitive",
+		DuplicateCallIDs: []string{"call_2"},
+	}}, result.Interruptions)
+	return result
+}
+
+func TestDedupeToolCallsWithApproval(t *testing.T) {
+	t.Run("approved", func(t *testing.T) {
+		var executed []string
+		interrupted := dedupedApprovalRun(t, &executed)
+
+		result, err := agents.Runner{}.Resume(t.Context(), interrupted, []agents.ApprovalDecision{
+			{CallID: "call_1", Approved: true},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+
+		// The tool runs once, and its output goes to both calls.
+		assert.Equal(t, []string{`{"to":"bob"}`}, executed)
+		assert.Equal(t, map[string]any{"call_1": "email sent", "call_2": "email sent"}, toolOutputs(result.NewItems))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		var executed []string
+		interrupted := dedupedApprovalRun(t, &executed)
+
+		result, err := agents.Runner{}.Resume(t.Context(), interrupted, []agents.ApprovalDecision{
+			{CallID: "call_1", Approved: false, Message: "no"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+
+		assert.Empty(t, executed)
+		assert.Equal(t, map[string]any{"call_1": "no", "call_2": "no"}, toolOutputs(result.NewItems))
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // RunErrorDetails provides data collected from an agent run when an error occurs.
@@ -84,6 +85,115 @@ func MaxTurnsExceededErrorf(format string, a ...any) MaxTurnsExceededError {
 	return MaxTurnsExceededError{AgentsError: AgentsErrorf(format, a...)}
 }
 
+// MaxConsecutiveToolOnlyTurnsExceededError is returned when the model keeps
+// calling tools, without producing any message, for more consecutive turns
+// than RunConfig.MaxConsecutiveToolOnlyTurns.
+type MaxConsecutiveToolOnlyTurnsExceededError struct {
+	*AgentsError
+}
+
+func (err MaxConsecutiveToolOnlyTurnsExceededError) Error() string {
+	if err.AgentsError == nil {
+		return "MaxConsecutiveToolOnlyTurnsExceededError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err MaxConsecutiveToolOnlyTurnsExceededError) Unwrap() error {
+	return err.AgentsError
+}
+
+func NewMaxConsecutiveToolOnlyTurnsExceededError(message string) MaxConsecutiveToolOnlyTurnsExceededError {
+	return MaxConsecutiveToolOnlyTurnsExceededError{AgentsError: NewAgentsError(message)}
+}
+
+func MaxConsecutiveToolOnlyTurnsExceededErrorf(format string, a ...any) MaxConsecutiveToolOnlyTurnsExceededError {
+	return MaxConsecutiveToolOnlyTurnsExceededError{AgentsError: AgentsErrorf(format, a...)}
+}
+
+// RepeatedOutputError is returned when the model produces the same message
+// text for RunConfig.MaxRepeatedOutputs consecutive turns.
+type RepeatedOutputError struct {
+	*AgentsError
+	// The message text repeated by the model.
+	Text string
+	// The number of consecutive turns in which the text was repeated.
+	Turns int
+}
+
+func (err RepeatedOutputError) Error() string {
+	if err.AgentsError == nil {
+		return "RepeatedOutputError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err RepeatedOutputError) Unwrap() error {
+	return err.AgentsError
+}
+
+func NewRepeatedOutputError(text string, turns int) RepeatedOutputError {
+	return RepeatedOutputError{
+		AgentsError: AgentsErrorf("the model repeated the same output for %d consecutive turns", turns),
+		Text:        text,
+		Turns:       turns,
+	}
+}
+
+// InputTooLargeError is returned when the model input has more items than
+// RunConfig.MaxInputItems, and they cannot be trimmed to fit.
+type InputTooLargeError struct {
+	*AgentsError
+	// The number of input items.
+	Items int
+	// The maximum number of input items allowed.
+	MaxItems int
+}
+
+func (err InputTooLargeError) Error() string {
+	if err.AgentsError == nil {
+		return "InputTooLargeError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err InputTooLargeError) Unwrap() error {
+	return err.AgentsError
+}
+
+func NewInputTooLargeError(items, maxItems int) InputTooLargeError {
+	return InputTooLargeError{
+		AgentsError: AgentsErrorf("the model input has %d items, exceeding the maximum of %d", items, maxItems),
+		Items:       items,
+		MaxItems:    maxItems,
+	}
+}
+
+// MaxAgentDepthError is returned when agents called as tools are nested
+// beyond the configured maximum depth (see AgentAsToolParams.MaxDepth).
+type MaxAgentDepthError struct {
+	*AgentsError
+}
+
+func (err MaxAgentDepthError) Error() string {
+	if err.AgentsError == nil {
+		return "MaxAgentDepthError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err MaxAgentDepthError) Unwrap() error {
+	return err.AgentsError
+}
+
+func NewMaxAgentDepthError(message string) MaxAgentDepthError {
+	return MaxAgentDepthError{AgentsError: NewAgentsError(message)}
+}
+
+func MaxAgentDepthErrorf(format string, a ...any) MaxAgentDepthError {
+	return MaxAgentDepthError{AgentsError: AgentsErrorf(format, a...)}
+}
+
 // ModelBehaviorError is returned when the model does something unexpected,
 // e.g. calling a tool that doesn't exist, or providing malformed JSON.
 type ModelBehaviorError struct {
@@ -109,6 +219,131 @@ func ModelBehaviorErrorf(format string, a ...any) ModelBehaviorError {
 	return ModelBehaviorError{AgentsError: AgentsErrorf(format, a...)}
 }
 
+// OutputParsingError is returned when the final output of the model can't be
+// parsed as the agent's output type, e.g. because the JSON text doesn't match
+// the output type JSON schema.
+//
+// It is also a ModelBehaviorError.
+type OutputParsingError struct {
+	*AgentsError
+	// The raw output text which could not be parsed.
+	RawText string
+	// The name of the target output type (see OutputTypeInterface.Name).
+	TypeName string
+	// The underlying error, from either the JSON schema validation or json.Unmarshal.
+	ParseErr error
+}
+
+func (err OutputParsingError) Error() string {
+	if err.AgentsError == nil {
+		return "OutputParsingError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err OutputParsingError) Unwrap() error {
+	return ModelBehaviorError{AgentsError: err.AgentsError}
+}
+
+func NewOutputParsingError(typeName, rawText string, parseErr error) OutputParsingError {
+	return OutputParsingError{
+		AgentsError: AgentsErrorf("failed to parse output as %s: %w", typeName, parseErr),
+		RawText:     rawText,
+		TypeName:    typeName,
+		ParseErr:    parseErr,
+	}
+}
+
+// HandoffLoopError is returned when agents keep handing off to each other for
+// more consecutive handoffs than RunConfig.MaxHandoffDepth.
+type HandoffLoopError struct {
+	*AgentsError
+	// The names of the agents involved in the consecutive handoffs, in order,
+	// from the agent making the first handoff to the last agent handed off to.
+	AgentNames []string
+}
+
+func (err HandoffLoopError) Error() string {
+	if err.AgentsError == nil {
+		return "HandoffLoopError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err HandoffLoopError) Unwrap() error {
+	return err.AgentsError
+}
+
+func NewHandoffLoopError(maxDepth int, agentNames []string) HandoffLoopError {
+	return HandoffLoopError{
+		AgentsError: AgentsErrorf(
+			"max handoff depth %d exceeded: %s",
+			maxDepth, strings.Join(agentNames, " -> "),
+		),
+		AgentNames: agentNames,
+	}
+}
+
+// MaxHandoffsPerAgentExceededError is returned when a run hands off to the
+// same agent more times than RunConfig.MaxHandoffsPerAgent.
+type MaxHandoffsPerAgentExceededError struct {
+	*AgentsError
+	// The name of the agent which was handed off to too many times.
+	AgentName string
+}
+
+func (err MaxHandoffsPerAgentExceededError) Error() string {
+	if err.AgentsError == nil {
+		return "MaxHandoffsPerAgentExceededError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err MaxHandoffsPerAgentExceededError) Unwrap() error {
+	return err.AgentsError
+}
+
+func NewMaxHandoffsPerAgentExceededError(maxHandoffs int, agentName string) MaxHandoffsPerAgentExceededError {
+	return MaxHandoffsPerAgentExceededError{
+		AgentsError: AgentsErrorf("max handoffs per agent %d exceeded for agent %q", maxHandoffs, agentName),
+		AgentName:   agentName,
+	}
+}
+
+// UnknownModelError is returned when a model name can't be resolved to any
+// model provider, e.g. because its prefix is not mapped in a MultiProvider.
+//
+// It is also a UserError.
+type UnknownModelError struct {
+	*AgentsError
+	// The model name which could not be resolved.
+	ModelName string
+	// The model name prefixes which can be resolved, in alphabetical order.
+	AvailablePrefixes []string
+}
+
+func (err UnknownModelError) Error() string {
+	if err.AgentsError == nil {
+		return "UnknownModelError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err UnknownModelError) Unwrap() error {
+	return UserError{AgentsError: err.AgentsError}
+}
+
+func NewUnknownModelError(modelName string, availablePrefixes []string) UnknownModelError {
+	return UnknownModelError{
+		AgentsError: AgentsErrorf(
+			"unknown model %q: no provider available (available prefixes: %s)",
+			modelName, strings.Join(availablePrefixes, ", "),
+		),
+		ModelName:         modelName,
+		AvailablePrefixes: availablePrefixes,
+	}
+}
+
 // UserError is returned when the user makes an error using the SDK.
 type UserError struct {
 	*AgentsError
@@ -182,3 +417,90 @@ func NewOutputGuardrailTripwireTriggeredError(guardrailResult OutputGuardrailRes
 		GuardrailResult: guardrailResult,
 	}
 }
+
+// MCPConnectionError is returned by MCP servers based on
+// MCPServerWithClientSession when the connection to the server is lost, and
+// could not be re-established within MCPConfig.MaxReconnectAttempts.
+type MCPConnectionError struct {
+	*AgentsError
+	// The name of the MCP server.
+	ServerName string
+	// The number of reconnection attempts made, zero if reconnection is
+	// disabled or the connection was lost again after reconnecting.
+	Attempts int
+}
+
+func (err MCPConnectionError) Error() string {
+	if err.AgentsError == nil {
+		return "MCPConnectionError"
+	}
+	return err.AgentsError.Error()
+}
+
+func (err MCPConnectionError) Unwrap() error {
+	return err.AgentsError
+}
+
+func NewMCPConnectionError(serverName string, attempts int, cause error) MCPConnectionError {
+	var agentsErr *AgentsError
+	if attempts > 0 {
+		agentsErr = AgentsErrorf("connection to MCP server %q lost, reconnection failed after %d attempts: %w", serverName, attempts, cause)
+	} else {
+		agentsErr = AgentsErrorf("connection to MCP server %q lost: %w", serverName, cause)
+	}
+	return MCPConnectionError{
+		AgentsError: agentsErr,
+		ServerName:  serverName,
+		Attempts:    attempts,
+	}
+}
+
+// AsMaxTurnsExceeded finds the first MaxTurnsExceededError in err's tree.
+// The details of the failed run, if any, are available from its RunData.
+func AsMaxTurnsExceeded(err error) (*MaxTurnsExceededError, bool) {
+	return asError[MaxTurnsExceededError](err)
+}
+
+// AsInputGuardrailTripwire finds the first InputGuardrailTripwireTriggeredError
+// in err's tree. The details of the failed run, if any, are available from its RunData.
+func AsInputGuardrailTripwire(err error) (*InputGuardrailTripwireTriggeredError, bool) {
+	return asError[InputGuardrailTripwireTriggeredError](err)
+}
+
+// AsOutputGuardrailTripwire finds the first OutputGuardrailTripwireTriggeredError
+// in err's tree. The details of the failed run, if any, are available from its RunData.
+func AsOutputGuardrailTripwire(err error) (*OutputGuardrailTripwireTriggeredError, bool) {
+	return asError[OutputGuardrailTripwireTriggeredError](err)
+}
+
+// AsModelBehaviorError finds the first ModelBehaviorError in err's tree.
+// The details of the failed run, if any, are available from its RunData.
+func AsModelBehaviorError(err error) (*ModelBehaviorError, bool) {
+	return asError[ModelBehaviorError](err)
+}
+
+// RunErrorDetailsFromError returns the details of the failed run attached to
+// the first AgentsError in err's tree, or nil if there are none.
+func RunErrorDetailsFromError(err error) *RunErrorDetails {
+	var agentsErr *AgentsError
+	if errors.As(err, &agentsErr) {
+		return agentsErr.RunData
+	}
+	return nil
+}
+
+// asError finds the first error of type T or *T in err's tree.
+func asError[T error, PT interface {
+	*T
+	error
+}](err error) (*T, bool) {
+	var v T
+	if errors.As(err, &v) {
+		return &v, true
+	}
+	var p PT
+	if errors.As(err, &p) && p != nil {
+		return p, true
+	}
+	return nil, false
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsMaxTurnsExceeded(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("foo", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("foo", `{}`)}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("foo", "result"))
+
+	_, err := agents.Runner{Config: agents.RunConfig{MaxTurns: 1}}.Run(t.Context(), agent, "user_message")
+	require.Error(t, err)
+
+	maxTurnsErr, ok := agents.AsMaxTurnsExceeded(fmt.Errorf("wrapped: %w", err))
+	require.True(t, ok)
+	require.NotNil(t, maxTurnsErr.RunData)
+	assert.Same(t, agent, maxTurnsErr.RunData.LastAgent)
+	assert.Same(t, maxTurnsErr.RunData, agents.RunErrorDetailsFromError(err))
+
+	_, ok = agents.AsModelBehaviorError(err)
+	assert.False(t, ok)
+}
+
+func TestAsInputGuardrailTripwire(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithInputGuardrails([]agents.InputGuardrail{{
+			Name: "guardrail_function",
+			GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+				return agents.GuardrailFunctionOutput{OutputInfo: "blocked", TripwireTriggered: true}, nil
+			},
+		}})
+
+	_, err := agents.Run(t.Context(), agent, "user_message")
+	require.Error(t, err)
+
+	tripwireErr, ok := agents.AsInputGuardrailTripwire(err)
+	require.True(t, ok)
+	assert.Equal(t, "blocked", tripwireErr.GuardrailResult.Output.OutputInfo)
+	require.NotNil(t, tripwireErr.RunData)
+	assert.Same(t, agent, tripwireErr.RunData.LastAgent)
+
+	_, ok = agents.AsOutputGuardrailTripwire(err)
+	assert.False(t, ok)
+}
+
+func TestAsOutputGuardrailTripwire(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithOutputGuardrails([]agents.OutputGuardrail{{
+			Name: "guardrail_function",
+			GuardrailFunction: func(context.Context, *agents.Agent, any) (agents.GuardrailFunctionOutput, error) {
+				return agents.GuardrailFunctionOutput{OutputInfo: "blocked", TripwireTriggered: true}, nil
+			},
+		}})
+
+	_, err := agents.Run(t.Context(), agent, "user_message")
+	require.Error(t, err)
+
+	tripwireErr, ok := agents.AsOutputGuardrailTripwire(err)
+	require.True(t, ok)
+	assert.Equal(t, "blocked", tripwireErr.GuardrailResult.Output.OutputInfo)
+	require.NotNil(t, tripwireErr.RunData)
+	assert.Len(t, tripwireErr.RunData.RawResponses, 1)
+
+	_, ok = agents.AsInputGuardrailTripwire(err)
+	assert.False(t, ok)
+}
+
+func TestAsModelBehaviorError(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("missing", `{}`)},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	_, err := agents.Run(t.Context(), agent, "user_message")
+	require.Error(t, err)
+
+	behaviorErr, ok := agents.AsModelBehaviorError(err)
+	require.True(t, ok)
+	assert.ErrorContains(t, behaviorErr, "tool missing not found")
+	require.NotNil(t, behaviorErr.RunData)
+	assert.Same(t, agent, behaviorErr.RunData.LastAgent)
+
+	// Pointers to errors are found too
+	pointerErr := &agents.ModelBehaviorError{AgentsError: agents.NewAgentsError("pointer")}
+	behaviorErr, ok = agents.AsModelBehaviorError(fmt.Errorf("wrapped: %w", pointerErr))
+	require.True(t, ok)
+	assert.Same(t, pointerErr, behaviorErr)
+
+	_, ok = agents.AsMaxTurnsExceeded(err)
+	assert.False(t, ok)
+	assert.Nil(t, agents.RunErrorDetailsFromError(fmt.Errorf("plain error")))
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides RunHooks which record agent runs as Prometheus
+// metrics.
+//
+// It is a separate Go module, so that the Prometheus client is only
+// required by the programs which use it.
+package metrics
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "agents"
+
+type prometheusHooks struct {
+	agents.NoOpRunHooks
+
+	runsStarted     *prometheus.CounterVec
+	runsFinished    *prometheus.CounterVec
+	runsErrored     *prometheus.CounterVec
+	turnDuration    *prometheus.HistogramVec
+	toolInvocations *prometheus.CounterVec
+	tokens          *prometheus.CounterVec
+
+	mu sync.Mutex
+	// The start time of the model call in progress, keyed by the context
+	// passed to OnLLMStart, which is the same one passed to OnLLMEnd.
+	callStarts map[context.Context]time.Time
+}
+
+// NewPrometheusHooks returns RunHooks which record the following metrics,
+// registering them with reg:
+//
+//   - agents_runs_started_total, agents_runs_finished_total and
+//     agents_runs_errored_total: the number of runs, labeled by starting agent
+//     (for started runs) or last agent (for finished and errored runs).
+//   - agents_turn_duration_seconds: the latency of each model call, labeled by agent.
+//   - agents_tool_invocations_total: the number of tool invocations, labeled
+//     by agent and tool name.
+//   - agents_tokens_total: the number of tokens used, labeled by agent and
+//     type ("input" or "output").
+//
+// The returned hooks also implement agents.RunLifecycleHooks, which is
+// required to count runs.
+// It panics if the metrics cannot be registered, as prometheus.MustRegister.
+func NewPrometheusHooks(reg prometheus.Registerer) agents.RunHooks {
+	h := &prometheusHooks{
+		runsStarted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_started_total",
+			Help:      "Number of agent runs started.",
+		}, []string{"agent"}),
+		runsFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_finished_total",
+			Help:      "Number of agent runs finished successfully.",
+		}, []string{"agent"}),
+		runsErrored: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_errored_total",
+			Help:      "Number of agent runs terminated by an error.",
+		}, []string{"agent"}),
+		turnDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "turn_duration_seconds",
+			Help:      "Latency of the model call of each turn.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+		}, []string{"agent"}),
+		toolInvocations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_invocations_total",
+			Help:      "Number of tool invocations.",
+		}, []string{"agent", "tool"}),
+		tokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tokens_total",
+			Help:      "Number of tokens used by model calls.",
+		}, []string{"agent", "type"}),
+		callStarts: make(map[context.Context]time.Time),
+	}
+	reg.MustRegister(
+		h.runsStarted,
+		h.runsFinished,
+		h.runsErrored,
+		h.turnDuration,
+		h.toolInvocations,
+		h.tokens,
+	)
+	return h
+}
+
+func (h *prometheusHooks) OnRunStart(_ context.Context, startingAgent *agents.Agent) error {
+	h.runsStarted.WithLabelValues(startingAgent.Name).Inc()
+	return nil
+}
+
+func (h *prometheusHooks) OnRunEnd(_ context.Context, lastAgent *agents.Agent, err error) error {
+	h.mu.Lock()
+	h.removeEndedCallsLocked()
+	h.mu.Unlock()
+
+	var name string
+	if lastAgent != nil {
+		name = lastAgent.Name
+	}
+	if err != nil {
+		h.runsErrored.WithLabelValues(name).Inc()
+	} else {
+		h.runsFinished.WithLabelValues(name).Inc()
+	}
+	return nil
+}
+
+func (h *prometheusHooks) OnLLMStart(ctx context.Context, _ *agents.Agent, _ param.Opt[string], _ []agents.TResponseInputItem) error {
+	// Contexts are used as map keys: the latency of model calls with a
+	// (very unlikely) non-comparable context is not recorded.
+	if !reflect.TypeOf(ctx).Comparable() {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeEndedCallsLocked()
+	h.callStarts[ctx] = time.Now()
+	return nil
+}
+
+func (h *prometheusHooks) OnLLMEnd(ctx context.Context, agent *agents.Agent, response agents.ModelResponse) error {
+	if reflect.TypeOf(ctx).Comparable() {
+		h.mu.Lock()
+		if start, ok := h.callStarts[ctx]; ok {
+			h.turnDuration.WithLabelValues(agent.Name).Observe(time.Since(start).Seconds())
+			delete(h.callStarts, ctx)
+		}
+		h.mu.Unlock()
+	}
+
+	if response.Usage != nil {
+		h.tokens.WithLabelValues(agent.Name, "input").Add(float64(response.Usage.InputTokens))
+		h.tokens.WithLabelValues(agent.Name, "output").Add(float64(response.Usage.OutputTokens))
+	}
+	return nil
+}
+
+// removeEndedCallsLocked forgets the model calls which failed, and therefore
+// never reached OnLLMEnd: the runner cancels their contexts when the run ends.
+func (h *prometheusHooks) removeEndedCallsLocked() {
+	for ctx := range h.callStarts {
+		if ctx.Err() != nil {
+			delete(h.callStarts, ctx)
+		}
+	}
+}
+
+func (h *prometheusHooks) OnToolStart(_ context.Context, agent *agents.Agent, tool agents.Tool) error {
+	h.toolInvocations.WithLabelValues(agent.Name, tool.ToolName()).Inc()
+	return nil
+}
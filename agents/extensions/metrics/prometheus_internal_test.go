@@ -0,0 +1,47 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusHooksForgetFailedModelCalls(t *testing.T) {
+	hooks := NewPrometheusHooks(prometheus.NewRegistry()).(*prometheusHooks)
+	runner := agents.Runner{Config: agents.RunConfig{Hooks: hooks}}
+
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Error: errors.New("boom"),
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	_, err := runner.Run(t.Context(), agent, "user_message")
+	require.Error(t, err)
+	assert.Empty(t, hooks.callStarts)
+
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	_, err = runner.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Empty(t, hooks.callStarts)
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agents/extensions/metrics"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatherValues returns the value of each counter, and the sample count of
+// each histogram, keyed by metric name and label values.
+func gatherValues(t *testing.T, reg *prometheus.Registry) map[string]float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			key := []string{family.GetName()}
+			for _, label := range m.GetLabel() {
+				key = append(key, label.GetValue())
+			}
+			if m.GetHistogram() != nil {
+				values[strings.Join(key, "/")] = float64(m.GetHistogram().GetSampleCount())
+			} else {
+				values[strings.Join(key, "/")] = m.GetCounter().GetValue()
+			}
+		}
+	}
+	return values
+}
+
+func TestPrometheusHooks(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	hooks := metrics.NewPrometheusHooks(reg)
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.SetHardcodedUsage(usage.Usage{InputTokens: 10, OutputTokens: 3})
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("foo", `{"a": "b"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("foo", "tool_result"))
+
+	runner := agents.Runner{Config: agents.RunConfig{Hooks: hooks}}
+	_, err := runner.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]float64{
+		"agents_runs_started_total/test":         1,
+		"agents_runs_finished_total/test":        1,
+		"agents_turn_duration_seconds/test":      2,
+		"agents_tool_invocations_total/test/foo": 1,
+		"agents_tokens_total/test/input":         20,
+		"agents_tokens_total/test/output":        6,
+	}, gatherValues(t, reg))
+
+	problems, err := testutil.GatherAndLint(reg)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestPrometheusHooksCountsErroredRuns(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	hooks := metrics.NewPrometheusHooks(reg)
+
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Error: errors.New("boom"),
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	runner := agents.Runner{Config: agents.RunConfig{Hooks: hooks}}
+	_, err := runner.Run(t.Context(), agent, "user_message")
+	require.Error(t, err)
+
+	values := gatherValues(t, reg)
+	assert.Equal(t, 1.0, values["agents_runs_errored_total/test"])
+	assert.NotContains(t, values, "agents_runs_finished_total/test")
+}
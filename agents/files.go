@@ -0,0 +1,64 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// UploadFile uploads the content of r to the OpenAI Files API with the given
+// file name and purpose (e.g. "user_data" or "assistants"), and returns the
+// ID of the new file.
+//
+// The ID can be referred to by input files, or added to a vector store used
+// by a FileSearchTool.
+func UploadFile(ctx context.Context, client OpenaiClient, name string, r io.Reader, purpose string) (fileID string, err error) {
+	if name == "" {
+		return "", NewUserError("a file name is required to upload a file")
+	}
+	if purpose == "" {
+		return "", NewUserError("a purpose is required to upload a file")
+	}
+
+	file, err := client.Files.New(ctx, openai.FileNewParams{
+		File:    openai.File(r, name, ""),
+		Purpose: openai.FilePurpose(purpose),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file %q: %w", name, err)
+	}
+	return file.ID, nil
+}
+
+// DeleteFile deletes a file previously uploaded to the OpenAI Files API,
+// for example with UploadFile.
+func DeleteFile(ctx context.Context, client OpenaiClient, fileID string) error {
+	if fileID == "" {
+		return NewUserError("a file ID is required to delete a file")
+	}
+
+	deleted, err := client.Files.Delete(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("failed to delete file %q: %w", fileID, err)
+	}
+	if !deleted.Deleted {
+		return fmt.Errorf("file %q was not deleted", fileID)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadAndDeleteFile(t *testing.T) {
+	var (
+		uploadedName    string
+		uploadedContent string
+		uploadedPurpose string
+		deletedPath     string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/files":
+			f, header, err := r.FormFile("file")
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			b, _ := io.ReadAll(f)
+			uploadedName = header.Filename
+			uploadedContent = string(b)
+			uploadedPurpose = r.FormValue("purpose")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":         "file-abc123",
+				"object":     "file",
+				"bytes":      len(b),
+				"created_at": 1,
+				"filename":   header.Filename,
+				"purpose":    uploadedPurpose,
+				"status":     "processed",
+			})
+		case r.Method == http.MethodDelete:
+			deletedPath = r.URL.Path
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":      "file-abc123",
+				"object":  "file",
+				"deleted": true,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := agents.NewOpenaiClient(param.NewOpt(server.URL), param.NewOpt("fake-key"))
+
+	fileID, err := agents.UploadFile(t.Context(), client, "doc.pdf", strings.NewReader("%PDF-1.4"), "user_data")
+	require.NoError(t, err)
+	assert.Equal(t, "file-abc123", fileID)
+	assert.Equal(t, "doc.pdf", uploadedName)
+	assert.Equal(t, "%PDF-1.4", uploadedContent)
+	assert.Equal(t, "user_data", uploadedPurpose)
+
+	err = agents.DeleteFile(t.Context(), client, fileID)
+	require.NoError(t, err)
+	assert.Equal(t, "/files/file-abc123", deletedPath)
+}
+
+func TestUploadFileErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"invalid purpose"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := agents.NewOpenaiClient(param.NewOpt(server.URL), param.NewOpt("fake-key"))
+
+	t.Run("missing name", func(t *testing.T) {
+		_, err := agents.UploadFile(t.Context(), client, "", strings.NewReader("x"), "user_data")
+		assert.ErrorAs(t, err, &agents.UserError{})
+	})
+
+	t.Run("missing purpose", func(t *testing.T) {
+		_, err := agents.UploadFile(t.Context(), client, "doc.pdf", strings.NewReader("x"), "")
+		assert.ErrorAs(t, err, &agents.UserError{})
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		_, err := agents.UploadFile(t.Context(), client, "doc.pdf", strings.NewReader("x"), "bogus")
+		assert.ErrorContains(t, err, `failed to upload file "doc.pdf"`)
+	})
+
+	t.Run("missing file ID", func(t *testing.T) {
+		err := agents.DeleteFile(t.Context(), client, "")
+		assert.ErrorAs(t, err, &agents.UserError{})
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func finalAnswerExtractor(text string) (string, bool) {
+	_, answer, found := strings.Cut(text, "Final Answer:")
+	if !found {
+		return "", false
+	}
+	return strings.TrimSpace(answer), true
+}
+
+func TestFinalOutputExtractor(t *testing.T) {
+	t.Run("marker found", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("Let me think about it.\nFinal Answer: 42"),
+			},
+		})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithFinalOutputExtractor(finalAnswerExtractor)
+
+		result, err := agents.Run(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "42", result.FinalOutput)
+	})
+
+	t.Run("marker not found", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("just 42")},
+		})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithFinalOutputExtractor(finalAnswerExtractor)
+
+		result, err := agents.Run(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "just 42", result.FinalOutput)
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("Let me think about it.\nFinal Answer: 42"),
+			},
+		})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithFinalOutputExtractor(finalAnswerExtractor)
+
+		result, err := agents.RunStreamed(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+		require.NoError(t, err)
+		assert.Equal(t, "42", result.FinalOutput())
+	})
+
+	t.Run("ignored for structured outputs", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetFinalOutputMessage(`{"bar": "baz"}`)},
+		})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithOutputType(agents.OutputType[AgentRunnerTestFoo]()).
+			WithFinalOutputExtractor(func(string) (string, bool) {
+				t.Error("unexpected call")
+				return "", false
+			})
+
+		result, err := agents.Run(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, AgentRunnerTestFoo{Bar: "baz"}, result.FinalOutput)
+	})
+}
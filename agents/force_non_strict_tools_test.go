@@ -0,0 +1,86 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strictFunctionTool(name string) agents.FunctionTool {
+	tool := agentstesting.GetFunctionTool(name, "result")
+	tool.StrictJSONSchema = param.NewOpt(true)
+	return tool
+}
+
+func lastTurnStrictness(t *testing.T, model *agentstesting.FakeModel) []param.Opt[bool] {
+	t.Helper()
+	var result []param.Opt[bool]
+	for _, tool := range model.LastTurnArgs.Tools {
+		functionTool, ok := tool.(agents.FunctionTool)
+		require.True(t, ok)
+		result = append(result, functionTool.StrictJSONSchema)
+	}
+	return result
+}
+
+func TestForceNonStrictTools(t *testing.T) {
+	secondModel := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	secondAgent := agents.New("second").
+		WithModelInstance(secondModel).
+		WithTools(strictFunctionTool("bar"))
+
+	firstModel := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(secondAgent, "", "")},
+	})
+	firstAgent := agents.New("first").
+		WithModelInstance(firstModel).
+		WithTools(strictFunctionTool("foo")).
+		WithAgentHandoffs(secondAgent).
+		WithForceNonStrictTools(true)
+
+	result, err := agents.Run(t.Context(), firstAgent, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	// Only the tools of the first agent become non-strict.
+	assert.Equal(t, []param.Opt[bool]{param.NewOpt(false)}, lastTurnStrictness(t, firstModel))
+	assert.Equal(t, []param.Opt[bool]{param.NewOpt(true)}, lastTurnStrictness(t, secondModel))
+
+	// The tool of the agent itself is not modified.
+	assert.Equal(t, param.NewOpt(true), firstAgent.Tools[0].(agents.FunctionTool).StrictJSONSchema)
+}
+
+func TestRunConfigForceNonStrictTools(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(strictFunctionTool("foo"), strictFunctionTool("bar"))
+
+	runner := agents.Runner{Config: agents.RunConfig{ForceNonStrictTools: true}}
+	_, err := runner.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+
+	assert.Equal(t, []param.Opt[bool]{param.NewOpt(false), param.NewOpt(false)}, lastTurnStrictness(t, model))
+}
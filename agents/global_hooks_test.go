@@ -16,6 +16,8 @@ package agents_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
@@ -494,3 +496,84 @@ func TestStructuredOutputStreamedRunHooks(t *testing.T) {
 		"OnAgentEnd":   1, // Should always have one end
 	}, hooks.Events)
 }
+
+type runLifecycleHooksForTests struct {
+	agents.NoOpRunHooks
+	events    []string
+	lastAgent *agents.Agent
+	runErr    error
+}
+
+func (h *runLifecycleHooksForTests) OnLLMStart(context.Context, *agents.Agent, param.Opt[string], []agents.TResponseInputItem) error {
+	h.events = append(h.events, "OnLLMStart")
+	return nil
+}
+
+func (h *runLifecycleHooksForTests) OnLLMEnd(context.Context, *agents.Agent, agents.ModelResponse) error {
+	h.events = append(h.events, "OnLLMEnd")
+	return nil
+}
+
+func (h *runLifecycleHooksForTests) OnRunStart(context.Context, *agents.Agent) error {
+	h.events = append(h.events, "OnRunStart")
+	return nil
+}
+
+func (h *runLifecycleHooksForTests) OnRunEnd(_ context.Context, lastAgent *agents.Agent, err error) error {
+	h.events = append(h.events, "OnRunEnd")
+	h.lastAgent = lastAgent
+	h.runErr = err
+	return nil
+}
+
+func TestRunLifecycleHooks(t *testing.T) {
+	for _, streamed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streamed %v", streamed), func(t *testing.T) {
+			model := agentstesting.NewFakeModel(false, nil)
+			agent2 := agents.New("agent_2").WithModelInstance(model)
+			agent1 := agents.New("agent_1").
+				WithModelInstance(model).
+				WithAgentHandoffs(agent2)
+			model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+				{Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(agent2, "", "")}},
+				{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+			})
+
+			hooks := &runLifecycleHooksForTests{}
+			runner := agents.Runner{Config: agents.RunConfig{Hooks: hooks}}
+			if streamed {
+				result, err := runner.RunStreamed(t.Context(), agent1, "user_message")
+				require.NoError(t, err)
+				require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+			} else {
+				_, err := runner.Run(t.Context(), agent1, "user_message")
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, []string{
+				"OnRunStart",
+				"OnLLMStart", "OnLLMEnd",
+				"OnLLMStart", "OnLLMEnd",
+				"OnRunEnd",
+			}, hooks.events)
+			assert.Same(t, agent2, hooks.lastAgent)
+			assert.NoError(t, hooks.runErr)
+		})
+	}
+}
+
+func TestRunLifecycleHooksOnError(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Error: errors.New("boom"),
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	hooks := &runLifecycleHooksForTests{}
+	runner := agents.Runner{Config: agents.RunConfig{Hooks: hooks}}
+	_, err := runner.Run(t.Context(), agent, "user_message")
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"OnRunStart", "OnLLMStart", "OnRunEnd"}, hooks.events)
+	assert.Same(t, agent, hooks.lastAgent)
+	assert.ErrorIs(t, hooks.runErr, err)
+}
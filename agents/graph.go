@@ -0,0 +1,189 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Graph is a serializable description of the graph of agents reachable from
+// a starting agent, with their handoffs and tools. See DescribeGraph.
+type Graph struct {
+	// The ID of the starting agent node.
+	Start string `json:"start"`
+
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+type GraphNodeType string
+
+const (
+	GraphNodeTypeAgent GraphNodeType = "agent"
+	GraphNodeTypeTool  GraphNodeType = "tool"
+)
+
+// GraphNode is an agent or a tool in a Graph.
+type GraphNode struct {
+	// The unique ID of the node. For agents, it is the agent name. For tools,
+	// it is the name of the agent owning the tool, followed by "/" and the
+	// tool name.
+	ID string `json:"id"`
+
+	Type GraphNodeType `json:"type"`
+
+	// The agent or tool name.
+	Label string `json:"label"`
+}
+
+type GraphEdgeType string
+
+const (
+	// GraphEdgeTypeHandoff is an edge from an agent to an agent it can hand off to.
+	GraphEdgeTypeHandoff GraphEdgeType = "handoff"
+	// GraphEdgeTypeTool is an edge from an agent to one of its tools.
+	GraphEdgeTypeTool GraphEdgeType = "tool"
+)
+
+// GraphEdge is a directed edge between two nodes of a Graph.
+type GraphEdge struct {
+	From string        `json:"from"`
+	To   string        `json:"to"`
+	Type GraphEdgeType `json:"type"`
+}
+
+// DescribeGraph walks the agents reachable from startingAgent through
+// Agent.AgentHandoffs, returning a Graph of the agents, with their tools and
+// handoffs. Agents are identified by name.
+//
+// Agent.Handoffs only refer to the target agent by name: the target appears
+// in the graph, but its own tools and handoffs are only described if it is
+// also reachable through Agent.AgentHandoffs.
+func DescribeGraph(startingAgent *Agent) Graph {
+	b := graphBuilder{
+		nodeIDs: make(map[string]struct{}),
+		walked:  make(map[string]struct{}),
+	}
+	b.walk(startingAgent)
+	b.graph.Start = startingAgent.Name
+	return b.graph
+}
+
+type graphBuilder struct {
+	graph   Graph
+	nodeIDs map[string]struct{}
+	walked  map[string]struct{} // names of the agents already walked
+}
+
+func (b *graphBuilder) addNode(node GraphNode) {
+	if _, ok := b.nodeIDs[node.ID]; ok {
+		return
+	}
+	b.nodeIDs[node.ID] = struct{}{}
+	b.graph.Nodes = append(b.graph.Nodes, node)
+}
+
+func (b *graphBuilder) walk(agent *Agent) {
+	if _, ok := b.walked[agent.Name]; ok {
+		return
+	}
+	b.walked[agent.Name] = struct{}{}
+
+	b.addNode(GraphNode{ID: agent.Name, Type: GraphNodeTypeAgent, Label: agent.Name})
+
+	for _, tool := range agent.Tools {
+		toolID := agent.Name + "/" + tool.ToolName()
+		b.addNode(GraphNode{ID: toolID, Type: GraphNodeTypeTool, Label: tool.ToolName()})
+		b.graph.Edges = append(b.graph.Edges, GraphEdge{From: agent.Name, To: toolID, Type: GraphEdgeTypeTool})
+	}
+
+	for _, handoff := range agent.Handoffs {
+		b.addNode(GraphNode{ID: handoff.AgentName, Type: GraphNodeTypeAgent, Label: handoff.AgentName})
+		b.graph.Edges = append(b.graph.Edges, GraphEdge{From: agent.Name, To: handoff.AgentName, Type: GraphEdgeTypeHandoff})
+	}
+
+	for _, handoffAgent := range agent.AgentHandoffs {
+		b.addNode(GraphNode{ID: handoffAgent.Name, Type: GraphNodeTypeAgent, Label: handoffAgent.Name})
+		b.graph.Edges = append(b.graph.Edges, GraphEdge{From: agent.Name, To: handoffAgent.Name, Type: GraphEdgeTypeHandoff})
+	}
+	for _, handoffAgent := range agent.AgentHandoffs {
+		b.walk(handoffAgent)
+	}
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Agents are drawn as
+// boxes and tools as stadiums, with handoffs as solid arrows and tool edges
+// as dotted arrows.
+func (g Graph) Mermaid() string {
+	ids := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for _, node := range g.Nodes {
+		label := strings.ReplaceAll(node.Label, `"`, "#quot;")
+		switch node.Type {
+		case GraphNodeTypeTool:
+			_, _ = fmt.Fprintf(&sb, "    %s([\"%s\"])\n", ids[node.ID], label)
+		default:
+			_, _ = fmt.Fprintf(&sb, "    %s[\"%s\"]\n", ids[node.ID], label)
+		}
+	}
+	if id, ok := ids[g.Start]; ok {
+		_, _ = fmt.Fprintf(&sb, "    style %s stroke-width:3px\n", id)
+	}
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Type == GraphEdgeTypeTool {
+			arrow = "-.->"
+		}
+		_, _ = fmt.Fprintf(&sb, "    %s %s %s\n", ids[edge.From], arrow, ids[edge.To])
+	}
+	return sb.String()
+}
+
+// DOT renders the graph in Graphviz DOT format. Agents are drawn as boxes and
+// tools as ellipses, with handoffs as solid arrows and tool edges as dotted
+// arrows.
+func (g Graph) DOT() string {
+	quote := func(s string) string {
+		return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph G {\n")
+	for _, node := range g.Nodes {
+		attrs := "shape=box"
+		switch {
+		case node.Type == GraphNodeTypeTool:
+			attrs = "shape=ellipse"
+		case node.ID == g.Start:
+			attrs = "shape=box, penwidth=3"
+		}
+		_, _ = fmt.Fprintf(&sb, "    %s [label=%s, %s];\n", quote(node.ID), quote(node.Label), attrs)
+	}
+	for _, edge := range g.Edges {
+		if edge.Type == GraphEdgeTypeTool {
+			_, _ = fmt.Fprintf(&sb, "    %s -> %s [style=dotted];\n", quote(edge.From), quote(edge.To))
+		} else {
+			_, _ = fmt.Fprintf(&sb, "    %s -> %s;\n", quote(edge.From), quote(edge.To))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
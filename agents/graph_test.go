@@ -0,0 +1,112 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGraphTestAgents() *agents.Agent {
+	billing := agents.New("Billing").WithTools(agents.FunctionTool{Name: "refund"})
+	support := agents.New("Support").WithTools(agents.FunctionTool{Name: "search"})
+	triage := agents.New("Triage").
+		WithTools(agents.FunctionTool{Name: "search"}).
+		WithAgentHandoffs(billing, support).
+		WithHandoffs(agents.Handoff{ToolName: "transfer_to_human", AgentName: "Human"})
+
+	// Cycle back to the starting agent
+	billing.WithAgentHandoffs(triage)
+	return triage
+}
+
+func TestDescribeGraph(t *testing.T) {
+	graph := agents.DescribeGraph(newGraphTestAgents())
+
+	assert.Equal(t, agents.Graph{
+		Start: "Triage",
+		Nodes: []agents.GraphNode{
+			{ID: "Triage", Type: agents.GraphNodeTypeAgent, Label: "Triage"},
+			{ID: "Triage/search", Type: agents.GraphNodeTypeTool, Label: "search"},
+			{ID: "Human", Type: agents.GraphNodeTypeAgent, Label: "Human"},
+			{ID: "Billing", Type: agents.GraphNodeTypeAgent, Label: "Billing"},
+			{ID: "Support", Type: agents.GraphNodeTypeAgent, Label: "Support"},
+			{ID: "Billing/refund", Type: agents.GraphNodeTypeTool, Label: "refund"},
+			{ID: "Support/search", Type: agents.GraphNodeTypeTool, Label: "search"},
+		},
+		Edges: []agents.GraphEdge{
+			{From: "Triage", To: "Triage/search", Type: agents.GraphEdgeTypeTool},
+			{From: "Triage", To: "Human", Type: agents.GraphEdgeTypeHandoff},
+			{From: "Triage", To: "Billing", Type: agents.GraphEdgeTypeHandoff},
+			{From: "Triage", To: "Support", Type: agents.GraphEdgeTypeHandoff},
+			{From: "Billing", To: "Billing/refund", Type: agents.GraphEdgeTypeTool},
+			{From: "Billing", To: "Triage", Type: agents.GraphEdgeTypeHandoff},
+			{From: "Support", To: "Support/search", Type: agents.GraphEdgeTypeTool},
+		},
+	}, graph)
+}
+
+func TestGraphJSON(t *testing.T) {
+	graph := agents.DescribeGraph(agents.New("A").WithTools(agents.FunctionTool{Name: "t"}))
+
+	b, err := json.Marshal(graph)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"start": "A",
+		"nodes": [
+			{"id": "A", "type": "agent", "label": "A"},
+			{"id": "A/t", "type": "tool", "label": "t"}
+		],
+		"edges": [{"from": "A", "to": "A/t", "type": "tool"}]
+	}`, string(b))
+
+	var decoded agents.Graph
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, graph, decoded)
+}
+
+func TestGraphMermaid(t *testing.T) {
+	graph := agents.DescribeGraph(agents.New("A").
+		WithTools(agents.FunctionTool{Name: "t"}).
+		WithAgentHandoffs(agents.New(`B "quoted"`)))
+
+	assert.Equal(t, "flowchart LR\n"+
+		"    n0[\"A\"]\n"+
+		"    n1([\"t\"])\n"+
+		"    n2[\"B #quot;quoted#quot;\"]\n"+
+		"    style n0 stroke-width:3px\n"+
+		"    n0 -.-> n1\n"+
+		"    n0 --> n2\n",
+		graph.Mermaid())
+}
+
+func TestGraphDOT(t *testing.T) {
+	graph := agents.DescribeGraph(agents.New("A").
+		WithTools(agents.FunctionTool{Name: "t"}).
+		WithAgentHandoffs(agents.New(`B "quoted"`)))
+
+	assert.Equal(t, "digraph G {\n"+
+		"    \"A\" [label=\"A\", shape=box, penwidth=3];\n"+
+		"    \"A/t\" [label=\"t\", shape=ellipse];\n"+
+		"    \"B \\\"quoted\\\"\" [label=\"B \\\"quoted\\\"\", shape=box];\n"+
+		"    \"A\" -> \"A/t\" [style=dotted];\n"+
+		"    \"A\" -> \"B \\\"quoted\\\"\";\n"+
+		"}\n",
+		graph.DOT())
+}
@@ -54,6 +54,11 @@ type InputGuardrailResult struct {
 
 	// The output of the guardrail function.
 	Output GuardrailFunctionOutput
+
+	// Whether the guardrail exceeded RunConfig.GuardrailTimeout. If so, Output
+	// is not provided by the guardrail function, and its TripwireTriggered
+	// value is RunConfig.GuardrailTimeoutTripwire.
+	TimedOut bool
 }
 
 // GuardrailFunctionOutput is the output of a guardrail function.
@@ -107,4 +112,9 @@ type OutputGuardrailResult struct {
 
 	// The output of the guardrail function.
 	Output GuardrailFunctionOutput
+
+	// Whether the guardrail exceeded RunConfig.GuardrailTimeout. If so, Output
+	// is not provided by the guardrail function, and its TripwireTriggered
+	// value is RunConfig.GuardrailTimeoutTripwire.
+	TimedOut bool
 }
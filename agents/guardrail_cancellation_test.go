@@ -0,0 +1,144 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingModel is a FakeModel which only returns once its context is done,
+// closing the given channel at that point.
+type blockingModel struct {
+	*agentstesting.FakeModel
+	canceled chan<- struct{}
+}
+
+func (m blockingModel) GetResponse(ctx context.Context, _ agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	<-ctx.Done()
+	close(m.canceled)
+	return nil, ctx.Err()
+}
+
+func (m blockingModel) StreamResponse(ctx context.Context, _ agents.ModelResponseParams, _ agents.ModelStreamResponseCallback) error {
+	<-ctx.Done()
+	close(m.canceled)
+	return ctx.Err()
+}
+
+func tripwireInputGuardrail() agents.InputGuardrail {
+	return agents.InputGuardrail{
+		Name: "tripwire",
+		GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+			return agents.GuardrailFunctionOutput{TripwireTriggered: true}, nil
+		},
+	}
+}
+
+// runWithDeadline runs fn, failing the test if it does not return promptly.
+func runWithDeadline(t *testing.T, fn func() error) error {
+	t.Helper()
+	errCh := make(chan error, 1)
+	go func() { errCh <- fn() }()
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("run was not canceled promptly")
+		return nil
+	}
+}
+
+func TestInputGuardrailsCanceledOnModelError(t *testing.T) {
+	modelError := errors.New("model error")
+
+	t.Run("non streamed", func(t *testing.T) {
+		guardrailCanceled := make(chan struct{})
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{Error: modelError})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithInputGuardrails([]agents.InputGuardrail{slowInputGuardrail("slow", guardrailCanceled)})
+
+		err := runWithDeadline(t, func() error {
+			_, err := agents.Run(t.Context(), agent, "user_message")
+			return err
+		})
+		assert.ErrorIs(t, err, modelError)
+		assert.NotErrorIs(t, err, context.Canceled)
+		<-guardrailCanceled
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		guardrailCanceled := make(chan struct{})
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{Error: modelError})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithInputGuardrails([]agents.InputGuardrail{slowInputGuardrail("slow", guardrailCanceled)})
+
+		err := runWithDeadline(t, func() error {
+			result, err := agents.RunStreamed(t.Context(), agent, "user_message")
+			if err != nil {
+				return err
+			}
+			return result.StreamEvents(func(agents.StreamEvent) error { return nil })
+		})
+		assert.ErrorIs(t, err, modelError)
+		assert.NotErrorIs(t, err, context.Canceled)
+		<-guardrailCanceled
+	})
+}
+
+func TestModelCallCanceledOnInputGuardrailTripwire(t *testing.T) {
+	t.Run("non streamed", func(t *testing.T) {
+		modelCanceled := make(chan struct{})
+		model := blockingModel{FakeModel: agentstesting.NewFakeModel(false, nil), canceled: modelCanceled}
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithInputGuardrails([]agents.InputGuardrail{tripwireInputGuardrail()})
+
+		err := runWithDeadline(t, func() error {
+			_, err := agents.Run(t.Context(), agent, "user_message")
+			return err
+		})
+		var tripwireErr agents.InputGuardrailTripwireTriggeredError
+		assert.ErrorAs(t, err, &tripwireErr)
+		<-modelCanceled
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		modelCanceled := make(chan struct{})
+		model := blockingModel{FakeModel: agentstesting.NewFakeModel(false, nil), canceled: modelCanceled}
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithInputGuardrails([]agents.InputGuardrail{tripwireInputGuardrail()})
+
+		err := runWithDeadline(t, func() error {
+			result, err := agents.RunStreamed(t.Context(), agent, "user_message")
+			if err != nil {
+				return err
+			}
+			return result.StreamEvents(func(agents.StreamEvent) error { return nil })
+		})
+		var tripwireErr agents.InputGuardrailTripwireTriggeredError
+		assert.ErrorAs(t, err, &tripwireErr)
+		<-modelCanceled
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"reflect"
+)
+
+type depsKey[T any] struct{}
+
+// ContextWithDeps returns a copy of ctx carrying a run-scoped dependency of
+// type T, such as a moderation client, to be used by guardrails created with
+// NewInputGuardrailWithDeps or NewOutputGuardrailWithDeps.
+//
+// Dependencies are identified by their type, so a context can carry at most
+// one dependency of each type. They are also available to tools, through
+// ToolContextValue.
+func ContextWithDeps[T any](ctx context.Context, deps T) context.Context {
+	return context.WithValue(ctx, depsKey[T]{}, deps)
+}
+
+// DepsFromContext returns the dependency of type T carried by ctx, if any.
+func DepsFromContext[T any](ctx context.Context) (T, bool) {
+	deps, ok := ctx.Value(depsKey[T]{}).(T)
+	return deps, ok
+}
+
+// InputGuardrailFunctionWithDeps is like InputGuardrailFunction, but it also
+// receives a dependency of type T.
+type InputGuardrailFunctionWithDeps[T any] = func(ctx context.Context, deps T, agent *Agent, input Input) (GuardrailFunctionOutput, error)
+
+// OutputGuardrailFunctionWithDeps is like OutputGuardrailFunction, but it also
+// receives a dependency of type T.
+type OutputGuardrailFunctionWithDeps[T any] = func(ctx context.Context, deps T, agent *Agent, agentOutput any) (GuardrailFunctionOutput, error)
+
+// NewInputGuardrailWithDeps creates an InputGuardrail whose function receives
+// the dependency of type T carried by the run context (see ContextWithDeps).
+// The guardrail fails with a UserError if the context carries no such
+// dependency.
+func NewInputGuardrailWithDeps[T any](name string, fn InputGuardrailFunctionWithDeps[T]) InputGuardrail {
+	return InputGuardrail{
+		Name: name,
+		GuardrailFunction: func(ctx context.Context, agent *Agent, input Input) (GuardrailFunctionOutput, error) {
+			deps, err := guardrailDeps[T](ctx, name)
+			if err != nil {
+				return GuardrailFunctionOutput{}, err
+			}
+			return fn(ctx, deps, agent, input)
+		},
+	}
+}
+
+// NewOutputGuardrailWithDeps creates an OutputGuardrail whose function
+// receives the dependency of type T carried by the run context (see
+// ContextWithDeps). The guardrail fails with a UserError if the context
+// carries no such dependency.
+func NewOutputGuardrailWithDeps[T any](name string, fn OutputGuardrailFunctionWithDeps[T]) OutputGuardrail {
+	return OutputGuardrail{
+		Name: name,
+		GuardrailFunction: func(ctx context.Context, agent *Agent, agentOutput any) (GuardrailFunctionOutput, error) {
+			deps, err := guardrailDeps[T](ctx, name)
+			if err != nil {
+				return GuardrailFunctionOutput{}, err
+			}
+			return fn(ctx, deps, agent, agentOutput)
+		},
+	}
+}
+
+func guardrailDeps[T any](ctx context.Context, guardrailName string) (T, error) {
+	deps, ok := DepsFromContext[T](ctx)
+	if !ok {
+		var zero T
+		return zero, UserErrorf("guardrail %q requires a dependency of type %s in the context", guardrailName, reflect.TypeFor[T]())
+	}
+	return deps, nil
+}
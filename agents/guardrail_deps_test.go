@@ -0,0 +1,129 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type guardrailDepsTestModerator interface {
+	IsFlagged(text string) bool
+}
+
+type guardrailDepsTestBlocklist map[string]bool
+
+func (b guardrailDepsTestBlocklist) IsFlagged(text string) bool { return b[text] }
+
+func TestDepsFromContext(t *testing.T) {
+	ctx := agents.ContextWithDeps(t.Context(), 42)
+	ctx = agents.ContextWithDeps(ctx, "foo")
+
+	n, ok := agents.DepsFromContext[int](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, 42, n)
+
+	s, ok := agents.DepsFromContext[string](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "foo", s)
+
+	_, ok = agents.DepsFromContext[float64](ctx)
+	assert.False(t, ok)
+}
+
+func TestInputGuardrailWithDeps(t *testing.T) {
+	var moderator guardrailDepsTestModerator = guardrailDepsTestBlocklist{"bad": true}
+
+	var receivedDeps guardrailDepsTestModerator
+	guardrail := agents.NewInputGuardrailWithDeps(
+		"moderation",
+		func(_ context.Context, deps guardrailDepsTestModerator, _ *agents.Agent, input agents.Input) (agents.GuardrailFunctionOutput, error) {
+			receivedDeps = deps
+			return agents.GuardrailFunctionOutput{
+				TripwireTriggered: deps.IsFlagged(input.(agents.InputString).String()),
+			}, nil
+		},
+	)
+	assert.Equal(t, "moderation", guardrail.Name)
+
+	model := agentstesting.NewFakeModel(false, nil)
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithInputGuardrails([]agents.InputGuardrail{guardrail})
+
+	ctx := agents.ContextWithDeps(t.Context(), moderator)
+
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	result, err := agents.Runner{}.Run(ctx, agent, "good")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Equal(t, moderator, receivedDeps)
+
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	_, err = agents.Runner{}.Run(ctx, agent, "bad")
+	assert.ErrorAs(t, err, &agents.InputGuardrailTripwireTriggeredError{})
+}
+
+func TestOutputGuardrailWithDeps(t *testing.T) {
+	var receivedDeps guardrailDepsTestModerator
+	guardrail := agents.NewOutputGuardrailWithDeps(
+		"moderation",
+		func(_ context.Context, deps guardrailDepsTestModerator, _ *agents.Agent, agentOutput any) (agents.GuardrailFunctionOutput, error) {
+			receivedDeps = deps
+			return agents.GuardrailFunctionOutput{
+				TripwireTriggered: deps.IsFlagged(agentOutput.(string)),
+			}, nil
+		},
+	)
+
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("bad")},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithOutputGuardrails([]agents.OutputGuardrail{guardrail})
+
+	moderator := guardrailDepsTestBlocklist{"bad": true}
+	ctx := agents.ContextWithDeps[guardrailDepsTestModerator](t.Context(), moderator)
+
+	_, err := agents.Runner{}.Run(ctx, agent, "user_message")
+	assert.ErrorAs(t, err, &agents.OutputGuardrailTripwireTriggeredError{})
+	assert.Equal(t, moderator, receivedDeps)
+}
+
+func TestGuardrailWithDepsMissingDeps(t *testing.T) {
+	called := false
+	guardrail := agents.NewInputGuardrailWithDeps(
+		"moderation",
+		func(context.Context, guardrailDepsTestModerator, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+			called = true
+			return agents.GuardrailFunctionOutput{}, nil
+		},
+	)
+
+	_, err := guardrail.Run(t.Context(), &agents.Agent{Name: "test"}, agents.InputString("test"))
+	assert.ErrorAs(t, err, &agents.UserError{})
+	assert.ErrorContains(t, err, "agents_test.guardrailDepsTestModerator")
+	assert.False(t, called)
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// JSONSchemaGuardrailOutputInfo is the GuardrailFunctionOutput.OutputInfo
+// of a guardrail created with NewJSONSchemaInputGuardrail.
+type JSONSchemaGuardrailOutputInfo struct {
+	// The validation failures. It is empty if the input is valid.
+	Errors []JSONSchemaValidationError
+}
+
+// JSONSchemaValidationError describes a single JSON schema validation failure.
+type JSONSchemaValidationError struct {
+	// The path of the invalid field, e.g. "(root).name".
+	Field string
+
+	// The type of the failure, e.g. "required" or "invalid_type".
+	Type string
+
+	// A human-readable description of the failure.
+	Description string
+}
+
+// NewJSONSchemaInputGuardrail creates an InputGuardrail which validates the
+// agent input against a JSON schema, triggering the tripwire if the input is
+// not valid JSON, or if it doesn't match the schema. In that case, the
+// OutputInfo is a JSONSchemaGuardrailOutputInfo describing the failures.
+//
+// For InputString, the whole string is validated. For InputItems, the text
+// of the last user message is validated.
+//
+// If the schema itself is invalid, the guardrail function returns a UserError.
+func NewJSONSchemaInputGuardrail(name string, schema map[string]any) InputGuardrail {
+	compiledSchema, schemaErr := gojsonschema.NewSchema(gojsonschema.NewGoLoader(schema))
+
+	return InputGuardrail{
+		Name: name,
+		GuardrailFunction: func(_ context.Context, _ *Agent, input Input) (GuardrailFunctionOutput, error) {
+			if schemaErr != nil {
+				return GuardrailFunctionOutput{}, UserErrorf("invalid JSON schema for guardrail %q: %w", name, schemaErr)
+			}
+
+			result, err := compiledSchema.Validate(gojsonschema.NewStringLoader(inputText(input)))
+			if err != nil {
+				return GuardrailFunctionOutput{
+					OutputInfo: JSONSchemaGuardrailOutputInfo{
+						Errors: []JSONSchemaValidationError{{
+							Field:       "(root)",
+							Type:        "invalid_json",
+							Description: fmt.Sprintf("Input is not valid JSON: %s", err),
+						}},
+					},
+					TripwireTriggered: true,
+				}, nil
+			}
+
+			var info JSONSchemaGuardrailOutputInfo
+			for _, e := range result.Errors() {
+				info.Errors = append(info.Errors, JSONSchemaValidationError{
+					Field:       e.Field(),
+					Type:        e.Type(),
+					Description: e.Description(),
+				})
+			}
+			return GuardrailFunctionOutput{
+				OutputInfo:        info,
+				TripwireTriggered: !result.Valid(),
+			}, nil
+		},
+	}
+}
+
+// inputText returns the text of a string input, or the text of the last user
+// message of a list of input items.
+func inputText(input Input) string {
+	switch v := input.(type) {
+	case InputString:
+		return v.String()
+	case InputItems:
+		for i := len(v) - 1; i >= 0; i-- {
+			message := v[i].OfMessage
+			if message == nil || message.Role != responses.EasyInputMessageRoleUser {
+				continue
+			}
+			if message.Content.OfString.Valid() {
+				return message.Content.OfString.Value
+			}
+			var sb strings.Builder
+			for _, c := range message.Content.OfInputItemContentList {
+				if c.OfInputText != nil {
+					sb.WriteString(c.OfInputText.Text)
+				}
+			}
+			return sb.String()
+		}
+		return ""
+	default:
+		// This would be an unrecoverable implementation bug, so a panic is appropriate.
+		panic(fmt.Errorf("unexpected Input type %T", v))
+	}
+}
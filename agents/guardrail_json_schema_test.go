@@ -0,0 +1,110 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchemaInputGuardrail(t *testing.T) {
+	guardrail := agents.NewJSONSchemaInputGuardrail("json_schema", map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string"},
+			"age":  map[string]any{"type": "integer"},
+		},
+		"required": []any{"name"},
+	})
+	assert.Equal(t, "json_schema", guardrail.Name)
+
+	agent := &agents.Agent{Name: "test"}
+
+	t.Run("valid input", func(t *testing.T) {
+		result, err := guardrail.Run(t.Context(), agent, agents.InputString(`{"name": "Joe", "age": 42}`))
+		require.NoError(t, err)
+		assert.False(t, result.Output.TripwireTriggered)
+		assert.Equal(t, agents.JSONSchemaGuardrailOutputInfo{}, result.Output.OutputInfo)
+	})
+
+	t.Run("valid input items", func(t *testing.T) {
+		result, err := guardrail.Run(t.Context(), agent, agents.InputItems{
+			agentstesting.GetTextInputItem("not json"),
+			agentstesting.GetTextInputItem(`{"name": "Joe"}`),
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Output.TripwireTriggered)
+	})
+
+	t.Run("schema mismatch", func(t *testing.T) {
+		result, err := guardrail.Run(t.Context(), agent, agents.InputString(`{"age": "old"}`))
+		require.NoError(t, err)
+		assert.True(t, result.Output.TripwireTriggered)
+
+		info, ok := result.Output.OutputInfo.(agents.JSONSchemaGuardrailOutputInfo)
+		require.True(t, ok)
+		require.Len(t, info.Errors, 2)
+
+		fields := []string{info.Errors[0].Field, info.Errors[1].Field}
+		assert.ElementsMatch(t, []string{"(root)", "age"}, fields)
+		types := []string{info.Errors[0].Type, info.Errors[1].Type}
+		assert.ElementsMatch(t, []string{"required", "invalid_type"}, types)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		result, err := guardrail.Run(t.Context(), agent, agents.InputString("hello"))
+		require.NoError(t, err)
+		assert.True(t, result.Output.TripwireTriggered)
+
+		info, ok := result.Output.OutputInfo.(agents.JSONSchemaGuardrailOutputInfo)
+		require.True(t, ok)
+		require.Len(t, info.Errors, 1)
+		assert.Equal(t, "invalid_json", info.Errors[0].Type)
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		g := agents.NewJSONSchemaInputGuardrail("bad", map[string]any{"type": 42})
+		_, err := g.Run(t.Context(), agent, agents.InputString(`{}`))
+		assert.ErrorAs(t, err, &agents.UserError{})
+	})
+}
+
+func TestJSONSchemaInputGuardrailTripsRun(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithInputGuardrails([]agents.InputGuardrail{
+			agents.NewJSONSchemaInputGuardrail("json_schema", map[string]any{"type": "object"}),
+		})
+
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	_, err := agents.Runner{}.Run(t.Context(), agent, "not an object")
+	var tripwireErr agents.InputGuardrailTripwireTriggeredError
+	require.ErrorAs(t, err, &tripwireErr)
+	assert.Equal(t, "json_schema", tripwireErr.GuardrailResult.Guardrail.Name)
+
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	result, err := agents.Runner{}.Run(t.Context(), agent, `{"foo": "bar"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+}
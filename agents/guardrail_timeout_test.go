@@ -0,0 +1,221 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowInputGuardrail returns a guardrail which only returns once its context
+// is done, closing the given channel at that point.
+func slowInputGuardrail(name string, canceled chan<- struct{}) agents.InputGuardrail {
+	return agents.InputGuardrail{
+		Name: name,
+		GuardrailFunction: func(ctx context.Context, _ *agents.Agent, _ agents.Input) (agents.GuardrailFunctionOutput, error) {
+			<-ctx.Done()
+			close(canceled)
+			return agents.GuardrailFunctionOutput{}, ctx.Err()
+		},
+	}
+}
+
+func newGuardrailTimeoutTestAgent() *agents.Agent {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	return agents.New("test").WithModelInstance(model)
+}
+
+func TestGuardrailTimeoutSkip(t *testing.T) {
+	slowCanceled := make(chan struct{})
+	var watcherCanceledByParent bool
+
+	agent := newGuardrailTimeoutTestAgent().WithInputGuardrails([]agents.InputGuardrail{
+		slowInputGuardrail("slow", slowCanceled),
+		{
+			Name: "fast",
+			GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+				return agents.GuardrailFunctionOutput{OutputInfo: "ok"}, nil
+			},
+		},
+		{
+			Name: "watcher",
+			GuardrailFunction: func(ctx context.Context, _ *agents.Agent, _ agents.Input) (agents.GuardrailFunctionOutput, error) {
+				<-slowCanceled
+				// Its own timeout may have expired too, but the context must
+				// not be canceled because of the slow guardrail.
+				watcherCanceledByParent = errors.Is(context.Cause(ctx), context.Canceled)
+				return agents.GuardrailFunctionOutput{}, nil
+			},
+		},
+	})
+
+	result, err := agents.Runner{Config: agents.RunConfig{
+		GuardrailTimeout: 20 * time.Millisecond,
+	}}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	require.Len(t, result.InputGuardrailResults, 3)
+
+	slowResult := result.InputGuardrailResults[0]
+	assert.Equal(t, "slow", slowResult.Guardrail.Name)
+	assert.True(t, slowResult.TimedOut)
+	assert.False(t, slowResult.Output.TripwireTriggered)
+
+	fastResult := result.InputGuardrailResults[1]
+	assert.False(t, fastResult.TimedOut)
+	assert.Equal(t, "ok", fastResult.Output.OutputInfo)
+
+	assert.False(t, watcherCanceledByParent, "sibling guardrail context must not be canceled")
+}
+
+func TestGuardrailTimeoutTripwire(t *testing.T) {
+	agent := newGuardrailTimeoutTestAgent().WithInputGuardrails([]agents.InputGuardrail{
+		slowInputGuardrail("slow", make(chan struct{})),
+	})
+
+	_, err := agents.Runner{Config: agents.RunConfig{
+		GuardrailTimeout:         20 * time.Millisecond,
+		GuardrailTimeoutTripwire: true,
+	}}.Run(t.Context(), agent, "user_message")
+
+	var tripwireErr agents.InputGuardrailTripwireTriggeredError
+	require.ErrorAs(t, err, &tripwireErr)
+	assert.Equal(t, "slow", tripwireErr.GuardrailResult.Guardrail.Name)
+	assert.True(t, tripwireErr.GuardrailResult.TimedOut)
+	assert.True(t, tripwireErr.GuardrailResult.Output.TripwireTriggered)
+}
+
+func TestGuardrailTimeoutIgnoredContext(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	agent := newGuardrailTimeoutTestAgent().WithInputGuardrails([]agents.InputGuardrail{{
+		Name: "stuck",
+		GuardrailFunction: func(context.Context, *agents.Agent, agents.Input) (agents.GuardrailFunctionOutput, error) {
+			<-release
+			return agents.GuardrailFunctionOutput{TripwireTriggered: true}, nil
+		},
+	}})
+
+	result, err := agents.Runner{Config: agents.RunConfig{
+		GuardrailTimeout: 20 * time.Millisecond,
+	}}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	require.Len(t, result.InputGuardrailResults, 1)
+	assert.True(t, result.InputGuardrailResults[0].TimedOut)
+}
+
+func TestGuardrailTimeoutHonouredContext(t *testing.T) {
+	// The guardrail returns as soon as the deadline expires, racing with the
+	// timeout detection: it must be reported as timed out every time.
+	for range 20 {
+		agent := newGuardrailTimeoutTestAgent().WithInputGuardrails([]agents.InputGuardrail{
+			slowInputGuardrail("slow", make(chan struct{})),
+		})
+
+		result, err := agents.Runner{Config: agents.RunConfig{
+			GuardrailTimeout: time.Millisecond,
+		}}.Run(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		require.Len(t, result.InputGuardrailResults, 1)
+		assert.True(t, result.InputGuardrailResults[0].TimedOut)
+	}
+}
+
+func TestOutputGuardrailTimeout(t *testing.T) {
+	slowOutputGuardrail := agents.OutputGuardrail{
+		Name: "slow",
+		GuardrailFunction: func(ctx context.Context, _ *agents.Agent, _ any) (agents.GuardrailFunctionOutput, error) {
+			<-ctx.Done()
+			return agents.GuardrailFunctionOutput{}, ctx.Err()
+		},
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		agent := newGuardrailTimeoutTestAgent().WithOutputGuardrails([]agents.OutputGuardrail{slowOutputGuardrail})
+
+		result, err := agents.Runner{Config: agents.RunConfig{
+			GuardrailTimeout: 20 * time.Millisecond,
+		}}.Run(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		require.Len(t, result.OutputGuardrailResults, 1)
+		assert.True(t, result.OutputGuardrailResults[0].TimedOut)
+		assert.Equal(t, "done", result.OutputGuardrailResults[0].AgentOutput)
+	})
+
+	t.Run("tripwire", func(t *testing.T) {
+		agent := newGuardrailTimeoutTestAgent().WithOutputGuardrails([]agents.OutputGuardrail{slowOutputGuardrail})
+
+		_, err := agents.Runner{Config: agents.RunConfig{
+			GuardrailTimeout:         20 * time.Millisecond,
+			GuardrailTimeoutTripwire: true,
+		}}.Run(t.Context(), agent, "user_message")
+
+		var tripwireErr agents.OutputGuardrailTripwireTriggeredError
+		require.ErrorAs(t, err, &tripwireErr)
+		assert.True(t, tripwireErr.GuardrailResult.TimedOut)
+	})
+}
+
+// delayedStreamingModel is a FakeModel which waits for the given delay before
+// streaming each response.
+type delayedStreamingModel struct {
+	*agentstesting.FakeModel
+	delay time.Duration
+}
+
+func (m delayedStreamingModel) StreamResponse(
+	ctx context.Context,
+	params agents.ModelResponseParams,
+	yield agents.ModelStreamResponseCallback,
+) error {
+	time.Sleep(m.delay)
+	return m.FakeModel.StreamResponse(ctx, params, yield)
+}
+
+func TestGuardrailTimeoutStreamed(t *testing.T) {
+	model := delayedStreamingModel{
+		FakeModel: agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		}),
+		delay: 100 * time.Millisecond,
+	}
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithInputGuardrails([]agents.InputGuardrail{
+			slowInputGuardrail("slow", make(chan struct{})),
+		})
+
+	result, err := agents.Runner{Config: agents.RunConfig{
+		GuardrailTimeout: 20 * time.Millisecond,
+	}}.RunStreamed(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	require.NoError(t, err)
+
+	assert.Equal(t, "done", result.FinalOutput())
+	require.Len(t, result.InputGuardrailResults(), 1)
+	assert.True(t, result.InputGuardrailResults()[0].TimedOut)
+}
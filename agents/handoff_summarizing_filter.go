@@ -0,0 +1,145 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
+)
+
+const summarizingHandoffInputFilterInstructions = "You are summarizing a conversation between a user and " +
+	"AI agents, so that another agent can continue it. Write a concise summary of the conversation below, " +
+	"keeping any facts, decisions, open questions and tool results which may be relevant later. " +
+	"Reply with the summary only."
+
+// SummarizingHandoffInputFilter returns a HandoffInputFilter which compacts
+// long conversation histories before they are passed to the next agent.
+//
+// When the pre-handoff history (HandoffInputData.InputHistory followed by
+// HandoffInputData.PreHandoffItems) has more than maxItems items, the older
+// items are replaced with a single system message summarizing them, and only
+// the most recent maxItems-1 items are preserved, so that the compacted
+// history has at most maxItems items. The preserved items never begin with a
+// tool call output separated from its call. HandoffInputData.NewItems,
+// including the handoff call and its output, are always preserved.
+//
+// Note that the summary is produced by making an extra call to the given
+// model, whose usage is added to the run usage.
+func SummarizingHandoffInputFilter(model Model, maxItems int) HandoffInputFilter {
+	return func(ctx context.Context, data HandoffInputData) (HandoffInputData, error) {
+		var historyItems []TResponseInputItem
+		if data.InputHistory != nil {
+			historyItems = ItemHelpers().InputToNewInputList(data.InputHistory)
+		}
+
+		allItems := make([]TResponseInputItem, 0, len(historyItems)+len(data.PreHandoffItems))
+		allItems = append(allItems, historyItems...)
+		for _, item := range data.PreHandoffItems {
+			allItems = append(allItems, item.ToInputItem())
+		}
+
+		if len(allItems) <= maxItems {
+			return data, nil
+		}
+
+		split := len(allItems) - max(maxItems-1, 0)
+		for split < len(allItems) && isToolCallOutputInputItem(allItems[split]) {
+			split++
+		}
+
+		summary, err := summarizeInputItems(ctx, model, allItems[:split])
+		if err != nil {
+			return HandoffInputData{}, fmt.Errorf("failed to summarize handoff input history: %w", err)
+		}
+
+		summaryItem := TResponseInputItem{
+			OfMessage: &responses.EasyInputMessageParam{
+				Content: responses.EasyInputMessageContentUnionParam{
+					OfString: param.NewOpt("Summary of the previous conversation:\n" + summary),
+				},
+				Role: responses.EasyInputMessageRoleSystem,
+				Type: responses.EasyInputMessageTypeMessage,
+			},
+		}
+
+		// Preserved items keep their original form, so that preserved
+		// pre-handoff run items are still part of the run result.
+		var (
+			newHistory         InputItems
+			newPreHandoffItems []RunItem
+		)
+		if split < len(historyItems) {
+			newHistory = append(InputItems{summaryItem}, historyItems[split:]...)
+			newPreHandoffItems = data.PreHandoffItems
+		} else {
+			newHistory = InputItems{summaryItem}
+			newPreHandoffItems = data.PreHandoffItems[split-len(historyItems):]
+		}
+
+		return HandoffInputData{
+			InputHistory:    newHistory,
+			PreHandoffItems: newPreHandoffItems,
+			NewItems:        data.NewItems,
+		}, nil
+	}
+}
+
+func summarizeInputItems(ctx context.Context, model Model, items []TResponseInputItem) (string, error) {
+	var sb strings.Builder
+	for _, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return "", fmt.Errorf("failed to JSON-marshal input item: %w", err)
+		}
+		sb.Write(b)
+		sb.WriteByte('\n')
+	}
+
+	response, err := model.GetResponse(ctx, ModelResponseParams{
+		SystemInstructions: param.NewOpt(summarizingHandoffInputFilterInstructions),
+		Input:              InputString(sb.String()),
+		Tracing:            ModelTracingEnabledWithoutData,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if contextUsage, ok := usage.FromContext(ctx); ok && response.Usage != nil {
+		contextUsage.AddForModel(modelInstanceName(model), response.Usage)
+	}
+
+	var summary strings.Builder
+	for _, item := range response.Output {
+		if text, ok := ItemHelpers().ExtractLastText(item); ok {
+			summary.WriteString(text)
+		}
+	}
+	if summary.Len() == 0 {
+		return "", NewModelBehaviorError("summarizer model returned no text")
+	}
+	return summary.String(), nil
+}
+
+func isToolCallOutputInputItem(item TResponseInputItem) bool {
+	return !param.IsOmitted(item.OfFunctionCallOutput) ||
+		!param.IsOmitted(item.OfComputerCallOutput) ||
+		!param.IsOmitted(item.OfLocalShellCallOutput)
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/openai/openai-go/v3/shared/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSummarizerModel(summary string) *agentstesting.FakeModel {
+	return agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage(summary)},
+	})
+}
+
+func getSummaryInputItem(summary string) agents.TResponseInputItem {
+	return agents.TResponseInputItem{
+		OfMessage: &responses.EasyInputMessageParam{
+			Content: responses.EasyInputMessageContentUnionParam{
+				OfString: param.NewOpt("Summary of the previous conversation:\n" + summary),
+			},
+			Role: responses.EasyInputMessageRoleSystem,
+			Type: responses.EasyInputMessageTypeMessage,
+		},
+	}
+}
+
+func getMessageOutputRunItem(agent *agents.Agent, content string) agents.MessageOutputItem {
+	return agents.MessageOutputItem{
+		Agent: agent,
+		RawItem: responses.ResponseOutputMessage{
+			ID: "1",
+			Content: []responses.ResponseOutputMessageContentUnion{{
+				Text: content,
+				Type: "output_text",
+			}},
+			Role:   constant.ValueOf[constant.Assistant](),
+			Status: responses.ResponseOutputMessageStatusCompleted,
+			Type:   constant.ValueOf[constant.Message](),
+		},
+		Type: "message_output_item",
+	}
+}
+
+func TestSummarizingHandoffInputFilter(t *testing.T) {
+	agent := agents.New("test")
+	newItems := []agents.RunItem{
+		agents.HandoffCallItem{Agent: agent, Type: "handoff_call_item"},
+		agents.HandoffOutputItem{Agent: agent, Type: "handoff_output_item"},
+	}
+
+	t.Run("short history is unchanged", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, nil)
+		filter := agents.SummarizingHandoffInputFilter(model, 3)
+
+		data := agents.HandoffInputData{
+			InputHistory:    agents.InputString("hello"),
+			PreHandoffItems: []agents.RunItem{getMessageOutputRunItem(agent, "hi")},
+			NewItems:        newItems,
+		}
+		filtered, err := filter(t.Context(), data)
+		require.NoError(t, err)
+		assert.Equal(t, data, filtered)
+		assert.Nil(t, model.LastTurnArgs.Input, "summarizer must not be called")
+	})
+
+	t.Run("older history items are summarized", func(t *testing.T) {
+		model := newSummarizerModel("the summary")
+		filter := agents.SummarizingHandoffInputFilter(model, 3)
+
+		filtered, err := filter(t.Context(), agents.HandoffInputData{
+			InputHistory: agents.InputItems{
+				agentstesting.GetTextInputItem("a"),
+				agentstesting.GetTextInputItem("b"),
+				agentstesting.GetTextInputItem("c"),
+				agentstesting.GetTextInputItem("d"),
+			},
+			PreHandoffItems: []agents.RunItem{getMessageOutputRunItem(agent, "e")},
+			NewItems:        newItems,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, agents.InputItems{
+			getSummaryInputItem("the summary"),
+			agentstesting.GetTextInputItem("d"),
+		}, filtered.InputHistory)
+		assert.Equal(t, []agents.RunItem{getMessageOutputRunItem(agent, "e")}, filtered.PreHandoffItems)
+		assert.Equal(t, newItems, filtered.NewItems)
+
+		summarizerInput, ok := model.LastTurnArgs.Input.(agents.InputString)
+		require.True(t, ok)
+		assert.Contains(t, summarizerInput.String(), `"a"`)
+		assert.Contains(t, summarizerInput.String(), `"c"`)
+		assert.NotContains(t, summarizerInput.String(), `"d"`)
+		assert.True(t, model.LastTurnArgs.SystemInstructions.Valid())
+	})
+
+	t.Run("older pre-handoff items are summarized", func(t *testing.T) {
+		model := newSummarizerModel("the summary")
+		filter := agents.SummarizingHandoffInputFilter(model, 3)
+
+		filtered, err := filter(t.Context(), agents.HandoffInputData{
+			InputHistory: agents.InputString("a"),
+			PreHandoffItems: []agents.RunItem{
+				getMessageOutputRunItem(agent, "b"),
+				getMessageOutputRunItem(agent, "c"),
+				getMessageOutputRunItem(agent, "d"),
+			},
+			NewItems: newItems,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, agents.InputItems{getSummaryInputItem("the summary")}, filtered.InputHistory)
+		assert.Equal(t, []agents.RunItem{
+			getMessageOutputRunItem(agent, "c"),
+			getMessageOutputRunItem(agent, "d"),
+		}, filtered.PreHandoffItems)
+		assert.Equal(t, newItems, filtered.NewItems)
+	})
+
+	t.Run("tool call output is not separated from its call", func(t *testing.T) {
+		model := newSummarizerModel("the summary")
+		filter := agents.SummarizingHandoffInputFilter(model, 3)
+
+		functionCall := agents.TResponseInputItem{
+			OfFunctionCall: &responses.ResponseFunctionToolCallParam{
+				Arguments: "{}",
+				CallID:    "call_1",
+				Name:      "foo",
+				Type:      constant.ValueOf[constant.FunctionCall](),
+			},
+		}
+		functionCallOutput := agents.TResponseInputItem{
+			OfFunctionCallOutput: &responses.ResponseInputItemFunctionCallOutputParam{
+				CallID: "call_1",
+				Output: responses.ResponseInputItemFunctionCallOutputOutputUnionParam{
+					OfString: param.NewOpt("bar"),
+				},
+				Type: constant.ValueOf[constant.FunctionCallOutput](),
+			},
+		}
+
+		filtered, err := filter(t.Context(), agents.HandoffInputData{
+			InputHistory: agents.InputItems{
+				agentstesting.GetTextInputItem("a"),
+				functionCall,
+				functionCallOutput,
+				agentstesting.GetTextInputItem("b"),
+			},
+			NewItems: newItems,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, agents.InputItems{
+			getSummaryInputItem("the summary"),
+			agentstesting.GetTextInputItem("b"),
+		}, filtered.InputHistory)
+	})
+
+	t.Run("summarizer usage is accounted to its model", func(t *testing.T) {
+		model := newSummarizerModel("the summary")
+		model.SetHardcodedUsage(usage.Usage{Requests: 1, InputTokens: 10, OutputTokens: 5, TotalTokens: 15})
+		filter := agents.SummarizingHandoffInputFilter(model, 1)
+
+		runUsage := usage.NewUsage()
+		ctx := usage.NewContext(t.Context(), runUsage)
+		_, err := filter(ctx, agents.HandoffInputData{
+			InputHistory:    agents.InputString("a"),
+			PreHandoffItems: []agents.RunItem{getMessageOutputRunItem(agent, "b")},
+			NewItems:        newItems,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(15), runUsage.TotalTokens)
+		require.Contains(t, runUsage.PerModel, "*agentstesting.FakeModel")
+		modelUsage := runUsage.PerModel["*agentstesting.FakeModel"]
+		assert.Equal(t, uint64(1), modelUsage.Requests)
+		assert.Equal(t, uint64(10), modelUsage.InputTokens)
+		assert.Equal(t, uint64(5), modelUsage.OutputTokens)
+	})
+
+	t.Run("summarizer error", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("foo", "{}")},
+		})
+		filter := agents.SummarizingHandoffInputFilter(model, 1)
+
+		_, err := filter(t.Context(), agents.HandoffInputData{
+			InputHistory: agents.InputItems{
+				agentstesting.GetTextInputItem("a"),
+				agentstesting.GetTextInputItem("b"),
+			},
+		})
+		assert.ErrorAs(t, err, &agents.ModelBehaviorError{})
+	})
+}
+
+func TestSummarizingHandoffInputFilterInRun(t *testing.T) {
+	summarizerModel := newSummarizerModel("the summary")
+	sourceModel := agentstesting.NewFakeModel(false, nil)
+	targetModel := agentstesting.NewFakeModel(false, nil)
+
+	agent1 := agents.New("agent_1").WithModelInstance(targetModel)
+	agent2 := agents.New("agent_2").WithModelInstance(sourceModel).WithHandoffs(
+		agents.HandoffFromAgent(agents.HandoffFromAgentParams{
+			Agent:       agent1,
+			InputFilter: agents.SummarizingHandoffInputFilter(summarizerModel, 2),
+		}),
+	)
+
+	sourceModel.SetNextOutput(agentstesting.FakeModelTurnOutput{Value: []agents.TResponseOutputItem{
+		agentstesting.GetTextMessage("1"),
+		agentstesting.GetTextMessage("2"),
+		agentstesting.GetHandoffToolCall(agent1, "", ""),
+	}})
+	targetModel.SetNextOutput(agentstesting.FakeModelTurnOutput{Value: []agents.TResponseOutputItem{
+		agentstesting.GetTextMessage("last"),
+	}})
+
+	result, err := agents.Runner{}.RunInputs(t.Context(), agent2, []agents.TResponseInputItem{
+		agentstesting.GetTextInputItem("a"),
+		agentstesting.GetTextInputItem("b"),
+		agentstesting.GetTextInputItem("c"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "last", result.FinalOutput)
+
+	targetInput, ok := targetModel.LastTurnArgs.Input.(agents.InputItems)
+	require.True(t, ok)
+	require.Len(t, targetInput, 6,
+		"should have summary, last history item, and new items: 2 messages, handoff call and output")
+	assert.Equal(t, getSummaryInputItem("the summary"), targetInput[0])
+	assert.Equal(t, agentstesting.GetTextInputItem("c"), targetInput[1])
+	assert.NotNil(t, targetInput[4].OfFunctionCall)
+	assert.NotNil(t, targetInput[5].OfFunctionCallOutput)
+}
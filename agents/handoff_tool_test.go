@@ -223,6 +223,83 @@ func TestOnHandoffWithoutInputError(t *testing.T) {
 	assert.ErrorIs(t, err, handoffErr)
 }
 
+func TestOnHandoffWithRawInputCalled(t *testing.T) {
+	schema, err := OutputType[HandoffToolTestFoo]().JSONSchema()
+	require.NoError(t, err)
+
+	for name, inputJSONSchema := range map[string]map[string]any{
+		"without schema": nil,
+		"with schema":    schema,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var rawInputs []string
+			onHandoff := func(_ context.Context, rawJSON string) error {
+				rawInputs = append(rawInputs, rawJSON)
+				return nil
+			}
+
+			agent := &Agent{Name: "test"}
+			obj, err := SafeHandoffFromAgent(HandoffFromAgentParams{
+				Agent:           agent,
+				OnHandoff:       OnHandoffWithRawInput(onHandoff),
+				InputJSONSchema: inputJSONSchema,
+			})
+			require.NoError(t, err)
+
+			// The raw arguments are passed as they are, without validation
+			for _, input := range []string{`{"bar": 42, "extra": [1, 2]}`, "not json", ""} {
+				invoked, err := obj.OnInvokeHandoff(t.Context(), input)
+				require.NoError(t, err)
+				assert.Same(t, agent, invoked)
+			}
+			assert.Equal(t, []string{`{"bar": 42, "extra": [1, 2]}`, "not json", ""}, rawInputs)
+		})
+	}
+}
+
+func TestOnHandoffWithRawInputSchema(t *testing.T) {
+	onHandoff := OnHandoffWithRawInput(func(context.Context, string) error { return nil })
+
+	t.Run("without schema", func(t *testing.T) {
+		obj, err := SafeHandoffFromAgent(HandoffFromAgentParams{
+			Agent:     &Agent{Name: "test"},
+			OnHandoff: onHandoff,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"type": "object", "additionalProperties": true}, obj.InputJSONSchema)
+		assert.Equal(t, param.NewOpt(false), obj.StrictJSONSchema)
+	})
+
+	t.Run("with schema", func(t *testing.T) {
+		schema, err := OutputType[HandoffToolTestFoo]().JSONSchema()
+		require.NoError(t, err)
+
+		obj, err := SafeHandoffFromAgent(HandoffFromAgentParams{
+			Agent:           &Agent{Name: "test"},
+			OnHandoff:       onHandoff,
+			InputJSONSchema: schema,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, schema, obj.InputJSONSchema)
+		assert.Equal(t, param.NewOpt(true), obj.StrictJSONSchema)
+	})
+}
+
+func TestOnHandoffWithRawInputError(t *testing.T) {
+	handoffErr := errors.New("error")
+
+	obj, err := SafeHandoffFromAgent(HandoffFromAgentParams{
+		Agent: &Agent{Name: "test"},
+		OnHandoff: OnHandoffWithRawInput(func(context.Context, string) error {
+			return handoffErr
+		}),
+	})
+	require.NoError(t, err)
+
+	_, err = obj.OnInvokeHandoff(t.Context(), `{"bar": "baz"}`)
+	assert.ErrorIs(t, err, handoffErr)
+}
+
 func TestHandoffInputSchemaIsStrict(t *testing.T) {
 	schema, err := OutputType[HandoffToolTestFoo]().JSONSchema()
 	require.NoError(t, err)
@@ -351,3 +428,37 @@ func TestHandoff_IsEnabled(t *testing.T) {
 		assert.Contains(t, agentNames, "agent_3")
 	})
 }
+
+func TestHandoffPriority(t *testing.T) {
+	low := &Agent{Name: "low"}
+	high := &Agent{Name: "high"}
+	medium := &Agent{Name: "medium"}
+	plain := &Agent{Name: "plain"}
+	negative := &Agent{Name: "negative"}
+
+	agent := &Agent{
+		Name: "triage",
+		Handoffs: []Handoff{
+			HandoffFromAgent(HandoffFromAgentParams{Agent: low, Priority: 1}),
+			HandoffFromAgent(HandoffFromAgentParams{Agent: negative, Priority: -1}),
+			HandoffFromAgent(HandoffFromAgentParams{Agent: high, Priority: 10}),
+			HandoffFromAgent(HandoffFromAgentParams{Agent: medium, Priority: 5}),
+		},
+		AgentHandoffs: []*Agent{plain},
+	}
+
+	handoffs, err := Runner{}.getHandoffs(t.Context(), agent)
+	require.NoError(t, err)
+
+	names := make([]string, len(handoffs))
+	for i, h := range handoffs {
+		names[i] = h.ToolName
+	}
+	assert.Equal(t, []string{
+		"transfer_to_high",
+		"transfer_to_medium",
+		"transfer_to_low",
+		"transfer_to_plain",
+		"transfer_to_negative",
+	}, names)
+}
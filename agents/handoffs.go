@@ -78,6 +78,19 @@ type Handoff struct {
 	// enable/disable a handoff based on your context/state.
 	// Default value, if omitted: true.
 	IsEnabled HandoffEnabler
+
+	// Optional acknowledgement message for the agent being handed off to,
+	// e.g. "You are now handling this because the user asked for a refund."
+	// If not empty, it is added as a system message to the input of the new
+	// agent, after the handoff output and after applying the InputFilter.
+	AcknowledgementMessage string
+
+	// Optional priority of the handoff, used as a lightweight hint to steer
+	// the model when more than one handoff could apply: the handoff tools are
+	// presented to the model in order of decreasing priority. Handoffs with
+	// the same priority keep their order, Handoffs before AgentHandoffs.
+	// Default: 0.
+	Priority int
 }
 
 func (h Handoff) GetTransferMessage(agent *Agent) string {
@@ -162,6 +175,17 @@ type OnHandoffWithoutInput func(context.Context) error
 
 func (OnHandoffWithoutInput) isOnHandoff() {}
 
+// OnHandoffWithRawInput is called with the raw arguments of the handoff tool
+// call, as an unparsed JSON string. It can be useful for logging, or for
+// forwarding opaque payloads.
+//
+// It does not require an InputJSONSchema. If one is provided, it is only
+// described to the model, and the arguments are not validated against it.
+// Without InputJSONSchema, the model is allowed to pass any JSON object.
+type OnHandoffWithRawInput func(ctx context.Context, rawJSON string) error
+
+func (OnHandoffWithRawInput) isOnHandoff() {}
+
 type HandoffFromAgentParams struct {
 	// The agent to hand off to.
 	Agent *Agent
@@ -176,7 +200,8 @@ type HandoffFromAgentParams struct {
 	OnHandoff OnHandoff
 
 	// Optional JSON schema describing the type of the input to the handoff.
-	// If provided, the input will be validated against this type.
+	// If provided, the input will be validated against this type, unless
+	// OnHandoff is OnHandoffWithRawInput.
 	// Only relevant if you pass a function that takes an input.
 	InputJSONSchema map[string]any
 
@@ -189,6 +214,13 @@ type HandoffFromAgentParams struct {
 	// Disabled handoffs are hidden from the LLM at runtime.
 	// Default value, if omitted: true.
 	IsEnabled HandoffEnabler
+
+	// Optional acknowledgement message added as a system message to the
+	// input of the agent being handed off to.
+	AcknowledgementMessage string
+
+	// Optional priority of the handoff. See Handoff.Priority.
+	Priority int
 }
 
 // HandoffFromAgent creates a Handoff from an Agent. It panics in case of problems.
@@ -209,8 +241,19 @@ func HandoffFromAgent(params HandoffFromAgentParams) Handoff {
 // you can use HandoffFromAgent instead (recommended for tests and examples only).
 func SafeHandoffFromAgent(params HandoffFromAgentParams) (*Handoff, error) {
 	var rawInputJSONSchema map[string]any
+	strictJSONSchema := true
 
-	if len(params.InputJSONSchema) > 0 {
+	_, isRawInput := params.OnHandoff.(OnHandoffWithRawInput)
+	if isRawInput {
+		rawInputJSONSchema = params.InputJSONSchema
+		if len(rawInputJSONSchema) == 0 {
+			rawInputJSONSchema = map[string]any{
+				"type":                 "object",
+				"additionalProperties": true,
+			}
+			strictJSONSchema = false
+		}
+	} else if len(params.InputJSONSchema) > 0 {
 		rawInputJSONSchema = params.InputJSONSchema
 		if params.OnHandoff == nil {
 			return nil, errors.New("OnHandoff must be present since InputJSONSchema is given")
@@ -239,7 +282,12 @@ func SafeHandoffFromAgent(params HandoffFromAgentParams) (*Handoff, error) {
 	}
 
 	invokeHandoff := func(ctx context.Context, jsonInput string) (*Agent, error) {
-		if len(params.InputJSONSchema) > 0 {
+		if isRawInput {
+			rawInputFunc := params.OnHandoff.(OnHandoffWithRawInput)
+			if err := rawInputFunc(ctx, jsonInput); err != nil {
+				return params.Agent, err
+			}
+		} else if len(params.InputJSONSchema) > 0 {
 			if jsonInput == "" {
 				AttachErrorToCurrentSpan(ctx, tracing.SpanError{
 					Message: `"Handoff function expected an input, but got empty value`,
@@ -285,13 +333,15 @@ func SafeHandoffFromAgent(params HandoffFromAgentParams) (*Handoff, error) {
 	}
 
 	return &Handoff{
-		ToolName:         toolName,
-		ToolDescription:  toolDescription,
-		InputJSONSchema:  rawInputJSONSchema,
-		OnInvokeHandoff:  invokeHandoff,
-		AgentName:        params.Agent.Name,
-		InputFilter:      params.InputFilter,
-		StrictJSONSchema: param.NewOpt(true),
-		IsEnabled:        isEnabled,
+		ToolName:               toolName,
+		ToolDescription:        toolDescription,
+		InputJSONSchema:        rawInputJSONSchema,
+		OnInvokeHandoff:        invokeHandoff,
+		AgentName:              params.Agent.Name,
+		InputFilter:            params.InputFilter,
+		StrictJSONSchema:       param.NewOpt(strictJSONSchema),
+		IsEnabled:              isEnabled,
+		AcknowledgementMessage: params.AcknowledgementMessage,
+		Priority:               params.Priority,
 	}, nil
 }
@@ -0,0 +1,77 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"encoding/base64"
+
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/openai/openai-go/v3/shared/constant"
+)
+
+// InputAudio returns an input_audio content part with base64-encoded audio
+// data, in "wav" or "mp3" format, for audio-capable models.
+//
+// Since responses.ResponseInputContentUnionParam has no audio variant, the
+// content part is an override of the union with a
+// responses.ResponseInputAudioParam value (see param.Override), which the
+// Chat Completions converter turns into an input_audio content part.
+func InputAudio(base64Data, format string) (responses.ResponseInputContentUnionParam, error) {
+	audio := responses.ResponseInputAudioParam{
+		InputAudio: responses.ResponseInputAudioInputAudioParam{
+			Data:   base64Data,
+			Format: format,
+		},
+		Type: constant.ValueOf[constant.InputAudio](),
+	}
+	if err := validateInputAudio(audio); err != nil {
+		return responses.ResponseInputContentUnionParam{}, err
+	}
+	return param.Override[responses.ResponseInputContentUnionParam](audio), nil
+}
+
+// inputAudioFromContent returns the audio of an input_audio content part
+// created with InputAudio, or with an equivalent override.
+func inputAudioFromContent(c responses.ResponseInputContentUnionParam) (responses.ResponseInputAudioParam, bool) {
+	v, ok := c.Overrides()
+	if !ok {
+		return responses.ResponseInputAudioParam{}, false
+	}
+	switch audio := v.(type) {
+	case responses.ResponseInputAudioParam:
+		return audio, true
+	case *responses.ResponseInputAudioParam:
+		if audio != nil {
+			return *audio, true
+		}
+	}
+	return responses.ResponseInputAudioParam{}, false
+}
+
+func validateInputAudio(audio responses.ResponseInputAudioParam) error {
+	switch audio.InputAudio.Format {
+	case "wav", "mp3":
+	default:
+		return UserErrorf("unsupported input audio format %q: only \"wav\" and \"mp3\" are supported", audio.InputAudio.Format)
+	}
+	if audio.InputAudio.Data == "" {
+		return NewUserError("input audio data is empty")
+	}
+	if _, err := base64.StdEncoding.DecodeString(audio.InputAudio.Data); err != nil {
+		return UserErrorf("input audio data is not valid base64: %v", err)
+	}
+	return nil
+}
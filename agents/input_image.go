@@ -0,0 +1,102 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"encoding/base64"
+	"net/http"
+	"os"
+
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/openai/openai-go/v3/shared/constant"
+)
+
+// MaxInputImageSize is the maximum size, in bytes, of the image data which
+// is encoded into a data URL for an input image.
+const MaxInputImageSize = 20 << 20
+
+// InputImageFromBytes returns an input_image content part whose URL is a
+// data URL of the given image data. The MIME type of the image is detected
+// from the data, which must be a PNG, JPEG, GIF or WebP image.
+// An empty detail means "auto".
+func InputImageFromBytes(data []byte, detail responses.ResponseInputImageDetail) (responses.ResponseInputContentUnionParam, error) {
+	imageURL, err := imageDataURL(data)
+	if err != nil {
+		return responses.ResponseInputContentUnionParam{}, err
+	}
+	return newInputImage(imageURL, detail), nil
+}
+
+// InputImageFromFile is like InputImageFromBytes, reading the image data
+// from a local file.
+//
+// This is the only way to send a local file as an input image: the URL of
+// an input image is always sent to the model as is, and never read from
+// the local file system.
+func InputImageFromFile(path string, detail responses.ResponseInputImageDetail) (responses.ResponseInputContentUnionParam, error) {
+	imageURL, err := localImageDataURL(path)
+	if err != nil {
+		return responses.ResponseInputContentUnionParam{}, err
+	}
+	return newInputImage(imageURL, detail), nil
+}
+
+func newInputImage(imageURL string, detail responses.ResponseInputImageDetail) responses.ResponseInputContentUnionParam {
+	if detail == "" {
+		detail = responses.ResponseInputImageDetailAuto
+	}
+	return responses.ResponseInputContentUnionParam{
+		OfInputImage: &responses.ResponseInputImageParam{
+			ImageURL: param.NewOpt(imageURL),
+			Detail:   detail,
+			Type:     constant.ValueOf[constant.InputImage](),
+		},
+	}
+}
+
+func localImageDataURL(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", UserErrorf("cannot read input image file %q: %v", path, err)
+	}
+	if info.IsDir() {
+		return "", UserErrorf("cannot read input image file %q: it is a directory", path)
+	}
+	if info.Size() > MaxInputImageSize {
+		return "", UserErrorf("input image file %q is too large: %d bytes, the maximum is %d", path, info.Size(), MaxInputImageSize)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", UserErrorf("cannot read input image file %q: %v", path, err)
+	}
+	return imageDataURL(data)
+}
+
+func imageDataURL(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", NewUserError("input image data is empty")
+	}
+	if len(data) > MaxInputImageSize {
+		return "", UserErrorf("input image is too large: %d bytes, the maximum is %d", len(data), MaxInputImageSize)
+	}
+	mimeType := http.DetectContentType(data)
+	switch mimeType {
+	case "image/png", "image/jpeg", "image/gif", "image/webp":
+	default:
+		return "", UserErrorf("unsupported input image type %q", mimeType)
+	}
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+// FilterOptions controls which items are kept by ToInputListFiltered, e.g.
+// to resume a conversation with a model of a different family, which would
+// not accept some items of the original one.
+type FilterOptions struct {
+	// Whether to drop the reasoning items, which are only understood by
+	// reasoning models through the Responses API.
+	DropReasoning bool
+
+	// Whether to drop all the tool calls, along with their outputs,
+	// including hosted tools, computer, shell and MCP items.
+	DropToolCalls bool
+
+	// Optional custom predicate, called for each item not already dropped
+	// by the other options: the item is kept only if it returns true.
+	Keep func(item TResponseInputItem) bool
+}
+
+// filterInputList returns the items kept according to the options.
+func filterInputList(items []TResponseInputItem, opts FilterOptions) []TResponseInputItem {
+	result := make([]TResponseInputItem, 0, len(items))
+	for _, item := range items {
+		if opts.DropReasoning && item.OfReasoning != nil {
+			continue
+		}
+		if opts.DropToolCalls && isToolInputItem(item) {
+			continue
+		}
+		if opts.Keep != nil && !opts.Keep(item) {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// isToolInputItem reports whether the item is a tool call, a tool call output,
+// or another item related to the use of a tool.
+func isToolInputItem(item TResponseInputItem) bool {
+	return item.OfFileSearchCall != nil ||
+		item.OfComputerCall != nil ||
+		item.OfComputerCallOutput != nil ||
+		item.OfWebSearchCall != nil ||
+		item.OfFunctionCall != nil ||
+		item.OfFunctionCallOutput != nil ||
+		item.OfImageGenerationCall != nil ||
+		item.OfCodeInterpreterCall != nil ||
+		item.OfLocalShellCall != nil ||
+		item.OfLocalShellCallOutput != nil ||
+		item.OfShellCall != nil ||
+		item.OfShellCallOutput != nil ||
+		item.OfApplyPatchCall != nil ||
+		item.OfApplyPatchCallOutput != nil ||
+		item.OfMcpListTools != nil ||
+		item.OfMcpApprovalRequest != nil ||
+		item.OfMcpApprovalResponse != nil ||
+		item.OfMcpCall != nil ||
+		item.OfCustomToolCall != nil ||
+		item.OfCustomToolCallOutput != nil
+}
@@ -46,6 +46,21 @@ type ModelResponse struct {
 	// If using OpenAI models via the Responses API, this is the `ResponseID` parameter, and it can
 	// be passed to `Runner.Run`.
 	ResponseID string
+
+	// The number of output tokens generated per second, measured over the
+	// whole model call. It is only computed for streamed turns, and it is zero
+	// if the model did not report any usage.
+	TokensPerSecond float64
+
+	// The fingerprint of the backend configuration which generated the
+	// response, which can be used together with ModelSettings.Seed to detect
+	// backend changes affecting determinism. It is only reported by the Chat
+	// Completions API.
+	SystemFingerprint string
+
+	// The index of the model which served the request, among the models of
+	// a FallbackModel: zero if the primary model served it.
+	FallbackIndex int
 }
 
 // ToInputItems converts the output into a list of input items suitable for passing to the model.
@@ -57,6 +72,56 @@ func (mr ModelResponse) ToInputItems() []TResponseInputItem {
 	return inputItems
 }
 
+// HasText reports whether any message in the output contains text content.
+// Refusals are not considered text.
+func (mr ModelResponse) HasText() bool {
+	for _, outputItem := range mr.Output {
+		if outputItem.Type != "message" {
+			continue
+		}
+		for _, content := range outputItem.Content {
+			if content.Type == "output_text" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TextContent concatenates all the text content from the messages in the output.
+func (mr ModelResponse) TextContent() string {
+	var sb strings.Builder
+	for _, outputItem := range mr.Output {
+		if outputItem.Type != "message" {
+			continue
+		}
+		for _, content := range outputItem.Content {
+			if content.Type == "output_text" {
+				sb.WriteString(content.Text)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// ToolCalls returns the function tool calls in the output, in order.
+func (mr ModelResponse) ToolCalls() []responses.ResponseFunctionToolCall {
+	var toolCalls []responses.ResponseFunctionToolCall
+	for _, outputItem := range mr.Output {
+		if outputItem.Type == "function_call" {
+			toolCalls = append(toolCalls, responses.ResponseFunctionToolCall{
+				Arguments: outputItem.Arguments,
+				CallID:    outputItem.CallID,
+				Name:      outputItem.Name,
+				Type:      constant.ValueOf[constant.FunctionCall](),
+				ID:        outputItem.ID,
+				Status:    responses.ResponseFunctionToolCallStatus(outputItem.Status),
+			})
+		}
+	}
+	return toolCalls
+}
+
 type itemHelpers struct{}
 
 func ItemHelpers() itemHelpers { return itemHelpers{} }
@@ -265,6 +265,54 @@ func TestToolCallOutputItemConstructsFunctionCallOutput(t *testing.T) {
 	assert.Equal(t, "result-string", payload.Output.OfString.Value)
 }
 
+func TestModelResponseInspectorsWithMixedOutput(t *testing.T) {
+	resp := agents.ModelResponse{
+		Output: []agents.TResponseOutputItem{
+			makeMessage(
+				responses.ResponseOutputMessageContentUnion{Text: "foo", Type: "output_text"},
+				responses.ResponseOutputMessageContentUnion{Refusal: "no", Type: "refusal"},
+			),
+			{ID: "f1", Arguments: `{"a":1}`, CallID: "c1", Name: "tool1", Type: "function_call"},
+			{ID: "rid", Type: "reasoning"},
+			makeMessage(responses.ResponseOutputMessageContentUnion{Text: "bar", Type: "output_text"}),
+			{ID: "f2", Arguments: `{}`, CallID: "c2", Name: "tool2", Type: "function_call"},
+		},
+		Usage: usage.NewUsage(),
+	}
+
+	assert.True(t, resp.HasText())
+	assert.Equal(t, "foobar", resp.TextContent())
+
+	toolCalls := resp.ToolCalls()
+	require.Len(t, toolCalls, 2)
+	assert.Equal(t, "tool1", toolCalls[0].Name)
+	assert.Equal(t, "c1", toolCalls[0].CallID)
+	assert.Equal(t, `{"a":1}`, toolCalls[0].Arguments)
+	assert.Equal(t, "tool2", toolCalls[1].Name)
+	assert.Equal(t, "c2", toolCalls[1].CallID)
+}
+
+func TestModelResponseInspectorsWithToolCallsOnly(t *testing.T) {
+	resp := agents.ModelResponse{
+		Output: []agents.TResponseOutputItem{
+			{ID: "f1", Arguments: `{}`, CallID: "c1", Name: "tool1", Type: "function_call"},
+			makeMessage(responses.ResponseOutputMessageContentUnion{Refusal: "no", Type: "refusal"}),
+		},
+		Usage: usage.NewUsage(),
+	}
+
+	assert.False(t, resp.HasText())
+	assert.Equal(t, "", resp.TextContent())
+	assert.Len(t, resp.ToolCalls(), 1)
+}
+
+func TestModelResponseInspectorsWithEmptyOutput(t *testing.T) {
+	var resp agents.ModelResponse
+	assert.False(t, resp.HasText())
+	assert.Equal(t, "", resp.TextContent())
+	assert.Empty(t, resp.ToolCalls())
+}
+
 /*
 The following tests ensure that every possible output item type defined by OpenAI's API
 can be converted back into an input item via ModelResponse.ToInputItems.
@@ -87,6 +87,25 @@ func (item HandoffOutputItem) ToInputItem() TResponseInputItem {
 	return item.RawItem
 }
 
+// HandoffNoteItem represents a note added to the input of the agent being
+// handed off to, as configured with Handoff.AcknowledgementMessage.
+type HandoffNoteItem struct {
+	// The agent being handed off to, which receives the note.
+	Agent *Agent
+
+	// The raw system message carrying the note.
+	RawItem responses.EasyInputMessageParam
+
+	// Always `handoff_note_item`.
+	Type string
+}
+
+func (HandoffNoteItem) isRunItem() {}
+
+func (item HandoffNoteItem) ToInputItem() TResponseInputItem {
+	return TResponseInputItem{OfMessage: &item.RawItem}
+}
+
 // ToolCallItem represents a tool call e.g. a function call or computer action call.
 type ToolCallItem struct {
 	// The agent whose run caused this item to be generated.
@@ -95,6 +114,10 @@ type ToolCallItem struct {
 	// The raw tool call item.
 	RawItem ToolCallItemType
 
+	// The structured representation of the action, for computer tool calls
+	// (see ResponseComputerToolCall) with a known action type. Nil otherwise.
+	ComputerCallAction ComputerCallAction
+
 	// Always `tool_call_item`.
 	Type string
 }
@@ -188,6 +211,10 @@ type ToolCallOutputItem struct {
 	// contains a string representation of the output.
 	Output any
 
+	// Set when RunConfig.ToolOutputSanitizer flagged the output. In this case, the `raw_item`
+	// contains the sanitized output.
+	Sanitization *ToolOutputSanitizerResult
+
 	// Always `tool_call_output_item`.
 	Type string
 }
@@ -69,6 +69,18 @@ func (NoOpRunHooks) OnToolEnd(context.Context, *Agent, Tool, any) error {
 	return nil
 }
 
+// RunLifecycleHooks can be optionally implemented by RunHooks to also receive
+// callbacks when a whole run starts and ends.
+type RunLifecycleHooks interface {
+	// OnRunStart is called once at the beginning of a run, before any other hook.
+	OnRunStart(ctx context.Context, startingAgent *Agent) error
+
+	// OnRunEnd is called once when a run ends, with the last agent which was
+	// running and the error which terminated the run, if any.
+	// A run which is interrupted for tool approval is considered ended without error.
+	OnRunEnd(ctx context.Context, lastAgent *Agent, err error) error
+}
+
 // AgentHooks is implemented by an object that receives callbacks on various
 // lifecycle events for a specific agent.
 // You can set this on `Agent.Hooks` to receive events for that specific agent.
@@ -79,7 +91,8 @@ type AgentHooks interface {
 	// OnEnd is called when the agent produces a final output.
 	OnEnd(ctx context.Context, agent *Agent, output any) error
 
-	// OnHandoff is called when the agent is being handed off to.
+	// OnHandoff is called when the agent is being handed off to, before it
+	// takes control of the run, e.g. to initialize per-conversation state.
 	// The `source` is the agent that is handing off to this agent.
 	OnHandoff(ctx context.Context, agent, source *Agent) error
 
@@ -95,3 +108,29 @@ type AgentHooks interface {
 	// OnLLMEnd is called immediately after the agent receives the LLM response.
 	OnLLMEnd(ctx context.Context, agent *Agent, response ModelResponse) error
 }
+
+// NoOpAgentHooks implements AgentHooks doing nothing. It can be embedded to
+// only implement the callbacks of interest.
+type NoOpAgentHooks struct{}
+
+func (NoOpAgentHooks) OnStart(context.Context, *Agent) error {
+	return nil
+}
+func (NoOpAgentHooks) OnEnd(context.Context, *Agent, any) error {
+	return nil
+}
+func (NoOpAgentHooks) OnHandoff(context.Context, *Agent, *Agent) error {
+	return nil
+}
+func (NoOpAgentHooks) OnToolStart(context.Context, *Agent, Tool, any) error {
+	return nil
+}
+func (NoOpAgentHooks) OnToolEnd(context.Context, *Agent, Tool, any) error {
+	return nil
+}
+func (NoOpAgentHooks) OnLLMStart(context.Context, *Agent, param.Opt[string], []TResponseInputItem) error {
+	return nil
+}
+func (NoOpAgentHooks) OnLLMEnd(context.Context, *Agent, ModelResponse) error {
+	return nil
+}
@@ -15,9 +15,11 @@
 package agents
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"sync/atomic"
+	"time"
 )
 
 var agentsLogger atomic.Pointer[slog.Logger]
@@ -52,3 +54,83 @@ func EnableVerboseStdoutLogging() {
 	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
 	agentsLogger.Store(slog.New(slog.NewTextHandler(os.Stderr, opts)))
 }
+
+// Attribute keys shared by the structured debug logs of the agent loop, so
+// that records about the same run, agent, turn or tool can be correlated.
+const (
+	logKeyAgent         = "agent"
+	logKeyTurn          = "turn"
+	logKeyTool          = "tool"
+	logKeyDurationMs    = "durationMs"
+	logKeyCorrelationID = "correlationId"
+)
+
+type (
+	logTurnContextKey          struct{}
+	logCorrelationIDContextKey struct{}
+)
+
+// contextWithLogCorrelationID records the RunConfig.CorrelationID of the run,
+// so that all the logs emitted while running it can report it.
+func contextWithLogCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, logCorrelationIDContextKey{}, correlationID)
+}
+
+// contextWithLogTurn records the current turn, so that the tool call and
+// handoff logs emitted while executing it can report it.
+func contextWithLogTurn(ctx context.Context, turn uint64) context.Context {
+	return context.WithValue(ctx, logTurnContextKey{}, turn)
+}
+
+// logAgentAttrs returns the agent attribute, followed by the correlation ID
+// and the turn recorded with contextWithLogCorrelationID and
+// contextWithLogTurn, if ctx carries them.
+func logAgentAttrs(ctx context.Context, agent *Agent) []slog.Attr {
+	attrs := []slog.Attr{slog.String(logKeyAgent, agent.Name)}
+	if correlationID, ok := ctx.Value(logCorrelationIDContextKey{}).(string); ok {
+		attrs = append(attrs, slog.String(logKeyCorrelationID, correlationID))
+	}
+	if turn, ok := ctx.Value(logTurnContextKey{}).(uint64); ok {
+		attrs = append(attrs, slog.Uint64(logKeyTurn, turn))
+	}
+	return attrs
+}
+
+func logModelCallStart(ctx context.Context, agent *Agent) {
+	Logger().LogAttrs(ctx, slog.LevelDebug, "Calling model", logAgentAttrs(ctx, agent)...)
+}
+
+func logModelCallEnd(ctx context.Context, agent *Agent, start time.Time, response *ModelResponse, err error) {
+	logger := Logger()
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := append(logAgentAttrs(ctx, agent), slog.Int64(logKeyDurationMs, time.Since(start).Milliseconds()))
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		logger.LogAttrs(ctx, slog.LevelDebug, "Model call failed", attrs...)
+		return
+	}
+	if response != nil && response.Usage != nil {
+		attrs = append(attrs,
+			slog.Uint64("inputTokens", response.Usage.InputTokens),
+			slog.Uint64("outputTokens", response.Usage.OutputTokens),
+		)
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "Model call completed", attrs...)
+}
+
+func logToolCall(ctx context.Context, agent *Agent, toolName string, start time.Time, err error) {
+	logger := Logger()
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := append(logAgentAttrs(ctx, agent),
+		slog.String(logKeyTool, toolName),
+		slog.Int64(logKeyDurationMs, time.Since(start).Milliseconds()),
+	)
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "Tool call completed", attrs...)
+}
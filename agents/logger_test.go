@@ -0,0 +1,175 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureLogRecords(t *testing.T, level slog.Level) func() []map[string]any {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	agents.SetLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: level})))
+	t.Cleanup(agents.ResetLogger)
+
+	return func() []map[string]any {
+		var records []map[string]any
+		decoder := json.NewDecoder(buf)
+		for decoder.More() {
+			var record map[string]any
+			require.NoError(t, decoder.Decode(&record))
+			records = append(records, record)
+		}
+		return records
+	}
+}
+
+func findLogRecord(records []map[string]any, msg string) map[string]any {
+	for _, record := range records {
+		if record["msg"] == msg {
+			return record
+		}
+	}
+	return nil
+}
+
+func TestRunTurnDebugLogs(t *testing.T) {
+	records := captureLogRecords(t, slog.LevelDebug)
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("foo", `{"a": "b"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("foo", "tool_result"))
+
+	_, err := agents.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+
+	logs := records()
+
+	toolRecord := findLogRecord(logs, "Tool call completed")
+	require.NotNil(t, toolRecord)
+	assert.Equal(t, "test", toolRecord["agent"])
+	assert.Equal(t, float64(1), toolRecord["turn"])
+	assert.Equal(t, "foo", toolRecord["tool"])
+	assert.Contains(t, toolRecord, "durationMs")
+
+	modelRecord := findLogRecord(logs, "Model call completed")
+	require.NotNil(t, modelRecord)
+	assert.Equal(t, "test", modelRecord["agent"])
+	assert.Equal(t, float64(1), modelRecord["turn"])
+	assert.Contains(t, modelRecord, "durationMs")
+	assert.Contains(t, modelRecord, "inputTokens")
+	assert.Contains(t, modelRecord, "outputTokens")
+}
+
+func TestRunTurnDebugLogsModelError(t *testing.T) {
+	for _, streamed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streamed=%v", streamed), func(t *testing.T) {
+			records := captureLogRecords(t, slog.LevelDebug)
+
+			model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+				Error: errors.New("model failure"),
+			})
+			agent := agents.New("test").WithModelInstance(model)
+
+			var err error
+			if streamed {
+				var result *agents.RunResultStreaming
+				result, err = agents.RunStreamed(t.Context(), agent, "user_message")
+				require.NoError(t, err)
+				err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+			} else {
+				_, err = agents.Run(t.Context(), agent, "user_message")
+			}
+			require.ErrorContains(t, err, "model failure")
+
+			logs := records()
+			assert.Nil(t, findLogRecord(logs, "Model call completed"))
+			modelRecord := findLogRecord(logs, "Model call failed")
+			require.NotNil(t, modelRecord)
+			assert.Equal(t, "test", modelRecord["agent"])
+			assert.Equal(t, float64(1), modelRecord["turn"])
+			assert.Contains(t, modelRecord, "durationMs")
+			assert.Equal(t, "model failure", modelRecord["error"])
+		})
+	}
+}
+
+func TestRunTurnDebugLogsCorrelationID(t *testing.T) {
+	for _, streamed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streamed=%v", streamed), func(t *testing.T) {
+			records := captureLogRecords(t, slog.LevelDebug)
+
+			model := agentstesting.NewFakeModel(false, nil)
+			model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+				{Value: []agents.TResponseOutputItem{
+					agentstesting.GetFunctionToolCall("foo", `{"a": "b"}`),
+				}},
+				{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+			})
+			agent := agents.New("test").
+				WithModelInstance(model).
+				WithTools(agentstesting.GetFunctionTool("foo", "tool_result"))
+			runner := agents.Runner{Config: agents.RunConfig{CorrelationID: "corr-123"}}
+
+			var err error
+			if streamed {
+				var result *agents.RunResultStreaming
+				result, err = runner.RunStreamed(t.Context(), agent, "user_message")
+				require.NoError(t, err)
+				err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+			} else {
+				_, err = runner.Run(t.Context(), agent, "user_message")
+			}
+			require.NoError(t, err)
+
+			logs := records()
+			for _, msg := range []string{"Running agent", "Calling model", "Model call completed", "Tool call completed"} {
+				record := findLogRecord(logs, msg)
+				require.NotNil(t, record, msg)
+				assert.Equal(t, "corr-123", record["correlationId"], msg)
+			}
+		})
+	}
+}
+
+func TestRunTurnLogsAreDebugOnly(t *testing.T) {
+	records := captureLogRecords(t, slog.LevelInfo)
+
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	_, err := agents.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Empty(t, records())
+}
@@ -75,6 +75,64 @@ func TestStreamedMaxTurns(t *testing.T) {
 	assert.ErrorAs(t, err, &agents.MaxTurnsExceededError{})
 }
 
+// maxTurnsAgent returns an agent whose model returns a message along with a
+// tool call for the given number of turns.
+func maxTurnsAgent(turns int) *agents.Agent {
+	model := agentstesting.NewFakeModel(false, nil)
+	for i := range turns {
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage(fmt.Sprintf("%d", i)),
+				agentstesting.GetFunctionToolCall("some_function", `{"a": "b"}`),
+			},
+		})
+	}
+	return agents.New("test_1").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("some_function", "result"))
+}
+
+func TestNonStreamedStopOnMaxTurns(t *testing.T) {
+	runner := agents.Runner{Config: agents.RunConfig{MaxTurns: 3, StopOnMaxTurns: true}}
+	result, err := runner.Run(t.Context(), maxTurnsAgent(5), "user_message")
+	require.NoError(t, err)
+
+	assert.True(t, result.StoppedEarly)
+	assert.Equal(t, "2", result.FinalOutput)
+	assert.Len(t, result.RawResponses, 3)
+	assert.Equal(t, "test_1", result.LastAgent.Name)
+}
+
+func TestNonStreamedStopOnMaxTurnsWithoutMessages(t *testing.T) {
+	runner := agents.Runner{Config: agents.RunConfig{MaxTurns: 2, StopOnMaxTurns: true}}
+	result, err := runner.Run(t.Context(), toolOnlyTurnsAgent(3), "user_message")
+	require.NoError(t, err)
+
+	assert.True(t, result.StoppedEarly)
+	assert.Nil(t, result.FinalOutput)
+}
+
+func TestStreamedStopOnMaxTurns(t *testing.T) {
+	runner := agents.Runner{Config: agents.RunConfig{MaxTurns: 3, StopOnMaxTurns: true}}
+	result, err := runner.RunStreamed(t.Context(), maxTurnsAgent(5), "user_message")
+	require.NoError(t, err)
+
+	var lastEvent agents.StreamEvent
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		lastEvent = event
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, agents.RunStoppedEarlyStreamEvent{
+		MaxTurns: 3,
+		Type:     "run_stopped_early_stream_event",
+	}, lastEvent)
+	assert.True(t, result.StoppedEarly())
+	assert.Equal(t, "2", result.FinalOutput())
+	assert.Len(t, result.RawResponses(), 3)
+}
+
 func TestStructuredOutputNonStreamedMaxTurns(t *testing.T) {
 	type Foo struct {
 		A string `json:"a"`
@@ -132,3 +190,316 @@ func TestStructuredOutputStreamedMaxTurns(t *testing.T) {
 	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
 	assert.ErrorAs(t, err, &agents.MaxTurnsExceededError{})
 }
+
+func toolOnlyTurnsAgent(toolOnlyTurns int) *agents.Agent {
+	model := agentstesting.NewFakeModel(false, nil)
+	for range toolOnlyTurns {
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetFunctionToolCall("some_function", `{"a": "b"}`),
+			},
+		})
+	}
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+
+	return agents.New("test_1").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("some_function", "result"))
+}
+
+func TestNonStreamedMaxConsecutiveToolOnlyTurns(t *testing.T) {
+	runner := agents.Runner{Config: agents.RunConfig{MaxConsecutiveToolOnlyTurns: 2}}
+
+	t.Run("exceeded", func(t *testing.T) {
+		_, err := runner.Run(t.Context(), toolOnlyTurnsAgent(3), "user_message")
+		assert.ErrorAs(t, err, &agents.MaxConsecutiveToolOnlyTurnsExceededError{})
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		result, err := runner.Run(t.Context(), toolOnlyTurnsAgent(2), "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+	})
+
+	t.Run("no limit by default", func(t *testing.T) {
+		result, err := agents.Runner{}.Run(t.Context(), toolOnlyTurnsAgent(5), "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+	})
+}
+
+func TestStreamedMaxConsecutiveToolOnlyTurns(t *testing.T) {
+	runner := agents.Runner{Config: agents.RunConfig{MaxConsecutiveToolOnlyTurns: 2}}
+
+	result, err := runner.RunStreamed(t.Context(), toolOnlyTurnsAgent(3), "user_message")
+	require.NoError(t, err)
+
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	assert.ErrorAs(t, err, &agents.MaxConsecutiveToolOnlyTurnsExceededError{})
+}
+
+func TestMaxConsecutiveToolOnlyTurnsResetByMessages(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	agent := agents.New("test_1").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("some_function", "result"))
+
+	toolCall := agentstesting.GetFunctionToolCall("some_function", `{"a": "b"}`)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{toolCall}},
+		{Value: []agents.TResponseOutputItem{toolCall}},
+		// A message along with the tool call resets the count
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("working"), toolCall}},
+		{Value: []agents.TResponseOutputItem{toolCall}},
+		{Value: []agents.TResponseOutputItem{toolCall}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+
+	runner := agents.Runner{Config: agents.RunConfig{MaxConsecutiveToolOnlyTurns: 2}}
+	result, err := runner.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+}
+
+// repeatedOutputAgent returns an agent whose model returns the same message,
+// along with a tool call, for the given number of turns, and then a final
+// message.
+func repeatedOutputAgent(turns int) *agents.Agent {
+	model := agentstesting.NewFakeModel(false, nil)
+	for range turns {
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("let me check"),
+				agentstesting.GetFunctionToolCall("some_function", `{"a": "b"}`),
+			},
+		})
+	}
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+
+	return agents.New("test_1").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("some_function", "result"))
+}
+
+func TestNonStreamedMaxRepeatedOutputs(t *testing.T) {
+	runner := agents.Runner{Config: agents.RunConfig{MaxRepeatedOutputs: 3, MaxTurns: 10}}
+
+	t.Run("exceeded", func(t *testing.T) {
+		_, err := runner.Run(t.Context(), repeatedOutputAgent(10), "user_message")
+		var repeatedErr agents.RepeatedOutputError
+		require.ErrorAs(t, err, &repeatedErr)
+		assert.Equal(t, "let me check", repeatedErr.Text)
+		assert.Equal(t, 3, repeatedErr.Turns)
+		assert.NotErrorAs(t, err, &agents.MaxTurnsExceededError{})
+		require.NotNil(t, repeatedErr.RunData)
+		assert.Len(t, repeatedErr.RunData.RawResponses, 3)
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		result, err := runner.Run(t.Context(), repeatedOutputAgent(2), "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+	})
+
+	t.Run("no detection by default", func(t *testing.T) {
+		result, err := agents.Runner{}.Run(t.Context(), repeatedOutputAgent(5), "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+	})
+}
+
+func TestStreamedMaxRepeatedOutputs(t *testing.T) {
+	runner := agents.Runner{Config: agents.RunConfig{MaxRepeatedOutputs: 3, MaxTurns: 10}}
+
+	result, err := runner.RunStreamed(t.Context(), repeatedOutputAgent(10), "user_message")
+	require.NoError(t, err)
+
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	var repeatedErr agents.RepeatedOutputError
+	require.ErrorAs(t, err, &repeatedErr)
+	assert.Equal(t, 3, repeatedErr.Turns)
+	assert.Equal(t, uint64(3), result.CurrentTurn())
+}
+
+func TestMaxRepeatedOutputsResetByDifferentOutputs(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	agent := agents.New("test_1").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("some_function", "result"))
+
+	toolCall := agentstesting.GetFunctionToolCall("some_function", `{"a": "b"}`)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("a"), toolCall}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("a"), toolCall}},
+		// A different message resets the count
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("b"), toolCall}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("a"), toolCall}},
+		// So does a turn without messages
+		{Value: []agents.TResponseOutputItem{toolCall}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("a"), toolCall}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("a"), toolCall}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+
+	runner := agents.Runner{Config: agents.RunConfig{MaxRepeatedOutputs: 3}}
+	result, err := runner.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+}
+
+// pingPongAgents returns two agents handing off to each other, using the same
+// model, which is returned too.
+func pingPongAgents() (*agents.Agent, *agents.Agent, *agentstesting.FakeModel) {
+	model := agentstesting.NewFakeModel(false, nil)
+	ping := agents.New("ping").WithModelInstance(model)
+	pong := agents.New("pong").WithModelInstance(model)
+	ping.WithAgentHandoffs(pong)
+	pong.WithAgentHandoffs(ping).WithTools(agentstesting.GetFunctionTool("some_function", "result"))
+	return ping, pong, model
+}
+
+func pingPongTurnOutputs(ping, pong *agents.Agent, handoffs int) []agentstesting.FakeModelTurnOutput {
+	outputs := make([]agentstesting.FakeModelTurnOutput, handoffs)
+	for i := range outputs {
+		target := pong
+		if i%2 == 1 {
+			target = ping
+		}
+		outputs[i] = agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetHandoffToolCall(target, "", "")},
+		}
+	}
+	return outputs
+}
+
+func TestNonStreamedMaxHandoffDepth(t *testing.T) {
+	runner := agents.Runner{Config: agents.RunConfig{MaxHandoffDepth: 3}}
+
+	t.Run("exceeded", func(t *testing.T) {
+		ping, pong, model := pingPongAgents()
+		model.AddMultipleTurnOutputs(pingPongTurnOutputs(ping, pong, 8))
+
+		_, err := runner.Run(t.Context(), ping, "user_message")
+		var loopErr agents.HandoffLoopError
+		require.ErrorAs(t, err, &loopErr)
+		assert.Equal(t, []string{"ping", "pong", "ping", "pong", "ping"}, loopErr.AgentNames)
+		assert.ErrorContains(t, err, "ping -> pong -> ping -> pong -> ping")
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		ping, pong, model := pingPongAgents()
+		model.AddMultipleTurnOutputs(pingPongTurnOutputs(ping, pong, 3))
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+
+		result, err := runner.Run(t.Context(), ping, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+		assert.Same(t, pong, result.LastAgent)
+	})
+
+	t.Run("reset by a turn without handoff", func(t *testing.T) {
+		ping, pong, model := pingPongAgents()
+		model.AddMultipleTurnOutputs(pingPongTurnOutputs(ping, pong, 3))
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("some_function", "{}")},
+		})
+		model.AddMultipleTurnOutputs(pingPongTurnOutputs(ping, pong, 3)[1:])
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+
+		result, err := runner.Run(t.Context(), ping, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+	})
+
+	t.Run("no limit by default", func(t *testing.T) {
+		ping, pong, model := pingPongAgents()
+		model.AddMultipleTurnOutputs(pingPongTurnOutputs(ping, pong, 8))
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+
+		result, err := agents.Runner{}.Run(t.Context(), ping, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+	})
+}
+
+func TestStreamedMaxHandoffDepth(t *testing.T) {
+	ping, pong, model := pingPongAgents()
+	model.AddMultipleTurnOutputs(pingPongTurnOutputs(ping, pong, 8))
+
+	runner := agents.Runner{Config: agents.RunConfig{MaxHandoffDepth: 3}}
+	result, err := runner.RunStreamed(t.Context(), ping, "user_message")
+	require.NoError(t, err)
+
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	var loopErr agents.HandoffLoopError
+	require.ErrorAs(t, err, &loopErr)
+	assert.Equal(t, []string{"ping", "pong", "ping", "pong", "ping"}, loopErr.AgentNames)
+}
+
+func TestNonStreamedMaxHandoffsPerAgent(t *testing.T) {
+	runner := agents.Runner{Config: agents.RunConfig{MaxHandoffsPerAgent: 2}}
+
+	t.Run("exceeded", func(t *testing.T) {
+		ping, pong, model := pingPongAgents()
+		model.AddMultipleTurnOutputs(pingPongTurnOutputs(ping, pong, 6))
+
+		_, err := runner.Run(t.Context(), ping, "user_message")
+		var maxErr agents.MaxHandoffsPerAgentExceededError
+		require.ErrorAs(t, err, &maxErr)
+		assert.Equal(t, "pong", maxErr.AgentName)
+		assert.Len(t, model.TurnOutputs, 1, "run must stop at the fifth handoff")
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		ping, pong, model := pingPongAgents()
+		model.AddMultipleTurnOutputs(pingPongTurnOutputs(ping, pong, 4))
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+
+		result, err := runner.Run(t.Context(), ping, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+		assert.Same(t, ping, result.LastAgent)
+	})
+
+	t.Run("counted across non-consecutive handoffs", func(t *testing.T) {
+		ping, pong, model := pingPongAgents()
+		ping.WithTools(agentstesting.GetFunctionTool("some_function", "result"))
+		for _, output := range pingPongTurnOutputs(ping, pong, 6) {
+			model.SetNextOutput(output)
+			model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+				Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("some_function", "{}")},
+			})
+		}
+
+		_, err := runner.Run(t.Context(), ping, "user_message")
+		var maxErr agents.MaxHandoffsPerAgentExceededError
+		require.ErrorAs(t, err, &maxErr)
+		assert.Equal(t, "pong", maxErr.AgentName)
+	})
+}
+
+func TestStreamedMaxHandoffsPerAgent(t *testing.T) {
+	ping, pong, model := pingPongAgents()
+	model.AddMultipleTurnOutputs(pingPongTurnOutputs(ping, pong, 6))
+
+	runner := agents.Runner{Config: agents.RunConfig{MaxHandoffsPerAgent: 2}}
+	result, err := runner.RunStreamed(t.Context(), ping, "user_message")
+	require.NoError(t, err)
+
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	var maxErr agents.MaxHandoffsPerAgentExceededError
+	require.ErrorAs(t, err, &maxErr)
+	assert.Equal(t, "pong", maxErr.AgentName)
+}
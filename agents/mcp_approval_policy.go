@@ -0,0 +1,49 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+
+	"github.com/openai/openai-go/v3/responses"
+)
+
+// MCPApprovalPolicy decides whether hosted MCP tool calls requiring approval
+// are approved, for hosted MCP tools without an OnApprovalRequest function.
+// See Agent.MCPApprovalPolicy.
+type MCPApprovalPolicy interface {
+	ApproveMCPToolCall(context.Context, responses.ResponseOutputItemMcpApprovalRequest) bool
+}
+
+// MCPApprovalAlwaysApprove returns an MCPApprovalPolicy which approves all
+// tool calls.
+func MCPApprovalAlwaysApprove() MCPApprovalPolicy { return mcpApprovalConstant(true) }
+
+// MCPApprovalAlwaysDeny returns an MCPApprovalPolicy which rejects all
+// tool calls.
+func MCPApprovalAlwaysDeny() MCPApprovalPolicy { return mcpApprovalConstant(false) }
+
+type mcpApprovalConstant bool
+
+func (p mcpApprovalConstant) ApproveMCPToolCall(context.Context, responses.ResponseOutputItemMcpApprovalRequest) bool {
+	return bool(p)
+}
+
+// MCPApprovalCallback lets you implement a custom MCPApprovalPolicy.
+type MCPApprovalCallback func(context.Context, responses.ResponseOutputItemMcpApprovalRequest) bool
+
+func (f MCPApprovalCallback) ApproveMCPToolCall(ctx context.Context, request responses.ResponseOutputItemMcpApprovalRequest) bool {
+	return f(ctx, request)
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mcpApprovalRequestItem(id, name string) responses.ResponseOutputItemUnion {
+	return responses.ResponseOutputItemUnion{
+		ID:          id,
+		Type:        "mcp_approval_request",
+		Name:        name,
+		Arguments:   `{"path":"/tmp"}`,
+		ServerLabel: "files",
+	}
+}
+
+func mcpApprovalAgent(onApprovalRequest agents.MCPToolApprovalFunction) (*agents.Agent, *agentstesting.FakeModel) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			mcpApprovalRequestItem("approval_1", "delete_file"),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("done"),
+		}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agents.HostedMCPTool{
+			ToolConfig:        responses.ToolMcpParam{ServerLabel: "files"},
+			OnApprovalRequest: onApprovalRequest,
+		})
+	return agent, model
+}
+
+func mcpApprovalResponses(items []agents.RunItem) []responses.ResponseInputItemMcpApprovalResponseParam {
+	var result []responses.ResponseInputItemMcpApprovalResponseParam
+	for _, item := range items {
+		if responseItem, ok := item.(agents.MCPApprovalResponseItem); ok {
+			result = append(result, responseItem.RawItem)
+		}
+	}
+	return result
+}
+
+func TestMCPApprovalRequestInterruptsRun(t *testing.T) {
+	agent, model := mcpApprovalAgent(nil)
+
+	result, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+
+	assert.Nil(t, result.FinalOutput)
+	assert.Equal(t, []agents.ApprovalRequest{{
+		ToolName:    "delete_file",
+		CallID:      "approval_1",
+		Arguments:   `{"path":"/tmp"}`,
+		ServerLabel: "files",
+	}}, result.Interruptions)
+	require.Len(t, result.NewItems, 1)
+	assert.IsType(t, agents.MCPApprovalRequestItem{}, result.NewItems[0])
+
+	for _, approved := range []bool{true, false} {
+		model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+		resumed, err := agents.Runner{}.Resume(t.Context(), result, []agents.ApprovalDecision{
+			{CallID: "approval_1", Approved: approved, Message: "too risky"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "done", resumed.FinalOutput)
+
+		responseItems := mcpApprovalResponses(resumed.NewItems)
+		require.Len(t, responseItems, 1)
+		assert.Equal(t, "approval_1", responseItems[0].ApprovalRequestID)
+		assert.Equal(t, approved, responseItems[0].Approve)
+		assert.Equal(t, !approved, responseItems[0].Reason.Valid())
+	}
+}
+
+func TestMCPApprovalPolicy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   agents.MCPApprovalPolicy
+		expected bool
+	}{
+		{"always approve", agents.MCPApprovalAlwaysApprove(), true},
+		{"always deny", agents.MCPApprovalAlwaysDeny(), false},
+		{"callback approving", agents.MCPApprovalCallback(
+			func(_ context.Context, request responses.ResponseOutputItemMcpApprovalRequest) bool {
+				return request.Name == "delete_file"
+			}), true},
+		{"callback denying", agents.MCPApprovalCallback(
+			func(_ context.Context, request responses.ResponseOutputItemMcpApprovalRequest) bool {
+				return request.Name != "delete_file"
+			}), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			agent, _ := mcpApprovalAgent(nil)
+			agent.WithMCPApprovalPolicy(tc.policy)
+
+			result, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+			require.NoError(t, err)
+
+			assert.Empty(t, result.Interruptions)
+			assert.Equal(t, "done", result.FinalOutput)
+			responseItems := mcpApprovalResponses(result.NewItems)
+			require.Len(t, responseItems, 1)
+			assert.Equal(t, tc.expected, responseItems[0].Approve)
+		})
+	}
+}
+
+func TestMCPApprovalToolCallbackTakesPrecedenceOverPolicy(t *testing.T) {
+	agent, _ := mcpApprovalAgent(func(context.Context, responses.ResponseOutputItemMcpApprovalRequest) (agents.MCPToolApprovalFunctionResult, error) {
+		return agents.MCPToolApprovalFunctionResult{Approve: false, Reason: "no"}, nil
+	})
+	agent.WithMCPApprovalPolicy(agents.MCPApprovalAlwaysApprove())
+
+	result, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+
+	responseItems := mcpApprovalResponses(result.NewItems)
+	require.Len(t, responseItems, 1)
+	assert.False(t, responseItems[0].Approve)
+	assert.Equal(t, "no", responseItems[0].Reason.Value)
+}
+
+func TestMCPApprovalStreamed(t *testing.T) {
+	t.Run("tool callback", func(t *testing.T) {
+		agent, _ := mcpApprovalAgent(func(context.Context, responses.ResponseOutputItemMcpApprovalRequest) (agents.MCPToolApprovalFunctionResult, error) {
+			return agents.MCPToolApprovalFunctionResult{Approve: true}, nil
+		})
+
+		result, err := agents.Runner{}.RunStreamed(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+
+		assert.Equal(t, "done", result.FinalOutput())
+		responseItems := mcpApprovalResponses(result.NewItems())
+		require.Len(t, responseItems, 1)
+		assert.True(t, responseItems[0].Approve)
+	})
+
+	t.Run("agent policy", func(t *testing.T) {
+		agent, _ := mcpApprovalAgent(nil)
+		agent.WithMCPApprovalPolicy(agents.MCPApprovalAlwaysDeny())
+
+		result, err := agents.Runner{}.RunStreamed(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+
+		assert.Equal(t, "done", result.FinalOutput())
+		responseItems := mcpApprovalResponses(result.NewItems())
+		require.Len(t, responseItems, 1)
+		assert.False(t, responseItems[0].Approve)
+	})
+
+	t.Run("no callback nor policy", func(t *testing.T) {
+		agent, _ := mcpApprovalAgent(nil)
+
+		result, err := agents.Runner{}.RunStreamed(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+		assert.ErrorAs(t, err, &agents.UserError{})
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Delay before the second reconnection attempt to an MCP server. The delay
+// is doubled after each failed attempt, up to mcpReconnectMaxBackoff.
+var (
+	mcpReconnectBackoff    = 200 * time.Millisecond
+	mcpReconnectMaxBackoff = 5 * time.Second
+)
+
+type mcpMaxReconnectAttemptsContextKey struct{}
+
+// contextWithMCPMaxReconnectAttempts returns a context carrying
+// MCPConfig.MaxReconnectAttempts to the MCP server calls made with it.
+func contextWithMCPMaxReconnectAttempts(ctx context.Context, attempts int) context.Context {
+	return context.WithValue(ctx, mcpMaxReconnectAttemptsContextKey{}, attempts)
+}
+
+func mcpMaxReconnectAttemptsFromContext(ctx context.Context) int {
+	attempts, _ := ctx.Value(mcpMaxReconnectAttemptsContextKey{}).(int)
+	return attempts
+}
+
+// withMCPReconnect calls fn with the current session of the server. If fn
+// fails because the connection to the server was lost, the session is
+// re-established, with up to MaxReconnectAttempts attempts (see MCPConfig),
+// and fn is retried once. Connection errors are returned as MCPConnectionError.
+func withMCPReconnect[T any](
+	ctx context.Context,
+	s *MCPServerWithClientSession,
+	fn func(*mcp.ClientSession) (T, error),
+) (T, error) {
+	var zero T
+
+	session := s.currentSession()
+	if session == nil {
+		return zero, NewUserError("server not initialized: make sure you call `Connect()` first")
+	}
+
+	result, err := fn(session)
+	if err == nil || !isMCPConnectionError(err) {
+		return result, err
+	}
+
+	maxAttempts := mcpMaxReconnectAttemptsFromContext(ctx)
+	if maxAttempts <= 0 {
+		return zero, NewMCPConnectionError(s.name, 0, err)
+	}
+
+	session, err = s.reconnect(ctx, session, maxAttempts)
+	if err != nil {
+		return zero, err
+	}
+
+	result, err = fn(session)
+	if err != nil && isMCPConnectionError(err) {
+		return zero, NewMCPConnectionError(s.name, 0, err)
+	}
+	return result, err
+}
+
+// currentSession returns the current session, or nil if the server is not
+// connected.
+func (s *MCPServerWithClientSession) currentSession() *mcp.ClientSession {
+	s.cleanupMu.Lock()
+	defer s.cleanupMu.Unlock()
+	return s.session
+}
+
+// reconnect replaces the given failed session with a new one, unless this was
+// already done concurrently, and returns the new session. It makes up to
+// maxAttempts connection attempts, with exponential backoff.
+func (s *MCPServerWithClientSession) reconnect(ctx context.Context, failed *mcp.ClientSession, maxAttempts int) (*mcp.ClientSession, error) {
+	s.cleanupMu.Lock()
+	defer s.cleanupMu.Unlock()
+
+	switch s.session {
+	case nil:
+		return nil, NewUserError("server not initialized: make sure you call `Connect()` first")
+	case failed:
+		_ = failed.Close()
+	default:
+		// Another call already reconnected.
+		return s.session, nil
+	}
+
+	var err error
+	backoff := mcpReconnectBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return nil, NewMCPConnectionError(s.name, attempt-1, errors.Join(err, ctx.Err()))
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, mcpReconnectMaxBackoff)
+		}
+
+		var session *mcp.ClientSession
+		session, err = s.connectSession(ctx)
+		if err == nil {
+			Logger().Info("Reconnected to MCP server",
+				slog.String("server", s.name),
+				slog.Int("attempt", attempt))
+			s.session = session
+			return session, nil
+		}
+		Logger().Warn("Error reconnecting to MCP server",
+			slog.String("server", s.name),
+			slog.Int("attempt", attempt),
+			slog.String("error", err.Error()))
+	}
+	return nil, NewMCPConnectionError(s.name, maxAttempts, err)
+}
+
+// isMCPConnectionError reports whether an error returned by an MCP session
+// is caused by the connection to the server being lost, as opposed to, for
+// instance, an error returned by the server.
+func isMCPConnectionError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, mcp.ErrConnectionClosed) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.ErrClosedPipe) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.As(err, &netErr)
+}
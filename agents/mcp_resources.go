@@ -0,0 +1,62 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MCPResourceInstructions returns an InstructionsGetter which appends the
+// text contents of an MCP server resource to the base instructions (if any),
+// so that the agent can rely on a server-provided document.
+//
+// The resource is read each time the instructions are needed, so that any
+// update on the server is picked up. An error is returned if the resource
+// has no text contents.
+func MCPResourceInstructions(base InstructionsGetter, server MCPServer, uri string) InstructionsGetter {
+	return InstructionsFunc(func(ctx context.Context, agent *Agent) (string, error) {
+		var instructions string
+		if base != nil {
+			var err error
+			instructions, err = base.GetInstructions(ctx, agent)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		result, err := server.ReadResource(ctx, uri)
+		if err != nil {
+			return "", fmt.Errorf("failed to read MCP resource %q from server %q: %w", uri, server.Name(), err)
+		}
+
+		var texts []string
+		for _, contents := range result.Contents {
+			if contents != nil && contents.Text != "" {
+				texts = append(texts, contents.Text)
+			}
+		}
+		if len(texts) == 0 {
+			return "", UserErrorf("MCP resource %q from server %q has no text contents", uri, server.Name())
+		}
+
+		resourceText := strings.Join(texts, "\n")
+		if instructions == "" {
+			return resourceText, nil
+		}
+		return instructions + "\n\n" + resourceText, nil
+	})
+}
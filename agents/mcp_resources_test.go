@@ -0,0 +1,95 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeMCPServerResources(t *testing.T) {
+	server := agentstesting.NewFakeMCPServer(nil, nil, "")
+	server.AddResource("file:///guide.md", "guide", "Always be polite.")
+
+	listResult, err := server.ListResources(t.Context())
+	require.NoError(t, err)
+	require.Len(t, listResult.Resources, 1)
+	assert.Equal(t, "file:///guide.md", listResult.Resources[0].URI)
+	assert.Equal(t, "guide", listResult.Resources[0].Name)
+
+	readResult, err := server.ReadResource(t.Context(), "file:///guide.md")
+	require.NoError(t, err)
+	require.Len(t, readResult.Contents, 1)
+	assert.Equal(t, "Always be polite.", readResult.Contents[0].Text)
+
+	_, err = server.ReadResource(t.Context(), "file:///missing.md")
+	assert.Error(t, err)
+}
+
+func TestMCPResourceInstructions(t *testing.T) {
+	server := agentstesting.NewFakeMCPServer(nil, nil, "")
+	server.AddResource("file:///guide.md", "guide", "Always be polite.")
+
+	t.Run("with base instructions", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+		agent := agents.New("test").WithModelInstance(model)
+		agent.Instructions = agents.MCPResourceInstructions(
+			agents.InstructionsStr("You are a support agent."), server, "file:///guide.md")
+
+		_, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, param.NewOpt("You are a support agent.\n\nAlways be polite."),
+			model.LastTurnArgs.SystemInstructions)
+	})
+
+	t.Run("without base instructions", func(t *testing.T) {
+		instructions := agents.MCPResourceInstructions(nil, server, "file:///guide.md")
+		got, err := instructions.GetInstructions(t.Context(), agents.New("test"))
+		require.NoError(t, err)
+		assert.Equal(t, "Always be polite.", got)
+	})
+
+	t.Run("resource updates are picked up", func(t *testing.T) {
+		server := agentstesting.NewFakeMCPServer(nil, nil, "")
+		server.AddResource("file:///news.md", "news", "old")
+		instructions := agents.MCPResourceInstructions(nil, server, "file:///news.md")
+
+		server.ResourceContents["file:///news.md"] = "new"
+		got, err := instructions.GetInstructions(t.Context(), agents.New("test"))
+		require.NoError(t, err)
+		assert.Equal(t, "new", got)
+	})
+
+	t.Run("unknown resource", func(t *testing.T) {
+		instructions := agents.MCPResourceInstructions(nil, server, "file:///missing.md")
+		_, err := instructions.GetInstructions(t.Context(), agents.New("test"))
+		assert.ErrorContains(t, err, "file:///missing.md")
+	})
+
+	t.Run("no text contents", func(t *testing.T) {
+		server := agentstesting.NewFakeMCPServer(nil, nil, "")
+		server.AddResource("file:///empty.md", "empty", "")
+		instructions := agents.MCPResourceInstructions(nil, server, "file:///empty.md")
+		_, err := instructions.GetInstructions(t.Context(), agents.New("test"))
+		assert.ErrorAs(t, err, &agents.UserError{})
+	})
+}
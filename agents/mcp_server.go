@@ -19,8 +19,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os/exec"
 	"sync"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -56,6 +58,12 @@ type MCPServer interface {
 
 	// GetPrompt returns a specific prompt from the server.
 	GetPrompt(ctx context.Context, name string, arguments map[string]string) (*mcp.GetPromptResult, error)
+
+	// ListResources lists the resources available on the server.
+	ListResources(ctx context.Context) (*mcp.ListResourcesResult, error)
+
+	// ReadResource returns the contents of a specific resource from the server.
+	ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error)
 }
 
 // MCPServerWithClientSession is a base type for MCP servers that uses an
@@ -64,12 +72,15 @@ type MCPServerWithClientSession struct {
 	transport            mcp.Transport
 	session              *mcp.ClientSession
 	cleanupMu            sync.Mutex
+	toolsMu              sync.Mutex
 	cacheToolsList       bool
 	cacheDirty           bool
 	toolsList            []*mcp.Tool
+	toolsListFetchedAt   time.Time
 	toolFilter           MCPToolFilter
 	name                 string
 	useStructuredContent bool
+	clientInfo           *mcp.Implementation
 }
 
 type MCPServerWithClientSessionParams struct {
@@ -93,6 +104,10 @@ type MCPServerWithClientSessionParams struct {
 	// content. You can set this to true if you know the server will not duplicate
 	// the structured content in `Content`.
 	UseStructuredContent bool
+
+	// Optional client information (name, title, version) sent to the server
+	// on initialization. If not provided, the server name is used as client name.
+	ClientInfo *mcp.Implementation
 }
 
 func NewMCPServerWithClientSession(params MCPServerWithClientSessionParams) *MCPServerWithClientSession {
@@ -104,6 +119,7 @@ func NewMCPServerWithClientSession(params MCPServerWithClientSessionParams) *MCP
 		toolFilter:           params.ToolFilter,
 		name:                 params.Name,
 		useStructuredContent: params.UseStructuredContent,
+		clientInfo:           params.ClientInfo,
 	}
 }
 
@@ -117,15 +133,30 @@ func (s *MCPServerWithClientSession) Connect(ctx context.Context) (err error) {
 		}
 	}()
 
-	client := mcp.NewClient(&mcp.Implementation{Name: s.name}, nil)
-	session, err := client.Connect(ctx, s.transport, nil)
+	session, err := s.connectSession(ctx)
 	if err != nil {
-		return fmt.Errorf("MCP client connection error: %w", err)
+		return err
 	}
+	s.cleanupMu.Lock()
 	s.session = session
+	s.cleanupMu.Unlock()
 	return nil
 }
 
+// connectSession connects to the server, initializing a new session.
+func (s *MCPServerWithClientSession) connectSession(ctx context.Context) (*mcp.ClientSession, error) {
+	clientInfo := s.clientInfo
+	if clientInfo == nil {
+		clientInfo = &mcp.Implementation{Name: s.name}
+	}
+	client := mcp.NewClient(clientInfo, nil)
+	session, err := client.Connect(ctx, s.transport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("MCP client connection error: %w", err)
+	}
+	return session, nil
+}
+
 func (s *MCPServerWithClientSession) Cleanup(context.Context) error {
 	s.cleanupMu.Lock()
 	defer func() {
@@ -152,22 +183,16 @@ func (s *MCPServerWithClientSession) UseStructuredContent() bool {
 }
 
 func (s *MCPServerWithClientSession) ListTools(ctx context.Context, agent *Agent) ([]*mcp.Tool, error) {
-	if s.session == nil {
+	if s.currentSession() == nil {
 		return nil, NewUserError("server not initialized: make sure you call `Connect()` first")
 	}
 
-	var tools []*mcp.Tool
-	// Return from cache if caching is enabled, we have tools, and the cache is not dirty
-	if s.cacheToolsList && !s.cacheDirty && len(s.toolsList) > 0 {
-		tools = s.toolsList
-	} else {
-		s.cacheDirty = false
-		listToolsResults, err := s.session.ListTools(ctx, nil)
-		if err != nil {
-			return nil, fmt.Errorf("MCP list tools error: %w", err)
-		}
-		tools = listToolsResults.Tools
-		s.toolsList = tools
+	if agent != nil && agent.MCPConfig.MaxReconnectAttempts > 0 {
+		ctx = contextWithMCPMaxReconnectAttempts(ctx, agent.MCPConfig.MaxReconnectAttempts)
+	}
+	tools, err := s.cachedToolsList(ctx, agent)
+	if err != nil {
+		return nil, err
 	}
 
 	filteredTools := tools
@@ -184,30 +209,71 @@ func (s *MCPServerWithClientSession) ListTools(ctx context.Context, agent *Agent
 	return filteredTools, nil
 }
 
-func (s *MCPServerWithClientSession) CallTool(ctx context.Context, toolName string, arguments map[string]any) (*mcp.CallToolResult, error) {
-	if s.session == nil {
-		return nil, NewUserError("server not initialized: make sure you call `Connect()` first")
+// cachedToolsList returns the unfiltered tools list, from the cache if caching
+// is enabled, either on the server or by the agent's MCPConfig, and the cache
+// is neither dirty nor expired.
+func (s *MCPServerWithClientSession) cachedToolsList(ctx context.Context, agent *Agent) ([]*mcp.Tool, error) {
+	s.toolsMu.Lock()
+	defer s.toolsMu.Unlock()
+
+	cacheToolsList := s.cacheToolsList
+	var cacheTTL time.Duration
+	if agent != nil {
+		cacheToolsList = cacheToolsList || agent.MCPConfig.CacheToolsList
+		cacheTTL = agent.MCPConfig.CacheTTL
 	}
-	return s.session.CallTool(ctx, &mcp.CallToolParams{
-		Name:      toolName,
-		Arguments: arguments,
+	expired := cacheTTL > 0 && time.Since(s.toolsListFetchedAt) >= cacheTTL
+
+	// Return from cache if caching is enabled, we have tools, and the cache is neither dirty nor expired
+	if cacheToolsList && !s.cacheDirty && !expired && len(s.toolsList) > 0 {
+		return s.toolsList, nil
+	}
+
+	s.cacheDirty = false
+	listToolsResults, err := withMCPReconnect(ctx, s, func(session *mcp.ClientSession) (*mcp.ListToolsResult, error) {
+		return session.ListTools(ctx, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("MCP list tools error: %w", err)
+	}
+	s.toolsList = listToolsResults.Tools
+	s.toolsListFetchedAt = time.Now()
+	return s.toolsList, nil
+}
+
+func (s *MCPServerWithClientSession) CallTool(ctx context.Context, toolName string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	return withMCPReconnect(ctx, s, func(session *mcp.ClientSession) (*mcp.CallToolResult, error) {
+		return session.CallTool(ctx, &mcp.CallToolParams{
+			Name:      toolName,
+			Arguments: arguments,
+		})
 	})
 }
 
 func (s *MCPServerWithClientSession) ListPrompts(ctx context.Context) (*mcp.ListPromptsResult, error) {
-	if s.session == nil {
-		return nil, NewUserError("server not initialized: make sure you call `Connect()` first")
-	}
-	return s.session.ListPrompts(ctx, nil)
+	return withMCPReconnect(ctx, s, func(session *mcp.ClientSession) (*mcp.ListPromptsResult, error) {
+		return session.ListPrompts(ctx, nil)
+	})
 }
 
 func (s *MCPServerWithClientSession) GetPrompt(ctx context.Context, name string, arguments map[string]string) (*mcp.GetPromptResult, error) {
-	if s.session == nil {
-		return nil, NewUserError("server not initialized: make sure you call `Connect()` first")
-	}
-	return s.session.GetPrompt(ctx, &mcp.GetPromptParams{
-		Name:      name,
-		Arguments: arguments,
+	return withMCPReconnect(ctx, s, func(session *mcp.ClientSession) (*mcp.GetPromptResult, error) {
+		return session.GetPrompt(ctx, &mcp.GetPromptParams{
+			Name:      name,
+			Arguments: arguments,
+		})
+	})
+}
+
+func (s *MCPServerWithClientSession) ListResources(ctx context.Context) (*mcp.ListResourcesResult, error) {
+	return withMCPReconnect(ctx, s, func(session *mcp.ClientSession) (*mcp.ListResourcesResult, error) {
+		return session.ListResources(ctx, nil)
+	})
+}
+
+func (s *MCPServerWithClientSession) ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	return withMCPReconnect(ctx, s, func(session *mcp.ClientSession) (*mcp.ReadResourceResult, error) {
+		return session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
 	})
 }
 
@@ -221,18 +287,41 @@ func (s *MCPServerWithClientSession) Run(ctx context.Context, fn func(context.Co
 			err = errors.Join(err, fmt.Errorf("MCP server cleanup error: %w", e))
 		}
 	}()
+	// Clean up as soon as the context is canceled, e.g. terminating the
+	// server process, even if fn doesn't return yet.
+	stop := context.AfterFunc(ctx, func() { _ = s.Cleanup(context.WithoutCancel(ctx)) })
+	defer stop()
 	return fn(ctx, s)
 }
 
 // InvalidateToolsCache invalidates the tools cache.
 func (s *MCPServerWithClientSession) InvalidateToolsCache() {
+	s.toolsMu.Lock()
+	defer s.toolsMu.Unlock()
 	s.cacheDirty = true
 }
 
 type MCPServerStdioParams struct {
-	// The command to run to start the server.
+	// The command to run to start the server. A command can only be started
+	// once, so the server can't reconnect after its process terminates: use
+	// Executable instead to allow it (see MCPConfig.MaxReconnectAttempts).
+	// If the command has no Stderr, the standard error of the process is
+	// forwarded to the package logger.
 	Command *exec.Cmd
 
+	// The executable to run to start the server, as an alternative to
+	// Command. It is resolved as with exec.Command, and a new process is
+	// started on each connection. The standard error of the process is
+	// forwarded to the package logger.
+	Executable string
+
+	// Optional arguments for Executable.
+	Args []string
+
+	// Optional environment for Executable, in the form "key=value". If nil,
+	// the process inherits the environment of the current process.
+	Env []string
+
 	// Whether to cache the tools list. If `true`, the tools list will be
 	// cached and only fetched from the server once. If `false`, the tools list will be
 	// fetched from the server on each call to `ListTools()`. The cache can be
@@ -253,6 +342,10 @@ type MCPServerStdioParams struct {
 	// content. You can set this to true if you know the server will not duplicate
 	// the structured content in `Content`.
 	UseStructuredContent bool
+
+	// Optional client information (name, title, version) sent to the server
+	// on initialization. If not provided, the server name is used as client name.
+	ClientInfo *mcp.Implementation
 }
 
 // MCPServerStdio is an MCP server implementation that uses the stdio transport.
@@ -263,19 +356,45 @@ type MCPServerStdio struct {
 }
 
 // NewMCPServerStdio creates a new MCP server based on the stdio transport.
+//
+// It panics if neither or both of Command and Executable are provided.
 func NewMCPServerStdio(params MCPServerStdioParams) *MCPServerStdio {
+	if (params.Command == nil) == (params.Executable == "") {
+		panic(errors.New("MCPServerStdio: exactly one of Command or Executable must be provided"))
+	}
+
 	name := params.Name
 	if name == "" {
-		name = fmt.Sprintf("stdio: %s", params.Command.Path)
+		if params.Command != nil {
+			name = fmt.Sprintf("stdio: %s", params.Command.Path)
+		} else {
+			name = fmt.Sprintf("stdio: %s", params.Executable)
+		}
+	}
+
+	var transport mcp.Transport
+	if params.Command != nil {
+		if params.Command.Stderr == nil {
+			params.Command.Stderr = newMCPStderrLogger(name)
+		}
+		transport = &mcp.CommandTransport{Command: params.Command}
+	} else {
+		transport = &mcpStdioProcessTransport{
+			executable: params.Executable,
+			args:       params.Args,
+			env:        params.Env,
+			stderr:     newMCPStderrLogger(name),
+		}
 	}
 
 	return &MCPServerStdio{
 		MCPServerWithClientSession: NewMCPServerWithClientSession(MCPServerWithClientSessionParams{
 			Name:                 name,
-			Transport:            &mcp.CommandTransport{Command: params.Command},
+			Transport:            transport,
 			CacheToolsList:       params.CacheToolsList,
 			ToolFilter:           params.ToolFilter,
 			UseStructuredContent: params.UseStructuredContent,
+			ClientInfo:           params.ClientInfo,
 		}),
 	}
 }
@@ -304,6 +423,19 @@ type MCPServerSSEParams struct {
 	// content. You can set this to true if you know the server will not duplicate
 	// the structured content in `Content`.
 	UseStructuredContent bool
+
+	// Optional client information (name, title, version) sent to the server
+	// on initialization. If not provided, the server name is used as client name.
+	ClientInfo *mcp.Implementation
+
+	// Optional HTTP headers sent with every request to the server, including
+	// the initial connection handshake.
+	Headers map[string]string
+
+	// Optional bearer token sent with every request to the server, in the
+	// Authorization header. It takes precedence over any Authorization
+	// header set in Headers.
+	BearerToken string
 }
 
 // MCPServerSSE is an MCP server implementation that uses the HTTP with SSE transport.
@@ -337,6 +469,7 @@ func NewMCPServerSSE(params MCPServerSSEParams) *MCPServerSSE {
 			HTTPClient: params.TransportOpts.HTTPClient,
 		}
 	}
+	transport.HTTPClient = mcpHTTPClientWithHeaders(transport.HTTPClient, params.Headers, params.BearerToken)
 	return &MCPServerSSE{
 		MCPServerWithClientSession: NewMCPServerWithClientSession(MCPServerWithClientSessionParams{
 			Name:                 name,
@@ -344,6 +477,7 @@ func NewMCPServerSSE(params MCPServerSSEParams) *MCPServerSSE {
 			CacheToolsList:       params.CacheToolsList,
 			ToolFilter:           params.ToolFilter,
 			UseStructuredContent: params.UseStructuredContent,
+			ClientInfo:           params.ClientInfo,
 		}),
 	}
 }
@@ -372,6 +506,19 @@ type MCPServerStreamableHTTPParams struct {
 	// content. You can set this to true if you know the server will not duplicate
 	// the structured content in `Content`.
 	UseStructuredContent bool
+
+	// Optional client information (name, title, version) sent to the server
+	// on initialization. If not provided, the server name is used as client name.
+	ClientInfo *mcp.Implementation
+
+	// Optional HTTP headers sent with every request to the server, including
+	// the initial connection handshake.
+	Headers map[string]string
+
+	// Optional bearer token sent with every request to the server, in the
+	// Authorization header. It takes precedence over any Authorization
+	// header set in Headers.
+	BearerToken string
 }
 
 // MCPServerStreamableHTTP is an MCP server implementation that uses the Streamable HTTP transport.
@@ -397,6 +544,7 @@ func NewMCPServerStreamableHTTP(params MCPServerStreamableHTTPParams) *MCPServer
 			MaxRetries: params.TransportOpts.MaxRetries,
 		}
 	}
+	transport.HTTPClient = mcpHTTPClientWithHeaders(transport.HTTPClient, params.Headers, params.BearerToken)
 	return &MCPServerStreamableHTTP{
 		MCPServerWithClientSession: NewMCPServerWithClientSession(MCPServerWithClientSessionParams{
 			Name:                 name,
@@ -404,6 +552,54 @@ func NewMCPServerStreamableHTTP(params MCPServerStreamableHTTPParams) *MCPServer
 			CacheToolsList:       params.CacheToolsList,
 			ToolFilter:           params.ToolFilter,
 			UseStructuredContent: params.UseStructuredContent,
+			ClientInfo:           params.ClientInfo,
 		}),
 	}
 }
+
+// mcpHTTPClientWithHeaders returns a copy of the given HTTP client (or of
+// http.DefaultClient, if nil) adding the given headers, and the bearer token
+// if not empty, to every request. The client is returned unchanged if there
+// are no headers to add.
+func mcpHTTPClientWithHeaders(client *http.Client, headers map[string]string, bearerToken string) *http.Client {
+	if len(headers) == 0 && bearerToken == "" {
+		return client
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	h := make(http.Header, len(headers)+1)
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	if bearerToken != "" {
+		h.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	c := *client
+	c.Transport = mcpHeadersRoundTripper{base: client.Transport, headers: h}
+	return &c
+}
+
+// mcpHeadersRoundTripper is an http.RoundTripper adding a fixed set of
+// headers to every request. Header values are never logged, since they
+// typically contain credentials.
+type mcpHeadersRoundTripper struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (rt mcpHeadersRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := rt.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// A RoundTripper must not modify the request.
+	req = req.Clone(req.Context())
+	for k, v := range rt.headers {
+		req.Header[k] = v
+	}
+	return base.RoundTrip(req)
+}
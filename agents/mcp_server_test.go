@@ -17,7 +17,14 @@ package agents
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
@@ -163,6 +170,12 @@ func TestMCPServerWithClientSessionErrors(t *testing.T) {
 
 		_, err = server.CallTool(ctx, "add_nop_tool", nil)
 		assert.ErrorAs(t, err, &UserError{})
+
+		_, err = server.ListResources(ctx)
+		assert.ErrorAs(t, err, &UserError{})
+
+		_, err = server.ReadResource(ctx, "file:///guide.md")
+		assert.ErrorAs(t, err, &UserError{})
 	})
 }
 
@@ -173,3 +186,418 @@ func collectMCPToolNames(tools []*mcp.Tool) []string {
 	}
 	return names
 }
+
+func TestMCPServerWithClientSessionClientInfo(t *testing.T) {
+	connect := func(t *testing.T, clientInfo *mcp.Implementation) *mcp.Implementation {
+		t.Helper()
+		clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+		initialized := make(chan *mcp.Implementation, 1)
+		fakeServer := mcp.NewServer(&mcp.Implementation{Name: "fake_server"}, &mcp.ServerOptions{
+			InitializedHandler: func(_ context.Context, req *mcp.InitializedRequest) {
+				initialized <- req.Session.InitializeParams().ClientInfo
+			},
+		})
+		serverSession, err := fakeServer.Connect(t.Context(), serverTransport, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = serverSession.Close() })
+
+		server := NewMCPServerWithClientSession(MCPServerWithClientSessionParams{
+			Name:       "test_server",
+			Transport:  clientTransport,
+			ClientInfo: clientInfo,
+		})
+		err = server.Run(t.Context(), func(context.Context, *MCPServerWithClientSession) error {
+			return nil
+		})
+		require.NoError(t, err)
+
+		return <-initialized
+	}
+
+	t.Run("custom client info", func(t *testing.T) {
+		got := connect(t, &mcp.Implementation{Name: "my-app", Title: "My App", Version: "1.2.3"})
+		assert.Equal(t, &mcp.Implementation{Name: "my-app", Title: "My App", Version: "1.2.3"}, got)
+	})
+
+	t.Run("default client info", func(t *testing.T) {
+		got := connect(t, nil)
+		assert.Equal(t, &mcp.Implementation{Name: "test_server"}, got)
+	})
+}
+
+func TestMCPServerWithClientSessionResources(t *testing.T) {
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+	fakeServer := mcp.NewServer(&mcp.Implementation{Name: "fake_server"}, nil)
+	fakeServer.AddResource(
+		&mcp.Resource{URI: "file:///guide.md", Name: "guide", MIMEType: "text/markdown"},
+		func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{
+				Contents: []*mcp.ResourceContents{{
+					URI:      req.Params.URI,
+					MIMEType: "text/markdown",
+					Text:     "# Guide",
+				}},
+			}, nil
+		},
+	)
+	serverSession, err := fakeServer.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	server := NewMCPServerWithClientSession(MCPServerWithClientSessionParams{
+		Name:      "test_server",
+		Transport: clientTransport,
+	})
+	err = server.Run(t.Context(), func(ctx context.Context, server *MCPServerWithClientSession) error {
+		listResult, err := server.ListResources(ctx)
+		require.NoError(t, err)
+		require.Len(t, listResult.Resources, 1)
+		assert.Equal(t, "file:///guide.md", listResult.Resources[0].URI)
+		assert.Equal(t, "guide", listResult.Resources[0].Name)
+
+		readResult, err := server.ReadResource(ctx, "file:///guide.md")
+		require.NoError(t, err)
+		require.Len(t, readResult.Contents, 1)
+		assert.Equal(t, "# Guide", readResult.Contents[0].Text)
+
+		_, err = server.ReadResource(ctx, "file:///missing.md")
+		assert.Error(t, err)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestMCPServerWithClientSessionAgentCacheConfig(t *testing.T) {
+	connect := func(t *testing.T) (*MCPServerWithClientSession, *atomic.Int32) {
+		t.Helper()
+		clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+		listCalls := new(atomic.Int32)
+		fakeServer := mcp.NewServer(&mcp.Implementation{Name: "fake_server"}, nil)
+		fakeServer.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+			return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				if method == "tools/list" {
+					listCalls.Add(1)
+				}
+				return next(ctx, method, req)
+			}
+		})
+		for _, name := range []string{"tool_a", "tool_b"} {
+			mcp.AddTool(fakeServer, &mcp.Tool{Name: name}, func(context.Context, *mcp.CallToolRequest, struct{}) (*mcp.CallToolResult, any, error) {
+				return &mcp.CallToolResult{}, nil, nil
+			})
+		}
+		serverSession, err := fakeServer.Connect(t.Context(), serverTransport, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = serverSession.Close() })
+
+		server := NewMCPServerWithClientSession(MCPServerWithClientSessionParams{
+			Name:      "test_server",
+			Transport: clientTransport,
+			ToolFilter: MCPToolFilterFunc(func(_ context.Context, filterCtx MCPToolFilterContext, tool *mcp.Tool) (bool, error) {
+				return filterCtx.Agent.Name != "agent_a" || tool.Name == "tool_a", nil
+			}),
+		})
+		require.NoError(t, server.Connect(t.Context()))
+		t.Cleanup(func() { _ = server.Cleanup(context.Background()) })
+		return server, listCalls
+	}
+
+	t.Run("cached within TTL", func(t *testing.T) {
+		server, listCalls := connect(t)
+		config := MCPConfig{CacheToolsList: true, CacheTTL: time.Hour}
+		agentA := New("agent_a").WithMCPConfig(config)
+		agentB := New("agent_b").WithMCPConfig(config)
+
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				tools, err := server.ListTools(t.Context(), agentA)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"tool_a"}, collectMCPToolNames(tools))
+			}()
+			go func() {
+				defer wg.Done()
+				tools, err := server.ListTools(t.Context(), agentB)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"tool_a", "tool_b"}, collectMCPToolNames(tools))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), listCalls.Load())
+
+		server.InvalidateToolsCache()
+		_, err := server.ListTools(t.Context(), agentA)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), listCalls.Load())
+	})
+
+	t.Run("refreshed after TTL", func(t *testing.T) {
+		server, listCalls := connect(t)
+		agent := New("agent_a").WithMCPConfig(MCPConfig{CacheToolsList: true, CacheTTL: time.Millisecond})
+
+		_, err := server.ListTools(t.Context(), agent)
+		require.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+		_, err = server.ListTools(t.Context(), agent)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), listCalls.Load())
+	})
+
+	t.Run("not cached by default", func(t *testing.T) {
+		server, listCalls := connect(t)
+		agent := New("agent_a")
+
+		for range 2 {
+			_, err := server.ListTools(t.Context(), agent)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), listCalls.Load())
+	})
+}
+
+func TestMCPServerHTTPAuthHeaders(t *testing.T) {
+	newFakeServer := func(*http.Request) *mcp.Server {
+		s := mcp.NewServer(&mcp.Implementation{Name: "fake_server"}, nil)
+		mcp.AddTool(s, &mcp.Tool{Name: "test_tool"}, func(context.Context, *mcp.CallToolRequest, struct{}) (*mcp.CallToolResult, any, error) {
+			return &mcp.CallToolResult{}, nil, nil
+		})
+		return s
+	}
+
+	// recordHeaders returns an httptest.Server serving the given MCP handler,
+	// recording the headers of each request.
+	recordHeaders := func(t *testing.T, handler http.Handler) (*httptest.Server, func() []http.Header) {
+		t.Helper()
+		var mu sync.Mutex
+		var headers []http.Header
+		httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			headers = append(headers, r.Header.Clone())
+			mu.Unlock()
+			handler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(httpServer.Close)
+		return httpServer, func() []http.Header {
+			mu.Lock()
+			defer mu.Unlock()
+			return slices.Clone(headers)
+		}
+	}
+
+	listTools := func(t *testing.T, server *MCPServerWithClientSession) {
+		t.Helper()
+		err := server.Run(t.Context(), func(ctx context.Context, server *MCPServerWithClientSession) error {
+			tools, err := server.ListTools(ctx, New("test_agent"))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"test_tool"}, collectMCPToolNames(tools))
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	assertHeaders := func(t *testing.T, headers []http.Header) {
+		t.Helper()
+		require.NotEmpty(t, headers)
+		for _, h := range headers {
+			assert.Equal(t, "Bearer secret-token", h.Get("Authorization"))
+			assert.Equal(t, "custom-value", h.Get("X-Custom"))
+		}
+	}
+
+	t.Run("streamable HTTP", func(t *testing.T) {
+		httpServer, headers := recordHeaders(t, mcp.NewStreamableHTTPHandler(newFakeServer, nil))
+		server := NewMCPServerStreamableHTTP(MCPServerStreamableHTTPParams{
+			URL: httpServer.URL,
+			Headers: map[string]string{
+				"Authorization": "Basic overridden",
+				"X-Custom":      "custom-value",
+			},
+			BearerToken: "secret-token",
+		})
+		listTools(t, server.MCPServerWithClientSession)
+		assertHeaders(t, headers())
+	})
+
+	t.Run("SSE", func(t *testing.T) {
+		httpServer, headers := recordHeaders(t, mcp.NewSSEHandler(newFakeServer))
+		server := NewMCPServerSSE(MCPServerSSEParams{
+			BaseURL:     httpServer.URL,
+			Headers:     map[string]string{"X-Custom": "custom-value"},
+			BearerToken: "secret-token",
+		})
+		listTools(t, server.MCPServerWithClientSession)
+		assertHeaders(t, headers())
+	})
+
+	t.Run("without auth", func(t *testing.T) {
+		httpServer, headers := recordHeaders(t, mcp.NewStreamableHTTPHandler(newFakeServer, nil))
+		server := NewMCPServerStreamableHTTP(MCPServerStreamableHTTPParams{URL: httpServer.URL})
+		listTools(t, server.MCPServerWithClientSession)
+		for _, h := range headers() {
+			assert.Empty(t, h.Get("Authorization"))
+		}
+	})
+}
+
+// restartableMCPTransport connects to a new in-memory fake server on each
+// Connect call, simulating a server which can be restarted.
+type restartableMCPTransport struct {
+	mu            sync.Mutex
+	connects      int
+	down          bool
+	serverSession *mcp.ServerSession
+	clientConn    mcp.Connection
+	toolStarted   chan struct{}
+	releaseTool   chan struct{}
+}
+
+func newRestartableMCPTransport() *restartableMCPTransport {
+	return &restartableMCPTransport{
+		toolStarted: make(chan struct{}, 1),
+		releaseTool: make(chan struct{}),
+	}
+}
+
+func (tr *restartableMCPTransport) Connect(ctx context.Context) (mcp.Connection, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.connects++
+	if tr.down {
+		return nil, syscall.ECONNREFUSED
+	}
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	fakeServer := mcp.NewServer(&mcp.Implementation{Name: "fake_server"}, nil)
+	mcp.AddTool(fakeServer, &mcp.Tool{Name: "echo"}, func(_ context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "echo"}}}, nil, nil
+	})
+	mcp.AddTool(fakeServer, &mcp.Tool{Name: "slow"}, func(ctx context.Context, _ *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, any, error) {
+		tr.toolStarted <- struct{}{}
+		select {
+		case <-tr.releaseTool:
+		case <-ctx.Done():
+		}
+		return &mcp.CallToolResult{}, nil, nil
+	})
+	serverSession, err := fakeServer.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	tr.serverSession = serverSession
+	tr.clientConn, err = clientTransport.Connect(ctx)
+	return tr.clientConn, err
+}
+
+// stop drops the current connection, optionally refusing new connections.
+func (tr *restartableMCPTransport) stop(refuseConnections bool) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.down = refuseConnections
+	_ = tr.clientConn.Close()
+	// Closing the server session waits for pending tool calls to complete.
+	go func(serverSession *mcp.ServerSession) { _ = serverSession.Close() }(tr.serverSession)
+}
+
+func (tr *restartableMCPTransport) connectCount() int {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.connects
+}
+
+func TestMCPServerWithClientSessionReconnect(t *testing.T) {
+	defaultBackoff := mcpReconnectBackoff
+	mcpReconnectBackoff = time.Millisecond
+	t.Cleanup(func() { mcpReconnectBackoff = defaultBackoff })
+
+	connect := func(t *testing.T) (*MCPServerWithClientSession, *restartableMCPTransport) {
+		t.Helper()
+		transport := newRestartableMCPTransport()
+		server := NewMCPServerWithClientSession(MCPServerWithClientSessionParams{
+			Name:      "test_server",
+			Transport: transport,
+		})
+		require.NoError(t, server.Connect(t.Context()))
+		t.Cleanup(func() { _ = server.Cleanup(context.Background()) })
+		return server, transport
+	}
+
+	t.Run("reconnects after server restart", func(t *testing.T) {
+		server, transport := connect(t)
+		agent := New("test_agent").WithMCPConfig(MCPConfig{MaxReconnectAttempts: 3})
+
+		transport.stop(false)
+
+		tools, err := server.ListTools(t.Context(), agent)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"echo", "slow"}, collectMCPToolNames(tools))
+		assert.Equal(t, 2, transport.connectCount())
+
+		// Tool calls made through the agent's function tools reconnect too.
+		functionTools, err := MCPUtil().GetFunctionTools(t.Context(), server, false, agent)
+		require.NoError(t, err)
+		transport.stop(false)
+
+		var echoTool FunctionTool
+		for _, tool := range functionTools {
+			if tool.ToolName() == "echo" {
+				echoTool = tool.(FunctionTool)
+			}
+		}
+		result, err := echoTool.OnInvokeTool(t.Context(), "{}")
+		require.NoError(t, err)
+		assert.Equal(t, `{"type":"text","text":"echo"}`, result)
+		assert.Equal(t, 3, transport.connectCount())
+	})
+
+	t.Run("reconnection disabled by default", func(t *testing.T) {
+		server, transport := connect(t)
+		transport.stop(false)
+
+		_, err := server.ListTools(t.Context(), New("test_agent"))
+		var connErr MCPConnectionError
+		require.ErrorAs(t, err, &connErr)
+		assert.Equal(t, "test_server", connErr.ServerName)
+		assert.Zero(t, connErr.Attempts)
+		assert.Equal(t, 1, transport.connectCount())
+	})
+
+	t.Run("terminal error when reconnection keeps failing", func(t *testing.T) {
+		server, transport := connect(t)
+		transport.stop(true)
+
+		ctx := contextWithMCPMaxReconnectAttempts(t.Context(), 3)
+		_, err := server.CallTool(ctx, "echo", nil)
+		var connErr MCPConnectionError
+		require.ErrorAs(t, err, &connErr)
+		assert.Equal(t, 3, connErr.Attempts)
+		assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+		assert.Equal(t, 4, transport.connectCount())
+	})
+
+	t.Run("in-flight call during disconnect", func(t *testing.T) {
+		server, transport := connect(t)
+
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := server.CallTool(t.Context(), "slow", nil)
+			errCh <- err
+		}()
+		<-transport.toolStarted
+		transport.stop(true)
+
+		select {
+		case err := <-errCh:
+			var connErr MCPConnectionError
+			assert.ErrorAs(t, err, &connErr)
+		case <-time.After(5 * time.Second):
+			t.Fatal("in-flight tool call must not hang after disconnection")
+		}
+		close(transport.releaseTool)
+	})
+}
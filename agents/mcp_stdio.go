@@ -0,0 +1,78 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os/exec"
+	"slices"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// mcpStdioProcessTransport is an mcp.Transport starting a new server process
+// on each connection, speaking MCP over its stdin and stdout.
+type mcpStdioProcessTransport struct {
+	executable string
+	args       []string
+	env        []string
+	stderr     io.Writer
+}
+
+func (t *mcpStdioProcessTransport) Connect(ctx context.Context) (mcp.Connection, error) {
+	cmd := exec.Command(t.executable, t.args...)
+	if t.env != nil {
+		cmd.Env = slices.Clone(t.env)
+	}
+	cmd.Stderr = t.stderr
+	return (&mcp.CommandTransport{Command: cmd}).Connect(ctx)
+}
+
+// mcpStderrLogger is an io.Writer logging each line written to it, used to
+// forward the standard error of MCP server processes to the package logger.
+type mcpStderrLogger struct {
+	serverName string
+	mu         sync.Mutex
+	buf        []byte
+}
+
+func newMCPStderrLogger(serverName string) *mcpStderrLogger {
+	return &mcpStderrLogger{serverName: serverName}
+}
+
+func (w *mcpStderrLogger) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := bytes.TrimRight(w.buf[:i], "\r")
+		if len(line) > 0 {
+			Logger().Info("MCP server stderr",
+				slog.String("server", w.serverName),
+				slog.String("line", string(line)))
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
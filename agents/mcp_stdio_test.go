@@ -0,0 +1,154 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package agents
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// captureLogs redirects the package logger to a buffer for the duration of the test.
+func captureLogs(t *testing.T) *lockedBuffer {
+	t.Helper()
+	buf := new(lockedBuffer)
+	SetLogger(slog.New(slog.NewTextHandler(buf, nil)))
+	t.Cleanup(ResetLogger)
+	return buf
+}
+
+var testMCPServerPIDRegexp = regexp.MustCompile(`test MCP server started: pid (\d+)`)
+
+// loggedMCPServerPID returns the PID of the test MCP server process, taken
+// from its standard error forwarded to the logs.
+func loggedMCPServerPID(t *testing.T, logs *lockedBuffer) int {
+	t.Helper()
+	var match []string
+	require.Eventually(t, func() bool {
+		match = testMCPServerPIDRegexp.FindStringSubmatch(logs.String())
+		return match != nil
+	}, 5*time.Second, 10*time.Millisecond, "the server stderr must be logged")
+	pid, err := strconv.Atoi(match[1])
+	require.NoError(t, err)
+	return pid
+}
+
+func processTerminated(pid int) bool {
+	return syscall.Kill(pid, 0) != nil
+}
+
+func newTestMCPServerStdio(t *testing.T) *MCPServerStdio {
+	t.Helper()
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	return NewMCPServerStdio(MCPServerStdioParams{
+		Executable: exe,
+		Args:       []string{"-test.run=^$"},
+		Env:        append(os.Environ(), runAsMCPServer+"=true"),
+	})
+}
+
+func TestMCPServerStdioExecutable(t *testing.T) {
+	t.Run("lists tools and forwards stderr", func(t *testing.T) {
+		logs := captureLogs(t)
+		server := newTestMCPServerStdio(t)
+
+		var pid int
+		err := server.Run(t.Context(), func(ctx context.Context, server *MCPServerWithClientSession) error {
+			tools, err := server.ListTools(ctx, New("test_agent"))
+			require.NoError(t, err)
+			assert.Contains(t, collectMCPToolNames(tools), "add_nop_tool")
+
+			pid = loggedMCPServerPID(t, logs)
+			assert.False(t, processTerminated(pid))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Contains(t, logs.String(), "MCP server stderr")
+		assert.Eventually(t, func() bool { return processTerminated(pid) }, 10*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("cleans up on context cancellation", func(t *testing.T) {
+		logs := captureLogs(t)
+		server := newTestMCPServerStdio(t)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		var pid int
+		err := server.Run(ctx, func(ctx context.Context, server *MCPServerWithClientSession) error {
+			pid = loggedMCPServerPID(t, logs)
+			cancel()
+
+			// The process is terminated even if this function doesn't return yet.
+			assert.Eventually(t, func() bool { return processTerminated(pid) }, 10*time.Second, 10*time.Millisecond)
+			assert.Nil(t, server.currentSession())
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("restarts the process on reconnection", func(t *testing.T) {
+		server := newTestMCPServerStdio(t)
+		agent := New("test_agent").WithMCPConfig(MCPConfig{MaxReconnectAttempts: 1})
+
+		err := server.Run(t.Context(), func(ctx context.Context, server *MCPServerWithClientSession) error {
+			_ = server.currentSession().Close()
+
+			tools, err := server.ListTools(ctx, agent)
+			require.NoError(t, err)
+			assert.Contains(t, collectMCPToolNames(tools), "add_nop_tool")
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		assert.Panics(t, func() { NewMCPServerStdio(MCPServerStdioParams{}) })
+		assert.Panics(t, func() {
+			NewMCPServerStdio(MCPServerStdioParams{
+				Command:    createMCPServerCommand(t),
+				Executable: "server",
+			})
+		})
+	})
+}
@@ -16,6 +16,7 @@ package agents
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/exec"
@@ -41,6 +42,7 @@ func runMCPServer() {
 		},
 	)
 
+	fmt.Fprintf(os.Stderr, "test MCP server started: pid %d\n", os.Getpid())
 	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil {
 		log.Fatal(err)
 	}
@@ -148,6 +148,13 @@ func (u mcpUtil) GetFunctionTools(
 		if err != nil {
 			return nil, err
 		}
+		if agent != nil && agent.MCPConfig.MaxReconnectAttempts > 0 {
+			invoke := funcTool.OnInvokeTool
+			funcTool.OnInvokeTool = func(ctx context.Context, arguments string) (any, error) {
+				ctx = contextWithMCPMaxReconnectAttempts(ctx, agent.MCPConfig.MaxReconnectAttempts)
+				return invoke(ctx, arguments)
+			}
+		}
 		functionTools[i] = funcTool
 	}
 	return functionTools, nil
@@ -0,0 +1,94 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+)
+
+var (
+	modelDefaults        = make(map[string]modelsettings.ModelSettings)
+	modelDefaultsMu      sync.RWMutex
+	defaultModelSettings atomic.Pointer[modelsettings.ModelSettings]
+)
+
+// SetDefaultModelSettings sets the default model settings to use for all the
+// agents and models, e.g. a default temperature for the whole application.
+//
+// They are the lowest-precedence layer of the model settings: they are
+// overlaid by the defaults of the specific model (see SetModelDefaults), then
+// by the agent's ModelSettings, and finally by the RunConfig.ModelSettings.
+func SetDefaultModelSettings(settings modelsettings.ModelSettings) {
+	defaultModelSettings.Store(&settings)
+}
+
+// GetDefaultModelSettings returns the default model settings set with
+// SetDefaultModelSettings, if any, or empty settings otherwise.
+func GetDefaultModelSettings() modelsettings.ModelSettings {
+	v := defaultModelSettings.Load()
+	if v == nil {
+		return modelsettings.ModelSettings{}
+	}
+	return *v
+}
+
+// ClearDefaultModelSettings removes the default model settings set with
+// SetDefaultModelSettings.
+func ClearDefaultModelSettings() {
+	defaultModelSettings.Store(nil)
+}
+
+// SetModelDefaults registers the default model settings to use for the
+// named model (e.g. a temperature of 1 for "gpt-5").
+//
+// The model settings are resolved in layers, each overlaying the previous
+// one: the global defaults (see SetDefaultModelSettings), the defaults of
+// the specific model, the agent's ModelSettings, and finally the
+// RunConfig.ModelSettings. Registering the defaults of a model again
+// replaces the previous ones.
+func SetModelDefaults(model string, settings modelsettings.ModelSettings) {
+	modelDefaultsMu.Lock()
+	defer modelDefaultsMu.Unlock()
+	modelDefaults[model] = settings
+}
+
+// GetModelDefaults returns the default model settings registered for the
+// named model with SetModelDefaults. A model name with the "openai/" prefix
+// (see MultiProvider) also matches the defaults registered without it.
+func GetModelDefaults(model string) (modelsettings.ModelSettings, bool) {
+	modelDefaultsMu.RLock()
+	defer modelDefaultsMu.RUnlock()
+
+	if settings, ok := modelDefaults[model]; ok {
+		return settings, true
+	}
+	if name, ok := strings.CutPrefix(model, "openai/"); ok {
+		settings, ok := modelDefaults[name]
+		return settings, ok
+	}
+	return modelsettings.ModelSettings{}, false
+}
+
+// ClearModelDefaults removes all the default model settings registered with
+// SetModelDefaults.
+func ClearModelDefaults() {
+	modelDefaultsMu.Lock()
+	defer modelDefaultsMu.Unlock()
+	clear(modelDefaults)
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runWithModelDefaults(
+	t *testing.T,
+	agentSettings modelsettings.ModelSettings,
+	runSettings modelsettings.ModelSettings,
+) modelsettings.ModelSettings {
+	t.Helper()
+
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").WithModel("model-x").WithModelSettings(agentSettings)
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		ModelProvider: NewDummyProvider(model),
+		ModelSettings: runSettings,
+	}}
+	_, err := runner.Run(t.Context(), agent, "hi")
+	require.NoError(t, err)
+
+	return model.LastTurnArgs.ModelSettings
+}
+
+func TestModelDefaultsApply(t *testing.T) {
+	t.Cleanup(agents.ClearModelDefaults)
+	agents.SetModelDefaults("model-x", modelsettings.ModelSettings{
+		Temperature: param.NewOpt(1.0),
+		TopP:        param.NewOpt(0.5),
+	})
+
+	settings := runWithModelDefaults(t, modelsettings.ModelSettings{}, modelsettings.ModelSettings{})
+	assert.Equal(t, param.NewOpt(1.0), settings.Temperature)
+	assert.Equal(t, param.NewOpt(0.5), settings.TopP)
+}
+
+func TestModelDefaultsAreOverriddenByAgentAndRunSettings(t *testing.T) {
+	t.Cleanup(agents.ClearModelDefaults)
+	agents.SetModelDefaults("model-x", modelsettings.ModelSettings{
+		Temperature: param.NewOpt(1.0),
+		TopP:        param.NewOpt(0.5),
+		MaxTokens:   param.NewOpt[int64](100),
+	})
+
+	settings := runWithModelDefaults(t,
+		modelsettings.ModelSettings{Temperature: param.NewOpt(0.7), TopP: param.NewOpt(0.6)},
+		modelsettings.ModelSettings{TopP: param.NewOpt(0.9)},
+	)
+	assert.Equal(t, param.NewOpt(0.7), settings.Temperature)
+	assert.Equal(t, param.NewOpt(0.9), settings.TopP)
+	assert.Equal(t, param.NewOpt[int64](100), settings.MaxTokens)
+}
+
+func TestModelDefaultsOfOtherModelsDoNotApply(t *testing.T) {
+	t.Cleanup(agents.ClearModelDefaults)
+	agents.SetModelDefaults("model-y", modelsettings.ModelSettings{Temperature: param.NewOpt(1.0)})
+
+	settings := runWithModelDefaults(t, modelsettings.ModelSettings{}, modelsettings.ModelSettings{})
+	assert.False(t, settings.Temperature.Valid())
+}
+
+func TestGetModelDefaults(t *testing.T) {
+	t.Cleanup(agents.ClearModelDefaults)
+	defaults := modelsettings.ModelSettings{Temperature: param.NewOpt(0.7)}
+	agents.SetModelDefaults("gpt-4o", defaults)
+
+	v, ok := agents.GetModelDefaults("gpt-4o")
+	assert.True(t, ok)
+	assert.Equal(t, defaults, v)
+
+	v, ok = agents.GetModelDefaults("openai/gpt-4o")
+	assert.True(t, ok)
+	assert.Equal(t, defaults, v)
+
+	_, ok = agents.GetModelDefaults("gpt-5")
+	assert.False(t, ok)
+
+	agents.ClearModelDefaults()
+	_, ok = agents.GetModelDefaults("gpt-4o")
+	assert.False(t, ok)
+}
+
+func TestDefaultModelSettingsApply(t *testing.T) {
+	t.Cleanup(agents.ClearDefaultModelSettings)
+	agents.SetDefaultModelSettings(modelsettings.ModelSettings{
+		Temperature: param.NewOpt(0.3),
+		MaxTokens:   param.NewOpt[int64](200),
+	})
+
+	settings := runWithModelDefaults(t, modelsettings.ModelSettings{}, modelsettings.ModelSettings{})
+	assert.Equal(t, param.NewOpt(0.3), settings.Temperature)
+	assert.Equal(t, param.NewOpt[int64](200), settings.MaxTokens)
+}
+
+func TestDefaultModelSettingsPrecedence(t *testing.T) {
+	t.Cleanup(agents.ClearDefaultModelSettings)
+	t.Cleanup(agents.ClearModelDefaults)
+	agents.SetDefaultModelSettings(modelsettings.ModelSettings{
+		Temperature:      param.NewOpt(0.3),
+		TopP:             param.NewOpt(0.1),
+		MaxTokens:        param.NewOpt[int64](200),
+		FrequencyPenalty: param.NewOpt(0.2),
+	})
+	agents.SetModelDefaults("model-x", modelsettings.ModelSettings{
+		TopP: param.NewOpt(0.5),
+	})
+
+	settings := runWithModelDefaults(t,
+		modelsettings.ModelSettings{Temperature: param.NewOpt(0.7)},
+		modelsettings.ModelSettings{MaxTokens: param.NewOpt[int64](50)},
+	)
+	assert.Equal(t, param.NewOpt(0.7), settings.Temperature)
+	assert.Equal(t, param.NewOpt(0.5), settings.TopP)
+	assert.Equal(t, param.NewOpt[int64](50), settings.MaxTokens)
+	assert.Equal(t, param.NewOpt(0.2), settings.FrequencyPenalty)
+}
+
+func TestClearDefaultModelSettings(t *testing.T) {
+	agents.SetDefaultModelSettings(modelsettings.ModelSettings{Temperature: param.NewOpt(0.3)})
+	assert.Equal(t, param.NewOpt(0.3), agents.GetDefaultModelSettings().Temperature)
+
+	agents.ClearDefaultModelSettings()
+	assert.Equal(t, modelsettings.ModelSettings{}, agents.GetDefaultModelSettings())
+
+	settings := runWithModelDefaults(t, modelsettings.ModelSettings{}, modelsettings.ModelSettings{})
+	assert.False(t, settings.Temperature.Valid())
+}
@@ -247,6 +247,19 @@ func (chatCmplConverter) ExtractAllContentFromResponseInputContentUnionParams(
 					Type: constant.ValueOf[constant.File](),
 				},
 			}
+		} else if audio, ok := inputAudioFromContent(c); ok {
+			if err := validateInputAudio(audio); err != nil {
+				return nil, err
+			}
+			out[i] = openai.ChatCompletionContentPartUnionParam{
+				OfInputAudio: &openai.ChatCompletionContentPartInputAudioParam{
+					InputAudio: openai.ChatCompletionContentPartInputAudioInputAudioParam{
+						Data:   audio.InputAudio.Data,
+						Format: audio.InputAudio.Format,
+					},
+					Type: constant.ValueOf[constant.InputAudio](),
+				},
+			}
 		} else {
 			return nil, UserErrorf("unknown content: %+v", c)
 		}
@@ -516,12 +529,8 @@ func (conv chatCmplConverter) itemsToMessages(items []TResponseInputItem) ([]ope
 			if !param.IsOmitted(funcOutput.Output.OfString) {
 				outputStr = funcOutput.Output.OfString.Value
 			} else if !param.IsOmitted(funcOutput.Output.OfResponseFunctionCallOutputItemArray) {
-				// Handle array output - serialize to JSON
-				b, err := json.Marshal(funcOutput.Output.OfResponseFunctionCallOutputItemArray)
-				if err != nil {
-					return nil, fmt.Errorf("failed to marshal function output array: %w", err)
-				}
-				outputStr = string(b)
+				// Handle array output - only text is supported
+				outputStr = functionCallOutputItemsToText(funcOutput.Output.OfResponseFunctionCallOutputItemArray)
 			} else {
 				return nil, UserErrorf("function call output has neither OfString nor OfResponseFunctionCallOutputItemArray set: %+v", funcOutput.Output)
 			}
@@ -62,14 +62,29 @@ func (sn *SequenceNumber) GetAndIncrement() int64 {
 	return n
 }
 
+func chunkUsageHasTokens(u openai.CompletionUsage) bool {
+	return u.PromptTokens != 0 || u.CompletionTokens != 0 || u.TotalTokens != 0
+}
+
 type chatCmplStreamHandler struct{}
 
 func ChatCmplStreamHandler() chatCmplStreamHandler { return chatCmplStreamHandler{} }
 
-func (chatCmplStreamHandler) HandleStream(
+func (h chatCmplStreamHandler) HandleStream(
 	response responses.Response,
 	stream *ssestream.Stream[openai.ChatCompletionChunk],
 	yield func(TResponseStreamEvent) error,
+) error {
+	return h.handleStream(response, stream, yield, nil)
+}
+
+// handleStream is like HandleStream, also passing each chunk to onChunk, if
+// not nil, before handling it.
+func (chatCmplStreamHandler) handleStream(
+	response responses.Response,
+	stream *ssestream.Stream[openai.ChatCompletionChunk],
+	yield func(TResponseStreamEvent) error,
+	onChunk func(openai.ChatCompletionChunk),
 ) (err error) {
 	defer func() {
 		if e := stream.Close(); e != nil {
@@ -83,6 +98,9 @@ func (chatCmplStreamHandler) HandleStream(
 
 	for stream.Next() {
 		chunk := stream.Current()
+		if onChunk != nil {
+			onChunk(chunk)
+		}
 
 		if !state.Started {
 			state.Started = true
@@ -95,8 +113,11 @@ func (chatCmplStreamHandler) HandleStream(
 			}
 		}
 
-		// This is always set by the OpenAI API, but not by others
-		if !reflect.ValueOf(chunk.Usage).IsZero() {
+		// This is always set by the OpenAI API, but not by others.
+		// Some providers report usage in a separate final chunk, and send
+		// empty usage objects along the other chunks: only keep usage
+		// actually reporting tokens, so it cannot be overwritten.
+		if !reflect.ValueOf(chunk.Usage).IsZero() && chunkUsageHasTokens(chunk.Usage) {
 			completionUsage = &chunk.Usage
 		}
 
@@ -0,0 +1,149 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// FallbackModel is a Model which tries a list of models in order, falling
+// back to the next one when a call fails with a retryable error, e.g.
+// because the previous model is unavailable or rate-limited.
+//
+// A streamed call only falls back if the failed model did not stream any
+// event yet.
+type FallbackModel struct {
+	// The models to try, in order.
+	Models []Model
+
+	// Optional function which reports whether to fall back to the next model
+	// after an error. If nil, IsRetryableModelError is used.
+	ShouldFallback func(error) bool
+}
+
+// NewFallbackModel returns a FallbackModel which tries the given models in
+// order.
+func NewFallbackModel(models ...Model) *FallbackModel {
+	return &FallbackModel{Models: models}
+}
+
+// IsRetryableModelError reports whether a model call which failed with err
+// can be retried, possibly with another model: that is the case for network
+// errors, and for API errors with status code 408 (request timeout), 409
+// (conflict), 429 (too many requests) or 5xx (server errors).
+// Context cancellation and deadline errors are not retryable.
+func IsRetryableModelError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) {
+		switch code := apiErr.StatusCode; {
+		case code == http.StatusRequestTimeout, code == http.StatusConflict, code == http.StatusTooManyRequests:
+			return true
+		default:
+			return code >= 500
+		}
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func (m *FallbackModel) GetResponse(ctx context.Context, params ModelResponseParams) (*ModelResponse, error) {
+	var errs []error
+	for i, model := range m.Models {
+		response, err := model.GetResponse(ctx, params)
+		if err == nil {
+			response.FallbackIndex = i
+			return response, nil
+		}
+		errs = append(errs, err)
+		if !m.shouldFallback(ctx, err) {
+			break
+		}
+	}
+	return nil, m.joinErrors(errs)
+}
+
+func (m *FallbackModel) StreamResponse(ctx context.Context, params ModelResponseParams, yield ModelStreamResponseCallback) error {
+	// The index is recorded before each attempt, since the runner builds the
+	// ModelResponse while streaming. Nested fallback models don't record it,
+	// as for non-streamed responses, where the outermost index is reported.
+	index := fallbackIndexRecorderFromContext(ctx)
+	if index != nil {
+		ctx = contextWithFallbackIndexRecorder(ctx, nil)
+	}
+
+	var errs []error
+	for i, model := range m.Models {
+		if index != nil {
+			index.Store(int64(i))
+		}
+		streamed := false
+		err := model.StreamResponse(ctx, params, func(ctx context.Context, event TResponseStreamEvent) error {
+			streamed = true
+			return yield(ctx, event)
+		})
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+		if streamed || !m.shouldFallback(ctx, err) {
+			break
+		}
+	}
+	return m.joinErrors(errs)
+}
+
+type fallbackIndexRecorderKey struct{}
+
+// contextWithFallbackIndexRecorder returns a context through which a
+// FallbackModel records the index of the model serving a streamed response.
+func contextWithFallbackIndexRecorder(ctx context.Context, index *atomic.Int64) context.Context {
+	return context.WithValue(ctx, fallbackIndexRecorderKey{}, index)
+}
+
+func fallbackIndexRecorderFromContext(ctx context.Context) *atomic.Int64 {
+	index, _ := ctx.Value(fallbackIndexRecorderKey{}).(*atomic.Int64)
+	return index
+}
+
+func (m *FallbackModel) shouldFallback(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if m.ShouldFallback != nil {
+		return m.ShouldFallback(err)
+	}
+	return IsRetryableModelError(err)
+}
+
+func (m *FallbackModel) joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return NewUserError("FallbackModel has no models")
+	case 1:
+		return errs[0]
+	default:
+		return fmt.Errorf("all of the %d models tried failed: %w", len(errs), errors.Join(errs...))
+	}
+}
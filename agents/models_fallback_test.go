@@ -0,0 +1,159 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAPIError(statusCode int) error {
+	return &openai.Error{
+		StatusCode: statusCode,
+		Request:    httptest.NewRequest(http.MethodPost, "https://example.com/v1/responses", nil),
+		Response:   &http.Response{StatusCode: statusCode},
+	}
+}
+
+func TestIsRetryableModelError(t *testing.T) {
+	testCases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("boom"), false},
+		{context.Canceled, false},
+		{newAPIError(http.StatusBadRequest), false},
+		{newAPIError(http.StatusRequestTimeout), true},
+		{newAPIError(http.StatusConflict), true},
+		{newAPIError(http.StatusTooManyRequests), true},
+		{newAPIError(http.StatusInternalServerError), true},
+		{newAPIError(http.StatusServiceUnavailable), true},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, agents.IsRetryableModelError(tc.err), "%v", tc.err)
+	}
+}
+
+func TestFallbackModel(t *testing.T) {
+	for _, streamed := range []bool{false, true} {
+		t.Run(map[bool]string{false: "GetResponse", true: "StreamResponse"}[streamed], func(t *testing.T) {
+			primary := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+				Error: newAPIError(http.StatusTooManyRequests),
+			})
+			secondary := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+				Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("from secondary")},
+			})
+			agent := agents.New("test").WithModelInstance(agents.NewFallbackModel(primary, secondary))
+
+			if streamed {
+				result, err := agents.RunStreamed(t.Context(), agent, "hi")
+				require.NoError(t, err)
+				require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+				assert.Equal(t, "from secondary", result.FinalOutput())
+				require.Len(t, result.RawResponses(), 1)
+				assert.Equal(t, 1, result.RawResponses()[0].FallbackIndex)
+			} else {
+				result, err := agents.Run(t.Context(), agent, "hi")
+				require.NoError(t, err)
+				assert.Equal(t, "from secondary", result.FinalOutput)
+				require.Len(t, result.RawResponses, 1)
+				assert.Equal(t, 1, result.RawResponses[0].FallbackIndex)
+			}
+			assert.NotNil(t, primary.LastTurnArgs.Input)
+			assert.NotNil(t, secondary.LastTurnArgs.Input)
+		})
+	}
+}
+
+func TestFallbackModelNonRetryableError(t *testing.T) {
+	primary := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Error: newAPIError(http.StatusBadRequest),
+	})
+	secondary := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("from secondary")},
+	})
+	model := agents.NewFallbackModel(primary, secondary)
+
+	_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{Input: agents.InputString("hi")})
+	var apiErr *openai.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Nil(t, secondary.LastTurnArgs.Input)
+}
+
+func TestFallbackModelAllFail(t *testing.T) {
+	first := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Error: newAPIError(http.StatusServiceUnavailable),
+	})
+	second := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Error: newAPIError(http.StatusTooManyRequests),
+	})
+	model := agents.NewFallbackModel(first, second)
+
+	_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{Input: agents.InputString("hi")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "all of the 2 models tried failed")
+	assert.Contains(t, err.Error(), "503")
+	assert.Contains(t, err.Error(), "429")
+}
+
+// failingAfterFirstEventModel streams one event and then fails.
+type failingAfterFirstEventModel struct {
+	agentstesting.FakeModel
+}
+
+func (m *failingAfterFirstEventModel) StreamResponse(
+	ctx context.Context,
+	_ agents.ModelResponseParams,
+	yield agents.ModelStreamResponseCallback,
+) error {
+	if err := yield(ctx, agents.TResponseStreamEvent{Type: "response.created"}); err != nil {
+		return err
+	}
+	return newAPIError(http.StatusInternalServerError)
+}
+
+func TestFallbackModelDoesNotFallBackAfterStreamStarted(t *testing.T) {
+	secondary := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("from secondary")},
+	})
+	model := agents.NewFallbackModel(&failingAfterFirstEventModel{}, secondary)
+
+	var events []string
+	err := model.StreamResponse(
+		t.Context(),
+		agents.ModelResponseParams{Input: agents.InputString("hi")},
+		func(_ context.Context, event agents.TResponseStreamEvent) error {
+			events = append(events, event.Type)
+			return nil
+		},
+	)
+	var apiErr *openai.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, []string{"response.created"}, events)
+	assert.Nil(t, secondary.LastTurnArgs.Input)
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go/v3/responses"
+)
+
+// FixtureModel is a Model which replays the responses recorded in a
+// RunFixture, in order, regardless of its input.
+//
+// To replay a run, use it with the same agents (tools, handoffs, output
+// type) and the same input of the recorded run.
+type FixtureModel struct {
+	fixture *RunFixture
+	next    int
+	mu      sync.Mutex
+}
+
+// NewFixtureModel creates a FixtureModel replaying the given fixture.
+func NewFixtureModel(fixture *RunFixture) *FixtureModel {
+	return &FixtureModel{fixture: fixture}
+}
+
+func (m *FixtureModel) nextResponse() (RunFixtureResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.next >= len(m.fixture.Responses) {
+		return RunFixtureResponse{}, NewUserError("run fixture has no more recorded responses")
+	}
+	resp := m.fixture.Responses[m.next]
+	m.next++
+	return resp, nil
+}
+
+func (m *FixtureModel) GetResponse(context.Context, ModelResponseParams) (*ModelResponse, error) {
+	resp, err := m.nextResponse()
+	if err != nil {
+		return nil, err
+	}
+
+	u := usage.NewUsage()
+	if resp.Usage != nil {
+		u.Add(resp.Usage)
+		u.Requests = 0 // counted by the runner
+	}
+
+	return &ModelResponse{
+		Output:     resp.Output,
+		Usage:      u,
+		ResponseID: resp.ResponseID,
+	}, nil
+}
+
+func (m *FixtureModel) StreamResponse(ctx context.Context, _ ModelResponseParams, yield ModelStreamResponseCallback) error {
+	resp, err := m.nextResponse()
+	if err != nil {
+		return err
+	}
+
+	var responseUsage responses.ResponseUsage
+	if u := resp.Usage; u != nil {
+		responseUsage = responses.ResponseUsage{
+			InputTokens:         int64(u.InputTokens),
+			InputTokensDetails:  u.InputTokensDetails,
+			OutputTokens:        int64(u.OutputTokens),
+			OutputTokensDetails: u.OutputTokensDetails,
+			TotalTokens:         int64(u.TotalTokens),
+		}
+	}
+
+	return yield(ctx, TResponseStreamEvent{ // responses.ResponseCompletedEvent
+		Response: responses.Response{
+			ID:     resp.ResponseID,
+			Object: "response",
+			Output: resp.Output,
+			Usage:  responseUsage,
+		},
+		Type: "response.completed",
+	})
+}
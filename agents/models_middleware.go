@@ -0,0 +1,230 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+)
+
+// ModelMiddleware adds behavior around the calls to a Model, such as
+// logging, caching, retrying or rate limiting. It is applied to a Model with
+// ChainModelMiddleware.
+//
+// Each function receives the next step of the chain, which it is expected to
+// call, unless it wants to short-circuit the call. A nil function lets the
+// calls through unchanged.
+type ModelMiddleware struct {
+	GetResponse func(
+		ctx context.Context,
+		params ModelResponseParams,
+		next func(context.Context, ModelResponseParams) (*ModelResponse, error),
+	) (*ModelResponse, error)
+
+	StreamResponse func(
+		ctx context.Context,
+		params ModelResponseParams,
+		yield ModelStreamResponseCallback,
+		next func(context.Context, ModelResponseParams, ModelStreamResponseCallback) error,
+	) error
+}
+
+// ChainModelMiddleware returns a Model which calls the inner Model through the
+// given middlewares. The first middleware is the outermost one, that is, it is
+// the first one to be called and the last one to return.
+func ChainModelMiddleware(inner Model, mw ...ModelMiddleware) Model {
+	model := inner
+	for i := len(mw) - 1; i >= 0; i-- {
+		model = middlewareModel{middleware: mw[i], next: model}
+	}
+	return model
+}
+
+type middlewareModel struct {
+	middleware ModelMiddleware
+	next       Model
+}
+
+func (m middlewareModel) GetResponse(ctx context.Context, params ModelResponseParams) (*ModelResponse, error) {
+	if m.middleware.GetResponse == nil {
+		return m.next.GetResponse(ctx, params)
+	}
+	return m.middleware.GetResponse(ctx, params, m.next.GetResponse)
+}
+
+func (m middlewareModel) StreamResponse(ctx context.Context, params ModelResponseParams, yield ModelStreamResponseCallback) error {
+	if m.middleware.StreamResponse == nil {
+		return m.next.StreamResponse(ctx, params, yield)
+	}
+	return m.middleware.StreamResponse(ctx, params, yield, m.next.StreamResponse)
+}
+
+// LoggingModelMiddleware returns a ModelMiddleware which logs each model call,
+// at debug level, with its duration and error, if any.
+// If logger is nil, the logger returned by Logger is used at the time of each call.
+func LoggingModelMiddleware(logger *slog.Logger) ModelMiddleware {
+	getLogger := func() *slog.Logger {
+		if logger != nil {
+			return logger
+		}
+		return Logger()
+	}
+	logCall := func(ctx context.Context, method string, start time.Time, err error) {
+		attrs := []slog.Attr{
+			slog.String("method", method),
+			slog.Int64(logKeyDurationMs, time.Since(start).Milliseconds()),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		getLogger().LogAttrs(ctx, slog.LevelDebug, "Model call completed", attrs...)
+	}
+
+	return ModelMiddleware{
+		GetResponse: func(
+			ctx context.Context,
+			params ModelResponseParams,
+			next func(context.Context, ModelResponseParams) (*ModelResponse, error),
+		) (*ModelResponse, error) {
+			start := time.Now()
+			response, err := next(ctx, params)
+			logCall(ctx, "GetResponse", start, err)
+			return response, err
+		},
+		StreamResponse: func(
+			ctx context.Context,
+			params ModelResponseParams,
+			yield ModelStreamResponseCallback,
+			next func(context.Context, ModelResponseParams, ModelStreamResponseCallback) error,
+		) error {
+			start := time.Now()
+			err := next(ctx, params, yield)
+			logCall(ctx, "StreamResponse", start, err)
+			return err
+		},
+	}
+}
+
+// RateLimitModelMiddleware returns a ModelMiddleware which limits the rate of
+// the model calls with a token bucket, allowing on average requestsPerSecond
+// calls per second, with bursts of at most burst calls.
+//
+// A call waits until it is allowed, or returns the error of ctx if it is done
+// first. A single middleware can be shared by multiple models, so that they
+// share the same limit.
+func RateLimitModelMiddleware(requestsPerSecond float64, burst int) ModelMiddleware {
+	bucket := newTokenBucket(requestsPerSecond, burst)
+	return ModelMiddleware{
+		GetResponse: func(
+			ctx context.Context,
+			params ModelResponseParams,
+			next func(context.Context, ModelResponseParams) (*ModelResponse, error),
+		) (*ModelResponse, error) {
+			if err := bucket.wait(ctx); err != nil {
+				return nil, err
+			}
+			return next(ctx, params)
+		},
+		StreamResponse: func(
+			ctx context.Context,
+			params ModelResponseParams,
+			yield ModelStreamResponseCallback,
+			next func(context.Context, ModelResponseParams, ModelStreamResponseCallback) error,
+		) error {
+			if err := bucket.wait(ctx); err != nil {
+				return err
+			}
+			return next(ctx, params, yield)
+		},
+	}
+}
+
+// tokenBucket is a simple token bucket rate limiter.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	capacity := math.Max(float64(burst), 1)
+	return &tokenBucket{
+		rate:     rate,
+		capacity: capacity,
+		tokens:   capacity,
+		last:     time.Now(),
+	}
+}
+
+// wait takes a token from the bucket, waiting for it to be available.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	return b.waitFor(ctx, true)
+}
+
+// waitFor waits for a token to be available, taking it if take is true.
+func (b *tokenBucket) waitFor(ctx context.Context, take bool) error {
+	for {
+		delay := b.reserve(take)
+		if delay == 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve checks whether a token is available, taking it if take is true,
+// and returns zero. Otherwise, it returns how long to wait before trying again.
+func (b *tokenBucket) reserve(take bool) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+
+	if b.tokens >= 1 {
+		if take {
+			b.tokens--
+		}
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Hour
+	}
+	return max(time.Duration((1-b.tokens)/b.rate*float64(time.Second)), time.Millisecond)
+}
+
+// consume removes n tokens from the bucket, possibly leaving it in debt, so
+// that subsequent calls to wait block until the debt is paid off.
+func (b *tokenBucket) consume(n float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.tokens -= n
+}
+
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordingMiddleware(name string, calls *[]string) agents.ModelMiddleware {
+	return agents.ModelMiddleware{
+		GetResponse: func(
+			ctx context.Context,
+			params agents.ModelResponseParams,
+			next func(context.Context, agents.ModelResponseParams) (*agents.ModelResponse, error),
+		) (*agents.ModelResponse, error) {
+			*calls = append(*calls, name+" before")
+			response, err := next(ctx, params)
+			*calls = append(*calls, name+" after")
+			return response, err
+		},
+		StreamResponse: func(
+			ctx context.Context,
+			params agents.ModelResponseParams,
+			yield agents.ModelStreamResponseCallback,
+			next func(context.Context, agents.ModelResponseParams, agents.ModelStreamResponseCallback) error,
+		) error {
+			*calls = append(*calls, name+" before")
+			err := next(ctx, params, yield)
+			*calls = append(*calls, name+" after")
+			return err
+		},
+	}
+}
+
+func TestChainModelMiddlewareOrder(t *testing.T) {
+	for _, streamed := range []bool{false, true} {
+		t.Run(map[bool]string{false: "GetResponse", true: "StreamResponse"}[streamed], func(t *testing.T) {
+			var calls []string
+			inner := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+				Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+			})
+			model := agents.ChainModelMiddleware(
+				inner,
+				recordingMiddleware("first", &calls),
+				agents.ModelMiddleware{}, // lets calls through
+				recordingMiddleware("second", &calls),
+			)
+
+			agent := agents.New("test").WithModelInstance(model)
+			if streamed {
+				result, err := agents.RunStreamed(t.Context(), agent, "hi")
+				require.NoError(t, err)
+				require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+				assert.Equal(t, "done", result.FinalOutput())
+			} else {
+				result, err := agents.Run(t.Context(), agent, "hi")
+				require.NoError(t, err)
+				assert.Equal(t, "done", result.FinalOutput)
+			}
+
+			assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, calls)
+		})
+	}
+}
+
+func TestChainModelMiddlewareShortCircuit(t *testing.T) {
+	inner := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("from model")},
+	})
+	cached := agents.ModelMiddleware{
+		GetResponse: func(
+			context.Context,
+			agents.ModelResponseParams,
+			func(context.Context, agents.ModelResponseParams) (*agents.ModelResponse, error),
+		) (*agents.ModelResponse, error) {
+			return &agents.ModelResponse{
+				Output: []agents.TResponseOutputItem{agentstesting.GetTextMessage("from cache")},
+			}, nil
+		},
+	}
+
+	agent := agents.New("test").WithModelInstance(agents.ChainModelMiddleware(inner, cached))
+	result, err := agents.Run(t.Context(), agent, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "from cache", result.FinalOutput)
+	assert.Nil(t, inner.LastTurnArgs.Input)
+}
+
+func TestLoggingModelMiddleware(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	inner := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	model := agents.ChainModelMiddleware(inner, agents.LoggingModelMiddleware(logger))
+
+	_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{Input: agents.InputString("hi")})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `msg="Model call completed" method=GetResponse durationMs=`)
+}
+
+func TestRateLimitModelMiddleware(t *testing.T) {
+	inner := agentstesting.NewFakeModel(false, nil)
+	inner.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("1")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("2")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("3")}},
+	})
+	// One call every 50ms, with bursts of 2 calls.
+	model := agents.ChainModelMiddleware(inner, agents.RateLimitModelMiddleware(20, 2))
+	params := agents.ModelResponseParams{Input: agents.InputString("hi")}
+
+	start := time.Now()
+	for range 3 {
+		_, err := model.GetResponse(t.Context(), params)
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	t.Run("context done while waiting", func(t *testing.T) {
+		model := agents.ChainModelMiddleware(inner, agents.RateLimitModelMiddleware(0.001, 1))
+		inner.SetNextOutput(agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("ok")},
+		})
+		_, err := model.GetResponse(t.Context(), params)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		_, err = model.GetResponse(ctx, params)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -16,6 +16,7 @@ package agents
 
 import (
 	"maps"
+	"slices"
 	"strings"
 
 	"github.com/openai/openai-go/v3/packages/param"
@@ -87,12 +88,24 @@ func (mp *MultiProvider) getPrefixAndModelName(modelName string) (_, _ string) {
 	return "", modelName
 }
 
-func (mp *MultiProvider) createFallbackProvider(prefix string) (ModelProvider, error) {
+func (mp *MultiProvider) createFallbackProvider(modelName string) (ModelProvider, error) {
 	// We didn't implement any fallback provider, so here we always return an error
-	return nil, UserErrorf("unknown prefix %q", prefix)
+	return nil, NewUnknownModelError(modelName, mp.availablePrefixes())
 }
 
-func (mp *MultiProvider) getFallbackProvider(prefix string) (ModelProvider, error) {
+// availablePrefixes returns the sorted model name prefixes which can be
+// resolved to a provider.
+func (mp *MultiProvider) availablePrefixes() []string {
+	prefixes := []string{"openai"}
+	if mp.ProviderMap != nil {
+		prefixes = slices.AppendSeq(prefixes, maps.Keys(mp.ProviderMap.m))
+	}
+	prefixes = slices.AppendSeq(prefixes, maps.Keys(mp.fallbackProviders))
+	slices.Sort(prefixes)
+	return slices.Compact(prefixes)
+}
+
+func (mp *MultiProvider) getFallbackProvider(prefix, modelName string) (ModelProvider, error) {
 	if prefix == "" || prefix == "openai" {
 		return mp.OpenAIProvider, nil
 	}
@@ -100,7 +113,7 @@ func (mp *MultiProvider) getFallbackProvider(prefix string) (ModelProvider, erro
 		return fp, nil
 	}
 
-	fp, err := mp.createFallbackProvider(prefix)
+	fp, err := mp.createFallbackProvider(modelName)
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +124,8 @@ func (mp *MultiProvider) getFallbackProvider(prefix string) (ModelProvider, erro
 // GetModel returns a Model based on the model name. The model name can have a prefix, ending with
 // a "/", which will be used to look up the ModelProvider. If there is no prefix, we will use
 // the OpenAI provider.
+//
+// If the prefix can't be resolved to any provider, an UnknownModelError is returned.
 func (mp *MultiProvider) GetModel(modelName string) (Model, error) {
 	prefix, name := mp.getPrefixAndModelName(modelName)
 
@@ -120,7 +135,7 @@ func (mp *MultiProvider) GetModel(modelName string) (Model, error) {
 		}
 	}
 
-	fp, err := mp.getFallbackProvider(prefix)
+	fp, err := mp.getFallbackProvider(prefix, modelName)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,141 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiProviderGetModel(t *testing.T) {
+	fakeModel := agentstesting.NewFakeModel(false, nil)
+
+	providerMap := agents.NewMultiProviderMap()
+	providerMap.AddProvider("custom", NewDummyProvider(fakeModel))
+	providerMap.AddProvider("another", NewDummyProvider(fakeModel))
+
+	mp := agents.NewMultiProvider(agents.NewMultiProviderParams{
+		ProviderMap:  providerMap,
+		OpenaiAPIKey: param.NewOpt("fake-key"),
+	})
+
+	t.Run("mapped prefix", func(t *testing.T) {
+		model, err := mp.GetModel("custom/some-model")
+		require.NoError(t, err)
+		assert.Same(t, fakeModel, model)
+	})
+
+	t.Run("OpenAI model", func(t *testing.T) {
+		for _, name := range []string{"gpt-4.1", "openai/gpt-4.1"} {
+			model, err := mp.GetModel(name)
+			require.NoError(t, err)
+			assert.NotNil(t, model)
+		}
+	})
+
+	t.Run("unroutable prefix", func(t *testing.T) {
+		_, err := mp.GetModel("unknown/some-model")
+
+		var unknownErr agents.UnknownModelError
+		require.ErrorAs(t, err, &unknownErr)
+		assert.Equal(t, "unknown/some-model", unknownErr.ModelName)
+		assert.Equal(t, []string{"another", "custom", "openai"}, unknownErr.AvailablePrefixes)
+		assert.ErrorContains(t, err, `"unknown/some-model"`)
+		assert.ErrorContains(t, err, "another, custom, openai")
+		assert.ErrorAs(t, err, &agents.UserError{}, "should also be a UserError")
+	})
+
+	t.Run("unroutable prefix without provider map", func(t *testing.T) {
+		mp := agents.NewMultiProvider(agents.NewMultiProviderParams{
+			OpenaiAPIKey: param.NewOpt("fake-key"),
+		})
+		_, err := mp.GetModel("unknown/some-model")
+
+		var unknownErr agents.UnknownModelError
+		require.ErrorAs(t, err, &unknownErr)
+		assert.Equal(t, []string{"openai"}, unknownErr.AvailablePrefixes)
+	})
+}
+
+func TestRunWithUnknownModel(t *testing.T) {
+	agent := agents.New("test").WithModel("unknown/some-model")
+
+	_, err := agents.Runner{Config: agents.RunConfig{
+		ModelProvider: agents.NewMultiProvider(agents.NewMultiProviderParams{
+			OpenaiAPIKey: param.NewOpt("fake-key"),
+		}),
+	}}.Run(t.Context(), agent, "user_message")
+
+	var unknownErr agents.UnknownModelError
+	require.ErrorAs(t, err, &unknownErr)
+	assert.Equal(t, "unknown/some-model", unknownErr.ModelName)
+}
+
+func TestMultiProviderClientDefaultAPI(t *testing.T) {
+	t.Cleanup(agents.ClearOpenaiSettings)
+
+	openaiClient := agents.NewOpenaiClient(param.Opt[string]{}, param.NewOpt("fake-key")).
+		WithDefaultAPI(agents.OpenaiAPITypeResponses)
+	litellmClient := agents.NewOpenaiClient(param.NewOpt("http://localhost:4000"), param.NewOpt("fake-key")).
+		WithDefaultAPI(agents.OpenaiAPITypeChatCompletions)
+
+	getModel := func(t *testing.T, params agents.NewMultiProviderParams) agents.Model {
+		t.Helper()
+		model, err := agents.NewMultiProvider(params).GetModel("gpt-4.1")
+		require.NoError(t, err)
+		return model
+	}
+
+	t.Run("per-client default", func(t *testing.T) {
+		model := getModel(t, agents.NewMultiProviderParams{OpenaiClient: &openaiClient})
+		assert.IsType(t, agents.OpenAIResponsesModel{}, model)
+
+		model = getModel(t, agents.NewMultiProviderParams{OpenaiClient: &litellmClient})
+		assert.IsType(t, agents.OpenAIChatCompletionsModel{}, model)
+	})
+
+	t.Run("client default takes precedence over global default", func(t *testing.T) {
+		agents.SetDefaultOpenaiAPI(agents.OpenaiAPITypeChatCompletions)
+		t.Cleanup(func() { agents.SetDefaultOpenaiAPI(agents.OpenaiAPITypeResponses) })
+
+		model := getModel(t, agents.NewMultiProviderParams{OpenaiClient: &openaiClient})
+		assert.IsType(t, agents.OpenAIResponsesModel{}, model)
+
+		plainClient := agents.NewOpenaiClient(param.Opt[string]{}, param.NewOpt("fake-key"))
+		model = getModel(t, agents.NewMultiProviderParams{OpenaiClient: &plainClient})
+		assert.IsType(t, agents.OpenAIChatCompletionsModel{}, model)
+	})
+
+	t.Run("explicit setting takes precedence over client default", func(t *testing.T) {
+		model := getModel(t, agents.NewMultiProviderParams{
+			OpenaiClient:       &litellmClient,
+			OpenaiUseResponses: param.NewOpt(true),
+		})
+		assert.IsType(t, agents.OpenAIResponsesModel{}, model)
+	})
+
+	t.Run("default client", func(t *testing.T) {
+		agents.SetDefaultOpenaiClient(litellmClient, false)
+		t.Cleanup(agents.ClearOpenaiSettings)
+
+		model := getModel(t, agents.NewMultiProviderParams{})
+		assert.IsType(t, agents.OpenAIChatCompletionsModel{}, model)
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"log/slog"
 	"reflect"
 	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/nlpodyssey/openai-agents-go/modelsettings"
@@ -142,9 +143,10 @@ func (m OpenAIChatCompletionsModel) GetResponse(
 				}
 			}
 			modelResponse = &ModelResponse{
-				Output:     items,
-				Usage:      u,
-				ResponseID: "",
+				Output:            items,
+				Usage:             u,
+				ResponseID:        "",
+				SystemFingerprint: response.SystemFingerprint,
 			}
 			return nil
 		},
@@ -207,12 +209,20 @@ func (m OpenAIChatCompletionsModel) StreamResponse(
 			}
 
 			var finalResponse *responses.Response
-			err = ChatCmplStreamHandler().HandleStream(response, stream, func(chunk TResponseStreamEvent) error {
+			var onChunk func(openai.ChatCompletionChunk)
+			if fingerprint := systemFingerprintRecorderFromContext(ctx); fingerprint != nil {
+				onChunk = func(chunk openai.ChatCompletionChunk) {
+					if chunk.SystemFingerprint != "" {
+						fingerprint.Store(&chunk.SystemFingerprint)
+					}
+				}
+			}
+			err = ChatCmplStreamHandler().handleStream(response, stream, func(chunk TResponseStreamEvent) error {
 				if chunk.Type == "response.completed" {
 					finalResponse = &chunk.Response
 				}
 				return yield(ctx, chunk)
-			})
+			}, onChunk)
 			if err != nil {
 				return err
 			}
@@ -229,16 +239,33 @@ func (m OpenAIChatCompletionsModel) StreamResponse(
 				}
 
 				if u := finalResponse.Usage; !reflect.ValueOf(u).IsZero() {
-					spanData.Usage = map[string]any{
-						"input_tokens":  u.InputTokens,
-						"output_tokens": u.OutputTokens,
+					// Preserve other entries, such as the tokens per second
+					// recorded by the runner.
+					if spanData.Usage == nil {
+						spanData.Usage = make(map[string]any, 2)
 					}
+					spanData.Usage["input_tokens"] = u.InputTokens
+					spanData.Usage["output_tokens"] = u.OutputTokens
 				}
 			}
 			return nil
 		})
 }
 
+type systemFingerprintRecorderKey struct{}
+
+// contextWithSystemFingerprintRecorder returns a context through which an
+// OpenAIChatCompletionsModel records the system fingerprint of a streamed
+// response, which is reported by the chunks.
+func contextWithSystemFingerprintRecorder(ctx context.Context, fingerprint *atomic.Pointer[string]) context.Context {
+	return context.WithValue(ctx, systemFingerprintRecorderKey{}, fingerprint)
+}
+
+func systemFingerprintRecorderFromContext(ctx context.Context) *atomic.Pointer[string] {
+	fingerprint, _ := ctx.Value(systemFingerprintRecorderKey{}).(*atomic.Pointer[string])
+	return fingerprint
+}
+
 func (m OpenAIChatCompletionsModel) generationSpanParams(params ModelResponseParams) (*tracing.GenerationSpanParams, error) {
 	modelConfig, err := util.JSONMap(params.ModelSettings)
 	if err != nil {
@@ -266,6 +293,10 @@ func (m OpenAIChatCompletionsModel) prepareRequest(
 	modelTracing ModelTracing,
 	stream bool,
 ) (*openai.ChatCompletionNewParams, []option.RequestOption, error) {
+	if err := validateRequestMetadata(modelSettings.Metadata); err != nil {
+		return nil, nil, err
+	}
+
 	convertedMessages, err := ChatCmplConverter().ItemsToMessages(input)
 	if err != nil {
 		return nil, nil, err
@@ -290,14 +321,7 @@ func (m OpenAIChatCompletionsModel) prepareRequest(
 		span.SpanData().(*tracing.GenerationSpanData).Input = in
 	}
 
-	var parallelToolCalls param.Opt[bool]
-	if modelSettings.ParallelToolCalls.Valid() {
-		if modelSettings.ParallelToolCalls.Value && len(tools) > 0 {
-			parallelToolCalls = param.NewOpt(true)
-		} else if !modelSettings.ParallelToolCalls.Value {
-			parallelToolCalls = param.NewOpt(false)
-		}
-	}
+	parallelToolCalls := parallelToolCallsParam(modelSettings, tools, handoffs)
 
 	toolChoice, err := ChatCmplConverter().ConvertToolChoice(modelSettings.ToolChoice)
 	if err != nil {
@@ -307,6 +331,16 @@ func (m OpenAIChatCompletionsModel) prepareRequest(
 	if err != nil {
 		return nil, nil, err
 	}
+	if format, ok, err := plainResponseFormat(modelSettings, outputType); err != nil {
+		return nil, nil, err
+	} else if ok {
+		switch format {
+		case modelsettings.ResponseFormatText:
+			responseFormat.OfText = &openai.ResponseFormatTextParam{}
+		case modelsettings.ResponseFormatJSONObject:
+			responseFormat.OfJSONObject = &openai.ResponseFormatJSONObjectParam{}
+		}
+	}
 
 	var convertedTools []openai.ChatCompletionToolUnionParam
 	for _, tool := range tools {
@@ -346,6 +380,9 @@ func (m OpenAIChatCompletionsModel) prepareRequest(
 		TopP:              modelSettings.TopP,
 		FrequencyPenalty:  modelSettings.FrequencyPenalty,
 		PresencePenalty:   modelSettings.PresencePenalty,
+		LogitBias:         modelSettings.LogitBias,
+		Stop:              openai.ChatCompletionNewParamsStopUnion{OfStringArray: modelSettings.Stop},
+		Seed:              modelSettings.Seed,
 		MaxTokens:         modelSettings.MaxTokens,
 		ToolChoice:        toolChoice,
 		ResponseFormat:    responseFormat,
@@ -15,6 +15,7 @@
 package agents
 
 import (
+	"fmt"
 	"slices"
 
 	"github.com/openai/openai-go/v3"
@@ -26,6 +27,11 @@ type OpenaiClient struct {
 	openai.Client
 	BaseURL param.Opt[string]
 	APIKey  param.Opt[string]
+
+	// Optional API to use by default for LLM requests made with this client,
+	// taking precedence over the global default (see SetDefaultOpenaiAPI).
+	// An explicit OpenAIProviderParams.UseResponses still takes precedence.
+	DefaultAPI param.Opt[OpenaiAPIType]
 }
 
 func NewOpenaiClient(baseURL, apiKey param.Opt[string], opts ...option.RequestOption) OpenaiClient {
@@ -41,3 +47,15 @@ func NewOpenaiClient(baseURL, apiKey param.Opt[string], opts ...option.RequestOp
 		APIKey:  apiKey,
 	}
 }
+
+// WithDefaultAPI returns a copy of the client using the given API by default
+// for LLM requests. See OpenaiClient.DefaultAPI.
+func (c OpenaiClient) WithDefaultAPI(api OpenaiAPIType) OpenaiClient {
+	switch api {
+	case OpenaiAPITypeChatCompletions, OpenaiAPITypeResponses:
+		c.DefaultAPI = param.NewOpt(api)
+		return c
+	default:
+		panic(fmt.Errorf("invalid OpenaiAPIType value %q", api))
+	}
+}
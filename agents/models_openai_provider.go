@@ -42,14 +42,17 @@ type OpenAIProviderParams struct {
 	// The project to use for the OpenAI client.
 	Project param.Opt[string]
 
-	// Whether to use the OpenAI responses API.
+	// Whether to use the OpenAI responses API. If not provided, the client's
+	// DefaultAPI is used, if set, or else the global default (see
+	// SetDefaultOpenaiAPI).
 	UseResponses param.Opt[bool]
 }
 
 type OpenAIProvider struct {
-	params       OpenAIProviderParams
-	useResponses bool
-	client       *OpenaiClient
+	params OpenAIProviderParams
+	// The global default at construction time.
+	useResponsesByDefault bool
+	client                *OpenaiClient
 }
 
 // NewOpenAIProvider creates a new OpenAI provider.
@@ -58,17 +61,10 @@ func NewOpenAIProvider(params OpenAIProviderParams) *OpenAIProvider {
 		panic(errors.New("OpenAIProvider: don't provide APIKey or BaseURL if you provide OpenaiClient"))
 	}
 
-	var useResponses bool
-	if params.UseResponses.Valid() {
-		useResponses = params.UseResponses.Value
-	} else {
-		useResponses = GetUseResponsesByDefault()
-	}
-
 	return &OpenAIProvider{
-		params:       params,
-		useResponses: useResponses,
-		client:       params.OpenaiClient,
+		params:                params,
+		useResponsesByDefault: GetUseResponsesByDefault(),
+		client:                params.OpenaiClient,
 	}
 }
 
@@ -79,12 +75,26 @@ func (provider *OpenAIProvider) GetModel(modelName string) (Model, error) {
 
 	client := provider.getClient()
 
-	if provider.useResponses {
+	if provider.useResponses(client) {
 		return NewOpenAIResponsesModel(modelName, client), nil
 	}
 	return NewOpenAIChatCompletionsModel(modelName, client), nil
 }
 
+// useResponses reports whether to use the responses API with the given
+// client: an explicit provider setting takes precedence over the client's
+// DefaultAPI, which in turn takes precedence over the global default.
+func (provider *OpenAIProvider) useResponses(client OpenaiClient) bool {
+	switch {
+	case provider.params.UseResponses.Valid():
+		return provider.params.UseResponses.Value
+	case client.DefaultAPI.Valid():
+		return client.DefaultAPI.Value == OpenaiAPITypeResponses
+	default:
+		return provider.useResponsesByDefault
+	}
+}
+
 // We lazy load the client in case you never actually use OpenAIProvider.
 // It panics if you don't have an API key set.
 func (provider *OpenAIProvider) getClient() OpenaiClient {
@@ -21,6 +21,7 @@ import (
 	"log/slog"
 	"reflect"
 	"slices"
+	"time"
 
 	"github.com/nlpodyssey/openai-agents-go/modelsettings"
 	"github.com/nlpodyssey/openai-agents-go/tracing"
@@ -32,6 +33,11 @@ import (
 	"github.com/openai/openai-go/v3/shared/constant"
 )
 
+// BackgroundResponsePollInterval is the interval at which OpenAIResponsesModel
+// polls background responses (see ModelSettings.Background) until they are
+// complete.
+var BackgroundResponsePollInterval = time.Second
+
 // OpenAIResponsesModel is an implementation of Model that uses the OpenAI Responses API.
 type OpenAIResponsesModel struct {
 	Model  openai.ChatModel
@@ -92,6 +98,14 @@ func (m OpenAIResponsesModel) GetResponse(
 				return err
 			}
 
+			if body.Background.Value {
+				response, err = m.waitBackgroundResponse(ctx, response, opts)
+				if err != nil {
+					Logger().Error("error getting background response", slog.String("error", err.Error()))
+					return err
+				}
+			}
+
 			if DontLogModelData {
 				Logger().Debug("LLM responded")
 			} else {
@@ -202,6 +216,41 @@ func (m OpenAIResponsesModel) StreamResponse(
 		})
 }
 
+// waitBackgroundResponse polls a background response until it is no longer
+// queued or in progress. If the context is canceled in the meantime, the
+// background response is canceled as well.
+func (m OpenAIResponsesModel) waitBackgroundResponse(
+	ctx context.Context,
+	response *responses.Response,
+	opts []option.RequestOption,
+) (*responses.Response, error) {
+	for response.Status == responses.ResponseStatusQueued || response.Status == responses.ResponseStatusInProgress {
+		select {
+		case <-ctx.Done():
+			if _, err := m.client.Responses.Cancel(context.WithoutCancel(ctx), response.ID, opts...); err != nil {
+				Logger().Warn("failed to cancel background response", slog.String("error", err.Error()))
+			}
+			return nil, ctx.Err()
+		case <-time.After(BackgroundResponsePollInterval):
+		}
+
+		var err error
+		response, err = m.client.Responses.Get(ctx, response.ID, responses.ResponseGetParams{}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error polling background response: %w", err)
+		}
+	}
+
+	switch response.Status {
+	case responses.ResponseStatusFailed:
+		return nil, fmt.Errorf("background response %s failed: %s", response.ID, response.Error.Message)
+	case responses.ResponseStatusCancelled:
+		return nil, fmt.Errorf("background response %s was cancelled", response.ID)
+	default:
+		return response, nil
+	}
+}
+
 func (m OpenAIResponsesModel) prepareRequest(
 	ctx context.Context,
 	systemInstructions param.Opt[string],
@@ -216,14 +265,25 @@ func (m OpenAIResponsesModel) prepareRequest(
 ) (*responses.ResponseNewParams, []option.RequestOption, error) {
 	listInput := ItemHelpers().InputToNewInputList(input)
 
-	var parallelToolCalls param.Opt[bool]
-	if modelSettings.ParallelToolCalls.Valid() {
-		if modelSettings.ParallelToolCalls.Value && len(tools) > 0 {
-			parallelToolCalls = param.NewOpt(true)
-		} else if !modelSettings.ParallelToolCalls.Value {
-			parallelToolCalls = param.NewOpt(false)
+	if modelSettings.Truncation.Valid() {
+		switch modelSettings.Truncation.Value {
+		case modelsettings.TruncationAuto, modelsettings.TruncationDisabled:
+		default:
+			return nil, nil, UserErrorf("invalid truncation strategy %q", modelSettings.Truncation.Value)
 		}
 	}
+	if modelSettings.Background.Value && modelSettings.Store.Valid() && !modelSettings.Store.Value {
+		// Background responses are retrieved by polling, so they must be stored.
+		return nil, nil, UserErrorf("background mode requires the response to be stored, but store is false")
+	}
+	if err := validateRequestMetadata(modelSettings.Metadata); err != nil {
+		return nil, nil, err
+	}
+	if err := validateInputFiles(listInput); err != nil {
+		return nil, nil, err
+	}
+
+	parallelToolCalls := parallelToolCallsParam(modelSettings, tools, handoffs)
 
 	toolChoice := ResponsesConverter().ConvertToolChoice(modelSettings.ToolChoice)
 	convertedTools, err := ResponsesConverter().ConvertTools(ctx, tools, handoffs)
@@ -234,6 +294,16 @@ func (m OpenAIResponsesModel) prepareRequest(
 	if err != nil {
 		return nil, nil, err
 	}
+	if format, ok, err := plainResponseFormat(modelSettings, outputType); err != nil {
+		return nil, nil, err
+	} else if ok {
+		switch format {
+		case modelsettings.ResponseFormatText:
+			responseFormat.Format.OfText = &openai.ResponseFormatTextParam{}
+		case modelsettings.ResponseFormatJSONObject:
+			responseFormat.Format.OfJSONObject = &openai.ResponseFormatJSONObjectParam{}
+		}
+	}
 
 	include := slices.Concat(convertedTools.Includes, modelSettings.ResponseInclude)
 	if modelSettings.TopLogprobs.Valid() {
@@ -283,6 +353,7 @@ func (m OpenAIResponsesModel) prepareRequest(
 		ParallelToolCalls:  parallelToolCalls,
 		Text:               responseFormat,
 		Store:              modelSettings.Store,
+		Background:         modelSettings.Background,
 		Reasoning:          modelSettings.Reasoning,
 		TopLogprobs:        modelSettings.TopLogprobs,
 		Metadata:           modelSettings.Metadata,
@@ -437,25 +508,18 @@ func (conv responsesConverter) convertTool(
 		}
 		includes = nil
 	case WebSearchTool:
-		convertedTool = &responses.ToolUnionParam{
-			OfWebSearch: &responses.WebSearchToolParam{
-				Type:              responses.WebSearchToolTypeWebSearch,
-				Filters:           t.Filters,
-				UserLocation:      t.UserLocation,
-				SearchContextSize: t.SearchContextSize,
-			},
+		webSearch, err := t.toolParam()
+		if err != nil {
+			return nil, nil, err
 		}
+		convertedTool = &responses.ToolUnionParam{OfWebSearch: webSearch}
 		includes = nil
 	case FileSearchTool:
-		convertedTool = &responses.ToolUnionParam{
-			OfFileSearch: &responses.FileSearchToolParam{
-				VectorStoreIDs: t.VectorStoreIDs,
-				MaxNumResults:  t.MaxNumResults,
-				Filters:        t.Filters,
-				RankingOptions: t.RankingOptions,
-				Type:           constant.ValueOf[constant.FileSearch](),
-			},
+		fileSearch, err := t.toolParam()
+		if err != nil {
+			return nil, nil, err
 		}
+		convertedTool = &responses.ToolUnionParam{OfFileSearch: fileSearch}
 		if t.IncludeSearchResults {
 			includes = new(responses.ResponseIncludable)
 			*includes = responses.ResponseIncludableFileSearchCallResults
@@ -520,3 +584,43 @@ func (responsesConverter) convertHandoffTool(handoff Handoff) responses.ToolUnio
 		},
 	}
 }
+
+// validateInputFiles returns a UserError if an input_file content part of the
+// input messages refers to no file: it must have either a file ID, a file
+// URL, or inline file data together with a filename.
+// The file parts are otherwise passed through to the Responses API unchanged.
+func validateInputFiles(items []TResponseInputItem) error {
+	validate := func(content []responses.ResponseInputContentUnionParam) error {
+		for _, c := range content {
+			f := c.OfInputFile
+			if f == nil {
+				continue
+			}
+			switch {
+			case f.FileID.Valid() && f.FileID.Value != "":
+			case f.FileURL.Valid() && f.FileURL.Value != "":
+			case f.FileData.Valid() && f.FileData.Value != "":
+				if !f.Filename.Valid() || f.Filename.Value == "" {
+					return NewUserError("a filename must be provided together with the inline data of an input file")
+				}
+			default:
+				return NewUserError("an input file must have either a file ID, a file URL, or inline data and a filename")
+			}
+		}
+		return nil
+	}
+
+	for _, item := range items {
+		var err error
+		switch {
+		case item.OfMessage != nil:
+			err = validate(item.OfMessage.Content.OfInputItemContentList)
+		case item.OfInputMessage != nil:
+			err = validate(item.OfInputMessage.Content)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
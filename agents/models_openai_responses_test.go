@@ -16,6 +16,7 @@ package agents
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -129,3 +130,72 @@ func TestOpenAIResponsesModel_prepareRequest(t *testing.T) {
 		require.ErrorIs(t, err, customError)
 	})
 }
+
+func TestOpenAIResponsesModelInputFiles(t *testing.T) {
+	prepare := func(t *testing.T, files ...responses.ResponseInputFileParam) (*responses.ResponseNewParams, error) {
+		t.Helper()
+		content := make(responses.ResponseInputMessageContentListParam, len(files))
+		for i := range files {
+			content[i] = responses.ResponseInputContentUnionParam{OfInputFile: &files[i]}
+		}
+		m := NewOpenAIResponsesModel("model-name", NewOpenaiClient(param.Opt[string]{}, param.Opt[string]{}))
+		params, _, err := m.prepareRequest(
+			t.Context(),
+			param.Opt[string]{},
+			InputItems{{
+				OfMessage: &responses.EasyInputMessageParam{
+					Content: responses.EasyInputMessageContentUnionParam{OfInputItemContentList: content},
+					Role:    responses.EasyInputMessageRoleUser,
+				},
+			}},
+			modelsettings.ModelSettings{},
+			nil,
+			nil,
+			nil,
+			"",
+			false,
+			responses.ResponsePromptParam{},
+		)
+		return params, err
+	}
+
+	t.Run("file ID and inline data are passed through", func(t *testing.T) {
+		byID := responses.ResponseInputFileParam{FileID: param.NewOpt("file-123")}
+		byData := responses.ResponseInputFileParam{
+			FileData: param.NewOpt("data:application/pdf;base64,JVBERi0xLjQ="),
+			Filename: param.NewOpt("doc.pdf"),
+		}
+		params, err := prepare(t, byID, byData)
+		require.NoError(t, err)
+
+		require.Len(t, params.Input.OfInputItemList, 1)
+		message := params.Input.OfInputItemList[0].OfMessage
+		require.NotNil(t, message)
+		content := message.Content.OfInputItemContentList
+		require.Len(t, content, 2)
+		assert.Equal(t, &byID, content[0].OfInputFile)
+		assert.Equal(t, &byData, content[1].OfInputFile)
+
+		b, err := json.Marshal(params.Input)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `{"file_id":"file-123","type":"input_file"}`)
+		assert.Contains(t, string(b), `"filename":"doc.pdf"`)
+	})
+
+	t.Run("file URL", func(t *testing.T) {
+		_, err := prepare(t, responses.ResponseInputFileParam{FileURL: param.NewOpt("https://example.com/doc.pdf")})
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing filename", func(t *testing.T) {
+		_, err := prepare(t, responses.ResponseInputFileParam{FileData: param.NewOpt("JVBERi0xLjQ=")})
+		assert.ErrorAs(t, err, &UserError{})
+		assert.ErrorContains(t, err, "filename must be provided")
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		_, err := prepare(t, responses.ResponseInputFileParam{Filename: param.NewOpt("doc.pdf")})
+		assert.ErrorAs(t, err, &UserError{})
+		assert.ErrorContains(t, err, "either a file ID")
+	})
+}
@@ -16,8 +16,11 @@ package agents
 
 import (
 	"fmt"
+	"log/slog"
 	"sync/atomic"
+	"unicode/utf8"
 
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
 	"github.com/nlpodyssey/openai-agents-go/tracing"
 	"github.com/openai/openai-go/v3/packages/param"
 )
@@ -108,3 +111,75 @@ func ClearOpenaiSettings() {
 	defaultOpenaiClient.Store(nil)
 	useResponsesByDefault.Store(true)
 }
+
+// Limits imposed by the OpenAI API on request metadata.
+const (
+	MaxRequestMetadataPairs       = 16
+	MaxRequestMetadataKeyLength   = 64
+	MaxRequestMetadataValueLength = 512
+)
+
+// validateRequestMetadata returns a UserError if the metadata exceeds the
+// limits imposed by the OpenAI API.
+func validateRequestMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxRequestMetadataPairs {
+		return UserErrorf("metadata can have at most %d key-value pairs, got %d", MaxRequestMetadataPairs, len(metadata))
+	}
+	for k, v := range metadata {
+		if n := utf8.RuneCountInString(k); n > MaxRequestMetadataKeyLength {
+			return UserErrorf("metadata key %q is too long: at most %d characters are allowed, got %d", k, MaxRequestMetadataKeyLength, n)
+		}
+		if n := utf8.RuneCountInString(v); n > MaxRequestMetadataValueLength {
+			return UserErrorf("metadata value for key %q is too long: at most %d characters are allowed, got %d", k, MaxRequestMetadataValueLength, n)
+		}
+	}
+	return nil
+}
+
+// plainResponseFormat returns the ModelSettings.ResponseFormat to use for a
+// request, if any. The JSON schema derived from a structured output type
+// takes precedence: in case of conflict, a warning is logged and false is
+// returned.
+func plainResponseFormat(
+	modelSettings modelsettings.ModelSettings,
+	outputType OutputTypeInterface,
+) (modelsettings.ResponseFormat, bool, error) {
+	if !modelSettings.ResponseFormat.Valid() {
+		return "", false, nil
+	}
+	format := modelSettings.ResponseFormat.Value
+	switch format {
+	case modelsettings.ResponseFormatText, modelsettings.ResponseFormatJSONObject:
+	default:
+		return "", false, UserErrorf("invalid response format %q", format)
+	}
+	if outputType != nil && !outputType.IsPlainText() {
+		Logger().Warn(
+			"Ignoring ModelSettings.ResponseFormat, since the agent has a structured output type",
+			slog.String("responseFormat", string(format)),
+		)
+		return "", false, nil
+	}
+	return format, true, nil
+}
+
+// parallelToolCallsParam returns the parallel_tool_calls request parameter
+// for ModelSettings.ParallelToolCalls. Enabling parallel tool calls is only
+// allowed when some tools (including handoffs) are provided, so it is omitted
+// otherwise.
+func parallelToolCallsParam(
+	modelSettings modelsettings.ModelSettings,
+	tools []Tool,
+	handoffs []Handoff,
+) param.Opt[bool] {
+	if !modelSettings.ParallelToolCalls.Valid() {
+		return param.Opt[bool]{}
+	}
+	if !modelSettings.ParallelToolCalls.Value {
+		return param.NewOpt(false)
+	}
+	if len(tools) > 0 || len(handoffs) > 0 {
+		return param.NewOpt(true)
+	}
+	return param.Opt[bool]{}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+)
+
+// RateLimit configures the rate limits enforced by a RateLimitedModel.
+type RateLimit struct {
+	// Maximum number of requests per minute. Zero means no limit.
+	RequestsPerMinute float64
+
+	// Maximum number of tokens per minute. Zero means no limit.
+	//
+	// Since the tokens used by a request are only known when it completes,
+	// they are accounted using the usage reported by each call: a call is
+	// allowed as long as the tokens used by the previous calls did not exceed
+	// the limit, and subsequent calls wait until they are paid off.
+	TokensPerMinute float64
+
+	// Maximum number of requests which can be made at once, in a burst.
+	// Default: 1, that is, requests are evenly spaced.
+	RequestBurst int
+}
+
+// RateLimitedModel is a Model which enforces a RateLimit on the calls to
+// another model. A call blocks until capacity is available, or returns the
+// error of its context if it is done first.
+type RateLimitedModel struct {
+	model   Model
+	limiter *rateLimiter
+}
+
+// NewRateLimitedModel returns a RateLimitedModel which limits the calls to
+// the given model.
+func NewRateLimitedModel(model Model, limit RateLimit) *RateLimitedModel {
+	return &RateLimitedModel{
+		model:   model,
+		limiter: newRateLimiter(limit),
+	}
+}
+
+func (m *RateLimitedModel) GetResponse(ctx context.Context, params ModelResponseParams) (*ModelResponse, error) {
+	if err := m.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	response, err := m.model.GetResponse(ctx, params)
+	if response != nil && response.Usage != nil {
+		m.limiter.consumeTokens(response.Usage.TotalTokens)
+	}
+	return response, err
+}
+
+func (m *RateLimitedModel) StreamResponse(ctx context.Context, params ModelResponseParams, yield ModelStreamResponseCallback) error {
+	if err := m.limiter.wait(ctx); err != nil {
+		return err
+	}
+	return m.model.StreamResponse(ctx, params, func(ctx context.Context, event TResponseStreamEvent) error {
+		if event.Type == "response.completed" {
+			m.limiter.consumeTokens(uint64(event.Response.Usage.TotalTokens))
+		}
+		return yield(ctx, event)
+	})
+}
+
+// NewRateLimitedModelProvider returns a ModelProvider whose models are
+// RateLimitedModels sharing the same rate limits, e.g. because they share
+// the same API key.
+func NewRateLimitedModelProvider(provider ModelProvider, limit RateLimit) ModelProvider {
+	return rateLimitedModelProvider{
+		provider: provider,
+		limiter:  newRateLimiter(limit),
+	}
+}
+
+type rateLimitedModelProvider struct {
+	provider ModelProvider
+	limiter  *rateLimiter
+}
+
+func (p rateLimitedModelProvider) GetModel(modelName string) (Model, error) {
+	model, err := p.provider.GetModel(modelName)
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimitedModel{model: model, limiter: p.limiter}, nil
+}
+
+type rateLimiter struct {
+	requests *tokenBucket // nil if unlimited
+	tokens   *tokenBucket // nil if unlimited
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	l := new(rateLimiter)
+	if limit.RequestsPerMinute > 0 {
+		l.requests = newTokenBucket(limit.RequestsPerMinute/60, limit.RequestBurst)
+	}
+	if limit.TokensPerMinute > 0 {
+		l.tokens = newTokenBucket(limit.TokensPerMinute/60, int(limit.TokensPerMinute))
+	}
+	return l
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l.tokens != nil {
+		if err := l.tokens.waitFor(ctx, false); err != nil {
+			return err
+		}
+	}
+	if l.requests != nil {
+		return l.requests.wait(ctx)
+	}
+	return nil
+}
+
+func (l *rateLimiter) consumeTokens(n uint64) {
+	if l.tokens != nil {
+		l.tokens.consume(float64(n))
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitTestModel(turns int) *agentstesting.FakeModel {
+	model := agentstesting.NewFakeModel(false, nil)
+	outputs := make([]agentstesting.FakeModelTurnOutput, turns)
+	for i := range outputs {
+		outputs[i] = agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		}
+	}
+	model.AddMultipleTurnOutputs(outputs)
+	return model
+}
+
+func TestRateLimitedModelRequestsPerMinute(t *testing.T) {
+	// 1200 RPM: one request every 50ms.
+	model := agents.NewRateLimitedModel(newRateLimitTestModel(2), agents.RateLimit{RequestsPerMinute: 1200})
+	params := agents.ModelResponseParams{Input: agents.InputString("hi")}
+
+	start := time.Now()
+	_, err := model.GetResponse(t.Context(), params)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 40*time.Millisecond)
+
+	_, err = model.GetResponse(t.Context(), params)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 45*time.Millisecond)
+}
+
+func TestRateLimitedModelTokensPerMinute(t *testing.T) {
+	for _, streamed := range []bool{false, true} {
+		t.Run(map[bool]string{false: "GetResponse", true: "StreamResponse"}[streamed], func(t *testing.T) {
+			inner := newRateLimitTestModel(2)
+			// The first call uses 50 tokens more than available, which are
+			// paid off in 50ms at 60000 TPM.
+			inner.SetHardcodedUsage(usage.Usage{TotalTokens: 60050})
+			model := agents.NewRateLimitedModel(inner, agents.RateLimit{TokensPerMinute: 60000})
+			params := agents.ModelResponseParams{Input: agents.InputString("hi")}
+
+			call := func() {
+				var err error
+				if streamed {
+					err = model.StreamResponse(t.Context(), params, func(context.Context, agents.TResponseStreamEvent) error {
+						return nil
+					})
+				} else {
+					_, err = model.GetResponse(t.Context(), params)
+				}
+				require.NoError(t, err)
+			}
+
+			start := time.Now()
+			call()
+			call()
+			assert.GreaterOrEqual(t, time.Since(start), 45*time.Millisecond)
+		})
+	}
+}
+
+func TestRateLimitedModelContextDone(t *testing.T) {
+	model := agents.NewRateLimitedModel(newRateLimitTestModel(1), agents.RateLimit{RequestsPerMinute: 1})
+	params := agents.ModelResponseParams{Input: agents.InputString("hi")}
+
+	_, err := model.GetResponse(t.Context(), params)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	_, err = model.GetResponse(ctx, params)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type fakeModelProvider struct {
+	model agents.Model
+}
+
+func (p fakeModelProvider) GetModel(string) (agents.Model, error) { return p.model, nil }
+
+func TestRateLimitedModelProviderSharesLimits(t *testing.T) {
+	provider := agents.NewRateLimitedModelProvider(
+		fakeModelProvider{model: newRateLimitTestModel(2)},
+		agents.RateLimit{RequestsPerMinute: 1200},
+	)
+	params := agents.ModelResponseParams{Input: agents.InputString("hi")}
+
+	start := time.Now()
+	for _, name := range []string{"model-a", "model-b"} {
+		model, err := provider.GetModel(name)
+		require.NoError(t, err)
+		_, err = model.GetResponse(t.Context(), params)
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 45*time.Millisecond)
+}
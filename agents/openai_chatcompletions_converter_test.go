@@ -15,6 +15,10 @@
 package agents_test
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
@@ -684,3 +688,147 @@ func TestAssistantMessagesInHistory(t *testing.T) {
 		},
 	}, v)
 }
+
+// A 1x1 red PNG image.
+const testPNGBase64 = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR4nGP4z8DwHwAFAAH/iZk9HQAAAABJRU5ErkJggg=="
+
+func TestExtractAllContentWithLocalImage(t *testing.T) {
+	pngData, err := base64.StdEncoding.DecodeString(testPNGBase64)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "image.png")
+	require.NoError(t, os.WriteFile(path, pngData, 0o600))
+
+	wantURL := "data:image/png;base64," + testPNGBase64
+
+	fromBytes, err := agents.InputImageFromBytes(pngData, responses.ResponseInputImageDetailLow)
+	require.NoError(t, err)
+	fromFile, err := agents.InputImageFromFile(path, "")
+	require.NoError(t, err)
+
+	// Local paths and file URLs are not read: they are sent as they are.
+	v, err := agents.ChatCmplConverter().ExtractAllContentFromResponseInputContentUnionParams([]responses.ResponseInputContentUnionParam{
+		{OfInputImage: &responses.ResponseInputImageParam{ImageURL: param.NewOpt(path)}},
+		{OfInputImage: &responses.ResponseInputImageParam{ImageURL: param.NewOpt("file://" + path)}},
+		fromBytes,
+		fromFile,
+		{OfInputImage: &responses.ResponseInputImageParam{ImageURL: param.NewOpt("https://example.com/image.png")}},
+	})
+	require.NoError(t, err)
+
+	imagePart := func(url, detail string) openai.ChatCompletionContentPartUnionParam {
+		return openai.ChatCompletionContentPartUnionParam{
+			OfImageURL: &openai.ChatCompletionContentPartImageParam{
+				ImageURL: openai.ChatCompletionContentPartImageImageURLParam{URL: url, Detail: detail},
+			},
+		}
+	}
+	assert.Equal(t, &openai.ChatCompletionUserMessageParamContentUnion{
+		OfArrayOfContentParts: []openai.ChatCompletionContentPartUnionParam{
+			imagePart(path, "auto"),
+			imagePart("file://"+path, "auto"),
+			imagePart(wantURL, "low"),
+			imagePart(wantURL, "auto"),
+			imagePart("https://example.com/image.png", "auto"),
+		},
+	}, v)
+}
+
+func TestExtractAllContentWithInvalidLocalImage(t *testing.T) {
+	dir := t.TempDir()
+	textPath := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(textPath, []byte("not an image"), 0o600))
+
+	testCases := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"missing file", filepath.Join(dir, "missing.png"), "cannot read input image file"},
+		{"directory", dir, "it is a directory"},
+		{"not an image", textPath, `unsupported input image type "text/plain; charset=utf-8"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := agents.InputImageFromFile(tc.path, "")
+			assert.ErrorAs(t, err, &agents.UserError{})
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := agents.InputImageFromBytes(make([]byte, agents.MaxInputImageSize+1), "")
+		assert.ErrorAs(t, err, &agents.UserError{})
+		assert.ErrorContains(t, err, "too large")
+	})
+}
+
+func TestExtractAllContentWithInputAudio(t *testing.T) {
+	audioData := base64.StdEncoding.EncodeToString([]byte("RIFF fake wav data"))
+	audio, err := agents.InputAudio(audioData, "wav")
+	require.NoError(t, err)
+
+	// The content part is serialized as an input_audio part.
+	b, err := json.Marshal(audio)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"input_audio","input_audio":{"data":"`+audioData+`","format":"wav"}}`, string(b))
+
+	text := responses.ResponseInputTextParam{Text: "transcribe this"}
+	messages, err := agents.ChatCmplConverter().ItemsToMessages(agents.InputItems{{
+		OfMessage: &responses.EasyInputMessageParam{
+			Content: responses.EasyInputMessageContentUnionParam{
+				OfInputItemContentList: responses.ResponseInputMessageContentListParam{
+					{OfInputText: &text},
+					audio,
+				},
+			},
+			Role: responses.EasyInputMessageRoleUser,
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.NotNil(t, messages[0].OfUser)
+	assert.Equal(t, []openai.ChatCompletionContentPartUnionParam{
+		{OfText: &openai.ChatCompletionContentPartTextParam{Text: "transcribe this", Type: constant.ValueOf[constant.Text]()}},
+		{OfInputAudio: &openai.ChatCompletionContentPartInputAudioParam{
+			InputAudio: openai.ChatCompletionContentPartInputAudioInputAudioParam{
+				Data:   audioData,
+				Format: "wav",
+			},
+			Type: constant.ValueOf[constant.InputAudio](),
+		}},
+	}, messages[0].OfUser.Content.OfArrayOfContentParts)
+}
+
+func TestInputAudioValidation(t *testing.T) {
+	validData := base64.StdEncoding.EncodeToString([]byte("audio"))
+
+	_, err := agents.InputAudio(validData, "mp3")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		data    string
+		format  string
+		wantErr string
+	}{
+		{"unsupported format", validData, "flac", `unsupported input audio format "flac"`},
+		{"empty data", "", "wav", "input audio data is empty"},
+		{"invalid base64", "not base64!", "wav", "not valid base64"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := agents.InputAudio(tc.data, tc.format)
+			assert.ErrorAs(t, err, &agents.UserError{})
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			// An invalid override is rejected by the converter too.
+			invalid := param.Override[responses.ResponseInputContentUnionParam](responses.ResponseInputAudioParam{
+				InputAudio: responses.ResponseInputAudioInputAudioParam{Data: tc.data, Format: tc.format},
+			})
+			_, err = agents.ChatCmplConverter().ExtractAllContentFromResponseInputContentUnionParams(
+				[]responses.ResponseInputContentUnionParam{invalid})
+			assert.ErrorAs(t, err, &agents.UserError{})
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
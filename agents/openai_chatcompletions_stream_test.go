@@ -24,6 +24,7 @@ import (
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
 	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/nlpodyssey/openai-agents-go/usage"
 	"github.com/openai/openai-go/v3"
 	"github.com/openai/openai-go/v3/option"
 	"github.com/openai/openai-go/v3/packages/param"
@@ -318,3 +319,89 @@ func TestStreamResponseYieldsEventsForToolCall(t *testing.T) {
 	assert.Equal(t, "response.output_item.done", outputEvents[3].Type)
 	assert.Equal(t, "response.completed", outputEvents[4].Type)
 }
+
+func TestStreamResponseCapturesUsageFromTrailingChunk(t *testing.T) {
+	// Some OpenAI-compatible providers (e.g. LiteLLM) send null or empty usage
+	// on content chunks, and report usage in a separate final chunk with no
+	// choices, after the one carrying the finish reason.
+	type m = map[string]any
+	chunk1 := m{ // ChatCompletionChunk
+		"id":      "chunk-id",
+		"created": 1,
+		"model":   "fake",
+		"object":  "chat.completion.chunk",
+		"choices": []m{{"index": 0, "delta": m{"content": "Hello"}}},
+		"usage":   nil,
+	}
+	chunk2 := m{ // ChatCompletionChunk
+		"id":      "chunk-id",
+		"created": 1,
+		"model":   "fake",
+		"object":  "chat.completion.chunk",
+		"choices": []m{{"index": 0, "delta": m{}, "finish_reason": "stop"}},
+		"usage":   m{"completion_tokens": 0, "prompt_tokens": 0, "total_tokens": 0},
+	}
+	usageChunk := m{ // ChatCompletionChunk
+		"id":      "chunk-id",
+		"created": 1,
+		"model":   "fake",
+		"object":  "chat.completion.chunk",
+		"choices": []m{},
+		"usage": m{ // CompletionUsage
+			"completion_tokens":         5,
+			"prompt_tokens":             7,
+			"total_tokens":              12,
+			"prompt_tokens_details":     m{"cached_tokens": 2},
+			"completion_tokens_details": m{"reasoning_tokens": 3},
+		},
+	}
+
+	emptyUsageChunk := m{ // ChatCompletionChunk
+		"id":      "chunk-id",
+		"created": 1,
+		"model":   "fake",
+		"object":  "chat.completion.chunk",
+		"choices": []m{},
+		"usage":   m{"completion_tokens": 0, "prompt_tokens": 0, "total_tokens": 0},
+	}
+
+	dummyClient := makeOpenaiClientWithStreamResponse(t, chunk1, chunk2, usageChunk, emptyUsageChunk)
+	provider := agents.NewOpenAIProvider(agents.OpenAIProviderParams{
+		OpenaiClient: &dummyClient,
+		UseResponses: param.NewOpt(false),
+	})
+
+	model, err := provider.GetModel("gpt-4")
+	require.NoError(t, err)
+	agent := agents.New("test").WithModelInstance(model)
+
+	ctx := usage.NewContext(t.Context(), usage.NewUsage())
+	result, err := agents.Runner{Config: agents.RunConfig{TracingDisabled: true}}.RunStreamed(ctx, agent, "hi")
+	require.NoError(t, err)
+
+	var completedResp *responses.Response
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if e, ok := event.(agents.RawResponsesStreamEvent); ok && e.Data.Type == "response.completed" {
+			completedResp = &e.Data.Response
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, completedResp)
+	assert.Equal(t, int64(7), completedResp.Usage.InputTokens)
+	assert.Equal(t, int64(5), completedResp.Usage.OutputTokens)
+	assert.Equal(t, int64(12), completedResp.Usage.TotalTokens)
+	assert.Equal(t, int64(2), completedResp.Usage.InputTokensDetails.CachedTokens)
+	assert.Equal(t, int64(3), completedResp.Usage.OutputTokensDetails.ReasoningTokens)
+
+	assert.Equal(t, "Hello", result.FinalOutput())
+
+	u, _ := usage.FromContext(ctx)
+	assert.Equal(t, uint64(1), u.Requests)
+	assert.Equal(t, uint64(7), u.InputTokens)
+	assert.Equal(t, uint64(5), u.OutputTokens)
+	assert.Equal(t, uint64(12), u.TotalTokens)
+	assert.Equal(t, int64(2), u.InputTokensDetails.CachedTokens)
+	assert.Equal(t, int64(3), u.OutputTokensDetails.ReasoningTokens)
+}
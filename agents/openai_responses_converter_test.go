@@ -16,6 +16,7 @@ package agents_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -294,3 +295,126 @@ func TestConvertToolsIncludesHandoffs(t *testing.T) {
 		Includes: nil,
 	}, converted)
 }
+
+func TestConvertWebSearchTool(t *testing.T) {
+	t.Run("location, context size and filters", func(t *testing.T) {
+		tool := agents.WebSearchTool{
+			Filters: responses.WebSearchToolFiltersParam{AllowedDomains: []string{"example.com"}},
+			UserLocation: responses.WebSearchToolUserLocationParam{
+				City:    param.NewOpt("Rome"),
+				Country: param.NewOpt("IT"),
+			},
+			SearchContextSize: responses.WebSearchToolSearchContextSizeLow,
+		}
+		converted, err := agents.ResponsesConverter().ConvertTools(t.Context(), []agents.Tool{tool}, nil)
+		require.NoError(t, err)
+		require.Len(t, converted.Tools, 1)
+		assert.Equal(t, &responses.WebSearchToolParam{
+			Type:    responses.WebSearchToolTypeWebSearch,
+			Filters: responses.WebSearchToolFiltersParam{AllowedDomains: []string{"example.com"}},
+			UserLocation: responses.WebSearchToolUserLocationParam{
+				City:    param.NewOpt("Rome"),
+				Country: param.NewOpt("IT"),
+				Type:    "approximate",
+			},
+			SearchContextSize: responses.WebSearchToolSearchContextSizeLow,
+		}, converted.Tools[0].OfWebSearch)
+	})
+
+	invalidTools := map[string]agents.WebSearchTool{
+		"search context size": {SearchContextSize: "huge"},
+		"location type": {UserLocation: responses.WebSearchToolUserLocationParam{
+			Type: "exact",
+		}},
+		"location country": {UserLocation: responses.WebSearchToolUserLocationParam{
+			Country: param.NewOpt("Italy"),
+		}},
+		"allowed domain URL": {Filters: responses.WebSearchToolFiltersParam{
+			AllowedDomains: []string{"https://example.com"},
+		}},
+		"empty allowed domain": {Filters: responses.WebSearchToolFiltersParam{
+			AllowedDomains: []string{""},
+		}},
+	}
+	for name, tool := range invalidTools {
+		t.Run("invalid "+name, func(t *testing.T) {
+			_, err := agents.ResponsesConverter().ConvertTools(t.Context(), []agents.Tool{tool}, nil)
+			assert.ErrorAs(t, err, &agents.UserError{})
+		})
+	}
+}
+
+func TestConvertFileSearchTool(t *testing.T) {
+	tool := agents.FileSearchTool{
+		VectorStoreIDs: []string{"vs1", "vs2"},
+		MaxNumResults:  param.NewOpt[int64](5),
+		RankingOptions: responses.FileSearchToolRankingOptionsParam{
+			Ranker:         "auto",
+			ScoreThreshold: param.NewOpt(0.5),
+		},
+	}
+	converted, err := agents.ResponsesConverter().ConvertTools(t.Context(), []agents.Tool{tool}, nil)
+	require.NoError(t, err)
+	require.Len(t, converted.Tools, 1)
+	assert.Equal(t, &responses.FileSearchToolParam{
+		VectorStoreIDs: []string{"vs1", "vs2"},
+		MaxNumResults:  param.NewOpt[int64](5),
+		RankingOptions: responses.FileSearchToolRankingOptionsParam{
+			Ranker:         "auto",
+			ScoreThreshold: param.NewOpt(0.5),
+		},
+		Type: constant.ValueOf[constant.FileSearch](),
+	}, converted.Tools[0].OfFileSearch)
+	assert.Empty(t, converted.Includes)
+
+	invalidTools := map[string]agents.FileSearchTool{
+		"no vector stores":   {},
+		"empty vector store": {VectorStoreIDs: []string{""}},
+		"max num results":    {VectorStoreIDs: []string{"vs1"}, MaxNumResults: param.NewOpt[int64](0)},
+		"too many results":   {VectorStoreIDs: []string{"vs1"}, MaxNumResults: param.NewOpt[int64](51)},
+		"score threshold":    {VectorStoreIDs: []string{"vs1"}, RankingOptions: responses.FileSearchToolRankingOptionsParam{ScoreThreshold: param.NewOpt(1.5)}},
+	}
+	for name, tool := range invalidTools {
+		t.Run("invalid "+name, func(t *testing.T) {
+			_, err := agents.ResponsesConverter().ConvertTools(t.Context(), []agents.Tool{tool}, nil)
+			assert.ErrorAs(t, err, &agents.UserError{})
+		})
+	}
+}
+
+func TestFileCitations(t *testing.T) {
+	var message responses.ResponseOutputMessage
+	err := json.Unmarshal([]byte(`{
+		"id": "msg_1",
+		"type": "message",
+		"role": "assistant",
+		"status": "completed",
+		"content": [{
+			"type": "output_text",
+			"text": "See the docs.",
+			"annotations": [
+				{"type": "file_citation", "file_id": "file_1", "filename": "a.pdf", "index": 3},
+				{"type": "url_citation", "url": "https://example.com", "title": "Example", "start_index": 0, "end_index": 3},
+				{"type": "file_citation", "file_id": "file_2", "filename": "b.pdf", "index": 12}
+			]
+		}]
+	}`), &message)
+	require.NoError(t, err)
+
+	citations := agents.ItemHelpers().FileCitations(agents.MessageOutputItem{RawItem: message})
+	require.Len(t, citations, 2)
+	assert.Equal(t, "file_1", citations[0].FileID)
+	assert.Equal(t, "a.pdf", citations[0].Filename)
+	assert.Equal(t, int64(3), citations[0].Index)
+	assert.Equal(t, "file_2", citations[1].FileID)
+	assert.Equal(t, "b.pdf", citations[1].Filename)
+
+	// The citations are kept when the message is sent back to the model.
+	var outputItem agents.TResponseOutputItem
+	require.NoError(t, json.Unmarshal([]byte(message.RawJSON()), &outputItem))
+	inputItems := agents.ModelResponse{Output: []agents.TResponseOutputItem{outputItem}}.ToInputItems()
+	require.Len(t, inputItems, 1)
+	annotations := inputItems[0].OfOutputMessage.Content[0].OfOutputText.Annotations
+	require.Len(t, annotations, 3)
+	assert.Equal(t, "a.pdf", annotations[0].OfFileCitation.Filename)
+}
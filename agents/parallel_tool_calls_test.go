@@ -0,0 +1,81 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelToolCallsRequestParameter(t *testing.T) {
+	models := []struct {
+		name     string
+		body     string
+		newModel func(agents.OpenaiClient) agents.Model
+	}{
+		{"responses", completedResponseJSON, func(client agents.OpenaiClient) agents.Model {
+			return agents.NewOpenAIResponsesModel("gpt-4", client)
+		}},
+		{"chat completions", completedChatCompletionJSON, func(client agents.OpenaiClient) agents.Model {
+			return agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+		}},
+	}
+	testCases := []struct {
+		name              string
+		parallelToolCalls param.Opt[bool]
+		withTools         bool
+		want              any
+		wantPresent       bool
+	}{
+		{"disabled", param.NewOpt(false), true, false, true},
+		{"enabled", param.NewOpt(true), true, true, true},
+		{"enabled without tools", param.NewOpt(true), false, nil, false},
+		{"unset", param.Opt[bool]{}, true, nil, false},
+	}
+
+	for _, m := range models {
+		for _, tc := range testCases {
+			t.Run(m.name+"/"+tc.name, func(t *testing.T) {
+				var requests []recordedRequest
+				client := newRecordingClient(t, &requests, m.body)
+
+				var tools []agents.Tool
+				if tc.withTools {
+					tools = []agents.Tool{agentstesting.GetFunctionTool("foo", "result")}
+				}
+				_, err := m.newModel(client).GetResponse(t.Context(), agents.ModelResponseParams{
+					Input: agents.InputString("hi"),
+					ModelSettings: modelsettings.ModelSettings{
+						ParallelToolCalls: tc.parallelToolCalls,
+					},
+					Tools:   tools,
+					Tracing: agents.ModelTracingDisabled,
+				})
+				require.NoError(t, err)
+
+				require.Len(t, requests, 1)
+				value, ok := requests[0].Body["parallel_tool_calls"]
+				assert.Equal(t, tc.wantPresent, ok)
+				assert.Equal(t, tc.want, value)
+			})
+		}
+	}
+}
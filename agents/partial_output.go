@@ -0,0 +1,217 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// OutputTypePartialParser is an optional interface which an
+// OutputTypeInterface can implement to support the streaming of partial
+// structured output (see RunConfig.EmitPartialStructuredOutput).
+type OutputTypePartialParser interface {
+	// ParsePartialJSON parses a JSON string which may be truncated, returning
+	// an output value with only the complete fields filled in.
+	// The value is not validated against the output type JSON schema.
+	ParsePartialJSON(jsonStr string) (any, error)
+}
+
+// CompletePartialJSON turns a truncated JSON text into valid JSON, keeping
+// only its complete values: incomplete trailing strings, numbers, literals
+// and object keys are dropped, and any open object or array is closed.
+//
+// It reports false if the text contains no complete value at all, e.g. when
+// it is empty, or when it is malformed up to the truncation point.
+// For a text which is already complete, it returns the text itself.
+func CompletePartialJSON(s string) (string, bool) {
+	var sc partialJSONScanner
+	sc.write(s)
+	return completedPrefix(s, sc.cutPos, sc.cutClosers)
+}
+
+// partialJSONScanner scans a truncated JSON text, which can be written in
+// chunks, keeping track of where its complete part ends. Each byte is
+// scanned only once, however many chunks are written.
+type partialJSONScanner struct {
+	closers     []byte // closing characters of the open containers
+	expectKey   []bool // for each open container, whether an object key comes next
+	inString    bool
+	escaped     bool
+	stringIsKey bool
+
+	// Number of bytes written so far.
+	n int
+	// The end of the complete part of the text, and the closers needed to
+	// make it valid JSON. Zero if no value is complete yet.
+	cutPos     int
+	cutClosers []byte
+	// Whether the top-level value is complete, or the text is malformed:
+	// in both cases, the rest of the text doesn't matter.
+	done bool
+}
+
+func (sc *partialJSONScanner) markCut(pos int) {
+	sc.cutPos = pos
+	sc.cutClosers = slices.Clone(sc.closers)
+}
+
+func (sc *partialJSONScanner) write(s string) {
+	if sc.done {
+		return
+	}
+	offset := sc.n
+	sc.n += len(s)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		pos := offset + i
+
+		if sc.inString {
+			switch {
+			case sc.escaped:
+				sc.escaped = false
+			case c == '\\':
+				sc.escaped = true
+			case c == '"':
+				sc.inString = false
+				if !sc.stringIsKey && len(sc.closers) > 0 {
+					sc.markCut(pos + 1)
+				}
+			}
+			continue
+		}
+
+		switch c {
+		case '{', '[':
+			closer := byte('}')
+			if c == '[' {
+				closer = ']'
+			}
+			sc.closers = append(sc.closers, closer)
+			sc.expectKey = append(sc.expectKey, c == '{')
+			sc.markCut(pos + 1)
+		case '}', ']':
+			if len(sc.closers) == 0 || sc.closers[len(sc.closers)-1] != c {
+				sc.done = true
+				return
+			}
+			sc.closers = sc.closers[:len(sc.closers)-1]
+			sc.expectKey = sc.expectKey[:len(sc.expectKey)-1]
+			sc.markCut(pos + 1)
+			if len(sc.closers) == 0 {
+				// The whole top-level value is complete
+				sc.done = true
+				return
+			}
+		case ',':
+			if len(sc.closers) == 0 {
+				sc.done = true
+				return
+			}
+			// The value before the comma is complete
+			sc.markCut(pos)
+			sc.expectKey[len(sc.expectKey)-1] = sc.closers[len(sc.closers)-1] == '}'
+		case ':':
+			if len(sc.expectKey) > 0 {
+				sc.expectKey[len(sc.expectKey)-1] = false
+			}
+		case '"':
+			sc.inString = true
+			sc.stringIsKey = len(sc.expectKey) > 0 && sc.expectKey[len(sc.expectKey)-1]
+		}
+	}
+}
+
+func completedPrefix(s string, cutPos int, closers []byte) (string, bool) {
+	if cutPos == 0 {
+		return "", false
+	}
+	b := make([]byte, 0, cutPos+len(closers))
+	b = append(b, s[:cutPos]...)
+	for i := len(closers) - 1; i >= 0; i-- {
+		b = append(b, closers[i])
+	}
+	return string(b), true
+}
+
+// Above this size, the complete part of the accumulated output text must grow
+// by at least an eighth before it is parsed again, so that parsing a long
+// output takes linear time overall, rather than quadratic.
+const partialOutputThrottleSize = 4096
+
+// partialOutputEmitter accumulates the output text deltas of a streamed
+// response, and emits a PartialOutputStreamEvent each time they can be parsed
+// to a new partial output value.
+type partialOutputEmitter struct {
+	parser    OutputTypePartialParser
+	itemID    string
+	text      strings.Builder
+	scanner   partialJSONScanner
+	parsedCut int // scanner.cutPos when the text was last parsed
+	lastValue any
+}
+
+// newPartialOutputEmitter returns a new emitter for the agent output type, or
+// nil if partial structured output is disabled or not supported.
+func newPartialOutputEmitter(agent *Agent, runConfig RunConfig) *partialOutputEmitter {
+	if !runConfig.EmitPartialStructuredOutput || agent.OutputType == nil || agent.OutputType.IsPlainText() {
+		return nil
+	}
+	parser, ok := agent.OutputType.(OutputTypePartialParser)
+	if !ok {
+		return nil
+	}
+	return &partialOutputEmitter{parser: parser}
+}
+
+func (e *partialOutputEmitter) handleEvent(event TResponseStreamEvent, streamedResult *RunResultStreaming) {
+	if e == nil || event.Type != "response.output_text.delta" {
+		return
+	}
+
+	// The final output comes from the last message: start over on a new one
+	if event.ItemID != e.itemID {
+		e.itemID = event.ItemID
+		e.text.Reset()
+		e.scanner = partialJSONScanner{}
+		e.parsedCut = 0
+		e.lastValue = nil
+	}
+	e.text.WriteString(event.Delta)
+	e.scanner.write(event.Delta)
+
+	// Parse only when more values are complete, and not too often for long
+	// outputs. The complete top-level value is always parsed.
+	growth := e.scanner.cutPos - e.parsedCut
+	if growth <= 0 || (!e.scanner.done && growth < (e.parsedCut-partialOutputThrottleSize)/8) {
+		return
+	}
+	e.parsedCut = e.scanner.cutPos
+
+	// Malformed intermediate states are simply skipped
+	value, err := e.parser.ParsePartialJSON(e.text.String())
+	if err != nil || reflect.DeepEqual(value, e.lastValue) {
+		return
+	}
+	e.lastValue = value
+	streamedResult.partialOutput.setValue(value)
+
+	streamedResult.eventQueue.Put(PartialOutputStreamEvent{
+		Value: value,
+		Type:  "partial_output_stream_event",
+	})
+}
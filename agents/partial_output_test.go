@@ -0,0 +1,222 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletePartialJSON(t *testing.T) {
+	testCases := []struct {
+		input  string
+		want   string
+		wantOk bool
+	}{
+		{``, ``, false},
+		{`  `, ``, false},
+		{`{`, `{}`, true},
+		{`{"na`, `{}`, true},
+		{`{"name"`, `{}`, true},
+		{`{"name":`, `{}`, true},
+		{`{"name": "Jo`, `{}`, true},
+		{`{"name": "Jo\"e"`, `{"name": "Jo\"e"}`, true},
+		{`{"name": "Joe", "age": 4`, `{"name": "Joe"}`, true},
+		{`{"name": "Joe", "age": 42,`, `{"name": "Joe", "age": 42}`, true},
+		{`{"tags": ["a", "b`, `{"tags": ["a"]}`, true},
+		{`{"tags": ["a", "b"], "x": {"y": tr`, `{"tags": ["a", "b"], "x": {}}`, true},
+		{`{"x": {"y": [1]}`, `{"x": {"y": [1]}}`, true},
+		{`[{"a": "b"}, {"c"`, `[{"a": "b"}, {}]`, true},
+		{`{"name": "Joe"}`, `{"name": "Joe"}`, true},
+		{`{"name": "Joe"} trailing`, `{"name": "Joe"}`, true},
+		{`{"name": "Joe"]`, `{"name": "Joe"}`, true},
+		{`]`, ``, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, ok := agents.CompletePartialJSON(tc.input)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+type PartialOutputTestFoo struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+func TestOutputTypeParsePartialJSON(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		parser, ok := agents.OutputType[PartialOutputTestFoo]().(agents.OutputTypePartialParser)
+		require.True(t, ok)
+
+		v, err := parser.ParsePartialJSON(`{"name": "Joe", "tags": ["a", "b`)
+		require.NoError(t, err)
+		assert.Equal(t, PartialOutputTestFoo{Name: "Joe", Tags: []string{"a"}}, v)
+
+		_, err = parser.ParsePartialJSON(``)
+		assert.ErrorAs(t, err, &agents.OutputParsingError{})
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		parser, ok := agents.OutputType[[]string]().(agents.OutputTypePartialParser)
+		require.True(t, ok)
+
+		v, err := parser.ParsePartialJSON(`{"response": ["a", "b`)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, v)
+	})
+
+	t.Run("plain text", func(t *testing.T) {
+		parser, ok := agents.OutputType[string]().(agents.OutputTypePartialParser)
+		require.True(t, ok)
+
+		_, err := parser.ParsePartialJSON(`"foo"`)
+		assert.ErrorAs(t, err, &agents.UserError{})
+	})
+}
+
+// textDeltaStreamingModel is a FakeModel which streams the given text deltas
+// before completing the response.
+type textDeltaStreamingModel struct {
+	*agentstesting.FakeModel
+	deltas []string
+}
+
+func (m *textDeltaStreamingModel) StreamResponse(
+	ctx context.Context,
+	params agents.ModelResponseParams,
+	yield agents.ModelStreamResponseCallback,
+) error {
+	for i, delta := range m.deltas {
+		err := yield(ctx, agents.TResponseStreamEvent{ // responses.ResponseTextDeltaEvent
+			ItemID:         "msg_1",
+			Delta:          delta,
+			Type:           "response.output_text.delta",
+			SequenceNumber: int64(i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return m.FakeModel.StreamResponse(ctx, params, yield)
+}
+
+func TestRunStreamedEmitPartialStructuredOutput(t *testing.T) {
+	const finalJSON = `{"name": "Joe", "tags": ["a", "b"]}`
+
+	newAgent := func() *agents.Agent {
+		fakeModel := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetFinalOutputMessage(finalJSON),
+			},
+		})
+		model := &textDeltaStreamingModel{
+			FakeModel: fakeModel,
+			deltas:    []string{`{"na`, `me": "Jo`, `e", "ta`, `gs": ["a", `, `"b"`, `]}`},
+		}
+		return agents.New("test").
+			WithModelInstance(model).
+			WithOutputType(agents.OutputType[PartialOutputTestFoo]())
+	}
+
+	collectPartialOutputs := func(t *testing.T, runConfig agents.RunConfig) ([]any, any) {
+		result, err := (agents.Runner{Config: runConfig}).RunStreamed(t.Context(), newAgent(), "user_message")
+		require.NoError(t, err)
+
+		var values []any
+		err = result.StreamEvents(func(event agents.StreamEvent) error {
+			if e, ok := event.(agents.PartialOutputStreamEvent); ok {
+				assert.Equal(t, "partial_output_stream_event", e.Type)
+				values = append(values, e.Value)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return values, result.FinalOutput()
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		values, finalOutput := collectPartialOutputs(t, agents.RunConfig{EmitPartialStructuredOutput: true})
+		assert.Equal(t, []any{
+			PartialOutputTestFoo{},
+			PartialOutputTestFoo{Name: "Joe"},
+			PartialOutputTestFoo{Name: "Joe", Tags: []string{"a"}},
+			PartialOutputTestFoo{Name: "Joe", Tags: []string{"a", "b"}},
+			PartialOutputTestFoo{Name: "Joe", Tags: []string{"a", "b"}},
+		}, values)
+		assert.Equal(t, finalOutput, values[len(values)-1])
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		values, _ := collectPartialOutputs(t, agents.RunConfig{})
+		assert.Empty(t, values)
+	})
+}
+
+// countingPartialParser counts the calls to ParsePartialJSON of the output type.
+type countingPartialParser struct {
+	agents.OutputTypeInterface
+	calls int
+}
+
+func (p *countingPartialParser) ParsePartialJSON(jsonStr string) (any, error) {
+	p.calls++
+	return p.OutputTypeInterface.(agents.OutputTypePartialParser).ParsePartialJSON(jsonStr)
+}
+
+func TestRunStreamedPartialStructuredOutputIsThrottled(t *testing.T) {
+	tags := make([]string, 2000)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("t%d", i)
+	}
+	finalJSONBytes, err := json.Marshal(PartialOutputTestFoo{Name: "Joe", Tags: tags})
+	require.NoError(t, err)
+	finalJSON := string(finalJSONBytes)
+
+	fakeModel := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetFinalOutputMessage(finalJSON)},
+	})
+	// One delta per character
+	model := &textDeltaStreamingModel{FakeModel: fakeModel, deltas: strings.Split(finalJSON, "")}
+	outputType := &countingPartialParser{OutputTypeInterface: agents.OutputType[PartialOutputTestFoo]()}
+	agent := agents.New("test").WithModelInstance(model).WithOutputType(outputType)
+
+	runner := agents.Runner{Config: agents.RunConfig{EmitPartialStructuredOutput: true}}
+	result, err := runner.RunStreamed(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+
+	var lastValue any
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if e, ok := event.(agents.PartialOutputStreamEvent); ok {
+			lastValue = e.Value
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Far fewer parses than complete values, and the last one is the full output.
+	assert.Less(t, outputType.calls, len(tags)/2)
+	assert.Equal(t, result.FinalOutput(), lastValue)
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getReasoningItem() agents.TResponseOutputItem {
+	return agents.TResponseOutputItem{ // responses.ResponseReasoningItem
+		ID:   "rs_1",
+		Type: "reasoning",
+		Summary: []responses.ResponseReasoningItemSummary{
+			{Text: "Need to call the tool.", Type: "summary_text"},
+		},
+		Content: []responses.ResponseOutputMessageContentUnion{
+			{Text: "The user wants foo.", Type: "reasoning_text"},
+		},
+		EncryptedContent: "encrypted-reasoning",
+		Status:           "completed",
+	}
+}
+
+func TestReasoningItemsPreservedAcrossTurns(t *testing.T) {
+	newAgent := func() (*agents.Agent, *agentstesting.FakeModel) {
+		model := agentstesting.NewFakeModel(false, nil)
+		model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+			{Value: []agents.TResponseOutputItem{
+				getReasoningItem(),
+				agentstesting.GetFunctionToolCall("foo", `{}`),
+			}},
+			{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+		})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithTools(agentstesting.GetFunctionTool("foo", "result"))
+		return agent, model
+	}
+
+	assertReasoningInInput := func(t *testing.T, input agents.Input) {
+		t.Helper()
+		require.IsType(t, agents.InputItems{}, input)
+		var reasoningItems []responses.ResponseReasoningItemParam
+		for _, item := range input.(agents.InputItems) {
+			if item.OfReasoning != nil {
+				reasoningItems = append(reasoningItems, *item.OfReasoning)
+			}
+		}
+		require.Len(t, reasoningItems, 1)
+		reasoning := reasoningItems[0]
+		assert.Equal(t, "rs_1", reasoning.ID)
+		assert.Equal(t, param.NewOpt("encrypted-reasoning"), reasoning.EncryptedContent)
+		require.Len(t, reasoning.Summary, 1)
+		assert.Equal(t, "Need to call the tool.", reasoning.Summary[0].Text)
+		require.Len(t, reasoning.Content, 1)
+		assert.Equal(t, "The user wants foo.", reasoning.Content[0].Text)
+	}
+
+	t.Run("non streamed", func(t *testing.T) {
+		agent, model := newAgent()
+		_, err := agents.Run(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		assertReasoningInInput(t, model.LastTurnArgs.Input)
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		agent, model := newAgent()
+		result, err := agents.RunStreamed(t.Context(), agent, "user_message")
+		require.NoError(t, err)
+		require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+		assertReasoningInInput(t, model.LastTurnArgs.Input)
+	})
+}
+
+func TestReasoningItemInputRequiresSummary(t *testing.T) {
+	item := getReasoningItem()
+	item.Summary = nil
+	inputItem := agents.ModelResponse{Output: []agents.TResponseOutputItem{item}}.ToInputItems()[0]
+
+	b, err := inputItem.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"summary":[]`)
+	assert.Contains(t, string(b), `"encrypted_content":"encrypted-reasoning"`)
+}
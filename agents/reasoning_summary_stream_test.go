@@ -0,0 +1,89 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reasoningDeltaStreamingModel is a FakeModel which streams the given
+// reasoning summary deltas before completing the response.
+type reasoningDeltaStreamingModel struct {
+	*agentstesting.FakeModel
+	deltas []string
+}
+
+func (m *reasoningDeltaStreamingModel) StreamResponse(
+	ctx context.Context,
+	params agents.ModelResponseParams,
+	yield agents.ModelStreamResponseCallback,
+) error {
+	for i, delta := range m.deltas {
+		err := yield(ctx, agents.TResponseStreamEvent{ // responses.ResponseReasoningSummaryTextDeltaEvent
+			ItemID:         "rs_1",
+			Delta:          delta,
+			SummaryIndex:   int64(i / 2),
+			Type:           "response.reasoning_summary_text.delta",
+			SequenceNumber: int64(i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return m.FakeModel.StreamResponse(ctx, params, yield)
+}
+
+func TestRunStreamedReasoningSummaryEvents(t *testing.T) {
+	model := &reasoningDeltaStreamingModel{
+		FakeModel: agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("42")},
+		}),
+		deltas: []string{"Thinking ", "hard.", "Almost ", "there."},
+	}
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithModelSettings(modelsettings.ModelSettings{
+			Reasoning: openai.ReasoningParam{Summary: openai.ReasoningSummaryAuto},
+		})
+
+	result, err := agents.RunStreamed(t.Context(), agent, "What is the answer?")
+	require.NoError(t, err)
+
+	var events []agents.ReasoningSummaryStreamEvent
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if e, ok := event.(agents.ReasoningSummaryStreamEvent); ok {
+			events = append(events, e)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []agents.ReasoningSummaryStreamEvent{
+		{Delta: "Thinking ", ItemID: "rs_1", SummaryIndex: 0, Type: "reasoning_summary_stream_event"},
+		{Delta: "hard.", ItemID: "rs_1", SummaryIndex: 0, Type: "reasoning_summary_stream_event"},
+		{Delta: "Almost ", ItemID: "rs_1", SummaryIndex: 1, Type: "reasoning_summary_stream_event"},
+		{Delta: "there.", ItemID: "rs_1", SummaryIndex: 1, Type: "reasoning_summary_stream_event"},
+	}, events)
+	assert.Equal(t, openai.ReasoningSummaryAuto, model.LastTurnArgs.ModelSettings.Reasoning.Summary)
+	assert.Equal(t, "42", result.FinalOutput())
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const completedChatCompletionJSON = `{
+	"id": "chatcmpl-1",
+	"object": "chat.completion",
+	"created": 0,
+	"model": "gpt-4",
+	"choices": [{
+		"index": 0,
+		"finish_reason": "stop",
+		"message": {"role": "assistant", "content": "done"}
+	}]
+}`
+
+func TestRequestMetadata(t *testing.T) {
+	t.Run("responses", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests, completedResponseJSON)
+
+		model := agents.NewOpenAIResponsesModel("gpt-4", client)
+		_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input: agents.InputString("hi"),
+			ModelSettings: modelsettings.ModelSettings{
+				Metadata: map[string]string{"team": "search"},
+			},
+			Tracing: agents.ModelTracingDisabled,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, map[string]any{"team": "search"}, requests[0].Body["metadata"])
+	})
+
+	t.Run("chat completions", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests, completedChatCompletionJSON)
+
+		model := agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+		_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input: agents.InputString("hi"),
+			ModelSettings: modelsettings.ModelSettings{
+				Metadata: map[string]string{"team": "search"},
+			},
+			Tracing: agents.ModelTracingDisabled,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, map[string]any{"team": "search"}, requests[0].Body["metadata"])
+	})
+}
+
+func TestRequestMetadataMergesRunLevelMetadata(t *testing.T) {
+	var requests []recordedRequest
+	client := newRecordingClient(t, &requests, completedResponseJSON)
+
+	agent := &agents.Agent{
+		Name:  "test",
+		Model: param.NewOpt(agents.NewAgentModel(agents.NewOpenAIResponsesModel("gpt-4", client))),
+		ModelSettings: modelsettings.ModelSettings{
+			Metadata: map[string]string{"team": "search", "env": "dev"},
+		},
+	}
+	_, err := agents.Runner{Config: agents.RunConfig{
+		ModelSettings: modelsettings.ModelSettings{
+			Metadata: map[string]string{"env": "prod", "user": "u1"},
+		},
+		TracingDisabled: true,
+	}}.Run(t.Context(), agent, "hi")
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, map[string]any{"team": "search", "env": "prod", "user": "u1"}, requests[0].Body["metadata"])
+}
+
+func TestRequestMetadataLimits(t *testing.T) {
+	tooManyPairs := make(map[string]string)
+	for i := range agents.MaxRequestMetadataPairs + 1 {
+		tooManyPairs[fmt.Sprintf("k%d", i)] = "v"
+	}
+
+	testCases := []struct {
+		name     string
+		metadata map[string]string
+		errMsg   string
+	}{
+		{
+			name:     "too many pairs",
+			metadata: tooManyPairs,
+			errMsg:   "metadata can have at most 16 key-value pairs, got 17",
+		},
+		{
+			name:     "key too long",
+			metadata: map[string]string{strings.Repeat("k", 65): "v"},
+			errMsg:   "is too long: at most 64 characters are allowed, got 65",
+		},
+		{
+			name:     "value too long",
+			metadata: map[string]string{"k": strings.Repeat("v", 513)},
+			errMsg:   `metadata value for key "k" is too long: at most 512 characters are allowed, got 513`,
+		},
+	}
+
+	models := map[string]func(agents.OpenaiClient) agents.Model{
+		"responses": func(client agents.OpenaiClient) agents.Model {
+			return agents.NewOpenAIResponsesModel("gpt-4", client)
+		},
+		"chat completions": func(client agents.OpenaiClient) agents.Model {
+			return agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+		},
+	}
+
+	for modelName, newModel := range models {
+		for _, tc := range testCases {
+			t.Run(modelName+"/"+tc.name, func(t *testing.T) {
+				var requests []recordedRequest
+				model := newModel(newRecordingClient(t, &requests))
+
+				_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+					Input:         agents.InputString("hi"),
+					ModelSettings: modelsettings.ModelSettings{Metadata: tc.metadata},
+					Tracing:       agents.ModelTracingDisabled,
+				})
+				var userErr agents.UserError
+				require.ErrorAs(t, err, &userErr)
+				assert.ErrorContains(t, err, tc.errMsg)
+				assert.Empty(t, requests)
+			})
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type responseFormatOutput struct {
+	Answer string `json:"answer"`
+}
+
+func TestResponseFormatJSONObject(t *testing.T) {
+	settings := modelsettings.ModelSettings{
+		ResponseFormat: param.NewOpt(modelsettings.ResponseFormatJSONObject),
+	}
+
+	t.Run("chat completions", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests, completedChatCompletionJSON)
+
+		model := agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+		_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input:         agents.InputString("hi"),
+			ModelSettings: settings,
+			Tracing:       agents.ModelTracingDisabled,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, map[string]any{"type": "json_object"}, requests[0].Body["response_format"])
+	})
+
+	t.Run("responses", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests, completedResponseJSON)
+
+		model := agents.NewOpenAIResponsesModel("gpt-4", client)
+		_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input:         agents.InputString("hi"),
+			ModelSettings: settings,
+			Tracing:       agents.ModelTracingDisabled,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, map[string]any{"format": map[string]any{"type": "json_object"}}, requests[0].Body["text"])
+	})
+}
+
+func TestResponseFormatOutputTypeTakesPrecedence(t *testing.T) {
+	var requests []recordedRequest
+	client := newRecordingClient(t, &requests, completedChatCompletionJSON)
+
+	model := agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+	_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+		Input: agents.InputString("hi"),
+		ModelSettings: modelsettings.ModelSettings{
+			ResponseFormat: param.NewOpt(modelsettings.ResponseFormatJSONObject),
+		},
+		OutputType: agents.OutputType[responseFormatOutput](),
+		Tracing:    agents.ModelTracingDisabled,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	responseFormat, ok := requests[0].Body["response_format"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "json_schema", responseFormat["type"])
+}
+
+func TestResponseFormatInvalid(t *testing.T) {
+	var requests []recordedRequest
+	client := newRecordingClient(t, &requests)
+
+	model := agents.NewOpenAIChatCompletionsModel("gpt-4", client)
+	_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+		Input: agents.InputString("hi"),
+		ModelSettings: modelsettings.ModelSettings{
+			ResponseFormat: param.NewOpt(modelsettings.ResponseFormat("yaml")),
+		},
+		Tracing: agents.ModelTracingDisabled,
+	})
+	assert.ErrorAs(t, err, &agents.UserError{})
+	assert.Empty(t, requests)
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Body   map[string]any
+}
+
+// newRecordingClient returns a client which records the requests, and replies
+// with the given JSON bodies, in order.
+func newRecordingClient(t *testing.T, requests *[]recordedRequest, bodies ...string) agents.OpenaiClient {
+	t.Helper()
+	return agents.OpenaiClient{
+		Client: openai.NewClient(
+			option.WithMiddleware(func(req *http.Request, _ option.MiddlewareNext) (*http.Response, error) {
+				recorded := recordedRequest{Method: req.Method, Path: req.URL.Path}
+				if req.Body != nil {
+					b, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+					if len(b) > 0 {
+						require.NoError(t, json.Unmarshal(b, &recorded.Body))
+					}
+				}
+				*requests = append(*requests, recorded)
+
+				require.NotEmpty(t, bodies, "unexpected request")
+				body := bodies[0]
+				bodies = bodies[1:]
+				return &http.Response{
+					StatusCode:    http.StatusOK,
+					Body:          io.NopCloser(strings.NewReader(body)),
+					ContentLength: int64(len(body)),
+					Header:        http.Header{"Content-Type": []string{"application/json"}},
+				}, nil
+			}),
+		),
+	}
+}
+
+const completedResponseJSON = `{
+	"id": "resp-1",
+	"status": "completed",
+	"output": [{
+		"type": "message",
+		"id": "msg-1",
+		"role": "assistant",
+		"status": "completed",
+		"content": [{"type": "output_text", "text": "done", "annotations": []}]
+	}]
+}`
+
+func TestResponsesModelStore(t *testing.T) {
+	var requests []recordedRequest
+	client := newRecordingClient(t, &requests, completedResponseJSON)
+
+	model := agents.NewOpenAIResponsesModel("gpt-4", client)
+	_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+		Input: agents.InputString("hi"),
+		ModelSettings: modelsettings.ModelSettings{
+			Store: param.NewOpt(false),
+		},
+		Tracing: agents.ModelTracingDisabled,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, false, requests[0].Body["store"])
+	assert.NotContains(t, requests[0].Body, "background")
+}
+
+func TestResponsesModelBackground(t *testing.T) {
+	pollInterval := agents.BackgroundResponsePollInterval
+	agents.BackgroundResponsePollInterval = time.Millisecond
+	t.Cleanup(func() { agents.BackgroundResponsePollInterval = pollInterval })
+
+	t.Run("polls until completed", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests,
+			`{"id": "resp-1", "status": "queued", "output": []}`,
+			`{"id": "resp-1", "status": "in_progress", "output": []}`,
+			completedResponseJSON,
+		)
+
+		model := agents.NewOpenAIResponsesModel("gpt-4", client)
+		response, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input: agents.InputString("hi"),
+			ModelSettings: modelsettings.ModelSettings{
+				Background: param.NewOpt(true),
+			},
+			Tracing: agents.ModelTracingDisabled,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "resp-1", response.ResponseID)
+		require.Len(t, response.Output, 1)
+		assert.Equal(t, "done", response.Output[0].Content[0].Text)
+
+		require.Len(t, requests, 3)
+		assert.Equal(t, http.MethodPost, requests[0].Method)
+		assert.Equal(t, true, requests[0].Body["background"])
+		for _, req := range requests[1:] {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.True(t, strings.HasSuffix(req.Path, "/responses/resp-1"), req.Path)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests,
+			`{"id": "resp-1", "status": "queued", "output": []}`,
+			`{"id": "resp-1", "status": "failed", "output": [], "error": {"code": "server_error", "message": "boom"}}`,
+		)
+
+		model := agents.NewOpenAIResponsesModel("gpt-4", client)
+		_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input: agents.InputString("hi"),
+			ModelSettings: modelsettings.ModelSettings{
+				Background: param.NewOpt(true),
+			},
+			Tracing: agents.ModelTracingDisabled,
+		})
+		require.EqualError(t, err, "background response resp-1 failed: boom")
+	})
+
+	t.Run("store false", func(t *testing.T) {
+		var requests []recordedRequest
+		client := newRecordingClient(t, &requests)
+
+		model := agents.NewOpenAIResponsesModel("gpt-4", client)
+		_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+			Input: agents.InputString("hi"),
+			ModelSettings: modelsettings.ModelSettings{
+				Store:      param.NewOpt(false),
+				Background: param.NewOpt(true),
+			},
+			Tracing: agents.ModelTracingDisabled,
+		})
+		require.ErrorAs(t, err, &agents.UserError{})
+		assert.Empty(t, requests)
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponsesModelTruncation(t *testing.T) {
+	var requests []recordedRequest
+	client := newRecordingClient(t, &requests, completedResponseJSON)
+
+	model := agents.NewOpenAIResponsesModel("gpt-4", client)
+	_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+		Input: agents.InputString("hi"),
+		ModelSettings: modelsettings.ModelSettings{
+			Truncation: param.NewOpt(modelsettings.TruncationAuto),
+		},
+		Tracing: agents.ModelTracingDisabled,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "auto", requests[0].Body["truncation"])
+}
+
+func TestResponsesModelTruncationRunConfigOverride(t *testing.T) {
+	var requests []recordedRequest
+	client := newRecordingClient(t, &requests, completedResponseJSON)
+
+	agent := &agents.Agent{
+		Name:  "test",
+		Model: param.NewOpt(agents.NewAgentModel(agents.NewOpenAIResponsesModel("gpt-4", client))),
+		ModelSettings: modelsettings.ModelSettings{
+			Truncation: param.NewOpt(modelsettings.TruncationAuto),
+		},
+	}
+	_, err := agents.Runner{Config: agents.RunConfig{
+		ModelSettings: modelsettings.ModelSettings{
+			Truncation: param.NewOpt(modelsettings.TruncationDisabled),
+		},
+		TracingDisabled: true,
+	}}.Run(t.Context(), agent, "hi")
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "disabled", requests[0].Body["truncation"])
+}
+
+func TestResponsesModelInvalidTruncation(t *testing.T) {
+	var requests []recordedRequest
+	client := newRecordingClient(t, &requests)
+
+	model := agents.NewOpenAIResponsesModel("gpt-4", client)
+	_, err := model.GetResponse(t.Context(), agents.ModelResponseParams{
+		Input: agents.InputString("hi"),
+		ModelSettings: modelsettings.ModelSettings{
+			Truncation: param.NewOpt[modelsettings.Truncation]("sometimes"),
+		},
+		Tracing: agents.ModelTracingDisabled,
+	})
+	var userErr agents.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.ErrorContains(t, err, `invalid truncation strategy "sometimes"`)
+	assert.Empty(t, requests)
+}
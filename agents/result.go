@@ -16,6 +16,7 @@ package agents
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
@@ -39,9 +40,19 @@ type RunResult struct {
 	// The raw LLM responses generated by the model during the agent run.
 	RawResponses []ModelResponse
 
-	// The output of the last agent.
+	// The responses of RunConfig.ShadowModel, one for each turn, if a shadow
+	// model was configured. They are recorded for comparison only, and never
+	// affect the run.
+	ShadowResponses []ShadowModelResponse
+
+	// The output of the last agent. When the run StoppedEarly, it is the text
+	// of the last message generated, if any, or nil.
 	FinalOutput any
 
+	// Whether the run was stopped upon reaching RunConfig.MaxTurns, without
+	// a final output, because RunConfig.StopOnMaxTurns is enabled.
+	StoppedEarly bool
+
 	// Guardrail results for the input messages.
 	InputGuardrailResults []InputGuardrailResult
 
@@ -50,6 +61,18 @@ type RunResult struct {
 
 	// The LastAgent that was run.
 	LastAgent *Agent
+
+	// The tool calls waiting for approval, when the run was suspended because
+	// some tools returned an ApprovalRequiredError. In this case, there is no
+	// FinalOutput, and the run can be continued with Runner.Resume.
+	Interruptions []ApprovalRequest
+
+	// The input given to the run, before being prepared with the session
+	// history, used for saving to the session a resumed run.
+	sessionInput Input
+
+	// The state of the step suspended by the Interruptions.
+	interruptedStep *interruptedStep
 }
 
 func (r RunResult) String() string {
@@ -61,11 +84,30 @@ func (r RunResult) ToInputList() []TResponseInputItem {
 	return toInputList(r.Input, r.NewItems)
 }
 
+// ToInputListFiltered is like ToInputList, but only keeps the items allowed
+// by the given options.
+func (r RunResult) ToInputListFiltered(opts FilterOptions) []TResponseInputItem {
+	return filterInputList(r.ToInputList(), opts)
+}
+
 // LastResponseID is a convenience method to get the response ID of the last model response.
 func (r RunResult) LastResponseID() string {
 	return lastResponseID(r.RawResponses)
 }
 
+// ToolInvocations pairs the tool calls made during the run with their outputs.
+// See ToolInvocation for details.
+func (r RunResult) ToolInvocations() []ToolInvocation {
+	return toolInvocations(r.NewItems)
+}
+
+// LastTextOutput is a convenience method to get the final output as text.
+// Structured outputs are marshaled to JSON. It returns false if there is
+// no final output.
+func (r RunResult) LastTextOutput() (string, bool) {
+	return lastTextOutput(r.FinalOutput)
+}
+
 // RunResultStreaming is the result of an agent run in streaming mode.
 // You can use the `StreamEvents` method to receive semantic events as they are generated.
 //
@@ -77,15 +119,20 @@ type RunResultStreaming struct {
 	input                  *atomic.Pointer[Input]
 	newItems               *atomic.Pointer[[]RunItem]
 	rawResponses           *atomic.Pointer[[]ModelResponse]
+	shadowResponses        *atomic.Pointer[[]ShadowModelResponse]
 	finalOutput            *atomic.Value
 	inputGuardrailResults  *atomic.Pointer[[]InputGuardrailResult]
 	outputGuardrailResults *atomic.Pointer[[]OutputGuardrailResult]
 	currentAgent           *atomic.Pointer[Agent]
 	currentTurn            *atomic.Uint64
 	maxTurns               *atomic.Uint64
+	stoppedEarly           *atomic.Bool
 	currentAgentOutputType *atomic.Pointer[OutputTypeInterface]
 	trace                  *atomic.Pointer[tracing.Trace]
 	isComplete             *atomic.Bool
+	isCancelled            *atomic.Bool
+	cancelMu               *sync.Mutex
+	partialOutput          *partialFinalOutput
 	eventQueue             *asyncqueue.Queue[StreamEvent]
 	inputGuardrailQueue    *asyncqueue.Queue[InputGuardrailResult]
 	runImplTask            *atomic.Pointer[asynctask.TaskNoValue]
@@ -100,15 +147,20 @@ func newRunResultStreaming(ctx context.Context) *RunResultStreaming {
 		input:                  newZeroValAtomicPointer[Input](),
 		newItems:               newZeroValAtomicPointer[[]RunItem](),
 		rawResponses:           newZeroValAtomicPointer[[]ModelResponse](),
+		shadowResponses:        newZeroValAtomicPointer[[]ShadowModelResponse](),
 		finalOutput:            new(atomic.Value),
 		inputGuardrailResults:  newZeroValAtomicPointer[[]InputGuardrailResult](),
 		outputGuardrailResults: newZeroValAtomicPointer[[]OutputGuardrailResult](),
 		currentAgent:           new(atomic.Pointer[Agent]),
 		currentTurn:            new(atomic.Uint64),
 		maxTurns:               new(atomic.Uint64),
+		stoppedEarly:           new(atomic.Bool),
 		currentAgentOutputType: newZeroValAtomicPointer[OutputTypeInterface](),
 		trace:                  newZeroValAtomicPointer[tracing.Trace](),
 		isComplete:             new(atomic.Bool),
+		isCancelled:            new(atomic.Bool),
+		cancelMu:               new(sync.Mutex),
+		partialOutput:          new(partialFinalOutput),
 		eventQueue:             asyncqueue.New[StreamEvent](),
 		inputGuardrailQueue:    asyncqueue.New[InputGuardrailResult](),
 		runImplTask:            new(atomic.Pointer[asynctask.TaskNoValue]),
@@ -142,8 +194,19 @@ func (r *RunResultStreaming) appendRawResponses(v ...ModelResponse) {
 	r.setRawResponses(append(r.RawResponses(), v...))
 }
 
+// ShadowResponses returns the responses of RunConfig.ShadowModel generated during the agent run.
+func (r *RunResultStreaming) ShadowResponses() []ShadowModelResponse {
+	return *r.shadowResponses.Load()
+}
+func (r *RunResultStreaming) setShadowResponses(v []ShadowModelResponse) { r.shadowResponses.Store(&v) }
+func (r *RunResultStreaming) appendShadowResponses(v ...ShadowModelResponse) {
+	r.setShadowResponses(append(r.ShadowResponses(), v...))
+}
+
 // FinalOutput returns the output of the last agent.
-// This is nil until the agent has finished running.
+// This is nil until the agent has finished running, unless the run is
+// cancelled while the model is generating the output: in that case, it is the
+// partial output received so far, if any (see Cancel).
 func (r *RunResultStreaming) FinalOutput() any     { return r.finalOutput.Load() }
 func (r *RunResultStreaming) setFinalOutput(v any) { r.finalOutput.Store(v) }
 
@@ -175,6 +238,12 @@ func (r *RunResultStreaming) setCurrentTurn(v uint64) { r.currentTurn.Store(v) }
 func (r *RunResultStreaming) MaxTurns() uint64     { return r.maxTurns.Load() }
 func (r *RunResultStreaming) setMaxTurns(v uint64) { r.maxTurns.Store(v) }
 
+// StoppedEarly reports whether the run was stopped upon reaching MaxTurns,
+// without a final output, because RunConfig.StopOnMaxTurns is enabled.
+// In this case, FinalOutput is the text of the last message generated, if any.
+func (r *RunResultStreaming) StoppedEarly() bool     { return r.stoppedEarly.Load() }
+func (r *RunResultStreaming) setStoppedEarly(v bool) { r.stoppedEarly.Store(v) }
+
 func (r *RunResultStreaming) getCurrentAgentOutputType() OutputTypeInterface {
 	return *r.currentAgentOutputType.Load()
 }
@@ -194,6 +263,9 @@ func (r *RunResultStreaming) IsComplete() bool     { return r.isComplete.Load()
 func (r *RunResultStreaming) setIsComplete(v bool) { r.isComplete.Store(v) }
 func (r *RunResultStreaming) markAsComplete()      { r.setIsComplete(true) }
 
+// IsCancelled reports whether the run was stopped by calling Cancel.
+func (r *RunResultStreaming) IsCancelled() bool { return r.isCancelled.Load() }
+
 func (r *RunResultStreaming) getRunImplTask() *asynctask.TaskNoValue  { return r.runImplTask.Load() }
 func (r *RunResultStreaming) setRunImplTask(v *asynctask.TaskNoValue) { r.runImplTask.Store(v) }
 func (r *RunResultStreaming) createRunImplTask(ctx context.Context, fn func(context.Context) error) {
@@ -228,11 +300,29 @@ func (r *RunResultStreaming) ToInputList() []TResponseInputItem {
 	return toInputList(r.Input(), r.NewItems())
 }
 
+// ToInputListFiltered is like ToInputList, but only keeps the items allowed
+// by the given options.
+func (r *RunResultStreaming) ToInputListFiltered(opts FilterOptions) []TResponseInputItem {
+	return filterInputList(r.ToInputList(), opts)
+}
+
 // LastResponseID is a convenience method to get the response ID of the last model response.
 func (r *RunResultStreaming) LastResponseID() string {
 	return lastResponseID(r.RawResponses())
 }
 
+// ToolInvocations pairs the tool calls made during the run with their outputs.
+// Updates as the agent run progresses.
+func (r *RunResultStreaming) ToolInvocations() []ToolInvocation {
+	return toolInvocations(r.NewItems())
+}
+
+// LastTextOutput is a convenience method to get the final output as text.
+// The final output is only available after the agent run is complete.
+func (r *RunResultStreaming) LastTextOutput() (string, bool) {
+	return lastTextOutput(r.FinalOutput())
+}
+
 // The LastAgent that was run.
 // Updates as the agent run progresses, so the true last agent is only
 // available after the agent run is complete.
@@ -241,11 +331,31 @@ func (r *RunResultStreaming) LastAgent() *Agent {
 }
 
 // Cancel the streaming run, stopping all background tasks and marking the run as complete.
+//
+// The state accumulated so far is kept: NewItems and RawResponses report the
+// completed turns, and, if the model was generating the final output of a
+// plain text agent, or of an agent emitting partial structured output (see
+// RunConfig.EmitPartialStructuredOutput), FinalOutput reports the partial
+// output received so far. The run is not saved to the session, if any: if it
+// is already being saved, Cancel waits for the save to complete.
+//
+// After cancellation, IsCancelled reports true, and StreamEvents returns
+// without errors.
 func (r *RunResultStreaming) Cancel() {
+	r.cancelMu.Lock()
+	r.isCancelled.Store(true)
+	r.cancelMu.Unlock()
+
 	r.markAsComplete() // Mark the run as complete to stop event streaming
 	r.cleanupTasks()   // Cancel all running tasks
 	r.awaitTasks()
 
+	if r.FinalOutput() == nil {
+		if v, ok := r.partialOutput.get(); ok {
+			r.setFinalOutput(v)
+		}
+	}
+
 	// Optionally, clear the event queue to prevent processing stale events
 	for !r.eventQueue.IsEmpty() {
 		_, _ = r.eventQueue.GetNoWait()
@@ -253,6 +363,9 @@ func (r *RunResultStreaming) Cancel() {
 	for !r.inputGuardrailQueue.IsEmpty() {
 		_, _ = r.inputGuardrailQueue.GetNoWait()
 	}
+
+	// Wake up a concurrent StreamEvents waiting for the next event.
+	r.eventQueue.Put(queueCompleteSentinel{})
 }
 
 // StreamEvents streams deltas for new items as they are generated.
@@ -318,7 +431,7 @@ func (r *RunResultStreaming) createErrorDetails() *RunErrorDetails {
 }
 
 func (r *RunResultStreaming) checkErrors() error {
-	if r.CurrentTurn() > r.MaxTurns() {
+	if r.CurrentTurn() > r.MaxTurns() && !r.StoppedEarly() {
 		maxTurnsErr := MaxTurnsExceededErrorf("Max turns (%d) exceeded", r.MaxTurns())
 		maxTurnsErr.AgentsError.RunData = r.createErrorDetails()
 		r.setStoredError(maxTurnsErr)
@@ -337,7 +450,7 @@ func (r *RunResultStreaming) checkErrors() error {
 	// Check the tasks for any error
 	if t := r.getRunImplTask(); t != nil && t.IsDone() {
 		result := t.Await()
-		if err := result.Error; err != nil {
+		if err := result.Error; err != nil && !r.isCancellationError(err) {
 			var agentsErr *AgentsError
 			if errors.As(err, &agentsErr) && agentsErr.RunData == nil {
 				agentsErr.RunData = r.createErrorDetails()
@@ -348,7 +461,9 @@ func (r *RunResultStreaming) checkErrors() error {
 
 	if t := r.getInputGuardrailsTask(); t != nil && t.IsDone() {
 		result := t.Await()
-		if err := result.Error; err != nil {
+		// Don't replace an error which caused the cancellation of the input guardrails.
+		if err := result.Error; err != nil && !r.isCancellationError(err) &&
+			(r.getStoredError() == nil || !errors.Is(err, context.Canceled)) {
 			var agentsErr *AgentsError
 			if errors.As(err, &agentsErr) && agentsErr.RunData == nil {
 				agentsErr.RunData = r.createErrorDetails()
@@ -359,7 +474,7 @@ func (r *RunResultStreaming) checkErrors() error {
 
 	if t := r.getOutputGuardrailsTask(); t != nil && t.IsDone() {
 		result := t.Await()
-		if err := result.Error; err != nil {
+		if err := result.Error; err != nil && !r.isCancellationError(err) {
 			var agentsErr *AgentsError
 			if errors.As(err, &agentsErr) && agentsErr.RunData == nil {
 				agentsErr.RunData = r.createErrorDetails()
@@ -371,6 +486,11 @@ func (r *RunResultStreaming) checkErrors() error {
 	return nil
 }
 
+// isCancellationError reports whether err is the result of a call to Cancel.
+func (r *RunResultStreaming) isCancellationError(err error) bool {
+	return r.IsCancelled() && (errors.Is(err, context.Canceled) || errors.Is(err, asynctask.TaskCanceledErr()))
+}
+
 func (r *RunResultStreaming) awaitTasks() {
 	var wg sync.WaitGroup
 	if t := r.getRunImplTask(); t != nil && !t.IsDone() {
@@ -409,6 +529,91 @@ func (r *RunResultStreaming) cleanupTasks() {
 	}
 }
 
+// partialFinalOutput tracks the output generated so far by the current turn of
+// a streamed run, which is reported as final output upon cancellation. The
+// output of a turn calling tools is discarded, as it is not the final one.
+type partialFinalOutput struct {
+	mu        sync.Mutex
+	itemID    string
+	text      string
+	value     any
+	ok        bool
+	discarded bool
+}
+
+func (p *partialFinalOutput) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.itemID, p.text, p.value, p.ok, p.discarded = "", "", nil, false, false
+}
+
+// discard discards the output of the current turn, which called tools.
+func (p *partialFinalOutput) discard() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.itemID, p.text, p.value, p.ok, p.discarded = "", "", nil, false, true
+}
+
+// handleEvent discards the output of the current turn if the event reports
+// a call to a tool run locally.
+func (p *partialFinalOutput) handleEvent(event TResponseStreamEvent) {
+	switch event.Type {
+	case "response.output_item.added":
+		if isLocalToolCallType(event.Item.Type) {
+			p.discard()
+		}
+	case "response.completed":
+		for _, item := range event.Response.Output {
+			if isLocalToolCallType(item.Type) {
+				p.discard()
+				return
+			}
+		}
+	}
+}
+
+// isLocalToolCallType reports whether an output item type is a tool call
+// which is run locally, making the run continue with another turn.
+func isLocalToolCallType(itemType string) bool {
+	switch itemType {
+	case "function_call", "computer_call", "local_shell_call", "custom_tool_call":
+		return true
+	default:
+		return false
+	}
+}
+
+// addText adds a text delta of a message. The final output comes from the
+// last message, so the text starts over on a new one.
+func (p *partialFinalOutput) addText(itemID, delta string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discarded {
+		return
+	}
+	if itemID != p.itemID {
+		p.itemID, p.text = itemID, ""
+	}
+	p.text += delta
+	p.value, p.ok = p.text, true
+}
+
+// setValue sets a partial structured output value.
+func (p *partialFinalOutput) setValue(v any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discarded {
+		return
+	}
+	p.value, p.ok = v, true
+}
+
+func (p *partialFinalOutput) get() (any, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.value, p.ok
+}
+
 func (r *RunResultStreaming) String() string {
 	return PrettyPrintRunResultStreaming(*r)
 }
@@ -430,3 +635,21 @@ func lastResponseID(rawResponses []ModelResponse) string {
 	}
 	return rawResponses[len(rawResponses)-1].ResponseID
 }
+
+// lastTextOutput returns the final output as is, if it is a string, or
+// marshaled to JSON otherwise. It returns false if there is no final output,
+// or if it cannot be marshaled.
+func lastTextOutput(finalOutput any) (string, bool) {
+	switch v := finalOutput.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunResultLastTextOutput(t *testing.T) {
+	t.Run("string output", func(t *testing.T) {
+		v, ok := agents.RunResult{FinalOutput: "hello"}.LastTextOutput()
+		assert.True(t, ok)
+		assert.Equal(t, "hello", v)
+	})
+
+	t.Run("struct output", func(t *testing.T) {
+		v, ok := agents.RunResult{FinalOutput: AgentRunnerTestFoo{Bar: "baz"}}.LastTextOutput()
+		assert.True(t, ok)
+		assert.JSONEq(t, `{"bar": "baz"}`, v)
+	})
+
+	t.Run("nil output", func(t *testing.T) {
+		v, ok := agents.RunResult{}.LastTextOutput()
+		assert.False(t, ok)
+		assert.Equal(t, "", v)
+	})
+
+	t.Run("unmarshalable output", func(t *testing.T) {
+		v, ok := agents.RunResult{FinalOutput: func() {}}.LastTextOutput()
+		assert.False(t, ok)
+		assert.Equal(t, "", v)
+	})
+}
+
+func TestRunResultStreamingLastTextOutput(t *testing.T) {
+	t.Run("string output", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+		agent := agents.New("test").WithModelInstance(model)
+
+		result, err := agents.Runner{}.RunStreamed(t.Context(), agent, "start")
+		require.NoError(t, err)
+		err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+		require.NoError(t, err)
+
+		v, ok := result.LastTextOutput()
+		assert.True(t, ok)
+		assert.Equal(t, "done", v)
+	})
+
+	t.Run("struct output", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetFinalOutputMessage(`{"bar": "baz"}`)},
+		})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithOutputType(agents.OutputType[AgentRunnerTestFoo]())
+
+		result, err := agents.Runner{}.RunStreamed(t.Context(), agent, "start")
+		require.NoError(t, err)
+		err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+		require.NoError(t, err)
+
+		v, ok := result.LastTextOutput()
+		assert.True(t, ok)
+		assert.JSONEq(t, `{"bar": "baz"}`, v)
+	})
+}
+
+// reasoningToolRunResult returns the result of a run with reasoning and tool
+// calls, as produced by a reasoning model.
+func reasoningToolRunResult(t *testing.T) *agents.RunResult {
+	t.Helper()
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			{
+				ID:      "rs1",
+				Type:    "reasoning",
+				Summary: []responses.ResponseReasoningItemSummary{{Text: "thinking", Type: "summary_text"}},
+			},
+			agentstesting.GetFunctionToolCall("some_function", `{"a": "b"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			{ID: "rs2", Type: "reasoning", Summary: []responses.ResponseReasoningItemSummary{}},
+			agentstesting.GetTextMessage("done"),
+		}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("some_function", "result"))
+
+	result, err := agents.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	return result
+}
+
+func TestRunResultToInputListFiltered(t *testing.T) {
+	result := reasoningToolRunResult(t)
+	require.Len(t, result.ToInputList(), 6)
+
+	t.Run("no options", func(t *testing.T) {
+		assert.Equal(t, result.ToInputList(), result.ToInputListFiltered(agents.FilterOptions{}))
+	})
+
+	t.Run("drop reasoning", func(t *testing.T) {
+		items := result.ToInputListFiltered(agents.FilterOptions{DropReasoning: true})
+		require.Len(t, items, 4)
+		for _, item := range items {
+			assert.Nil(t, item.OfReasoning)
+		}
+
+		messages, err := agents.ChatCmplConverter().ItemsToMessages(agents.InputItems(items))
+		require.NoError(t, err)
+		require.Len(t, messages, 4)
+		assert.NotNil(t, messages[0].OfUser)
+		require.NotNil(t, messages[1].OfAssistant)
+		assert.Len(t, messages[1].OfAssistant.ToolCalls, 1)
+		assert.NotNil(t, messages[2].OfTool)
+		require.NotNil(t, messages[3].OfAssistant)
+		assert.Equal(t, "done", messages[3].OfAssistant.Content.OfString.Value)
+	})
+
+	t.Run("drop reasoning and tool calls", func(t *testing.T) {
+		items := result.ToInputListFiltered(agents.FilterOptions{DropReasoning: true, DropToolCalls: true})
+		require.Len(t, items, 2)
+
+		messages, err := agents.ChatCmplConverter().ItemsToMessages(agents.InputItems(items))
+		require.NoError(t, err)
+		require.Len(t, messages, 2)
+		assert.NotNil(t, messages[0].OfUser)
+		require.NotNil(t, messages[1].OfAssistant)
+		assert.Empty(t, messages[1].OfAssistant.ToolCalls)
+	})
+
+	t.Run("custom predicate", func(t *testing.T) {
+		items := result.ToInputListFiltered(agents.FilterOptions{
+			DropReasoning: true,
+			Keep: func(item agents.TResponseInputItem) bool {
+				return item.OfOutputMessage == nil
+			},
+		})
+		require.Len(t, items, 3)
+		for _, item := range items {
+			assert.Nil(t, item.OfOutputMessage)
+		}
+	})
+}
+
+func TestRunResultStreamingToInputListFiltered(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{
+			{ID: "rs1", Type: "reasoning", Summary: []responses.ResponseReasoningItemSummary{}},
+			agentstesting.GetTextMessage("done"),
+		},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+
+	items := result.ToInputListFiltered(agents.FilterOptions{DropReasoning: true})
+	require.Len(t, items, 2)
+	_, err = agents.ChatCmplConverter().ItemsToMessages(agents.InputItems(items))
+	require.NoError(t, err)
+}
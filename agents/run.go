@@ -21,10 +21,13 @@ import (
 	"fmt"
 	"iter"
 	"log/slog"
+	"maps"
 	"reflect"
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/nlpodyssey/openai-agents-go/memory"
 	"github.com/nlpodyssey/openai-agents-go/modelsettings"
@@ -51,6 +54,11 @@ type CallModelData struct {
 // CallModelInputFilter is a type alias for the optional input filter callback.
 type CallModelInputFilter = func(context.Context, CallModelData) (*ModelInputData, error)
 
+// Retriever is a type alias for the optional retrieval callback, which
+// receives the model input of the turn and returns the items (e.g. relevant
+// documents) to prepend to it.
+type Retriever = func(ctx context.Context, input []TResponseInputItem) ([]TResponseInputItem, error)
+
 // DefaultRunner is the default Runner instance used by package-level Run
 // helpers.
 var DefaultRunner = Runner{}
@@ -64,6 +72,9 @@ type Runner struct {
 
 const DefaultWorkflowName = "Agent workflow"
 
+// CorrelationIDMetadataKey is the metadata key used to propagate RunConfig.CorrelationID.
+const CorrelationIDMetadataKey = "correlation_id"
+
 // RunConfig configures settings for the entire agent run.
 type RunConfig struct {
 	// The model to use for the entire agent run. If set, will override the model set on every
@@ -73,10 +84,34 @@ type RunConfig struct {
 	// Optional model provider to use when looking up string model names. Defaults to OpenAI (MultiProvider).
 	ModelProvider ModelProvider
 
+	// Optional "shadow" model, called on each turn with the same input as
+	// the primary model, for offline comparison. Its responses are recorded
+	// in RunResult.ShadowResponses (or RunResultStreaming.ShadowResponses),
+	// but never affect the run: only the primary model's output is used, and
+	// shadow model errors are recorded rather than returned.
+	// The shadow calls never block the run: they run concurrently with the
+	// rest of it, and are only waited for at its end, up to ShadowModelTimeout.
+	// They are stateless, as PreviousResponseID is not forwarded, and are
+	// neither traced nor counted in the run usage.
+	ShadowModel param.Opt[AgentModel]
+
+	// Maximum time to wait at the end of the run for the pending ShadowModel
+	// calls. Those still running are canceled, and recorded with an error.
+	// Default (when zero or negative): DefaultShadowModelTimeout.
+	ShadowModelTimeout time.Duration
+
 	// Optional global model settings. Any non-null or non-zero values will
 	// override the agent-specific model settings.
 	ModelSettings modelsettings.ModelSettings
 
+	// Optional function returning the temperature to use for a given turn
+	// (starting from 1), e.g. to explore with a high temperature early and
+	// converge with a lower one later. It is consulted each turn, after
+	// resolving the model settings: when it returns true, the returned
+	// temperature overrides the resolved one; otherwise the resolved
+	// temperature is left untouched.
+	TemperatureSchedule func(turn int) (float64, bool)
+
 	// Optional global input filter to apply to all handoffs. If `Handoff.InputFilter` is set, then that
 	// will take precedence. The input filter allows you to edit the inputs that are sent to the new
 	// agent. See the documentation in `Handoff.InputFilter` for more details.
@@ -88,6 +123,25 @@ type RunConfig struct {
 	// A list of output guardrails to run on the final output of the run.
 	OutputGuardrails []OutputGuardrail
 
+	// Optional maximum duration of each input and output guardrail run.
+	// When a guardrail exceeds it, its own context is canceled, without
+	// affecting the other guardrails, and its result is marked as TimedOut.
+	// The timed-out guardrail is then either treated as a triggered tripwire,
+	// or skipped without blocking the run: see GuardrailTimeoutTripwire.
+	// Default (when left zero): no timeout.
+	GuardrailTimeout time.Duration
+
+	// Whether a guardrail exceeding GuardrailTimeout is treated as a
+	// triggered tripwire. If false, the guardrail is skipped.
+	GuardrailTimeoutTripwire bool
+
+	// Whether the input guardrails must complete before the first model call.
+	// By default, they run concurrently with the first turn, which is faster,
+	// but the model is called (and its usage is billed) even when a tripwire
+	// is triggered. If true, the model is only called if all input guardrails
+	// pass.
+	GuardrailsBeforeModel bool
+
 	// Whether tracing is disabled for the agent run. If disabled, we will not trace the agent run.
 	// Default: false (tracing enabled).
 	TracingDisabled bool
@@ -98,6 +152,11 @@ type RunConfig struct {
 	// Default: true.
 	TraceIncludeSensitiveData param.Opt[bool]
 
+	// Optional scrubber applied to the inputs and outputs included in the spans of the run
+	// (for example, to redact emails or API keys), before they are passed to the trace processors.
+	// It applies regardless of TraceIncludeSensitiveData. See tracing.NewRegexpDataScrubber.
+	TraceDataScrubber tracing.DataScrubber
+
 	// The name of the run, used for tracing. Should be a logical name for the run, like
 	// "Code generation workflow" or "Customer support agent".
 	// Default: DefaultWorkflowName.
@@ -114,6 +173,27 @@ type RunConfig struct {
 	// An optional dictionary of additional metadata to include with the trace.
 	TraceMetadata map[string]any
 
+	// Optional identifier used to correlate this run across systems.
+	// If set, it is added to the trace metadata and to the model request
+	// metadata (e.g. the Responses API `metadata` field) under the
+	// CorrelationIDMetadataKey key, and to the debug logs of the run (see
+	// Logger) under the "correlationId" key.
+	CorrelationID string
+
+	// Optional text prepended to the instructions of every agent of the run,
+	// e.g. to apply a global policy such as "Always be concise" without
+	// editing each agent. It is separated from the instructions by a blank
+	// line. It wraps the resolved instructions, i.e. the output of the
+	// InstructionsFunc for agents with dynamic instructions, and is applied
+	// before CallModelInputFilter, which therefore sees the composed
+	// instructions. For agents without instructions, the prefix and the
+	// suffix alone are used as instructions.
+	InstructionsPrefix string
+
+	// Optional text appended to the instructions of every agent of the run,
+	// separated by a blank line. See InstructionsPrefix for the details.
+	InstructionsSuffix string
+
 	// Optional callback that is invoked immediately before calling the model. It receives the current
 	// agent and the model input (instructions and input items), and must return a possibly
 	// modified `ModelInputData` to use for the model call.
@@ -122,11 +202,96 @@ type RunConfig struct {
 	// For example, you can use this to add a system prompt to the input.
 	CallModelInputFilter CallModelInputFilter
 
+	// Optional callback that is invoked on each turn, before CallModelInputFilter, to
+	// retrieve context for the model, e.g. documents relevant to the latest user message
+	// for retrieval-augmented generation. The returned items are prepended to the model
+	// input (the instructions are sent separately), only for the current turn: they are
+	// not added to the run items, nor saved to the session.
+	Retriever Retriever
+
+	// Optional maximum number of items of the model input, checked on each
+	// turn after CallModelInputFilter, to prevent oversized requests when the
+	// history grows unbounded. When exceeded, the run fails with an
+	// InputTooLargeError, unless TrimOldestInputItems is set.
+	// Default (when zero or negative): no limit.
+	MaxInputItems int
+
+	// Whether to drop the oldest input items when MaxInputItems is exceeded,
+	// instead of failing. System and developer messages, the items prepended
+	// by the Retriever, and the items of the current turn (from the latest user
+	// message onward), are preserved; tool calls of any kind are dropped along
+	// with their outputs, and reasoning items along with the item following
+	// them. An InputTooLargeError is still returned if the preserved items
+	// alone exceed the limit.
+	TrimOldestInputItems bool
+
+	// Optional function applied to the output of each function tool (including
+	// MCP tools) before it is sent back to the model, e.g. to detect prompt
+	// injection attempts: see NewPromptInjectionSanitizer. Flagged outputs are
+	// reported in ToolCallOutputItem.Sanitization. Hosted tools are not
+	// affected, as their outputs never pass through the runner.
+	ToolOutputSanitizer ToolOutputSanitizer
+
+	// Whether to validate each agent when it starts running (see
+	// Agent.Validate), failing the run with the problems found, if any.
+	// Regardless of this flag, the JSON schema of strict output types is
+	// always checked (see ValidateStrictOutputType).
+	ValidateAgents bool
+
+	// Whether to send the function tools (including MCP tools) of every agent
+	// to the model with a non-strict JSON schema, as if Agent.ForceNonStrictTools
+	// was set on all of them.
+	ForceNonStrictTools bool
+
 	// Optional maximum number of turns to run the agent for.
 	// A turn is defined as one AI invocation (including any tool calls that might occur).
 	// Default (when left zero): DefaultMaxTurns.
 	MaxTurns uint64
 
+	// Whether to stop the run gracefully upon reaching MaxTurns, instead of
+	// returning a MaxTurnsExceededError. The run then returns a normal
+	// result, marked as StoppedEarly, whose FinalOutput is the text of the
+	// last message generated, if any. In streaming mode, a
+	// RunStoppedEarlyStreamEvent is emitted as the last event.
+	StopOnMaxTurns bool
+
+	// Optional maximum number of consecutive turns in which the model only
+	// calls tools, without producing any message. When exceeded, the run is
+	// aborted with a MaxConsecutiveToolOnlyTurnsExceededError: this catches
+	// models stuck calling tools without ever answering.
+	// Turns ending with a handoff reset the count.
+	// Default (when zero or negative): no limit.
+	MaxConsecutiveToolOnlyTurns int
+
+	// Optional number of consecutive turns producing the very same message
+	// text after which the run is aborted with a RepeatedOutputError: this
+	// catches models stuck repeating themselves, burning the turn budget.
+	// Turns without any message text, and handoffs, reset the count.
+	// Default (when lower than 2): no detection.
+	MaxRepeatedOutputs int
+
+	// Optional maximum number of consecutive handoffs, i.e. handoffs which
+	// are not separated by any turn without a handoff. When exceeded, the run
+	// is aborted with a HandoffLoopError naming the agents involved: this
+	// catches agents accidentally handing off to each other endlessly.
+	// Default (when zero or negative): no limit.
+	MaxHandoffDepth int
+
+	// Optional maximum number of handoffs to any single agent during the
+	// run, whether consecutive or not. When exceeded, the run is aborted with
+	// a MaxHandoffsPerAgentExceededError naming the target agent: this
+	// prevents a run from oscillating back to the same agent.
+	// Default (when zero or negative): no limit.
+	MaxHandoffsPerAgent int
+
+	// Whether to emit PartialOutputStreamEvent events in streaming mode,
+	// when the agent has a structured OutputType supporting it (see
+	// OutputTypePartialParser). The text received so far is parsed after
+	// each text delta, and an event is emitted each time it yields a new
+	// partial output value: malformed intermediate states are simply skipped.
+	// Once the run completes, a last event carries the final output.
+	EmitPartialStructuredOutput bool
+
 	// Optional object that receives callbacks on various lifecycle events.
 	Hooks RunHooks
 
@@ -141,6 +306,42 @@ type RunConfig struct {
 	LimitMemory int
 }
 
+// traceMetadata returns the trace metadata, including the correlation ID, if any.
+func (c RunConfig) traceMetadata() map[string]any {
+	if c.CorrelationID == "" {
+		return c.TraceMetadata
+	}
+	metadata := maps.Clone(c.TraceMetadata)
+	if metadata == nil {
+		metadata = make(map[string]any, 1)
+	}
+	metadata[CorrelationIDMetadataKey] = c.CorrelationID
+	return metadata
+}
+
+// shadowModelTimeout returns ShadowModelTimeout, or its default value.
+func (c RunConfig) shadowModelTimeout() time.Duration {
+	if c.ShadowModelTimeout <= 0 {
+		return DefaultShadowModelTimeout
+	}
+	return c.ShadowModelTimeout
+}
+
+// composeInstructions wraps the given agent instructions with
+// InstructionsPrefix and InstructionsSuffix.
+func (c RunConfig) composeInstructions(instructions param.Opt[string]) param.Opt[string] {
+	if c.InstructionsPrefix == "" && c.InstructionsSuffix == "" {
+		return instructions
+	}
+	parts := make([]string, 0, 3)
+	for _, part := range []string{c.InstructionsPrefix, instructions.Or(""), c.InstructionsSuffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return param.NewOpt(strings.Join(parts, "\n\n"))
+}
+
 // EventSeqResult contains the sequence of streaming events generated by
 // RunStreamedSeq and the error, if any, that occurred while streaming.
 type EventSeqResult struct {
@@ -191,6 +392,20 @@ func RunInputsStreamedSeq(ctx context.Context, startingAgent *Agent, input []TRe
 	return DefaultRunner.RunInputStreamedSeq(ctx, startingAgent, input)
 }
 
+// RunStreamedSeq2 runs a workflow starting at the given agent in streaming
+// mode using the DefaultRunner and returns a sequence of events paired with errors.
+// See Runner.RunStreamedSeq2.
+func RunStreamedSeq2(ctx context.Context, startingAgent *Agent, input string) (iter.Seq2[StreamEvent, error], error) {
+	return DefaultRunner.RunStreamedSeq2(ctx, startingAgent, input)
+}
+
+// RunInputsStreamedSeq2 runs a workflow starting at the given agent in streaming
+// mode using the DefaultRunner and returns a sequence of events paired with errors.
+// See Runner.RunStreamedSeq2.
+func RunInputsStreamedSeq2(ctx context.Context, startingAgent *Agent, input []TResponseInputItem) (iter.Seq2[StreamEvent, error], error) {
+	return DefaultRunner.RunInputStreamedSeq2(ctx, startingAgent, input)
+}
+
 // RunInputs executes startingAgent with the provided list of input items using the DefaultRunner.
 func RunInputs(ctx context.Context, startingAgent *Agent, input []TResponseInputItem) (*RunResult, error) {
 	return DefaultRunner.RunInputs(ctx, startingAgent, input)
@@ -212,15 +427,18 @@ func RunInputsStreamed(ctx context.Context, startingAgent *Agent, input []TRespo
 //  4. Else, we run tool calls (if any), and re-run the loop.
 //
 // In two cases, the agent run may return an error:
-//  1. If the MaxTurns is exceeded, a MaxTurnsExceededError is returned.
+//  1. If the MaxTurns is exceeded, a MaxTurnsExceededError is returned (see RunConfig.StopOnMaxTurns).
 //  2. If a guardrail tripwire is triggered, a *GuardrailTripwireTriggeredError is returned.
 //
 // Note that only the first agent's input guardrails are run.
 //
 // It returns a run result containing all the inputs, guardrail results and the output of the last
 // agent. Agents may perform handoffs, so we don't know the specific type of the output.
+//
+// If some tools return an ApprovalRequiredError, the run is suspended instead, and the result
+// lists them in RunResult.Interruptions: see Runner.Resume.
 func (r Runner) Run(ctx context.Context, startingAgent *Agent, input string) (*RunResult, error) {
-	return r.run(ctx, startingAgent, InputString(input))
+	return r.run(ctx, startingAgent, InputString(input), nil)
 }
 
 // RunStreamed runs a workflow starting at the given agent in streaming mode.
@@ -234,7 +452,7 @@ func (r Runner) Run(ctx context.Context, startingAgent *Agent, input string) (*R
 //  4. Else, we run tool calls (if any), and re-run the loop.
 //
 // In two cases, the agent run may return an error:
-//  1. If the MaxTurns is exceeded, a MaxTurnsExceededError is returned.
+//  1. If the MaxTurns is exceeded, a MaxTurnsExceededError is returned (see RunConfig.StopOnMaxTurns).
 //  2. If a guardrail tripwire is triggered, a *GuardrailTripwireTriggeredError is returned.
 //
 // Note that only the first agent's input guardrails are run.
@@ -246,7 +464,7 @@ func (r Runner) RunStreamed(ctx context.Context, startingAgent *Agent, input str
 
 // RunInputs executes startingAgent with the provided list of input items using the Runner configuration.
 func (r Runner) RunInputs(ctx context.Context, startingAgent *Agent, input []TResponseInputItem) (*RunResult, error) {
-	return r.run(ctx, startingAgent, InputItems(input))
+	return r.run(ctx, startingAgent, InputItems(input), nil)
 }
 
 // RunInputsStreamed executes startingAgent with the provided list of input items using the Runner configuration and returns a streaming result.
@@ -284,6 +502,22 @@ func (r Runner) RunInputStreamedSeq(ctx context.Context, startingAgent *Agent, i
 	return r.runStreamedSeq(ctx, startingAgent, InputItems(input))
 }
 
+// RunStreamedSeq2 runs a workflow starting at the given agent in streaming
+// mode and returns a sequence of events paired with errors.
+// Each event is yielded with a nil error. If streaming fails, a final pair
+// with a nil event and the streaming error is yielded.
+// The sequence is single-use; breaking out of the loop cancels the run.
+func (r Runner) RunStreamedSeq2(ctx context.Context, startingAgent *Agent, input string) (iter.Seq2[StreamEvent, error], error) {
+	return r.runStreamedSeq2(ctx, startingAgent, InputString(input))
+}
+
+// RunInputStreamedSeq2 runs a workflow starting at the given agent in streaming
+// mode and returns a sequence of events paired with errors.
+// See RunStreamedSeq2.
+func (r Runner) RunInputStreamedSeq2(ctx context.Context, startingAgent *Agent, input []TResponseInputItem) (iter.Seq2[StreamEvent, error], error) {
+	return r.runStreamedSeq2(ctx, startingAgent, InputItems(input))
+}
+
 func (r Runner) runStreamedChan(ctx context.Context, startingAgent *Agent, input Input) (<-chan StreamEvent, <-chan error, error) {
 	result, err := r.runStreamed(ctx, startingAgent, input)
 	if err != nil {
@@ -324,15 +558,48 @@ func (r Runner) runStreamedSeq(ctx context.Context, startingAgent *Agent, input
 	return res, nil
 }
 
-func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*RunResult, error) {
+func (r Runner) runStreamedSeq2(ctx context.Context, startingAgent *Agent, input Input) (iter.Seq2[StreamEvent, error], error) {
+	res, err := r.runStreamedSeq(ctx, startingAgent, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(StreamEvent, error) bool) {
+		stopped := false
+		for event := range res.Seq {
+			if !yield(event, nil) {
+				stopped = true
+				break
+			}
+		}
+		if !stopped && res.Err != nil {
+			yield(nil, res.Err)
+		}
+	}, nil
+}
+
+func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input, resumed *resumedRun) (runResult *RunResult, err error) {
 	if startingAgent == nil {
 		return nil, fmt.Errorf("startingAgent must not be nil")
 	}
+	if r.Config.TraceDataScrubber != nil {
+		ctx = tracing.ContextWithDataScrubber(ctx, r.Config.TraceDataScrubber)
+	}
+	if r.Config.CorrelationID != "" {
+		ctx = contextWithLogCorrelationID(ctx, r.Config.CorrelationID)
+	}
 
-	// Prepare input with session if enabled
-	preparedInput, err := r.prepareInputWithSession(ctx, input)
-	if err != nil {
-		return nil, err
+	// Prepare input with session if enabled. A resumed run was already
+	// prepared, and is saved to the session with its original input.
+	preparedInput := input
+	if resumed != nil {
+		input = resumed.priorResult.sessionInput
+	} else {
+		var err error
+		preparedInput, err = r.prepareInputWithSession(ctx, input)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	hooks := r.Config.Hooks
@@ -340,15 +607,31 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 		hooks = NoOpRunHooks{}
 	}
 
-	toolUseTracker := NewAgentToolUseTracker()
+	if lifecycleHooks, ok := hooks.(RunLifecycleHooks); ok {
+		if err := lifecycleHooks.OnRunStart(ctx, startingAgent); err != nil {
+			return nil, fmt.Errorf("RunHooks.OnRunStart failed: %w", err)
+		}
+		defer func() {
+			lastAgent := startingAgent
+			var agentsErr *AgentsError
+			if runResult != nil {
+				lastAgent = runResult.LastAgent
+			} else if errors.As(err, &agentsErr) && agentsErr.RunData != nil {
+				lastAgent = agentsErr.RunData.LastAgent
+			}
+			if e := lifecycleHooks.OnRunEnd(ctx, lastAgent, err); e != nil {
+				err = errors.Join(err, fmt.Errorf("RunHooks.OnRunEnd failed: %w", e))
+			}
+		}()
+	}
 
-	var runResult *RunResult
+	toolUseTracker := NewAgentToolUseTracker()
 
 	traceParams := tracing.TraceParams{
 		WorkflowName: cmp.Or(r.Config.WorkflowName, DefaultWorkflowName),
 		TraceID:      r.Config.TraceID,
 		GroupID:      r.Config.GroupID,
-		Metadata:     r.Config.TraceMetadata,
+		Metadata:     r.Config.traceMetadata(),
 		Disabled:     r.Config.TracingDisabled,
 	}
 	err = ManageTraceCtx(ctx, traceParams, func(ctx context.Context) (err error) {
@@ -363,6 +646,7 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 		var (
 			generatedItems         []RunItem
 			modelResponses         []ModelResponse
+			shadowResponses        []ShadowModelResponse
 			inputGuardrailResults  []InputGuardrailResult
 			outputGuardrailResults []OutputGuardrailResult
 			currentSpan            tracing.Span
@@ -372,8 +656,27 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 			ctx = usage.NewContext(ctx, usage.NewUsage())
 		}
 
+		if r.Config.ShadowModel.Valid() {
+			var shadowCalls *shadowModelCalls
+			ctx, shadowCalls = contextWithShadowModelCalls(ctx)
+			defer func() {
+				if runResult == nil {
+					shadowCalls.cancel()
+					return
+				}
+				runResult.ShadowResponses = append(
+					runResult.ShadowResponses,
+					shadowCalls.wait(r.Config.shadowModelTimeout())...,
+				)
+			}()
+		}
+
 		currentAgent := startingAgent
 		shouldRunAgentStartHooks := true
+		toolOnlyTurns := 0
+		var repeatedOutputs repeatedOutputCounter
+		var handoffChain []string
+		handoffCounts := make(map[string]int)
 
 		defer func() {
 			if err != nil {
@@ -401,8 +704,42 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 		childCtx, cancel := context.WithCancel(ctx)
 		defer cancel()
 
+		// The interrupted step completed with the approval decisions, which
+		// takes the place of the first turn of a resumed run.
+		var resumedStep *SingleStepResult
+		if resumed != nil {
+			generatedItems = slices.Clone(resumed.priorResult.NewItems)
+			modelResponses = slices.Clone(resumed.priorResult.RawResponses)
+			shadowResponses = slices.Clone(resumed.priorResult.ShadowResponses)
+			inputGuardrailResults = resumed.priorResult.InputGuardrailResults
+			// Continuing the count also prevents input guardrails from running again.
+			currentTurn = uint64(len(modelResponses))
+			shouldRunAgentStartHooks = false
+
+			step := resumed.priorResult.interruptedStep
+			toolUseTracker.AddToolUse(currentAgent, step.processedResponse.ToolsUsed)
+			allTools, err := r.getAllTools(childCtx, currentAgent, toolUseTracker)
+			if err != nil {
+				return err
+			}
+			resumedStep, err = RunImpl().resumeInterruptedStep(
+				childCtx,
+				currentAgent,
+				allTools,
+				originalInput,
+				step,
+				resumed.priorResult.Interruptions,
+				resumed.decisions,
+				hooks,
+				r.Config,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		for {
-			allTools, err := r.getAllTools(childCtx, currentAgent)
+			allTools, err := r.getAllTools(childCtx, currentAgent, toolUseTracker)
 			if err != nil {
 				return err
 			}
@@ -414,6 +751,9 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 				if err != nil {
 					return err
 				}
+				if err = r.validateAgent(currentAgent); err != nil {
+					return err
+				}
 				handoffNames := make([]string, len(handoffs))
 				for i, handoff := range handoffs {
 					handoffNames[i] = handoff.AgentName
@@ -439,44 +779,102 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 				currentSpan.SpanData().(*tracing.AgentSpanData).Tools = toolNames
 			}
 
-			currentTurn += 1
-			if currentTurn > maxTurns {
-				AttachErrorToSpan(currentSpan, tracing.SpanError{
-					Message: "Max turns exceeded",
-					Data:    map[string]any{"max_turns": maxTurns},
-				})
-				return MaxTurnsExceededErrorf("max turns %d exceeded", maxTurns)
-			}
-			Logger().Debug(
-				"Running agent",
-				slog.String("agentName", currentAgent.Name),
-				slog.Uint64("turn", currentTurn),
-			)
-
 			var turnResult *SingleStepResult
-
-			if currentTurn == 1 {
-				var wg sync.WaitGroup
-				wg.Add(2)
-
-				var guardrailsError error
-				go func() {
-					defer wg.Done()
-					inputGuardrailResults, guardrailsError = r.runInputGuardrails(
-						childCtx,
-						startingAgent,
-						slices.Concat(startingAgent.InputGuardrails, r.Config.InputGuardrails),
-						CopyInput(preparedInput),
-					)
-					if guardrailsError != nil {
-						cancel()
+			if resumedStep != nil {
+				turnResult, resumedStep = resumedStep, nil
+			} else {
+				currentTurn += 1
+				if currentTurn > maxTurns {
+					if r.Config.StopOnMaxTurns {
+						Logger().Debug("Max turns reached, stopping the run", slog.Uint64("maxTurns", maxTurns))
+						runResult = &RunResult{
+							Input:                 originalInput,
+							NewItems:              generatedItems,
+							RawResponses:          modelResponses,
+							ShadowResponses:       shadowResponses,
+							FinalOutput:           lastMessageText(generatedItems),
+							StoppedEarly:          true,
+							InputGuardrailResults: inputGuardrailResults,
+							LastAgent:             currentAgent,
+						}
+						return r.saveResultToSession(ctx, input, runResult)
+					}
+					AttachErrorToSpan(currentSpan, tracing.SpanError{
+						Message: "Max turns exceeded",
+						Data:    map[string]any{"max_turns": maxTurns},
+					})
+					return MaxTurnsExceededErrorf("max turns %d exceeded", maxTurns)
+				}
+				Logger().LogAttrs(ctx, slog.LevelDebug, "Running agent",
+					append(logAgentAttrs(ctx, currentAgent), slog.Uint64(logKeyTurn, currentTurn))...)
+
+				if currentTurn == 1 && !r.Config.GuardrailsBeforeModel {
+					// The first error cancels the other task.
+					raceCtx, cancelRace := context.WithCancelCause(childCtx)
+
+					var wg sync.WaitGroup
+					wg.Add(2)
+
+					var guardrailsError error
+					go func() {
+						defer wg.Done()
+						inputGuardrailResults, guardrailsError = r.runInputGuardrails(
+							raceCtx,
+							startingAgent,
+							slices.Concat(startingAgent.InputGuardrails, r.Config.InputGuardrails),
+							CopyInput(preparedInput),
+						)
+						if guardrailsError != nil {
+							cancelRace(guardrailsError)
+						}
+					}()
+
+					var turnError error
+					go func() {
+						defer wg.Done()
+						turnResult, turnError = r.runSingleTurn(
+							raceCtx,
+							currentAgent,
+							allTools,
+							originalInput,
+							generatedItems,
+							hooks,
+							r.Config,
+							shouldRunAgentStartHooks,
+							toolUseTracker,
+							r.Config.PreviousResponseID,
+							currentTurn,
+						)
+						if turnError != nil {
+							cancelRace(turnError)
+						}
+					}()
+
+					wg.Wait()
+					err = errors.Join(turnError, guardrailsError)
+					// Only report the error which caused the cancellation, not the
+					// consequent cancellation error of the other task.
+					if cause := context.Cause(raceCtx); err != nil && cause != nil && errors.Is(err, cause) {
+						err = cause
+					}
+					cancelRace(nil)
+					if err != nil {
+						return err
+					}
+				} else {
+					if currentTurn == 1 {
+						inputGuardrailResults, err = r.runInputGuardrails(
+							childCtx,
+							startingAgent,
+							slices.Concat(startingAgent.InputGuardrails, r.Config.InputGuardrails),
+							CopyInput(preparedInput),
+						)
+						if err != nil {
+							return err
+						}
 					}
-				}()
 
-				var turnError error
-				go func() {
-					defer wg.Done()
-					turnResult, turnError = r.runSingleTurn(
+					turnResult, err = r.runSingleTurn(
 						childCtx,
 						currentAgent,
 						allTools,
@@ -487,37 +885,17 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 						shouldRunAgentStartHooks,
 						toolUseTracker,
 						r.Config.PreviousResponseID,
+						currentTurn,
 					)
-					if turnError != nil {
-						cancel()
+					if err != nil {
+						return err
 					}
-				}()
-
-				wg.Wait()
-				if err = errors.Join(turnError, guardrailsError); err != nil {
-					return err
-				}
-			} else {
-				turnResult, err = r.runSingleTurn(
-					childCtx,
-					currentAgent,
-					allTools,
-					originalInput,
-					generatedItems,
-					hooks,
-					r.Config,
-					shouldRunAgentStartHooks,
-					toolUseTracker,
-					r.Config.PreviousResponseID,
-				)
-				if err != nil {
-					return err
 				}
-			}
 
-			shouldRunAgentStartHooks = false
+				shouldRunAgentStartHooks = false
 
-			modelResponses = append(modelResponses, turnResult.ModelResponse)
+				modelResponses = append(modelResponses, turnResult.ModelResponse)
+			}
 			originalInput = turnResult.OriginalInput
 			generatedItems = turnResult.GeneratedItems()
 
@@ -536,6 +914,7 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 					Input:                  originalInput,
 					NewItems:               generatedItems,
 					RawResponses:           modelResponses,
+					ShadowResponses:        shadowResponses,
 					FinalOutput:            nextStep.Output,
 					InputGuardrailResults:  inputGuardrailResults,
 					OutputGuardrailResults: outputGuardrailResults,
@@ -550,6 +929,14 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 
 				return nil
 			case NextStepHandoff:
+				err = trackHandoff(r.Config, currentSpan, currentAgent, nextStep.NewAgent, &handoffChain)
+				if err != nil {
+					return err
+				}
+				err = countHandoff(r.Config, currentSpan, nextStep.NewAgent, handoffCounts)
+				if err != nil {
+					return err
+				}
 				currentAgent = nextStep.NewAgent
 				err = currentSpan.Finish(ctx, true)
 				if err != nil {
@@ -557,8 +944,31 @@ func (r Runner) run(ctx context.Context, startingAgent *Agent, input Input) (*Ru
 				}
 				currentSpan = nil
 				shouldRunAgentStartHooks = true
+				toolOnlyTurns = 0
+				repeatedOutputs = repeatedOutputCounter{}
 			case NextStepRunAgain:
-				// Nothing to do
+				handoffChain = nil
+				err = countToolOnlyTurn(r.Config, currentSpan, turnResult, &toolOnlyTurns)
+				if err != nil {
+					return err
+				}
+				err = repeatedOutputs.count(r.Config, currentSpan, turnResult)
+				if err != nil {
+					return err
+				}
+			case NextStepInterruption:
+				runResult = &RunResult{
+					Input:                 originalInput,
+					NewItems:              generatedItems,
+					RawResponses:          modelResponses,
+					ShadowResponses:       shadowResponses,
+					InputGuardrailResults: inputGuardrailResults,
+					LastAgent:             currentAgent,
+					Interruptions:         nextStep.Interruptions,
+					sessionInput:          input,
+					interruptedStep:       nextStep.step,
+				}
+				return nil
 			default:
 				// This would be an unrecoverable implementation bug, so a panic is appropriate.
 				panic(fmt.Errorf("unexpected NextStep type %T", nextStep))
@@ -572,6 +982,12 @@ func (r Runner) runStreamed(ctx context.Context, startingAgent *Agent, input Inp
 	if startingAgent == nil {
 		return nil, fmt.Errorf("startingAgent must not be nil")
 	}
+	if r.Config.TraceDataScrubber != nil {
+		ctx = tracing.ContextWithDataScrubber(ctx, r.Config.TraceDataScrubber)
+	}
+	if r.Config.CorrelationID != "" {
+		ctx = contextWithLogCorrelationID(ctx, r.Config.CorrelationID)
+	}
 
 	maxTurns := r.Config.MaxTurns
 	if maxTurns == 0 {
@@ -592,7 +1008,7 @@ func (r Runner) runStreamed(ctx context.Context, startingAgent *Agent, input Inp
 			WorkflowName: cmp.Or(r.Config.WorkflowName, DefaultWorkflowName),
 			TraceID:      r.Config.TraceID,
 			GroupID:      r.Config.GroupID,
-			Metadata:     r.Config.TraceMetadata,
+			Metadata:     r.Config.traceMetadata(),
 			Disabled:     r.Config.TracingDisabled,
 		})
 	}
@@ -608,9 +1024,22 @@ func (r Runner) runStreamed(ctx context.Context, startingAgent *Agent, input Inp
 	streamedResult.setCurrentAgentOutputType(startingAgent.OutputType)
 	streamedResult.setTrace(newTrace)
 
+	// Let agents running as tools forward their events to this stream (see Agent.AsToolStreamed).
+	ctx = contextWithStreamEventEmitter(ctx, streamedResult.eventQueue.Put)
+
 	// Kick off the actual agent loop in the background and return the streamed result object.
 	streamedResult.createRunImplTask(ctx, func(ctx context.Context) error {
-		return r.startStreaming(
+		lifecycleHooks, hasLifecycleHooks := hooks.(RunLifecycleHooks)
+		if hasLifecycleHooks {
+			if err := lifecycleHooks.OnRunStart(ctx, startingAgent); err != nil {
+				return fmt.Errorf("RunHooks.OnRunStart failed: %w", err)
+			}
+		}
+		var shadowCalls *shadowModelCalls
+		if r.Config.ShadowModel.Valid() {
+			ctx, shadowCalls = contextWithShadowModelCalls(ctx)
+		}
+		err := r.startStreaming(
 			ctx,
 			input,
 			streamedResult,
@@ -620,12 +1049,25 @@ func (r Runner) runStreamed(ctx context.Context, startingAgent *Agent, input Inp
 			r.Config,
 			r.Config.PreviousResponseID,
 		)
+		if shadowCalls != nil {
+			if err != nil {
+				shadowCalls.cancel()
+			} else {
+				streamedResult.appendShadowResponses(shadowCalls.wait(r.Config.shadowModelTimeout())...)
+			}
+		}
+		if hasLifecycleHooks {
+			if e := lifecycleHooks.OnRunEnd(ctx, streamedResult.CurrentAgent(), err); e != nil {
+				err = errors.Join(err, fmt.Errorf("RunHooks.OnRunEnd failed: %w", e))
+			}
+		}
+		return err
 	})
 
 	return streamedResult, nil
 }
 
-// Apply optional CallModelInputFilter to modify model input.
+// Apply optional Retriever and CallModelInputFilter to modify model input.
 //
 // Returns a ModelInputData that will be sent to the model.
 func (r Runner) maybeFilterModelInput(
@@ -636,13 +1078,17 @@ func (r Runner) maybeFilterModelInput(
 	systemInstructions param.Opt[string],
 ) (_ *ModelInputData, err error) {
 	effectiveInstructions := systemInstructions
-	effectiveInput := inputItems
+	effectiveInput, err := r.maybeRetrieve(ctx, runConfig, inputItems)
+	if err != nil {
+		return nil, err
+	}
+	retrievedItems := len(effectiveInput) - len(inputItems)
 
 	if runConfig.CallModelInputFilter == nil {
-		return &ModelInputData{
+		return limitModelInputItems(runConfig, &ModelInputData{
 			Input:        effectiveInput,
 			Instructions: effectiveInstructions,
-		}, nil
+		}, retrievedItems)
 	}
 
 	defer func() {
@@ -671,7 +1117,152 @@ func (r Runner) maybeFilterModelInput(
 	if updated == nil {
 		return nil, fmt.Errorf("CallModelInputFilter returned nil *ModelInputData but no error")
 	}
-	return updated, nil
+	return limitModelInputItems(runConfig, updated, min(retrievedItems, len(updated.Input)))
+}
+
+// Apply RunConfig.MaxInputItems, either trimming the oldest input items or
+// failing with an InputTooLargeError. The first pinned items, prepended by
+// the Retriever, are never trimmed.
+func limitModelInputItems(runConfig RunConfig, data *ModelInputData, pinned int) (*ModelInputData, error) {
+	maxItems := runConfig.MaxInputItems
+	if maxItems <= 0 || len(data.Input) <= maxItems {
+		return data, nil
+	}
+	if !runConfig.TrimOldestInputItems {
+		return nil, NewInputTooLargeError(len(data.Input), maxItems)
+	}
+
+	trimmed := trimOldestInputItems(data.Input, maxItems, pinned)
+	if len(trimmed) > maxItems {
+		return nil, NewInputTooLargeError(len(trimmed), maxItems)
+	}
+	Logger().Debug("Trimmed oldest input items",
+		slog.Int("dropped", len(data.Input)-len(trimmed)), slog.Int("maxItems", maxItems))
+	return &ModelInputData{
+		Input:        trimmed,
+		Instructions: data.Instructions,
+	}, nil
+}
+
+// trimOldestInputItems drops the oldest items until at most maxItems are left,
+// preserving the first pinned items, system and developer messages, and all
+// the items from the latest user message onward. Tool calls are dropped along
+// with their outputs, and reasoning items along with the item following them.
+func trimOldestInputItems(items []TResponseInputItem, maxItems, pinned int) []TResponseInputItem {
+	currentTurnStart := len(items)
+	for i := len(items) - 1; i >= pinned; i-- {
+		if inputItemRole(items[i]) == "user" {
+			currentTurnStart = i
+			break
+		}
+	}
+
+	toDrop := len(items) - maxItems
+	droppedCallIDs := make(map[string]struct{})
+	dropNext := false
+	result := make([]TResponseInputItem, 0, len(items))
+	for i, item := range items {
+		callID, isOutput := inputItemCallID(item)
+		if isOutput {
+			if _, ok := droppedCallIDs[callID]; ok {
+				toDrop--
+				continue
+			}
+		}
+
+		if i >= pinned && i < currentTurnStart && (toDrop > 0 || dropNext) {
+			switch role := inputItemRole(item); {
+			case role == "system" || role == "developer":
+				// Always preserved
+			case isOutput:
+				// The output of a preserved tool call
+			default:
+				if callID != "" {
+					droppedCallIDs[callID] = struct{}{}
+				}
+				dropNext = item.OfReasoning != nil
+				toDrop--
+				continue
+			}
+		}
+		dropNext = false
+		result = append(result, item)
+	}
+	return result
+}
+
+// inputItemCallID returns the call ID of a tool call or tool call output
+// input item, reporting whether it is an output. It returns an empty string
+// for any other item.
+func inputItemCallID(item TResponseInputItem) (callID string, isOutput bool) {
+	switch {
+	case item.OfFunctionCall != nil:
+		return item.OfFunctionCall.CallID, false
+	case item.OfFunctionCallOutput != nil:
+		return item.OfFunctionCallOutput.CallID, true
+	case item.OfComputerCall != nil:
+		return item.OfComputerCall.CallID, false
+	case item.OfComputerCallOutput != nil:
+		return item.OfComputerCallOutput.CallID, true
+	case item.OfLocalShellCall != nil:
+		return item.OfLocalShellCall.CallID, false
+	case item.OfLocalShellCallOutput != nil:
+		// The output ID is the call ID of the local shell call
+		return item.OfLocalShellCallOutput.ID, true
+	case item.OfShellCall != nil:
+		return item.OfShellCall.CallID, false
+	case item.OfShellCallOutput != nil:
+		return item.OfShellCallOutput.CallID, true
+	case item.OfApplyPatchCall != nil:
+		return item.OfApplyPatchCall.CallID, false
+	case item.OfApplyPatchCallOutput != nil:
+		return item.OfApplyPatchCallOutput.CallID, true
+	case item.OfCustomToolCall != nil:
+		return item.OfCustomToolCall.CallID, false
+	case item.OfCustomToolCallOutput != nil:
+		return item.OfCustomToolCallOutput.CallID, true
+	default:
+		return "", false
+	}
+}
+
+// inputItemRole returns the role of a message input item, or an empty string
+// for any other item.
+func inputItemRole(item TResponseInputItem) string {
+	switch {
+	case item.OfMessage != nil:
+		return string(item.OfMessage.Role)
+	case item.OfInputMessage != nil:
+		return item.OfInputMessage.Role
+	case item.OfOutputMessage != nil:
+		return "assistant"
+	default:
+		return ""
+	}
+}
+
+// Apply optional Retriever, prepending the retrieved items to the input.
+func (r Runner) maybeRetrieve(
+	ctx context.Context,
+	runConfig RunConfig,
+	inputItems []TResponseInputItem,
+) ([]TResponseInputItem, error) {
+	if runConfig.Retriever == nil {
+		return inputItems, nil
+	}
+
+	retrieved, err := runConfig.Retriever(ctx, slices.Clone(inputItems))
+	if err != nil {
+		AttachErrorToCurrentSpan(ctx, tracing.SpanError{
+			Message: "Error in Retriever",
+			Data:    map[string]any{"error": err.Error()},
+		})
+		return nil, err
+	}
+	if len(retrieved) == 0 {
+		return inputItems, nil
+	}
+	return slices.Concat(retrieved, inputItems), nil
 }
 
 func (r Runner) runInputGuardrailsWithQueue(
@@ -681,6 +1272,7 @@ func (r Runner) runInputGuardrailsWithQueue(
 	input Input,
 	streamedResult *RunResultStreaming,
 	parentSpan tracing.Span,
+	cancelTurns context.CancelCauseFunc,
 ) error {
 	queue := streamedResult.inputGuardrailQueue
 
@@ -700,10 +1292,11 @@ func (r Runner) runInputGuardrailsWithQueue(
 		go func() {
 			defer wg.Done()
 
-			result, err := RunImpl().RunSingleInputGuardrail(childCtx, agent, guardrail, input)
+			result, err := r.runSingleInputGuardrail(childCtx, agent, guardrail, input)
 			if err != nil {
 				cancel()
 				guardrailErrors[i] = fmt.Errorf("failed to run input guardrail %s: %w", guardrail.Name, err)
+				cancelTurns(guardrailErrors[i])
 				return
 			}
 
@@ -711,6 +1304,9 @@ func (r Runner) runInputGuardrailsWithQueue(
 			queue.Put(result)
 
 			if result.Output.TripwireTriggered {
+				// Stop the model call running concurrently.
+				cancelTurns(NewInputGuardrailTripwireTriggeredError(result))
+
 				mu.Lock()
 				defer mu.Unlock()
 				AttachErrorToSpan(parentSpan, tracing.SpanError{
@@ -798,6 +1394,10 @@ func (r Runner) startStreaming(
 	currentTurn := uint64(0)
 	shouldRunAgentStartHooks := true
 	toolUseTracker := NewAgentToolUseTracker()
+	toolOnlyTurns := 0
+	var repeatedOutputs repeatedOutputCounter
+	var handoffChain []string
+	handoffCounts := make(map[string]int)
 
 	streamedResult.eventQueue.Put(AgentUpdatedStreamEvent{
 		NewAgent: currentAgent,
@@ -813,8 +1413,13 @@ func (r Runner) startStreaming(
 	// Update the streamed result with the prepared input
 	streamedResult.setInput(preparedInput)
 
+	// Canceled by the input guardrails, running concurrently, when they fail
+	// or trigger a tripwire, to stop the model calls.
+	turnsCtx, cancelTurns := context.WithCancelCause(ctx)
+	defer cancelTurns(nil)
+
 	for !streamedResult.IsComplete() {
-		allTools, err := r.getAllTools(ctx, currentAgent)
+		allTools, err := r.getAllTools(ctx, currentAgent, toolUseTracker)
 		if err != nil {
 			return err
 		}
@@ -826,6 +1431,9 @@ func (r Runner) startStreaming(
 			if err != nil {
 				return err
 			}
+			if err = r.validateAgent(currentAgent); err != nil {
+				return err
+			}
 			handoffNames := make([]string, len(handoffs))
 			for i, handoff := range handoffs {
 				handoffNames[i] = handoff.AgentName
@@ -853,18 +1461,47 @@ func (r Runner) startStreaming(
 
 		currentTurn += 1
 		streamedResult.setCurrentTurn(currentTurn)
+		Logger().LogAttrs(ctx, slog.LevelDebug, "Running agent",
+			append(logAgentAttrs(ctx, currentAgent), slog.Uint64(logKeyTurn, currentTurn))...)
 
 		if currentTurn > maxTurns {
-			AttachErrorToSpan(currentSpan, tracing.SpanError{
-				Message: "Max turns exceeded",
-				Data:    map[string]any{"max_turns": maxTurns},
-			})
+			if runConfig.StopOnMaxTurns {
+				Logger().Debug("Max turns reached, stopping the run", slog.Uint64("maxTurns", maxTurns))
+				streamedResult.setStoppedEarly(true)
+				if text := lastMessageText(streamedResult.NewItems()); text != nil {
+					streamedResult.setFinalOutput(text)
+				}
+				err = r.saveStreamedResultToSession(ctx, streamedResult, startingInput, &RunResult{
+					Input:                 streamedResult.Input(),
+					NewItems:              streamedResult.NewItems(),
+					RawResponses:          streamedResult.RawResponses(),
+					FinalOutput:           streamedResult.FinalOutput(),
+					StoppedEarly:          true,
+					InputGuardrailResults: streamedResult.InputGuardrailResults(),
+					LastAgent:             currentAgent,
+				})
+				if err != nil {
+					return err
+				}
+				streamedResult.eventQueue.Put(RunStoppedEarlyStreamEvent{
+					MaxTurns: maxTurns,
+					Type:     "run_stopped_early_stream_event",
+				})
+			} else {
+				AttachErrorToSpan(currentSpan, tracing.SpanError{
+					Message: "Max turns exceeded",
+					Data:    map[string]any{"max_turns": maxTurns},
+				})
+			}
 			streamedResult.eventQueue.Put(queueCompleteSentinel{})
 			break
 		}
 
 		if currentTurn == 1 {
-			// Run the input guardrails in the background and put the results on the queue
+			// Run the input guardrails in the background and put the results on the queue.
+			// The span is copied, since currentSpan is reset after a handoff while
+			// the guardrails may still be running.
+			guardrailsSpan := currentSpan
 			streamedResult.createInputGuardrailsTask(ctx, func(ctx context.Context) error {
 				return r.runInputGuardrailsWithQueue(
 					ctx,
@@ -872,13 +1509,25 @@ func (r Runner) startStreaming(
 					slices.Concat(startingAgent.InputGuardrails, runConfig.InputGuardrails),
 					InputItems(ItemHelpers().InputToNewInputList(preparedInput)),
 					streamedResult,
-					currentSpan,
+					guardrailsSpan,
+					cancelTurns,
 				)
 			})
+
+			if runConfig.GuardrailsBeforeModel {
+				if err = streamedResult.getInputGuardrailsTask().Await().Error; err != nil {
+					return err
+				}
+				for _, result := range streamedResult.InputGuardrailResults() {
+					if result.Output.TripwireTriggered {
+						return NewInputGuardrailTripwireTriggeredError(result)
+					}
+				}
+			}
 		}
 
 		turnResult, err := r.runSingleTurnStreamed(
-			ctx,
+			turnsCtx,
 			streamedResult,
 			currentAgent,
 			hooks,
@@ -889,6 +1538,10 @@ func (r Runner) startStreaming(
 			previousResponseID,
 		)
 		if err != nil {
+			// Report the input guardrail error which caused the cancellation, if any.
+			if cause := context.Cause(turnsCtx); cause != nil && errors.Is(err, context.Canceled) && ctx.Err() == nil {
+				return cause
+			}
 			return err
 		}
 		shouldRunAgentStartHooks = false
@@ -899,6 +1552,13 @@ func (r Runner) startStreaming(
 
 		switch nextStep := turnResult.NextStep.(type) {
 		case NextStepFinalOutput:
+			if newPartialOutputEmitter(currentAgent, runConfig) != nil {
+				streamedResult.eventQueue.Put(PartialOutputStreamEvent{
+					Value: nextStep.Output,
+					Type:  "partial_output_stream_event",
+				})
+			}
+
 			streamedResult.createOutputGuardrailsTask(ctx, func(ctx context.Context) ([]OutputGuardrailResult, error) {
 				return r.runOutputGuardrails(
 					ctx,
@@ -931,13 +1591,21 @@ func (r Runner) startStreaming(
 				OutputGuardrailResults: streamedResult.OutputGuardrailResults(),
 				LastAgent:              currentAgent,
 			}
-			err = r.saveResultToSession(ctx, startingInput, tempResult)
+			err = r.saveStreamedResultToSession(ctx, streamedResult, startingInput, tempResult)
 			if err != nil {
 				return err
 			}
 
 			streamedResult.eventQueue.Put(queueCompleteSentinel{})
 		case NextStepHandoff:
+			err = trackHandoff(runConfig, currentSpan, currentAgent, nextStep.NewAgent, &handoffChain)
+			if err != nil {
+				return err
+			}
+			err = countHandoff(runConfig, currentSpan, nextStep.NewAgent, handoffCounts)
+			if err != nil {
+				return err
+			}
 			currentAgent = nextStep.NewAgent
 			err = currentSpan.Finish(ctx, true)
 			if err != nil {
@@ -945,12 +1613,35 @@ func (r Runner) startStreaming(
 			}
 			currentSpan = nil
 			shouldRunAgentStartHooks = true
+			toolOnlyTurns = 0
+			repeatedOutputs = repeatedOutputCounter{}
 			streamedResult.eventQueue.Put(AgentUpdatedStreamEvent{
 				NewAgent: currentAgent,
 				Type:     "agent_updated_stream_event",
 			})
 		case NextStepRunAgain:
-			// Nothing to do
+			handoffChain = nil
+			err = countToolOnlyTurn(runConfig, currentSpan, turnResult, &toolOnlyTurns)
+			if err != nil {
+				return err
+			}
+			err = repeatedOutputs.count(runConfig, currentSpan, turnResult)
+			if err != nil {
+				return err
+			}
+		case NextStepInterruption:
+			// Approvals handled by OnApprovalRequest or MCPApprovalPolicy never
+			// interrupt the run: only suspending it is unsupported here.
+			for _, interruption := range nextStep.Interruptions {
+				if interruption.ServerLabel != "" {
+					return UserErrorf(
+						"hosted MCP approval request for tool %q of server %q can't suspend a streamed run: "+
+							"set HostedMCPTool.OnApprovalRequest or Agent.MCPApprovalPolicy",
+						interruption.ToolName, interruption.ServerLabel,
+					)
+				}
+			}
+			return NewUserError("tool calls requiring approval are not supported in streaming mode")
 		default:
 			// This would be an unrecoverable implementation bug, so a panic is appropriate.
 			panic(fmt.Errorf("unexpected NextStep type %T", nextStep))
@@ -961,6 +1652,134 @@ func (r Runner) startStreaming(
 	return nil
 }
 
+// countToolOnlyTurn updates the count of consecutive tool-only turns with the
+// result of a turn which is going to be followed by another one. It returns a
+// MaxConsecutiveToolOnlyTurnsExceededError if the count exceeds
+// RunConfig.MaxConsecutiveToolOnlyTurns.
+func countToolOnlyTurn(runConfig RunConfig, span tracing.Span, turnResult *SingleStepResult, count *int) error {
+	if !isToolOnlyTurn(turnResult.NewStepItems) {
+		*count = 0
+		return nil
+	}
+
+	*count += 1
+	maxTurns := runConfig.MaxConsecutiveToolOnlyTurns
+	if maxTurns <= 0 || *count <= maxTurns {
+		return nil
+	}
+
+	AttachErrorToSpan(span, tracing.SpanError{
+		Message: "Max consecutive tool-only turns exceeded",
+		Data:    map[string]any{"max_consecutive_tool_only_turns": maxTurns},
+	})
+	return MaxConsecutiveToolOnlyTurnsExceededErrorf("max consecutive tool-only turns %d exceeded", maxTurns)
+}
+
+// lastMessageText returns the text of the last message among the items, or
+// nil if there is no message.
+func lastMessageText(items []RunItem) any {
+	for _, item := range slices.Backward(items) {
+		if message, ok := item.(MessageOutputItem); ok {
+			return ItemHelpers().TextMessageOutput(message)
+		}
+	}
+	return nil
+}
+
+// repeatedOutputCounter counts the consecutive turns producing the same
+// message text.
+type repeatedOutputCounter struct {
+	text  string
+	turns int
+}
+
+// count updates the counter with the result of a turn which is going to be
+// followed by another one. It returns a RepeatedOutputError if the same text
+// was produced for RunConfig.MaxRepeatedOutputs consecutive turns.
+func (c *repeatedOutputCounter) count(runConfig RunConfig, span tracing.Span, turnResult *SingleStepResult) error {
+	text := ItemHelpers().TextMessageOutputs(turnResult.NewStepItems)
+	switch {
+	case text == "":
+		*c = repeatedOutputCounter{}
+		return nil
+	case text == c.text:
+		c.turns += 1
+	default:
+		*c = repeatedOutputCounter{text: text, turns: 1}
+	}
+
+	maxRepeated := runConfig.MaxRepeatedOutputs
+	if maxRepeated < 2 || c.turns < maxRepeated {
+		return nil
+	}
+
+	AttachErrorToSpan(span, tracing.SpanError{
+		Message: "Repeated model output",
+		Data:    map[string]any{"max_repeated_outputs": maxRepeated},
+	})
+	return NewRepeatedOutputError(c.text, c.turns)
+}
+
+// trackHandoff adds a handoff to the chain of the agents involved in
+// consecutive handoffs. It returns a HandoffLoopError if the number of
+// consecutive handoffs exceeds RunConfig.MaxHandoffDepth.
+func trackHandoff(runConfig RunConfig, span tracing.Span, fromAgent, toAgent *Agent, chain *[]string) error {
+	if len(*chain) == 0 {
+		*chain = append(*chain, fromAgent.Name)
+	}
+	*chain = append(*chain, toAgent.Name)
+
+	maxDepth := runConfig.MaxHandoffDepth
+	if maxDepth <= 0 || len(*chain)-1 <= maxDepth {
+		return nil
+	}
+
+	AttachErrorToSpan(span, tracing.SpanError{
+		Message: "Max handoff depth exceeded",
+		Data: map[string]any{
+			"max_handoff_depth": maxDepth,
+			"agents":            slices.Clone(*chain),
+		},
+	})
+	return NewHandoffLoopError(maxDepth, slices.Clone(*chain))
+}
+
+// countHandoff counts a handoff to the given agent. It returns a
+// MaxHandoffsPerAgentExceededError if the number of handoffs to that agent
+// exceeds RunConfig.MaxHandoffsPerAgent.
+func countHandoff(runConfig RunConfig, span tracing.Span, toAgent *Agent, counts map[string]int) error {
+	counts[toAgent.Name]++
+
+	maxHandoffs := runConfig.MaxHandoffsPerAgent
+	if maxHandoffs <= 0 || counts[toAgent.Name] <= maxHandoffs {
+		return nil
+	}
+
+	AttachErrorToSpan(span, tracing.SpanError{
+		Message: "Max handoffs per agent exceeded",
+		Data: map[string]any{
+			"max_handoffs_per_agent": maxHandoffs,
+			"agent":                  toAgent.Name,
+		},
+	})
+	return NewMaxHandoffsPerAgentExceededError(maxHandoffs, toAgent.Name)
+}
+
+// isToolOnlyTurn reports whether the items generated during a turn contain
+// tool calls, but no message.
+func isToolOnlyTurn(items []RunItem) bool {
+	hasToolCalls := false
+	for _, item := range items {
+		switch item.(type) {
+		case MessageOutputItem:
+			return false
+		case ToolCallItem:
+			hasToolCalls = true
+		}
+	}
+	return hasToolCalls
+}
+
 func (r Runner) runSingleTurnStreamed(
 	ctx context.Context,
 	streamedResult *RunResultStreaming,
@@ -972,6 +1791,8 @@ func (r Runner) runSingleTurnStreamed(
 	allTools []Tool,
 	previousResponseID string,
 ) (*SingleStepResult, error) {
+	ctx = contextWithLogTurn(ctx, streamedResult.CurrentTurn())
+
 	if shouldRunAgentStartHooks {
 		childCtx, cancel := context.WithCancel(ctx)
 		defer cancel()
@@ -1009,13 +1830,9 @@ func (r Runner) runSingleTurnStreamed(
 
 	streamedResult.setCurrentAgent(agent)
 	streamedResult.setCurrentAgentOutputType(agent.OutputType)
+	streamedResult.partialOutput.reset()
 
-	systemPrompt, promptConfig, err := getAgentSystemPromptAndPromptConfig(ctx, agent)
-	if err != nil {
-		return nil, err
-	}
-
-	handoffs, err := r.getHandoffs(ctx, agent)
+	systemPrompt, promptConfig, handoffs, err := r.prepareTurn(ctx, agent, runConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -1024,8 +1841,6 @@ func (r Runner) runSingleTurnStreamed(
 	if err != nil {
 		return nil, fmt.Errorf("failed to get model: %w", err)
 	}
-	modelSettings := agent.ModelSettings.Resolve(runConfig.ModelSettings)
-	modelSettings = RunImpl().MaybeResetToolChoice(agent, toolUseTracker, modelSettings)
 
 	var finalResponse *ModelResponse
 
@@ -1034,18 +1849,24 @@ func (r Runner) runSingleTurnStreamed(
 		input = append(input, item.ToInputItem())
 	}
 
-	filtered, err := r.maybeFilterModelInput(
+	filtered, modelSettings, err := r.prepareModelInput(
 		ctx,
 		agent,
-		runConfig,
-		input,
 		systemPrompt,
+		input,
+		runConfig,
+		toolUseTracker,
+		r.getModelName(agent, runConfig, model),
+		streamedResult.CurrentTurn(),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// Call hook just before the model is invoked, with the correct system prompt.
+	// Call hooks just before the model is invoked, with the correct system prompt.
+	if err = hooks.OnLLMStart(ctx, agent, filtered.Instructions, filtered.Input); err != nil {
+		return nil, fmt.Errorf("RunHooks.OnLLMStart failed: %w", err)
+	}
 	if agent.Hooks != nil {
 		err = agent.Hooks.OnLLMStart(ctx, agent, filtered.Instructions, filtered.Input)
 		if err != nil {
@@ -1068,8 +1889,18 @@ func (r Runner) runSingleTurnStreamed(
 		PreviousResponseID: previousResponseID,
 		Prompt:             promptConfig,
 	}
+	partialOutput := newPartialOutputEmitter(agent, runConfig)
+	isPlainText := agent.OutputType == nil || agent.OutputType.IsPlainText()
+	r.startShadowModel(ctx, agent, runConfig, modelResponseParams, streamedResult.CurrentTurn())
+
+	logModelCallStart(ctx, agent)
+	streamStart := time.Now()
+	var fallbackIndex atomic.Int64
+	var systemFingerprint atomic.Pointer[string]
+	modelCtx := contextWithFallbackIndexRecorder(ctx, &fallbackIndex)
+	modelCtx = contextWithSystemFingerprintRecorder(modelCtx, &systemFingerprint)
 	err = model.StreamResponse(
-		ctx, modelResponseParams,
+		modelCtx, modelResponseParams,
 		func(ctx context.Context, event TResponseStreamEvent) error {
 			if event.Type == "response.completed" {
 				u := usage.NewUsage()
@@ -1084,26 +1915,51 @@ func (r Runner) runSingleTurnStreamed(
 					}
 				}
 				finalResponse = &ModelResponse{
-					Output:     event.Response.Output,
-					Usage:      u,
-					ResponseID: event.Response.ID,
+					Output:          event.Response.Output,
+					Usage:           u,
+					ResponseID:      event.Response.ID,
+					TokensPerSecond: tokensPerSecond(u.OutputTokens, time.Since(streamStart)),
+					FallbackIndex:   int(fallbackIndex.Load()),
+				}
+				if fingerprint := systemFingerprint.Load(); fingerprint != nil {
+					finalResponse.SystemFingerprint = *fingerprint
 				}
+				recordTokensPerSecond(ctx, finalResponse.TokensPerSecond)
 				if contextUsage, _ := usage.FromContext(ctx); contextUsage != nil {
-					contextUsage.Add(u)
+					contextUsage.AddForModel(r.getModelName(agent, runConfig, model), u)
 				}
 			}
 			streamedResult.eventQueue.Put(RawResponsesStreamEvent{
 				Data: event,
 				Type: "raw_response_event",
 			})
+			if event.Type == "response.reasoning_summary_text.delta" {
+				streamedResult.eventQueue.Put(ReasoningSummaryStreamEvent{
+					Delta:        event.Delta,
+					ItemID:       event.ItemID,
+					SummaryIndex: event.SummaryIndex,
+					Type:         "reasoning_summary_stream_event",
+				})
+			}
+			if isPlainText && event.Type == "response.output_text.delta" {
+				streamedResult.partialOutput.addText(event.ItemID, event.Delta)
+			}
+			streamedResult.partialOutput.handleEvent(event)
+			partialOutput.handleEvent(event, streamedResult)
 			return nil
 		},
 	)
+	logModelCallEnd(ctx, agent, streamStart, finalResponse, err)
 	if err != nil {
 		return nil, err
 	}
 
-	// Call hook just after the model response is finalized.
+	// Call hooks just after the model response is finalized.
+	if finalResponse != nil {
+		if err = hooks.OnLLMEnd(ctx, agent, *finalResponse); err != nil {
+			return nil, fmt.Errorf("RunHooks.OnLLMEnd failed: %w", err)
+		}
+	}
 	if agent.Hooks != nil && finalResponse != nil {
 		err = agent.Hooks.OnLLMEnd(ctx, agent, *finalResponse)
 		if err != nil {
@@ -1149,7 +2005,10 @@ func (r Runner) runSingleTurn(
 	shouldRunAgentStartHooks bool,
 	toolUseTracker *AgentToolUseTracker,
 	previousResponseID string,
+	turn uint64,
 ) (*SingleStepResult, error) {
+	ctx = contextWithLogTurn(ctx, turn)
+
 	// Ensure we run the hooks before anything else
 	if shouldRunAgentStartHooks {
 		childCtx, cancel := context.WithCancel(ctx)
@@ -1186,12 +2045,7 @@ func (r Runner) runSingleTurn(
 		}
 	}
 
-	systemPrompt, promptConfig, err := getAgentSystemPromptAndPromptConfig(ctx, agent)
-	if err != nil {
-		return nil, err
-	}
-
-	handoffs, err := r.getHandoffs(ctx, agent)
+	systemPrompt, promptConfig, handoffs, err := r.prepareTurn(ctx, agent, runConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -1209,10 +2063,12 @@ func (r Runner) runSingleTurn(
 		agent.OutputType,
 		allTools,
 		handoffs,
+		hooks,
 		runConfig,
 		toolUseTracker,
 		previousResponseID,
 		promptConfig,
+		turn,
 	)
 	if err != nil {
 		return nil, err
@@ -1309,7 +2165,7 @@ func (Runner) getSingleStepResultFromResponse(
 	)
 }
 
-func (Runner) runInputGuardrails(
+func (r Runner) runInputGuardrails(
 	ctx context.Context,
 	agent *Agent,
 	guardrails []InputGuardrail,
@@ -1335,7 +2191,7 @@ func (Runner) runInputGuardrails(
 		go func() {
 			defer wg.Done()
 
-			result, err := RunImpl().RunSingleInputGuardrail(childCtx, agent, guardrail, input)
+			result, err := r.runSingleInputGuardrail(childCtx, agent, guardrail, input)
 			if err != nil {
 				cancel()
 				guardrailErrors[i] = fmt.Errorf("failed to run input guardrail %s: %w", guardrail.Name, err)
@@ -1373,7 +2229,7 @@ func (Runner) runInputGuardrails(
 	return guardrailResults, nil
 }
 
-func (Runner) runOutputGuardrails(
+func (r Runner) runOutputGuardrails(
 	ctx context.Context,
 	guardrails []OutputGuardrail,
 	agent *Agent,
@@ -1399,7 +2255,7 @@ func (Runner) runOutputGuardrails(
 		go func() {
 			defer wg.Done()
 
-			result, err := RunImpl().RunSingleOutputGuardrail(childCtx, guardrail, agent, agentOutput)
+			result, err := r.runSingleOutputGuardrail(childCtx, guardrail, agent, agentOutput)
 			if err != nil {
 				cancel()
 				guardrailErrors[i] = fmt.Errorf("failed to run output guardrail %s: %w", guardrail.Name, err)
@@ -1437,19 +2293,137 @@ func (Runner) runOutputGuardrails(
 	return guardrailResults, nil
 }
 
-func (r Runner) getNewResponse(
+var errGuardrailTimeout = errors.New("guardrail timed out")
+
+// runSingleInputGuardrail runs an input guardrail, applying
+// RunConfig.GuardrailTimeout.
+func (r Runner) runSingleInputGuardrail(
+	ctx context.Context,
+	agent *Agent,
+	guardrail InputGuardrail,
+	input Input,
+) (InputGuardrailResult, error) {
+	if r.Config.GuardrailTimeout <= 0 {
+		return RunImpl().RunSingleInputGuardrail(ctx, agent, guardrail, input)
+	}
+
+	result, timedOut, err := runWithGuardrailTimeout(
+		ctx, r.Config.GuardrailTimeout,
+		func(ctx context.Context) (InputGuardrailResult, error) {
+			return RunImpl().RunSingleInputGuardrail(ctx, agent, guardrail, input)
+		},
+	)
+	if timedOut {
+		return InputGuardrailResult{
+			Guardrail: guardrail,
+			Output:    GuardrailFunctionOutput{TripwireTriggered: r.Config.GuardrailTimeoutTripwire},
+			TimedOut:  true,
+		}, nil
+	}
+	return result, err
+}
+
+// runSingleOutputGuardrail runs an output guardrail, applying
+// RunConfig.GuardrailTimeout.
+func (r Runner) runSingleOutputGuardrail(
+	ctx context.Context,
+	guardrail OutputGuardrail,
+	agent *Agent,
+	agentOutput any,
+) (OutputGuardrailResult, error) {
+	if r.Config.GuardrailTimeout <= 0 {
+		return RunImpl().RunSingleOutputGuardrail(ctx, guardrail, agent, agentOutput)
+	}
+
+	result, timedOut, err := runWithGuardrailTimeout(
+		ctx, r.Config.GuardrailTimeout,
+		func(ctx context.Context) (OutputGuardrailResult, error) {
+			return RunImpl().RunSingleOutputGuardrail(ctx, guardrail, agent, agentOutput)
+		},
+	)
+	if timedOut {
+		return OutputGuardrailResult{
+			Guardrail:   guardrail,
+			AgentOutput: agentOutput,
+			Agent:       agent,
+			Output:      GuardrailFunctionOutput{TripwireTriggered: r.Config.GuardrailTimeoutTripwire},
+			TimedOut:    true,
+		}, nil
+	}
+	return result, err
+}
+
+// runWithGuardrailTimeout runs fn with a context canceled after the given
+// timeout, reporting whether it timed out. In that case, it returns without
+// waiting for fn, so that a guardrail ignoring its context cannot stall the run.
+func runWithGuardrailTimeout[T any](
+	ctx context.Context,
+	timeout time.Duration,
+	fn func(context.Context) (T, error),
+) (_ T, timedOut bool, _ error) {
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, errGuardrailTimeout)
+	defer cancel()
+
+	type fnResult struct {
+		value T
+		err   error
+	}
+	done := make(chan fnResult, 1)
+	go func() {
+		value, err := fn(ctx)
+		done <- fnResult{value: value, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		// A guardrail honouring its context fails with the context error
+		// when the deadline expires: that is a timeout too.
+		if res.err != nil && errors.Is(context.Cause(ctx), errGuardrailTimeout) {
+			var zero T
+			return zero, true, nil
+		}
+		return res.value, false, res.err
+	case <-ctx.Done():
+		var zero T
+		if errors.Is(context.Cause(ctx), errGuardrailTimeout) {
+			return zero, true, nil
+		}
+		return zero, false, context.Cause(ctx)
+	}
+}
+
+// prepareTurn returns the system prompt, the prompt config and the handoffs
+// of the agent for its next turn.
+func (r Runner) prepareTurn(
+	ctx context.Context,
+	agent *Agent,
+	runConfig RunConfig,
+) (param.Opt[string], responses.ResponsePromptParam, []Handoff, error) {
+	systemPrompt, promptConfig, err := getAgentSystemPromptAndPromptConfig(ctx, agent)
+	if err != nil {
+		return param.Opt[string]{}, responses.ResponsePromptParam{}, nil, err
+	}
+	systemPrompt = runConfig.composeInstructions(systemPrompt)
+
+	handoffs, err := r.getHandoffs(ctx, agent)
+	if err != nil {
+		return param.Opt[string]{}, responses.ResponsePromptParam{}, nil, err
+	}
+	return systemPrompt, promptConfig, handoffs, nil
+}
+
+// prepareModelInput returns the model input and the model settings for the
+// next call of the named model.
+func (r Runner) prepareModelInput(
 	ctx context.Context,
 	agent *Agent,
 	systemPrompt param.Opt[string],
 	input []TResponseInputItem,
-	outputType OutputTypeInterface,
-	allTools []Tool,
-	handoffs []Handoff,
 	runConfig RunConfig,
 	toolUseTracker *AgentToolUseTracker,
-	previousResponseID string,
-	promptConfig responses.ResponsePromptParam,
-) (*ModelResponse, error) {
+	modelName string,
+	turn uint64,
+) (*ModelInputData, modelsettings.ModelSettings, error) {
 	// Allow user to modify model input right before the call, if configured
 	filtered, err := r.maybeFilterModelInput(
 		ctx,
@@ -1459,18 +2433,53 @@ func (r Runner) getNewResponse(
 		systemPrompt,
 	)
 	if err != nil {
-		return nil, err
+		return nil, modelsettings.ModelSettings{}, err
 	}
 
+	modelSettings := r.resolveModelSettings(agent, runConfig, modelName, turn)
+	modelSettings = RunImpl().MaybeResetToolChoice(agent, toolUseTracker, modelSettings)
+
+	return filtered, modelSettings, nil
+}
+
+func (r Runner) getNewResponse(
+	ctx context.Context,
+	agent *Agent,
+	systemPrompt param.Opt[string],
+	input []TResponseInputItem,
+	outputType OutputTypeInterface,
+	allTools []Tool,
+	handoffs []Handoff,
+	hooks RunHooks,
+	runConfig RunConfig,
+	toolUseTracker *AgentToolUseTracker,
+	previousResponseID string,
+	promptConfig responses.ResponsePromptParam,
+	turn uint64,
+) (*ModelResponse, error) {
 	model, err := r.getModel(agent, runConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get model: %w", err)
 	}
 
-	modelSettings := agent.ModelSettings.Resolve(runConfig.ModelSettings)
-	modelSettings = RunImpl().MaybeResetToolChoice(agent, toolUseTracker, modelSettings)
+	filtered, modelSettings, err := r.prepareModelInput(
+		ctx,
+		agent,
+		systemPrompt,
+		input,
+		runConfig,
+		toolUseTracker,
+		r.getModelName(agent, runConfig, model),
+		turn,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	// If the agent has hooks, we need to call them before and after the LLM call
+	// The hooks need to be called before and after the LLM call
+	if err = hooks.OnLLMStart(ctx, agent, filtered.Instructions, filtered.Input); err != nil {
+		return nil, fmt.Errorf("RunHooks.OnLLMStart failed: %w", err)
+	}
 	if agent.Hooks != nil {
 		err = agent.Hooks.OnLLMStart(ctx, agent, filtered.Instructions, filtered.Input)
 		if err != nil {
@@ -1478,7 +2487,7 @@ func (r Runner) getNewResponse(
 		}
 	}
 
-	newResponse, err := model.GetResponse(ctx, ModelResponseParams{
+	modelResponseParams := ModelResponseParams{
 		SystemInstructions: filtered.Instructions,
 		Input:              InputItems(filtered.Input),
 		ModelSettings:      modelSettings,
@@ -1491,12 +2500,20 @@ func (r Runner) getNewResponse(
 		),
 		PreviousResponseID: previousResponseID,
 		Prompt:             promptConfig,
-	})
+	}
+	r.startShadowModel(ctx, agent, runConfig, modelResponseParams, turn)
+
+	logModelCallStart(ctx, agent)
+	start := time.Now()
+	newResponse, err := model.GetResponse(ctx, modelResponseParams)
+	logModelCallEnd(ctx, agent, start, newResponse, err)
 	if err != nil {
 		return nil, err
 	}
 
-	// If the agent has hooks, we need to call them after the LLM call
+	if err = hooks.OnLLMEnd(ctx, agent, *newResponse); err != nil {
+		return nil, fmt.Errorf("RunHooks.OnLLMEnd failed: %w", err)
+	}
 	if agent.Hooks != nil {
 		err = agent.Hooks.OnLLMEnd(ctx, agent, *newResponse)
 		if err != nil {
@@ -1511,10 +2528,10 @@ func (r Runner) getNewResponse(
 	}
 
 	if contextUsage, _ := usage.FromContext(ctx); contextUsage != nil {
-		contextUsage.Add(newResponse.Usage)
+		contextUsage.AddForModel(r.getModelName(agent, runConfig, model), newResponse.Usage)
 	}
 
-	return newResponse, err
+	return newResponse, nil
 }
 
 func (Runner) getHandoffs(ctx context.Context, agent *Agent) ([]Handoff, error) {
@@ -1567,11 +2584,78 @@ func (Runner) getHandoffs(ctx context.Context, agent *Agent) ([]Handoff, error)
 		}
 	}
 
+	// Present the handoffs with higher priority first
+	slices.SortStableFunc(enabledHandoffs, func(a, b Handoff) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+
 	return enabledHandoffs, nil
 }
 
-func (Runner) getAllTools(ctx context.Context, agent *Agent) ([]Tool, error) {
-	return agent.GetAllTools(ctx)
+// resolveModelSettings returns the global default settings (see
+// SetDefaultModelSettings) overlaid with the default settings of the model
+// (see SetModelDefaults), with the agent model settings, and then with the
+// run-level settings and metadata. Unlike the other settings, the run-level
+// metadata is merged into the agent metadata, overriding only the keys they
+// have in common. Finally, the temperature is overridden by
+// RunConfig.TemperatureSchedule for the given turn, if any.
+func (Runner) resolveModelSettings(agent *Agent, runConfig RunConfig, modelName string, turn uint64) modelsettings.ModelSettings {
+	modelDefaults, _ := GetModelDefaults(modelName)
+	modelSettings := GetDefaultModelSettings().Resolve(modelDefaults).Resolve(agent.ModelSettings)
+	agentMetadata := modelSettings.Metadata
+	modelSettings = modelSettings.Resolve(runConfig.ModelSettings)
+	if len(agentMetadata) > 0 && len(runConfig.ModelSettings.Metadata) > 0 {
+		metadata := maps.Clone(agentMetadata)
+		maps.Copy(metadata, runConfig.ModelSettings.Metadata)
+		modelSettings.Metadata = metadata
+	}
+	if runConfig.TemperatureSchedule != nil {
+		if temperature, ok := runConfig.TemperatureSchedule(int(turn)); ok {
+			modelSettings.Temperature = param.NewOpt(temperature)
+		}
+	}
+	if runConfig.CorrelationID != "" {
+		metadata := maps.Clone(modelSettings.Metadata)
+		if metadata == nil {
+			metadata = make(map[string]string, 1)
+		}
+		metadata[CorrelationIDMetadataKey] = runConfig.CorrelationID
+		modelSettings.Metadata = metadata
+	}
+	return modelSettings
+}
+
+// validateAgent checks the agent before it starts running. The JSON schema of
+// a strict output type is always checked, even without RunConfig.ValidateAgents,
+// as the model would reject it anyway.
+func (r Runner) validateAgent(agent *Agent) error {
+	if r.Config.ValidateAgents {
+		return agent.Validate()
+	}
+	return ValidateStrictOutputType(agent.OutputType)
+}
+
+func (r Runner) getAllTools(ctx context.Context, agent *Agent, toolUseTracker *AgentToolUseTracker) ([]Tool, error) {
+	tools, err := agent.GetAllTools(ContextWithToolUseTracker(ctx, toolUseTracker))
+	if err != nil || !(agent.ForceNonStrictTools || r.Config.ForceNonStrictTools) {
+		return tools, err
+	}
+	return toNonStrictTools(tools), nil
+}
+
+// toNonStrictTools returns a copy of the tools in which all the function
+// tools have a non-strict JSON schema. A strict schema is also a valid
+// non-strict one, so the schemas themselves are left unchanged.
+func toNonStrictTools(tools []Tool) []Tool {
+	result := make([]Tool, len(tools))
+	for i, tool := range tools {
+		if functionTool, ok := tool.(FunctionTool); ok {
+			functionTool.StrictJSONSchema = param.NewOpt(false)
+			tool = functionTool
+		}
+		result[i] = tool
+	}
+	return result
 }
 
 func (r Runner) getModel(agent *Agent, runConfig RunConfig) (Model, error) {
@@ -1599,6 +2683,57 @@ func (r Runner) getModel(agent *Agent, runConfig RunConfig) (Model, error) {
 	return modelProvider.GetModel("")
 }
 
+// getModelName returns the name of the model resolved by getModel, used to
+// break usage down by model. An explicitly configured model name takes
+// precedence; otherwise, the name is taken from the model instance, falling
+// back to its type name for models which don't expose one.
+func (r Runner) getModelName(agent *Agent, runConfig RunConfig, model Model) string {
+	if runConfig.Model.Valid() {
+		if name, ok := runConfig.Model.Value.SafeModelName(); ok && name != "" {
+			return name
+		}
+	} else if agent.Model.Valid() {
+		if name, ok := agent.Model.Value.SafeModelName(); ok && name != "" {
+			return name
+		}
+	}
+
+	return modelInstanceName(model)
+}
+
+// modelInstanceName returns the name of the model, for the OpenAI models,
+// or its type name otherwise.
+func modelInstanceName(model Model) string {
+	switch m := model.(type) {
+	case OpenAIResponsesModel:
+		return m.Model
+	case *OpenAIResponsesModel:
+		return m.Model
+	case OpenAIChatCompletionsModel:
+		return m.Model
+	case *OpenAIChatCompletionsModel:
+		return m.Model
+	default:
+		return fmt.Sprintf("%T", model)
+	}
+}
+
+// getConfiguredModelName returns the same model name as getModelName, without
+// getting the model from the model provider, which may require credentials.
+func (r Runner) getConfiguredModelName(agent *Agent, runConfig RunConfig) string {
+	agentModel := agent.Model
+	if runConfig.Model.Valid() {
+		agentModel = runConfig.Model
+	}
+	if !agentModel.Valid() {
+		return ""
+	}
+	if model, ok := agentModel.Value.SafeModel(); ok {
+		return r.getModelName(agent, runConfig, model)
+	}
+	return agentModel.Value.ModelName()
+}
+
 // prepareInputWithSession prepares input by combining it with session history if enabled.
 func (r Runner) prepareInputWithSession(ctx context.Context, input Input) (Input, error) {
 	session := r.Config.Session
@@ -1606,14 +2741,16 @@ func (r Runner) prepareInputWithSession(ctx context.Context, input Input) (Input
 		return input, nil
 	}
 
-	// Validate that we don't have both a session and a list input, as this creates
-	// ambiguity about whether the list should append to or replace existing session history
-	if _, ok := input.(InputItems); ok {
+	// A list input only made of new messages (e.g. user text plus an image) is
+	// appended to the session history. Other items, such as assistant messages
+	// or tool calls, create ambiguity about whether the list should append to
+	// or replace existing session history.
+	if items, ok := input.(InputItems); ok && slices.ContainsFunc(items, isNotNewMessageInputItem) {
 		return nil, NewUserError(
-			"Cannot provide both a session and a list of input items. " +
-				"When using session memory, provide only a string input to append to the " +
-				"conversation, or use Session: nil and provide a list to manually manage " +
-				"conversation history.",
+			"Cannot provide both a session and a list of input items other than user, " +
+				"system or developer messages. When using session memory, provide only new " +
+				"messages to append to the conversation, or use Session: nil and provide a " +
+				"list to manually manage conversation history.",
 		)
 	}
 
@@ -1633,6 +2770,36 @@ func (r Runner) prepareInputWithSession(ctx context.Context, input Input) (Input
 	return InputItems(combinedInput), nil
 }
 
+// isNotNewMessageInputItem reports whether the item is anything other than
+// a user, system or developer message.
+func isNotNewMessageInputItem(item TResponseInputItem) bool {
+	switch {
+	case item.OfMessage != nil:
+		return item.OfMessage.Role == responses.EasyInputMessageRoleAssistant
+	case item.OfInputMessage != nil:
+		return false
+	default:
+		return true
+	}
+}
+
+// saveStreamedResultToSession is like saveResultToSession, but a cancelled run
+// is never saved (see RunResultStreaming.Cancel). The run can't be cancelled
+// while it is being saved.
+func (r Runner) saveStreamedResultToSession(
+	ctx context.Context,
+	streamedResult *RunResultStreaming,
+	originalInput Input,
+	result *RunResult,
+) error {
+	streamedResult.cancelMu.Lock()
+	defer streamedResult.cancelMu.Unlock()
+	if streamedResult.IsCancelled() {
+		return nil
+	}
+	return r.saveResultToSession(ctx, originalInput, result)
+}
+
 // saveResultToSession saves the conversation turn to session.
 func (r Runner) saveResultToSession(ctx context.Context, originalInput Input, result *RunResult) error {
 	session := r.Config.Session
@@ -0,0 +1,83 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nlpodyssey/openai-agents-go/usage"
+)
+
+// RunBatch runs startingAgent over each of the inputs using the DefaultRunner,
+// with bounded concurrency. See Runner.RunBatch.
+func RunBatch(ctx context.Context, startingAgent *Agent, inputs []string, concurrency int) ([]*RunResult, []error) {
+	return DefaultRunner.RunBatch(ctx, startingAgent, inputs, concurrency)
+}
+
+// RunBatch runs startingAgent over each of the inputs, running at most
+// concurrency runs at the same time (no limit, if zero or negative).
+//
+// It returns the results and the errors of the runs, both index-aligned with
+// the inputs: for each input, either the result or the error is nil. Each run
+// gets its own usage tracker (see usage.FromContext).
+//
+// The agent is shared by all the runs, which only read it; the same goes for
+// the Runner configuration, so a RunConfig.Session would be shared as well,
+// mixing the conversations of the runs.
+//
+// When ctx is canceled, the pending inputs are not run, and their error is
+// the context error.
+func (r Runner) RunBatch(ctx context.Context, startingAgent *Agent, inputs []string, concurrency int) ([]*RunResult, []error) {
+	results := make([]*RunResult, len(inputs))
+	errs := make([]error, len(inputs))
+	if len(inputs) == 0 {
+		return results, errs
+	}
+	if concurrency <= 0 || concurrency > len(inputs) {
+		concurrency = len(inputs)
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for range concurrency {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				runCtx := usage.NewContext(ctx, usage.NewUsage())
+				results[i], errs[i] = r.Run(runCtx, startingAgent, inputs[i])
+			}
+		}()
+	}
+
+	for i := range inputs {
+		if ctx.Err() == nil {
+			select {
+			case indices <- i:
+				continue
+			case <-ctx.Done():
+			}
+		}
+		for j := i; j < len(inputs); j++ {
+			errs[j] = ctx.Err()
+		}
+		break
+	}
+	close(indices)
+	wg.Wait()
+
+	return results, errs
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoModel is a concurrency-safe model replying with the text of the last
+// input message, recording the maximum number of concurrent calls.
+type echoModel struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (m *echoModel) GetResponse(ctx context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for {
+		maxN := m.maxInFlight.Load()
+		if n <= maxN || m.maxInFlight.CompareAndSwap(maxN, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	input := params.Input.(agents.InputItems)
+	text := input[len(input)-1].OfMessage.Content.OfString.Value
+	return &agents.ModelResponse{
+		Output: []agents.TResponseOutputItem{agentstesting.GetTextMessage("echo: " + text)},
+		Usage:  &usage.Usage{Requests: 1},
+	}, nil
+}
+
+func (m *echoModel) StreamResponse(context.Context, agents.ModelResponseParams, agents.ModelStreamResponseCallback) error {
+	return fmt.Errorf("streaming not supported")
+}
+
+func TestRunBatch(t *testing.T) {
+	model := &echoModel{}
+	agent := agents.New("test").WithModelInstance(model)
+
+	inputs := make([]string, 10)
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("input %d", i)
+	}
+
+	results, errs := agents.RunBatch(t.Context(), agent, inputs, 3)
+	require.Len(t, results, len(inputs))
+	require.Len(t, errs, len(inputs))
+	for i, input := range inputs {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "echo: "+input, results[i].FinalOutput)
+	}
+	assert.LessOrEqual(t, model.maxInFlight.Load(), int32(3))
+}
+
+func TestRunBatchCanceledContext(t *testing.T) {
+	agent := agents.New("test").WithModelInstance(&echoModel{})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	results, errs := agents.RunBatch(ctx, agent, []string{"a", "b", "c"}, 1)
+	require.Len(t, results, 3)
+	for i := range errs {
+		assert.Nil(t, results[i])
+		assert.ErrorIs(t, errs[i], context.Canceled)
+	}
+}
+
+func TestRunBatchEmpty(t *testing.T) {
+	agent := agents.New("test").WithModelInstance(&echoModel{})
+	results, errs := agents.RunBatch(t.Context(), agent, nil, 2)
+	assert.Empty(t, results)
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingStreamingModel is a FakeModel which, once its turn outputs are
+// exhausted, streams the given text deltas and then waits for the context to
+// be cancelled.
+type blockingStreamingModel struct {
+	*agentstesting.FakeModel
+	deltas []string
+}
+
+func (m *blockingStreamingModel) StreamResponse(
+	ctx context.Context,
+	params agents.ModelResponseParams,
+	yield agents.ModelStreamResponseCallback,
+) error {
+	if len(m.TurnOutputs) > 0 {
+		return m.FakeModel.StreamResponse(ctx, params, yield)
+	}
+	for i, delta := range m.deltas {
+		err := yield(ctx, agents.TResponseStreamEvent{ // responses.ResponseTextDeltaEvent
+			ItemID:         "msg_1",
+			Delta:          delta,
+			Type:           "response.output_text.delta",
+			SequenceNumber: int64(i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRunStreamedCancelKeepsPartialResults(t *testing.T) {
+	session, err := memory.NewSQLiteSession(t.Context(), memory.SQLiteSessionParams{
+		SessionID:        "test",
+		DBDataSourceName: filepath.Join(t.TempDir(), "test.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, session.Close()) })
+
+	model := &blockingStreamingModel{
+		FakeModel: agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("foo", "")},
+		}),
+		deltas: []string{"Hel", "lo"},
+	}
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("foo", "tool_result"))
+
+	runner := agents.Runner{Config: agents.RunConfig{Session: session}}
+	result, err := runner.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.False(t, result.IsCancelled())
+
+	var deltas int
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if e, ok := event.(agents.RawResponsesStreamEvent); ok && e.Data.Type == "response.output_text.delta" {
+			deltas++
+			if deltas == 2 {
+				result.Cancel()
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.IsCancelled())
+	assert.True(t, result.IsComplete())
+
+	// The first turn, with the tool call, was completed.
+	assert.Len(t, result.RawResponses(), 1)
+	newItems := result.NewItems()
+	require.Len(t, newItems, 2)
+	assert.IsType(t, agents.ToolCallItem{}, newItems[0])
+	assert.IsType(t, agents.ToolCallOutputItem{}, newItems[1])
+
+	// The text of the interrupted turn is the partial final output.
+	assert.Equal(t, "Hello", result.FinalOutput())
+
+	// The cancelled run is not saved to the session.
+	items, err := session.GetItems(t.Context(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestRunStreamedCancelBeforeOutput(t *testing.T) {
+	model := &blockingStreamingModel{FakeModel: agentstesting.NewFakeModel(false, nil)}
+	agent := agents.New("test").WithModelInstance(model)
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+
+	err = result.StreamEvents(func(event agents.StreamEvent) error {
+		if _, ok := event.(agents.AgentUpdatedStreamEvent); ok {
+			result.Cancel()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.IsCancelled())
+	assert.Nil(t, result.FinalOutput())
+	assert.Empty(t, result.NewItems())
+}
+
+// preambleStreamingModel is a FakeModel which streams the given text deltas
+// before its first response.
+type preambleStreamingModel struct {
+	*agentstesting.FakeModel
+	deltas []string
+}
+
+func (m *preambleStreamingModel) StreamResponse(
+	ctx context.Context,
+	params agents.ModelResponseParams,
+	yield agents.ModelStreamResponseCallback,
+) error {
+	for i, delta := range m.deltas {
+		err := yield(ctx, agents.TResponseStreamEvent{ // responses.ResponseTextDeltaEvent
+			ItemID:         "msg_1",
+			Delta:          delta,
+			Type:           "response.output_text.delta",
+			SequenceNumber: int64(i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	m.deltas = nil
+	return m.FakeModel.StreamResponse(ctx, params, yield)
+}
+
+func TestRunStreamedCancelDuringToolCall(t *testing.T) {
+	model := &preambleStreamingModel{
+		FakeModel: agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("Let me check"),
+				agentstesting.GetFunctionToolCall("slow", ""),
+			},
+		}),
+		deltas: []string{"Let me ", "check"},
+	}
+	toolStarted := make(chan struct{})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agents.FunctionTool{
+			Name:             "slow",
+			ParamsJSONSchema: map[string]any{"type": "object"},
+			OnInvokeTool: func(ctx context.Context, _ string) (any, error) {
+				close(toolStarted)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		})
+
+	result, err := agents.RunStreamed(t.Context(), agent, "hello")
+	require.NoError(t, err)
+
+	go func() {
+		<-toolStarted
+		result.Cancel()
+	}()
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	require.NoError(t, err)
+
+	// The text preceding the tool call is not the final output.
+	assert.True(t, result.IsCancelled())
+	assert.Nil(t, result.FinalOutput())
+}
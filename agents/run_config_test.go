@@ -15,10 +15,14 @@
 package agents_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
 	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/nlpodyssey/openai-agents-go/tracing"
+	"github.com/nlpodyssey/openai-agents-go/tracing/tracingtesting"
 	"github.com/openai/openai-go/v3/packages/param"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -147,3 +151,145 @@ func TestAgentModelObjectIsUsedWhenPresent(t *testing.T) {
 	assert.Nil(t, provider.LastRequested)
 	assert.Equal(t, "from-agent-object", result.FinalOutput)
 }
+
+func TestRunConfigCorrelationIDIsPropagated(t *testing.T) {
+	tracingtesting.Setup(t)
+
+	fakeModel := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("done"),
+		},
+	})
+	agent := &agents.Agent{
+		Name:  "test",
+		Model: param.NewOpt(agents.NewAgentModel(fakeModel)),
+		ModelSettings: modelsettings.ModelSettings{
+			Metadata: map[string]string{"foo": "bar"},
+		},
+	}
+	runConfig := agents.RunConfig{
+		CorrelationID: "corr-123",
+		TraceMetadata: map[string]any{"baz": "qux"},
+	}
+	_, err := (agents.Runner{Config: runConfig}).Run(t.Context(), agent, "any")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"foo":            "bar",
+		"correlation_id": "corr-123",
+	}, fakeModel.LastTurnArgs.ModelSettings.Metadata)
+
+	traces := tracingtesting.FetchTraces()
+	require.Len(t, traces, 1)
+	assert.Equal(t, map[string]any{
+		"baz":            "qux",
+		"correlation_id": "corr-123",
+	}, traces[0].(*tracing.TraceImpl).Metadata)
+
+	// The user-provided maps must not be modified.
+	assert.Equal(t, map[string]string{"foo": "bar"}, agent.ModelSettings.Metadata)
+	assert.Equal(t, map[string]any{"baz": "qux"}, runConfig.TraceMetadata)
+}
+
+func TestRunConfigCorrelationIDIsPropagatedStreamed(t *testing.T) {
+	tracingtesting.Setup(t)
+
+	fakeModel := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("done"),
+		},
+	})
+	agent := &agents.Agent{
+		Name:  "test",
+		Model: param.NewOpt(agents.NewAgentModel(fakeModel)),
+	}
+	runConfig := agents.RunConfig{CorrelationID: "corr-456"}
+	result, err := (agents.Runner{Config: runConfig}).RunStreamed(t.Context(), agent, "any")
+	require.NoError(t, err)
+	require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+
+	assert.Equal(t, map[string]string{"correlation_id": "corr-456"},
+		fakeModel.LastTurnArgs.ModelSettings.Metadata)
+
+	traces := tracingtesting.FetchTraces()
+	require.Len(t, traces, 1)
+	assert.Equal(t, map[string]any{"correlation_id": "corr-456"},
+		traces[0].(*tracing.TraceImpl).Metadata)
+}
+
+// temperatureRecordingModel wraps a FakeModel, recording the temperature
+// requested at each call.
+type temperatureRecordingModel struct {
+	*agentstesting.FakeModel
+	temperatures []param.Opt[float64]
+}
+
+func (m *temperatureRecordingModel) GetResponse(ctx context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	m.temperatures = append(m.temperatures, params.ModelSettings.Temperature)
+	return m.FakeModel.GetResponse(ctx, params)
+}
+
+func (m *temperatureRecordingModel) StreamResponse(ctx context.Context, params agents.ModelResponseParams, yield agents.ModelStreamResponseCallback) error {
+	m.temperatures = append(m.temperatures, params.ModelSettings.Temperature)
+	return m.FakeModel.StreamResponse(ctx, params, yield)
+}
+
+func TestRunConfigTemperatureSchedule(t *testing.T) {
+	for _, streamed := range []bool{false, true} {
+		name := "non-streamed"
+		if streamed {
+			name = "streamed"
+		}
+		t.Run(name, func(t *testing.T) {
+			model := &temperatureRecordingModel{FakeModel: agentstesting.NewFakeModel(false, nil)}
+			model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+				{Value: []agents.TResponseOutputItem{
+					agentstesting.GetFunctionToolCall("foo", `{}`),
+				}},
+				{Value: []agents.TResponseOutputItem{
+					agentstesting.GetFunctionToolCall("foo", `{}`),
+				}},
+				{Value: []agents.TResponseOutputItem{
+					agentstesting.GetTextMessage("done"),
+				}},
+			})
+			agent := agents.New("test").
+				WithModelInstance(model).
+				WithTools(agentstesting.GetFunctionTool("foo", "result"))
+
+			var scheduledTurns []int
+			runner := agents.Runner{Config: agents.RunConfig{
+				ModelSettings: modelsettings.ModelSettings{Temperature: param.NewOpt(0.2)},
+				TemperatureSchedule: func(turn int) (float64, bool) {
+					scheduledTurns = append(scheduledTurns, turn)
+					switch turn {
+					case 1:
+						return 1.0, true
+					case 2:
+						return 0.5, true
+					default:
+						return 0, false
+					}
+				},
+			}}
+
+			if streamed {
+				result, err := runner.RunStreamed(t.Context(), agent, "user_message")
+				require.NoError(t, err)
+				require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+				assert.Equal(t, "done", result.FinalOutput())
+			} else {
+				result, err := runner.Run(t.Context(), agent, "user_message")
+				require.NoError(t, err)
+				assert.Equal(t, "done", result.FinalOutput)
+			}
+
+			assert.Equal(t, []int{1, 2, 3}, scheduledTurns)
+			assert.Equal(t, []param.Opt[float64]{
+				param.NewOpt(1.0),
+				param.NewOpt(0.5),
+				param.NewOpt(0.2),
+			}, model.temperatures)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+)
+
+// DryRun returns what the first model call of a run of startingAgent with the
+// given input would send, using the DefaultRunner. See Runner.DryRun.
+func DryRun(
+	ctx context.Context,
+	startingAgent *Agent,
+	input string,
+) (*ModelInputData, modelsettings.ModelSettings, []Tool, []Handoff, error) {
+	return DefaultRunner.DryRun(ctx, startingAgent, input)
+}
+
+// DryRun returns what the first model call of a run of startingAgent with the
+// given input would send, without calling the model, e.g. to debug the prompt
+// construction: the model input (after adding the session history, and
+// applying RunConfig.Retriever, RunConfig.CallModelInputFilter and the input
+// limits), the resolved model settings, and the enabled tools and handoffs.
+//
+// The model is not obtained from the model provider, so no credentials are
+// needed. No hooks or guardrails are run, and nothing is saved to the session.
+func (r Runner) DryRun(
+	ctx context.Context,
+	startingAgent *Agent,
+	input string,
+) (*ModelInputData, modelsettings.ModelSettings, []Tool, []Handoff, error) {
+	preparedInput, err := r.prepareInputWithSession(ctx, InputString(input))
+	if err != nil {
+		return nil, modelsettings.ModelSettings{}, nil, nil, err
+	}
+
+	if err = r.validateAgent(startingAgent); err != nil {
+		return nil, modelsettings.ModelSettings{}, nil, nil, err
+	}
+
+	toolUseTracker := NewAgentToolUseTracker()
+	allTools, err := r.getAllTools(ctx, startingAgent, toolUseTracker)
+	if err != nil {
+		return nil, modelsettings.ModelSettings{}, nil, nil, err
+	}
+
+	systemPrompt, _, handoffs, err := r.prepareTurn(ctx, startingAgent, r.Config)
+	if err != nil {
+		return nil, modelsettings.ModelSettings{}, nil, nil, err
+	}
+
+	modelInput, modelSettings, err := r.prepareModelInput(
+		ctx,
+		startingAgent,
+		systemPrompt,
+		ItemHelpers().InputToNewInputList(preparedInput),
+		r.Config,
+		toolUseTracker,
+		r.getConfiguredModelName(startingAgent, r.Config),
+		1,
+	)
+	if err != nil {
+		return nil, modelsettings.ModelSettings{}, nil, nil, err
+	}
+
+	return modelInput, modelSettings, allTools, handoffs, nil
+}
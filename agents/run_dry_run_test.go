@@ -0,0 +1,85 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunMatchesRealRun(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").
+		WithInstructions("Be helpful.").
+		WithModelInstance(model).
+		WithModelSettings(modelsettings.ModelSettings{Temperature: param.NewOpt(0.3)}).
+		WithTools(agentstesting.GetFunctionTool("foo", "result")).
+		WithAgentHandoffs(agents.New("other"))
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		InstructionsPrefix: "Prefix.",
+		CallModelInputFilter: func(_ context.Context, data agents.CallModelData) (*agents.ModelInputData, error) {
+			input := append(data.ModelData.Input, agentstesting.GetTextInputItem("filtered"))
+			return &agents.ModelInputData{Input: input, Instructions: data.ModelData.Instructions}, nil
+		},
+	}}
+
+	modelInput, modelSettings, tools, handoffs, err := runner.DryRun(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Nil(t, model.LastTurnArgs.Input, "the model must not be called")
+
+	require.Len(t, handoffs, 1)
+	assert.Equal(t, "transfer_to_other", handoffs[0].ToolName)
+
+	_, err = runner.Run(t.Context(), agent, "hello")
+	require.NoError(t, err)
+
+	assert.Equal(t, model.LastTurnArgs.SystemInstructions, modelInput.Instructions)
+	assert.Equal(t, model.LastTurnArgs.Input, agents.InputItems(modelInput.Input))
+	assert.Equal(t, model.LastTurnArgs.ModelSettings, modelSettings)
+	require.Len(t, tools, len(model.LastTurnArgs.Tools))
+	for i, tool := range tools {
+		assert.Equal(t, model.LastTurnArgs.Tools[i].ToolName(), tool.ToolName())
+	}
+
+	// Sanity check of the contents.
+	assert.Contains(t, modelInput.Instructions.Value, "Prefix.")
+	assert.Contains(t, modelInput.Instructions.Value, "Be helpful.")
+	assert.Len(t, modelInput.Input, 2)
+	assert.Equal(t, param.NewOpt(0.3), modelSettings.Temperature)
+}
+
+func TestDryRunModelName(t *testing.T) {
+	// The model is not obtained from the provider, which would need credentials.
+	t.Setenv("OPENAI_API_KEY", "")
+	agents.SetModelDefaults("dry-run-model", modelsettings.ModelSettings{Temperature: param.NewOpt(0.7)})
+	t.Cleanup(agents.ClearModelDefaults)
+
+	agent := agents.New("test").WithModel("dry-run-model")
+
+	modelInput, modelSettings, _, _, err := agents.DryRun(t.Context(), agent, "hello")
+	require.NoError(t, err)
+	assert.Len(t, modelInput.Input, 1)
+	assert.Equal(t, param.NewOpt(0.7), modelSettings.Temperature)
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nlpodyssey/openai-agents-go/usage"
+)
+
+// RunFixture is a self-contained record of an agent run: its input, the
+// responses of the model and the agent configuration.
+//
+// It is produced by RunResult.ExportFixture, and it can be replayed with a
+// FixtureModel, e.g. to reproduce a bug without access to the original model.
+type RunFixture struct {
+	// Name of the last agent that was run.
+	AgentName string `json:"agent_name"`
+
+	// Model name of the last agent, if it was configured by name.
+	Model string `json:"model,omitempty"`
+
+	// Model settings of the last agent. They are recorded for inspection
+	// only, and they are not applied on replay.
+	ModelSettings json.RawMessage `json:"model_settings,omitempty"`
+
+	// The original input items of the run.
+	Input []TResponseInputItem `json:"input"`
+
+	// The model responses, in the order they were produced.
+	Responses []RunFixtureResponse `json:"responses"`
+
+	// The final output of the run, as JSON.
+	FinalOutput json.RawMessage `json:"final_output,omitempty"`
+}
+
+// RunFixtureResponse is a model response recorded in a RunFixture.
+type RunFixtureResponse struct {
+	Output     []TResponseOutputItem `json:"output"`
+	Usage      *usage.Usage          `json:"usage,omitempty"`
+	ResponseID string                `json:"response_id,omitempty"`
+}
+
+func (r RunFixtureResponse) MarshalJSON() ([]byte, error) {
+	// Prefer the raw JSON of the output items, when available, not to
+	// serialize all the (mostly empty) fields of the union type.
+	output := make([]json.RawMessage, len(r.Output))
+	for i, item := range r.Output {
+		if raw := item.RawJSON(); raw != "" {
+			output[i] = json.RawMessage(raw)
+			continue
+		}
+		b, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to JSON-marshal output item: %w", err)
+		}
+		output[i] = b
+	}
+
+	type fixtureResponse struct {
+		Output     []json.RawMessage `json:"output"`
+		Usage      *usage.Usage      `json:"usage,omitempty"`
+		ResponseID string            `json:"response_id,omitempty"`
+	}
+	return json.Marshal(fixtureResponse{
+		Output:     output,
+		Usage:      r.Usage,
+		ResponseID: r.ResponseID,
+	})
+}
+
+// ExportFixture writes a JSON RunFixture of the run to w.
+func (r RunResult) ExportFixture(w io.Writer) error {
+	fixture := RunFixture{
+		Input:     ItemHelpers().InputToNewInputList(r.Input),
+		Responses: make([]RunFixtureResponse, len(r.RawResponses)),
+	}
+
+	if a := r.LastAgent; a != nil {
+		fixture.AgentName = a.Name
+		if a.Model.Valid() {
+			fixture.Model, _ = a.Model.Value.SafeModelName()
+		}
+		modelSettings, err := json.Marshal(a.ModelSettings)
+		if err != nil {
+			return fmt.Errorf("failed to JSON-marshal model settings: %w", err)
+		}
+		fixture.ModelSettings = modelSettings
+	}
+
+	for i, resp := range r.RawResponses {
+		fixture.Responses[i] = RunFixtureResponse{
+			Output:     resp.Output,
+			Usage:      resp.Usage,
+			ResponseID: resp.ResponseID,
+		}
+	}
+
+	if r.FinalOutput != nil {
+		finalOutput, err := json.Marshal(r.FinalOutput)
+		if err != nil {
+			return fmt.Errorf("failed to JSON-marshal final output: %w", err)
+		}
+		fixture.FinalOutput = finalOutput
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(fixture); err != nil {
+		return fmt.Errorf("failed to write run fixture: %w", err)
+	}
+	return nil
+}
+
+// ReadRunFixture reads a JSON RunFixture, as written by RunResult.ExportFixture.
+func ReadRunFixture(r io.Reader) (*RunFixture, error) {
+	var fixture RunFixture
+	if err := json.NewDecoder(r).Decode(&fixture); err != nil {
+		return nil, UserErrorf("failed to read run fixture: %w", err)
+	}
+	return &fixture, nil
+}
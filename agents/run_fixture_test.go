@@ -0,0 +1,152 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordFixtureRun(t *testing.T) (*agents.RunResult, *bytes.Buffer) {
+	t.Helper()
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.SetHardcodedUsage(usage.Usage{InputTokens: 3, OutputTokens: 2, TotalTokens: 5})
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("foo", `{"a": "b"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFinalOutputMessage(`{"bar": "baz"}`),
+		}},
+	})
+
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithModelSettings(modelsettings.ModelSettings{Temperature: param.NewOpt(0.5)}).
+		WithTools(agentstesting.GetFunctionTool("foo", "tool_result")).
+		WithOutputType(agents.OutputType[AgentRunnerTestFoo]())
+
+	result, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, result.ExportFixture(&buf))
+	return result, &buf
+}
+
+func fixtureReplayAgent(fixture *agents.RunFixture) *agents.Agent {
+	return agents.New(fixture.AgentName).
+		WithModelInstance(agents.NewFixtureModel(fixture)).
+		WithTools(agentstesting.GetFunctionTool("foo", "tool_result")).
+		WithOutputType(agents.OutputType[AgentRunnerTestFoo]())
+}
+
+func TestRunResultExportFixture(t *testing.T) {
+	_, buf := recordFixtureRun(t)
+
+	fixture, err := agents.ReadRunFixture(buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", fixture.AgentName)
+	assert.JSONEq(t, `{"bar": "baz"}`, string(fixture.FinalOutput))
+	var modelSettings map[string]any
+	require.NoError(t, json.Unmarshal(fixture.ModelSettings, &modelSettings))
+	assert.Equal(t, 0.5, modelSettings["temperature"])
+	require.Len(t, fixture.Input, 1)
+	assert.Equal(t, "user_message", fixture.Input[0].OfMessage.Content.OfString.Value)
+
+	require.Len(t, fixture.Responses, 2)
+	require.Len(t, fixture.Responses[0].Output, 1)
+	assert.Equal(t, "foo", fixture.Responses[0].Output[0].AsFunctionCall().Name)
+	assert.Equal(t, uint64(5), fixture.Responses[1].Usage.TotalTokens)
+}
+
+func TestRunFixtureReplay(t *testing.T) {
+	original, buf := recordFixtureRun(t)
+
+	fixture, err := agents.ReadRunFixture(buf)
+	require.NoError(t, err)
+
+	tracker := usage.NewUsage()
+	ctx := usage.NewContext(t.Context(), tracker)
+
+	replayed, err := agents.Runner{}.RunInputs(ctx, fixtureReplayAgent(fixture), fixture.Input)
+	require.NoError(t, err)
+
+	assert.Equal(t, original.FinalOutput, replayed.FinalOutput)
+	assert.Equal(t, len(original.NewItems), len(replayed.NewItems))
+	assert.Len(t, replayed.RawResponses, 2)
+	assert.Equal(t, uint64(2), tracker.Requests)
+	assert.Equal(t, uint64(10), tracker.TotalTokens)
+
+	// Exporting the replayed run yields the same responses
+	var replayedBuf bytes.Buffer
+	require.NoError(t, replayed.ExportFixture(&replayedBuf))
+	replayedFixture, err := agents.ReadRunFixture(&replayedBuf)
+	require.NoError(t, err)
+
+	expectedResponses, err := json.Marshal(fixture.Responses)
+	require.NoError(t, err)
+	actualResponses, err := json.Marshal(replayedFixture.Responses)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedResponses), string(actualResponses))
+}
+
+func TestRunFixtureReplayStreamed(t *testing.T) {
+	original, buf := recordFixtureRun(t)
+
+	fixture, err := agents.ReadRunFixture(buf)
+	require.NoError(t, err)
+
+	result, err := agents.Runner{}.RunInputsStreamed(t.Context(), fixtureReplayAgent(fixture), fixture.Input)
+	require.NoError(t, err)
+	require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+
+	assert.Equal(t, original.FinalOutput, result.FinalOutput())
+	assert.Equal(t, len(original.NewItems), len(result.NewItems()))
+}
+
+func TestFixtureModelExhausted(t *testing.T) {
+	fixture := &agents.RunFixture{
+		Responses: []agents.RunFixtureResponse{{
+			Output: []agents.TResponseOutputItem{
+				agentstesting.GetFunctionToolCall("foo", `{"a": "b"}`),
+			},
+		}},
+	}
+	agent := agents.New("test").
+		WithModelInstance(agents.NewFixtureModel(fixture)).
+		WithTools(agentstesting.GetFunctionTool("foo", "tool_result"))
+
+	_, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+	assert.ErrorAs(t, err, &agents.UserError{})
+	assert.ErrorContains(t, err, "run fixture has no more recorded responses")
+}
+
+func TestReadRunFixtureInvalid(t *testing.T) {
+	_, err := agents.ReadRunFixture(strings.NewReader("not JSON"))
+	assert.ErrorAs(t, err, &agents.UserError{})
+}
@@ -20,8 +20,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/nlpodyssey/openai-agents-go/asyncqueue"
 	"github.com/nlpodyssey/openai-agents-go/computer"
@@ -71,12 +74,33 @@ func (t *AgentToolUseTracker) HasUsedTools(agent *Agent) bool {
 	return index != -1 && len(t.AgentToTools[index].ToolNames) > 0
 }
 
+// HasUsedTool reports whether the agent has used the tool with the given name.
+func (t *AgentToolUseTracker) HasUsedTool(agent *Agent, toolName string) bool {
+	index := t.agentIndex(agent)
+	return index != -1 && slices.Contains(t.AgentToTools[index].ToolNames, toolName)
+}
+
 func (t *AgentToolUseTracker) agentIndex(agent *Agent) int {
 	return slices.IndexFunc(t.AgentToTools, func(item AgentToToolsItem) bool {
 		return item.Agent == agent
 	})
 }
 
+type toolUseTrackerKey struct{}
+
+// ContextWithToolUseTracker returns a copy of ctx carrying the tool use tracker
+// of the current run.
+func ContextWithToolUseTracker(ctx context.Context, t *AgentToolUseTracker) context.Context {
+	return context.WithValue(ctx, toolUseTrackerKey{}, t)
+}
+
+// ToolUseTrackerFromContext returns the tool use tracker of the current run,
+// or nil if not set. The runner sets it when evaluating whether tools are enabled.
+func ToolUseTrackerFromContext(ctx context.Context) *AgentToolUseTracker {
+	v, _ := ctx.Value(toolUseTrackerKey{}).(*AgentToolUseTracker)
+	return v
+}
+
 type ToolRunHandoff struct {
 	Handoff  Handoff
 	ToolCall ResponseFunctionToolCall
@@ -85,6 +109,15 @@ type ToolRunHandoff struct {
 type ToolRunFunction struct {
 	ToolCall     ResponseFunctionToolCall
 	FunctionTool FunctionTool
+	// Call IDs of the identical tool calls of the same turn which were
+	// collapsed into this one (see Agent.DedupeToolCalls). They get the same
+	// output as ToolCall, without running the tool again.
+	DuplicateCallIDs []string
+}
+
+// callIDs returns the call ID of the tool call, followed by the ones of its duplicates.
+func (tr ToolRunFunction) callIDs() []string {
+	return append([]string{tr.ToolCall.CallID}, tr.DuplicateCallIDs...)
 }
 
 type ToolRunComputerAction struct {
@@ -110,8 +143,10 @@ type ProcessedResponse struct {
 	LocalShellCalls []ToolRunLocalShellCall
 	// Names of all tools used, including hosted tools
 	ToolsUsed []string
-	// Only requests with callbacks
+	// Only requests with callbacks or an agent MCPApprovalPolicy
 	MCPApprovalRequests []ToolRunMCPApprovalRequest
+	// Requests which must be approved by the caller, suspending the run
+	PendingMCPApprovalRequests []responses.ResponseOutputItemMcpApprovalRequest
 }
 
 func (pr *ProcessedResponse) HasToolsOrApprovalsToRun() bool {
@@ -119,7 +154,7 @@ func (pr *ProcessedResponse) HasToolsOrApprovalsToRun() bool {
 	// Hosted tools have already run, so there's nothing to do.
 	return len(pr.Handoffs) > 0 || len(pr.Functions) > 0 ||
 		len(pr.ComputerActions) > 0 || len(pr.LocalShellCalls) > 0 ||
-		len(pr.MCPApprovalRequests) > 0
+		len(pr.MCPApprovalRequests) > 0 || len(pr.PendingMCPApprovalRequests) > 0
 }
 
 type NextStep interface {
@@ -142,6 +177,17 @@ type NextStepRunAgain struct{}
 
 func (NextStepRunAgain) isNextStep() {}
 
+// NextStepInterruption means that the run must be suspended, because some
+// tool calls need approval (see ApprovalRequiredError).
+type NextStepInterruption struct {
+	Interruptions []ApprovalRequest
+
+	// The state needed to complete the step when the run is resumed.
+	step *interruptedStep
+}
+
+func (NextStepInterruption) isNextStep() {}
+
 type SingleStepResult struct {
 	// The input items i.e. the items before Run() was called. May be mutated by handoff input filters.
 	OriginalInput Input
@@ -198,16 +244,17 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 	var newStepItems []RunItem
 	newStepItems = append(newStepItems, processedResponse.NewItems...)
 
-	// First, let's run the tool calls - function tools and computer actions
+	// First, let's run the tool calls - function tools, computer actions and local shell calls
 	childCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	var (
 		functionResults []FunctionToolResult
 		computerResults []RunItem
-		toolErrors      [2]error
+		shellResults    []RunItem
+		toolErrors      [3]error
 		wg              sync.WaitGroup
 	)
-	wg.Add(2)
+	wg.Add(3)
 	go func() {
 		defer wg.Done()
 		functionResults, toolErrors[0] = ri.ExecuteFunctionToolCalls(
@@ -227,15 +274,30 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 			hooks,
 		)
 	}()
+	go func() {
+		defer wg.Done()
+		shellResults, toolErrors[2] = ri.ExecuteLocalShellCalls(
+			childCtx,
+			agent,
+			processedResponse.LocalShellCalls,
+			hooks,
+		)
+	}()
 	wg.Wait()
 	if err := errors.Join(toolErrors[:]...); err != nil {
 		return nil, err
 	}
 
+	var interruptions []ApprovalRequest
 	for _, result := range functionResults {
+		if result.ApprovalRequest != nil {
+			interruptions = append(interruptions, *result.ApprovalRequest)
+			continue
+		}
 		newStepItems = append(newStepItems, result.RunItem)
 	}
 	newStepItems = append(newStepItems, computerResults...)
+	newStepItems = append(newStepItems, shellResults...)
 
 	// Next, run the MCP approval requests
 	if mcpApprovalRequests := processedResponse.MCPApprovalRequests; len(mcpApprovalRequests) > 0 {
@@ -245,8 +307,68 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 		}
 		newStepItems = append(newStepItems, approvalResults...)
 	}
+	for _, request := range processedResponse.PendingMCPApprovalRequests {
+		interruptions = append(interruptions, ApprovalRequest{
+			ToolName:    request.Name,
+			CallID:      request.ID,
+			Arguments:   request.Arguments,
+			ServerLabel: request.ServerLabel,
+		})
+	}
 
-	// Next, check if there are any handoffs
+	// Next, suspend the run if any tool call needs approval. The rest of
+	// the step is completed when the run is resumed.
+	if len(interruptions) > 0 {
+		return &SingleStepResult{
+			OriginalInput: originalInput,
+			ModelResponse: newResponse,
+			PreStepItems:  preStepItems,
+			NewStepItems:  newStepItems,
+			NextStep: NextStepInterruption{
+				Interruptions: interruptions,
+				step: &interruptedStep{
+					preStepItems:      preStepItems,
+					newStepItems:      newStepItems,
+					modelResponse:     newResponse,
+					processedResponse: processedResponse,
+					functionResults:   functionResults,
+				},
+			},
+		}, nil
+	}
+
+	return ri.completeStep(
+		ctx,
+		agent,
+		originalInput,
+		preStepItems,
+		newStepItems,
+		newResponse,
+		processedResponse,
+		functionResults,
+		outputType,
+		hooks,
+		runConfig,
+	)
+}
+
+// completeStep determines the next step once all the tool calls of the
+// processed response have been run: it executes the handoffs, if any, or
+// checks whether the step produced a final output.
+func (ri runImpl) completeStep(
+	ctx context.Context,
+	agent *Agent,
+	originalInput Input,
+	preStepItems []RunItem,
+	newStepItems []RunItem,
+	newResponse ModelResponse,
+	processedResponse ProcessedResponse,
+	functionResults []FunctionToolResult,
+	outputType OutputTypeInterface,
+	hooks RunHooks,
+	runConfig RunConfig,
+) (*SingleStepResult, error) {
+	// Check if there are any handoffs
 	if runHandoffs := processedResponse.Handoffs; len(runHandoffs) > 0 {
 		return ri.ExecuteHandoffs(
 			ctx,
@@ -313,6 +435,18 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 	if outputType != nil && !outputType.IsPlainText() && potentialFinalOutputText != "" {
 		finalOutput, err := outputType.ValidateJSON(ctx, potentialFinalOutputText)
 		if err != nil {
+			// The raw text is model output, so it's only traced when sensitive data is included.
+			var parsingErr OutputParsingError
+			if errors.As(err, &parsingErr) && runConfig.TraceIncludeSensitiveData.Or(true) {
+				AttachErrorToCurrentSpan(ctx, tracing.SpanError{
+					Message: "Invalid JSON",
+					Data: map[string]any{
+						"details":     parsingErr.Error(),
+						"output_type": parsingErr.TypeName,
+						"raw_text":    parsingErr.RawText,
+					},
+				})
+			}
 			return nil, fmt.Errorf("final output type JSON validation failed: %w", err)
 		}
 		return ri.ExecuteFinalOutput(
@@ -326,6 +460,11 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 			hooks,
 		)
 	} else if (outputType == nil || outputType.IsPlainText()) && !processedResponse.HasToolsOrApprovalsToRun() {
+		if agent.FinalOutputExtractor != nil {
+			if extracted, ok := agent.FinalOutputExtractor(potentialFinalOutputText); ok {
+				potentialFinalOutputText = extracted
+			}
+		}
 		return ri.ExecuteFinalOutput(
 			ctx,
 			agent,
@@ -348,8 +487,11 @@ func (ri runImpl) ExecuteToolsAndSideEffects(
 	}
 }
 
-// MaybeResetToolChoice resets tool choice to nil if the agent has used tools
-// and the agent's ResetToolChoice flag is true.
+// MaybeResetToolChoice resets tool choice to the one configured by the agent
+// itself (agent.ModelSettings.ToolChoice, possibly nil) if the agent has used
+// tools and the agent's ResetToolChoice flag is true.
+// A tool choice forced by other settings, such as RunConfig.ModelSettings,
+// is therefore dropped, while the agent's own choice is preserved.
 func (runImpl) MaybeResetToolChoice(
 	agent *Agent,
 	toolUseTracker *AgentToolUseTracker,
@@ -358,7 +500,7 @@ func (runImpl) MaybeResetToolChoice(
 	resetToolChoice := agent.ResetToolChoice.Or(true)
 	if resetToolChoice && toolUseTracker.HasUsedTools(agent) {
 		newSettings := modelSettings
-		newSettings.ToolChoice = nil
+		newSettings.ToolChoice = agent.ModelSettings.ToolChoice
 		return newSettings
 	}
 	return modelSettings
@@ -372,15 +514,16 @@ func (runImpl) ProcessModelResponse(
 	handoffs []Handoff,
 ) (*ProcessedResponse, error) {
 	var (
-		items               []RunItem
-		runHandoffs         []ToolRunHandoff
-		functions           []ToolRunFunction
-		computerActions     []ToolRunComputerAction
-		localShellCalls     []ToolRunLocalShellCall
-		mcpApprovalRequests []ToolRunMCPApprovalRequest
-		computerTool        *ComputerTool
-		localShellTool      *LocalShellTool
-		toolsUsed           []string
+		items                      []RunItem
+		runHandoffs                []ToolRunHandoff
+		functions                  []ToolRunFunction
+		computerActions            []ToolRunComputerAction
+		localShellCalls            []ToolRunLocalShellCall
+		mcpApprovalRequests        []ToolRunMCPApprovalRequest
+		pendingMCPApprovalRequests []responses.ResponseOutputItemMcpApprovalRequest
+		computerTool               *ComputerTool
+		localShellTool             *LocalShellTool
+		toolsUsed                  []string
 	)
 
 	handoffMap := make(map[string]Handoff, len(handoffs))
@@ -389,6 +532,9 @@ func (runImpl) ProcessModelResponse(
 	}
 
 	functionMap := make(map[string]FunctionTool)
+	// Index in functions of the first call for each name and arguments,
+	// only used with Agent.DedupeToolCalls.
+	functionCallIndices := make(map[[2]string]int)
 	hostedMCPServerMap := make(map[string]HostedMCPTool)
 
 	for _, tool := range allTools {
@@ -451,6 +597,7 @@ func (runImpl) ProcessModelResponse(
 				ID:               outputUnion.ID,
 				Summary:          outputUnion.Summary,
 				Type:             constant.ValueOf[constant.Reasoning](),
+				Content:          openaitypes.ResponseReasoningItemContentSliceFromResponseOutputMessageContentUnions(outputUnion.Content),
 				EncryptedContent: outputUnion.EncryptedContent,
 				Status:           responses.ResponseReasoningItemStatus(outputUnion.Status),
 			}
@@ -468,10 +615,12 @@ func (runImpl) ProcessModelResponse(
 				Status:              responses.ResponseComputerToolCallStatus(outputUnion.Status),
 				Type:                responses.ResponseComputerToolCallTypeComputerCall,
 			}
+			computerCallAction, _ := NewComputerCallAction(output.Action)
 			items = append(items, ToolCallItem{
-				Agent:   agent,
-				RawItem: ResponseComputerToolCall(output),
-				Type:    "tool_call_item",
+				Agent:              agent,
+				RawItem:            ResponseComputerToolCall(output),
+				ComputerCallAction: computerCallAction,
+				Type:               "tool_call_item",
 			})
 			toolsUsed = append(toolsUsed, "computer_use")
 			if computerTool == nil {
@@ -501,14 +650,13 @@ func (runImpl) ProcessModelResponse(
 					Data:    map[string]any{"server_label": output.ServerLabel},
 				})
 				return nil, ModelBehaviorErrorf("MCP server label %q not found", output.ServerLabel)
-			} else if server.OnApprovalRequest != nil {
+			} else if server.OnApprovalRequest != nil || agent.MCPApprovalPolicy != nil {
 				mcpApprovalRequests = append(mcpApprovalRequests, ToolRunMCPApprovalRequest{
 					RequestItem: output,
 					MCPTool:     server,
 				})
 			} else {
-				Logger().Warn("MCP server has no OnApprovalRequest hook",
-					slog.String("serverLabel", output.ServerLabel))
+				pendingMCPApprovalRequests = append(pendingMCPApprovalRequests, output)
 			}
 		case "mcp_list_tools":
 			output := responses.ResponseOutputItemMcpListTools{
@@ -626,6 +774,14 @@ func (runImpl) ProcessModelResponse(
 					RawItem: ResponseFunctionToolCall(output),
 					Type:    "tool_call_item",
 				})
+				if agent.DedupeToolCalls {
+					key := [2]string{output.Name, output.Arguments}
+					if i, ok := functionCallIndices[key]; ok {
+						functions[i].DuplicateCallIDs = append(functions[i].DuplicateCallIDs, output.CallID)
+						continue
+					}
+					functionCallIndices[key] = len(functions)
+				}
 				functions = append(functions, ToolRunFunction{
 					ToolCall:     ResponseFunctionToolCall(output),
 					FunctionTool: functionTool,
@@ -636,17 +792,59 @@ func (runImpl) ProcessModelResponse(
 		}
 	}
 
+	if agent.ToolCallOrdering != nil && len(functions) > 0 {
+		ordered := agent.ToolCallOrdering(slices.Clone(functions))
+		if err := validateToolCallOrdering(functions, ordered); err != nil {
+			return nil, err
+		}
+		functions = ordered
+	}
+
 	return &ProcessedResponse{
-		NewItems:            items,
-		Handoffs:            runHandoffs,
-		Functions:           functions,
-		ComputerActions:     computerActions,
-		LocalShellCalls:     localShellCalls,
-		ToolsUsed:           toolsUsed,
-		MCPApprovalRequests: mcpApprovalRequests,
+		NewItems:                   items,
+		Handoffs:                   runHandoffs,
+		Functions:                  functions,
+		ComputerActions:            computerActions,
+		LocalShellCalls:            localShellCalls,
+		ToolsUsed:                  toolsUsed,
+		MCPApprovalRequests:        mcpApprovalRequests,
+		PendingMCPApprovalRequests: pendingMCPApprovalRequests,
 	}, nil
 }
 
+// validateToolCallOrdering checks that the calls returned by an Agent.ToolCallOrdering
+// are the original ones, unmodified, with no call dropped or duplicated.
+func validateToolCallOrdering(original, ordered []ToolRunFunction) error {
+	if len(ordered) != len(original) {
+		return UserErrorf("ToolCallOrdering returned %d tool calls, expected %d", len(ordered), len(original))
+	}
+	used := make([]bool, len(original))
+	for _, run := range ordered {
+		i := -1
+		for j, o := range original {
+			if !used[j] && sameToolRunFunction(o, run) {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return UserErrorf("ToolCallOrdering returned an unexpected, modified or duplicated tool call %q", run.ToolCall.CallID)
+		}
+		used[i] = true
+	}
+	return nil
+}
+
+// sameToolRunFunction reports whether a and b are the same function tool call.
+func sameToolRunFunction(a, b ToolRunFunction) bool {
+	return a.ToolCall.ID == b.ToolCall.ID &&
+		a.ToolCall.CallID == b.ToolCall.CallID &&
+		a.ToolCall.Name == b.ToolCall.Name &&
+		a.ToolCall.Arguments == b.ToolCall.Arguments &&
+		a.FunctionTool.Name == b.FunctionTool.Name &&
+		slices.Equal(a.DuplicateCallIDs, b.DuplicateCallIDs)
+}
+
 type FunctionToolResult struct {
 	// The tool that was run.
 	Tool FunctionTool
@@ -656,6 +854,10 @@ type FunctionToolResult struct {
 
 	// The run item that was produced as a result of the tool call.
 	RunItem RunItem
+
+	// Set when the tool returned an ApprovalRequiredError, in which case
+	// there are no Output and RunItem.
+	ApprovalRequest *ApprovalRequest
 }
 
 func (runImpl) ExecuteFunctionToolCalls(
@@ -669,8 +871,11 @@ func (runImpl) ExecuteFunctionToolCalls(
 		ctx context.Context,
 		funcTool FunctionTool,
 		toolCall ResponseFunctionToolCall,
-	) (any, error) {
-		var result any
+	) (any, *ApprovalRequest, error) {
+		var (
+			result          any
+			approvalRequest *ApprovalRequest
+		)
 
 		traceIncludeSensitiveData := config.TraceIncludeSensitiveData.Or(true)
 
@@ -705,6 +910,8 @@ func (runImpl) ExecuteFunctionToolCalls(
 
 				var wg sync.WaitGroup
 
+				toolStart := time.Now()
+
 				wg.Add(1)
 				go func() {
 					defer wg.Done()
@@ -731,7 +938,8 @@ func (runImpl) ExecuteFunctionToolCalls(
 				go func() {
 					defer wg.Done()
 					result, toolError = funcTool.OnInvokeTool(ctx, toolCall.Arguments)
-					if toolError != nil && errorFn == nil {
+					logToolCall(ctx, agent, funcTool.Name, toolStart, toolError)
+					if _, ok := asApprovalRequired(ctx, toolError); toolError != nil && errorFn == nil && !ok {
 						cancel()
 					}
 				}()
@@ -742,6 +950,16 @@ func (runImpl) ExecuteFunctionToolCalls(
 					return err
 				}
 
+				if approvalErr, ok := asApprovalRequired(ctx, toolError); ok {
+					approvalRequest = &ApprovalRequest{
+						ToolName:  funcTool.Name,
+						CallID:    toolCall.CallID,
+						Arguments: toolCall.Arguments,
+						Reason:    approvalErr.Reason,
+					}
+					return nil
+				}
+
 				if toolError != nil {
 					if errorFn == nil {
 						return fmt.Errorf("error running tool %s: %w", funcTool.Name, toolError)
@@ -794,46 +1012,77 @@ func (runImpl) ExecuteFunctionToolCalls(
 			})
 
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		return result, nil
+		return result, approvalRequest, nil
 	}
 
 	results := make([]any, len(toolRuns))
+	approvalRequests := make([]*ApprovalRequest, len(toolRuns))
 	resultErrors := make([]error, len(toolRuns))
 
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
 	defer cancel()
 
-	var wg sync.WaitGroup
-	wg.Add(len(toolRuns))
-
-	for i, toolRun := range toolRuns {
-		go func() {
-			defer wg.Done()
-			results[i], resultErrors[i] = runSingleTool(ctx, toolRun.FunctionTool, toolRun.ToolCall)
+	if agent.ToolCallOrdering != nil {
+		// An explicit ordering is honored by running the calls one at a time.
+		for i, toolRun := range toolRuns {
+			results[i], approvalRequests[i], resultErrors[i] = runSingleTool(ctx, toolRun.FunctionTool, toolRun.ToolCall)
 			if resultErrors[i] != nil {
-				cancel()
+				break
 			}
-		}()
-	}
+		}
+	} else {
+		var wg sync.WaitGroup
+		wg.Add(len(toolRuns))
+
+		for i, toolRun := range toolRuns {
+			go func() {
+				defer wg.Done()
+				results[i], approvalRequests[i], resultErrors[i] = runSingleTool(ctx, toolRun.FunctionTool, toolRun.ToolCall)
+				if resultErrors[i] != nil {
+					cancel()
+				}
+			}()
+		}
 
-	wg.Wait()
+		wg.Wait()
+	}
 	if err := errors.Join(resultErrors...); err != nil {
 		return nil, err
 	}
 
-	functionToolResults := make([]FunctionToolResult, len(results))
+	// Duplicated tool calls get their own results, right after the original one.
+	functionToolResults := make([]FunctionToolResult, 0, len(results))
 	for i, result := range results {
 		toolRun := toolRuns[i]
 
+		if approvalRequests[i] != nil {
+			// A single approval request covers the duplicates too.
+			approvalRequests[i].DuplicateCallIDs = toolRun.DuplicateCallIDs
+			functionToolResults = append(functionToolResults, FunctionToolResult{
+				Tool:            toolRun.FunctionTool,
+				ApprovalRequest: approvalRequests[i],
+			})
+			continue
+		}
+
 		var strResult string
+		var content *ToolOutputContent
 		switch v := result.(type) {
 		case string:
 			strResult = v
 		case []byte:
 			strResult = string(v)
+		case ToolOutputContent:
+			content = &v
+			strResult = v.Text
+		case *ToolOutputContent:
+			if v != nil {
+				content = v
+				strResult = v.Text
+			}
 		default:
 			out, err := json.Marshal(v)
 			if err != nil {
@@ -842,16 +1091,53 @@ func (runImpl) ExecuteFunctionToolCalls(
 			strResult = string(out)
 		}
 
-		functionToolResults[i] = FunctionToolResult{
-			Tool:   toolRun.FunctionTool,
-			Output: result,
-			RunItem: ToolCallOutputItem{
-				Agent: agent,
-				RawItem: ResponseInputItemFunctionCallOutputParam(
-					ItemHelpers().ToolCallOutputItem(toolRun.ToolCall, strResult)),
+		var sanitization *ToolOutputSanitizerResult
+		if config.ToolOutputSanitizer != nil {
+			sanitized, err := config.ToolOutputSanitizer(ctx, ToolOutputSanitizerData{
+				Agent:    agent,
+				ToolName: toolRun.FunctionTool.Name,
+				CallID:   toolRun.ToolCall.CallID,
+				Output:   strResult,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to sanitize output of tool %s: %w", toolRun.FunctionTool.Name, err)
+			}
+			strResult = sanitized.Output
+			if sanitized.Flagged {
+				sanitization = &sanitized
+				AttachErrorToCurrentSpan(ctx, tracing.SpanError{
+					Message: "Tool output flagged by sanitizer",
+					Data: map[string]any{
+						"tool_name": toolRun.FunctionTool.Name,
+						"reasons":   sanitized.Reasons,
+					},
+				})
+			}
+		}
+
+		for _, callID := range toolRun.callIDs() {
+			toolCall := toolRun.ToolCall
+			toolCall.CallID = callID
+			rawItem := ItemHelpers().ToolCallOutputItem(toolCall, strResult)
+			if content != nil && len(content.Images) > 0 {
+				sanitizedContent := *content
+				sanitizedContent.Text = strResult
+				rawItem.Output = responses.ResponseInputItemFunctionCallOutputOutputUnionParam{
+					OfResponseFunctionCallOutputItemArray: sanitizedContent.outputItems(),
+				}
+			}
+
+			functionToolResults = append(functionToolResults, FunctionToolResult{
+				Tool:   toolRun.FunctionTool,
 				Output: result,
-				Type:   "tool_call_output_item",
-			},
+				RunItem: ToolCallOutputItem{
+					Agent:        agent,
+					RawItem:      ResponseInputItemFunctionCallOutputParam(rawItem),
+					Output:       result,
+					Sanitization: sanitization,
+					Type:         "tool_call_output_item",
+				},
+			})
 		}
 	}
 
@@ -964,6 +1250,8 @@ func (runImpl) ExecuteHandoffs(
 			}
 
 			spanHandoff.SpanData().(*tracing.HandoffSpanData).ToAgent = newAgent.Name
+			handoffAttrs := append(logAgentAttrs(ctx, agent), slog.String("toAgent", newAgent.Name))
+			Logger().LogAttrs(ctx, slog.LevelDebug, "Handing off", handoffAttrs...)
 			if multipleHandoffs {
 				requestedAgents := make([]string, len(runHandoffs))
 				for i, h := range runHandoffs {
@@ -1013,11 +1301,11 @@ func (runImpl) ExecuteHandoffs(
 		}
 	}()
 
-	if agent.Hooks != nil {
+	if newAgent.Hooks != nil {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			err := agent.Hooks.OnHandoff(childCtx, newAgent, agent)
+			err := newAgent.Hooks.OnHandoff(childCtx, newAgent, agent)
 			if err != nil {
 				cancel()
 				handoffErrors[1] = fmt.Errorf("AgentHooks.OnHandoff failed: %w", err)
@@ -1052,6 +1340,20 @@ func (runImpl) ExecuteHandoffs(
 		newStepItems = slices.Clone(filtered.NewItems)
 	}
 
+	if handoff.AcknowledgementMessage != "" {
+		newStepItems = append(newStepItems, HandoffNoteItem{
+			Agent: newAgent,
+			RawItem: responses.EasyInputMessageParam{
+				Content: responses.EasyInputMessageContentUnionParam{
+					OfString: param.NewOpt(handoff.AcknowledgementMessage),
+				},
+				Role: responses.EasyInputMessageRoleSystem,
+				Type: responses.EasyInputMessageTypeMessage,
+			},
+			Type: "handoff_note_item",
+		})
+	}
+
 	return &SingleStepResult{
 		OriginalInput: originalInput,
 		ModelResponse: newResponse,
@@ -1102,23 +1404,30 @@ func (ri runImpl) runSingleMCPApproval(
 	agent *Agent,
 	approvalRequest ToolRunMCPApprovalRequest,
 ) (RunItem, error) {
-	callback := approvalRequest.MCPTool.OnApprovalRequest
-	if callback == nil {
-		return nil, errors.New("callback is required for MCP approval requests")
+	var result MCPToolApprovalFunctionResult
+	if callback := approvalRequest.MCPTool.OnApprovalRequest; callback != nil {
+		var err error
+		result, err = callback(ctx, approvalRequest.RequestItem)
+		if err != nil {
+			return nil, err
+		}
+	} else if agent.MCPApprovalPolicy != nil {
+		result.Approve = agent.MCPApprovalPolicy.ApproveMCPToolCall(ctx, approvalRequest.RequestItem)
+	} else {
+		return nil, errors.New("callback or agent MCPApprovalPolicy is required for MCP approval requests")
 	}
 
-	result, err := callback(ctx, approvalRequest.RequestItem)
-	if err != nil {
-		return nil, err
-	}
+	return newMCPApprovalResponseItem(agent, approvalRequest.RequestItem.ID, result), nil
+}
 
+func newMCPApprovalResponseItem(agent *Agent, approvalRequestID string, result MCPToolApprovalFunctionResult) MCPApprovalResponseItem {
 	var reason param.Opt[string]
 	if !result.Approve && result.Reason != "" {
 		reason = param.NewOpt(result.Reason)
 	}
 
 	rawItem := responses.ResponseInputItemMcpApprovalResponseParam{
-		ApprovalRequestID: approvalRequest.RequestItem.ID,
+		ApprovalRequestID: approvalRequestID,
 		Approve:           result.Approve,
 		ID:                param.Opt[string]{},
 		Reason:            reason,
@@ -1129,7 +1438,7 @@ func (ri runImpl) runSingleMCPApproval(
 		Agent:   agent,
 		RawItem: rawItem,
 		Type:    "mcp_approval_response_item",
-	}, nil
+	}
 }
 
 func (ri runImpl) ExecuteFinalOutput(
@@ -1265,6 +1574,8 @@ func (runImpl) StreamStepResultToQueue(stepResult SingleStepResult, queue *async
 			event = NewRunItemStreamEvent(StreamEventMCPApprovalRequested, item)
 		case MCPListToolsItem:
 			event = NewRunItemStreamEvent(StreamEventMCPListTools, item)
+		case HandoffNoteItem:
+			// Not streamed: it is only an input for the new agent
 		// TODO: is it right not to handle MCPApprovalResponseItem here?
 		default:
 			Logger().Warn(fmt.Sprintf("Unexpected RunItem type %T", item))
@@ -1353,7 +1664,9 @@ func (ca computerAction) Execute(
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		start := time.Now()
 		output, toolError = ca.getScreenshot(ctx, action.ComputerTool.Computer, action.ToolCall)
+		logToolCall(ctx, agent, action.ComputerTool.ToolName(), start, toolError)
 		if toolError != nil {
 			cancel()
 		}
@@ -1418,34 +1731,31 @@ func (computerAction) getScreenshot(
 	comp computer.Computer,
 	toolCall responses.ResponseComputerToolCall,
 ) (string, error) {
-	action := toolCall.Action
+	action, ok := NewComputerCallAction(toolCall.Action)
+	if !ok {
+		return "", fmt.Errorf("unexpected ResponseComputerToolCallActionUnion type %q", toolCall.Action.Type)
+	}
 
 	var err error
-	switch action.Type {
-	case "click":
-		err = comp.Click(ctx, action.X, action.Y, computer.Button(action.Button))
-	case "double_click":
+	switch action := action.(type) {
+	case ComputerCallActionClick:
+		err = comp.Click(ctx, action.X, action.Y, action.Button)
+	case ComputerCallActionDoubleClick:
 		err = comp.DoubleClick(ctx, action.X, action.Y)
-	case "drag":
-		path := make([]computer.Position, len(action.Path))
-		for i, p := range action.Path {
-			path[i] = computer.Position{X: p.X, Y: p.Y}
-		}
-		err = comp.Drag(ctx, path)
-	case "keypress":
+	case ComputerCallActionDrag:
+		err = comp.Drag(ctx, action.Path)
+	case ComputerCallActionKeypress:
 		err = comp.Keypress(ctx, action.Keys)
-	case "move":
+	case ComputerCallActionMove:
 		err = comp.Move(ctx, action.X, action.Y)
-	case "screenshot":
+	case ComputerCallActionScreenshot:
 		_, err = comp.Screenshot(ctx)
-	case "scroll":
+	case ComputerCallActionScroll:
 		err = comp.Scroll(ctx, action.X, action.Y, action.ScrollX, action.ScrollY)
-	case "type":
+	case ComputerCallActionType:
 		err = comp.Type(ctx, action.Text)
-	case "wait":
+	case ComputerCallActionWait:
 		err = comp.Wait(ctx)
-	default:
-		err = fmt.Errorf("unexpected ResponseComputerToolCallActionUnion type %q", action.Type)
 	}
 	if err != nil {
 		return "", err
@@ -1499,10 +1809,29 @@ func (localShellAction) Execute(
 	}
 
 	// TODO: why this does not run concurrently with the hooks, as for other tools?
-	request := LocalShellCommandRequest{Data: call.ToolCall}
-	result, err := call.LocalShellTool.Executor(ctx, request)
-	if err != nil {
+	shellAction := call.ToolCall.Action
+	env := make([]string, 0, len(shellAction.Env))
+	for _, key := range slices.Sorted(maps.Keys(shellAction.Env)) {
+		env = append(env, key+"="+shellAction.Env[key])
+	}
+	start := time.Now()
+	stdout, stderr, exitCode, err := call.LocalShellTool.executor()(
+		ctx,
+		shellAction.Command,
+		env,
+		shellAction.WorkingDirectory,
+		int(shellAction.TimeoutMs),
+	)
+	logToolCall(ctx, agent, call.LocalShellTool.ToolName(), start, err)
+	var result string
+	var rejectedErr LocalShellCommandRejectedError
+	if errors.As(err, &rejectedErr) {
+		// Let the model know, so that it can try something else
+		result = rejectedErr.Error()
+	} else if err != nil {
 		return nil, err
+	} else {
+		result = localShellOutput(stdout, stderr, exitCode)
 	}
 
 	wg.Add(1)
@@ -1537,10 +1866,31 @@ func (localShellAction) Execute(
 		RawItem: ResponseInputItemLocalShellCallOutputParam{
 			ID:     call.ToolCall.CallID,
 			Output: result,
-			Status: "",
+			Status: "completed",
 			Type:   constant.ValueOf[constant.LocalShellCallOutput](),
 		},
 		Output: result,
 		Type:   "tool_call_output_item",
 	}, nil
 }
+
+// localShellOutput builds the output sent back to the model for a local shell
+// command: the standard output, followed by the standard error and the exit
+// code when they are relevant.
+func localShellOutput(stdout, stderr string, exitCode int) string {
+	var sb strings.Builder
+	sb.WriteString(stdout)
+	if stderr != "" {
+		if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "\n") {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(stderr)
+	}
+	if exitCode != 0 {
+		if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "\n") {
+			sb.WriteByte('\n')
+		}
+		_, _ = fmt.Fprintf(&sb, "exit code: %d", exitCode)
+	}
+	return sb.String()
+}
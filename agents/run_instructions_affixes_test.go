@@ -0,0 +1,115 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunConfigInstructionsAffixes(t *testing.T) {
+	testCases := []struct {
+		name   string
+		agent  func(*agents.Agent) *agents.Agent
+		config agents.RunConfig
+		want   param.Opt[string]
+	}{
+		{
+			name:   "prefix and suffix",
+			agent:  func(a *agents.Agent) *agents.Agent { return a.WithInstructions("Help the user.") },
+			config: agents.RunConfig{InstructionsPrefix: "Always be concise.", InstructionsSuffix: "Never swear."},
+			want:   param.NewOpt("Always be concise.\n\nHelp the user.\n\nNever swear."),
+		},
+		{
+			name:   "prefix only",
+			agent:  func(a *agents.Agent) *agents.Agent { return a.WithInstructions("Help the user.") },
+			config: agents.RunConfig{InstructionsPrefix: "Always be concise."},
+			want:   param.NewOpt("Always be concise.\n\nHelp the user."),
+		},
+		{
+			name: "dynamic instructions",
+			agent: func(a *agents.Agent) *agents.Agent {
+				return a.WithInstructionsFunc(func(context.Context, *agents.Agent) (string, error) {
+					return "Dynamic.", nil
+				})
+			},
+			config: agents.RunConfig{InstructionsSuffix: "Never swear."},
+			want:   param.NewOpt("Dynamic.\n\nNever swear."),
+		},
+		{
+			name:   "agent without instructions",
+			agent:  func(a *agents.Agent) *agents.Agent { return a },
+			config: agents.RunConfig{InstructionsPrefix: "Always be concise."},
+			want:   param.NewOpt("Always be concise."),
+		},
+		{
+			name:   "no affixes",
+			agent:  func(a *agents.Agent) *agents.Agent { return a },
+			config: agents.RunConfig{},
+			want:   param.Opt[string]{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("non streamed", func(t *testing.T) {
+				model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+					Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("ok")},
+				})
+				agent := tc.agent(agents.New("test").WithModelInstance(model))
+
+				_, err := agents.Runner{Config: tc.config}.Run(t.Context(), agent, "start")
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, model.LastTurnArgs.SystemInstructions)
+			})
+
+			t.Run("streamed", func(t *testing.T) {
+				model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+					Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("ok")},
+				})
+				agent := tc.agent(agents.New("test").WithModelInstance(model))
+
+				result, err := agents.Runner{Config: tc.config}.RunStreamed(t.Context(), agent, "start")
+				require.NoError(t, err)
+				require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+				assert.Equal(t, tc.want, model.LastTurnArgs.SystemInstructions)
+			})
+		})
+	}
+}
+
+func TestRunConfigInstructionsAffixesBeforeCallModelInputFilter(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("ok")},
+	})
+	agent := agents.New("test").WithInstructions("Help the user.").WithModelInstance(model)
+
+	var filterInstructions param.Opt[string]
+	_, err := agents.Runner{Config: agents.RunConfig{
+		InstructionsPrefix: "Always be concise.",
+		CallModelInputFilter: func(_ context.Context, data agents.CallModelData) (*agents.ModelInputData, error) {
+			filterInstructions = data.ModelData.Instructions
+			return &data.ModelData, nil
+		},
+	}}.Run(t.Context(), agent, "start")
+	require.NoError(t, err)
+	assert.Equal(t, param.NewOpt("Always be concise.\n\nHelp the user."), filterInstructions)
+}
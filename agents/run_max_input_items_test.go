@@ -0,0 +1,192 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inputItemTexts(t *testing.T, input agents.Input) []string {
+	t.Helper()
+	require.IsType(t, agents.InputItems{}, input)
+	var texts []string
+	for _, item := range input.(agents.InputItems) {
+		switch {
+		case item.OfMessage != nil:
+			texts = append(texts, item.OfMessage.Content.OfString.Value)
+		case item.OfFunctionCall != nil:
+			texts = append(texts, "call:"+item.OfFunctionCall.CallID)
+		case item.OfFunctionCallOutput != nil:
+			texts = append(texts, "output:"+item.OfFunctionCallOutput.CallID)
+		default:
+			texts = append(texts, "other")
+		}
+	}
+	return texts
+}
+
+func TestMaxInputItemsError(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	runner := agents.Runner{Config: agents.RunConfig{MaxInputItems: 2}}
+	input := agents.InputList("a", agents.AssistantMessage("b"), "c")
+
+	t.Run("non streamed", func(t *testing.T) {
+		_, err := runner.RunInputs(t.Context(), agent, input)
+		var inputErr agents.InputTooLargeError
+		require.ErrorAs(t, err, &inputErr)
+		assert.Equal(t, 3, inputErr.Items)
+		assert.Equal(t, 2, inputErr.MaxItems)
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		result, err := runner.RunInputsStreamed(t.Context(), agent, input)
+		require.NoError(t, err)
+		err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+		assert.ErrorAs(t, err, &agents.InputTooLargeError{})
+	})
+}
+
+func TestMaxInputItemsTrimOldest(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("foo", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("foo", "result"))
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		MaxInputItems:        4,
+		TrimOldestInputItems: true,
+	}}
+	input := agents.InputList(
+		agents.SystemMessage("rules"),
+		"a",
+		agents.AssistantMessage("b"),
+		"c",
+	)
+
+	result, err := runner.RunInputs(t.Context(), agent, input)
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	// The system message and the current turn are preserved
+	assert.Equal(t, []string{"rules", "c", "call:2", "output:2"}, inputItemTexts(t, model.LastTurnArgs.Input))
+}
+
+func TestMaxInputItemsTrimDropsToolCallsWithOutputs(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		MaxInputItems:        3,
+		TrimOldestInputItems: true,
+	}}
+	input := agents.InputList(
+		"a",
+		responses.ResponseInputItemParamOfFunctionCall(`{}`, "x", "foo"),
+		agents.AssistantMessage("b"),
+		responses.ResponseInputItemParamOfFunctionCallOutput("x", "result"),
+		agents.AssistantMessage("c"),
+		"d",
+	)
+
+	_, err := runner.RunInputs(t.Context(), agent, input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, inputItemTexts(t, model.LastTurnArgs.Input))
+}
+
+func TestMaxInputItemsTrimCurrentTurnTooLarge(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		MaxInputItems:        1,
+		TrimOldestInputItems: true,
+	}}
+	input := agents.InputList(agents.SystemMessage("rules"), "a", "b")
+
+	_, err := runner.RunInputs(t.Context(), agent, input)
+	var inputErr agents.InputTooLargeError
+	require.ErrorAs(t, err, &inputErr)
+	assert.Equal(t, 2, inputErr.Items)
+	assert.Equal(t, 1, inputErr.MaxItems)
+}
+
+func TestMaxInputItemsTrimDropsReasoningAndCustomToolCalls(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		MaxInputItems:        4,
+		TrimOldestInputItems: true,
+	}}
+	input := agents.InputList(
+		responses.ResponseInputItemParamOfReasoning("rs", nil),
+		responses.ResponseInputItemParamOfCustomToolCall("x", "input", "foo"),
+		responses.ResponseInputItemParamOfCustomToolCallOutput("x", "result"),
+		agents.AssistantMessage("b"),
+		"c",
+	)
+
+	_, err := runner.RunInputs(t.Context(), agent, input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, inputItemTexts(t, model.LastTurnArgs.Input))
+}
+
+func TestMaxInputItemsTrimPreservesRetrievedItems(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		MaxInputItems:        3,
+		TrimOldestInputItems: true,
+		Retriever: func(context.Context, []agents.TResponseInputItem) ([]agents.TResponseInputItem, error) {
+			return []agents.TResponseInputItem{
+				responses.ResponseInputItemParamOfMessage("doc", responses.EasyInputMessageRoleUser),
+			}, nil
+		},
+	}}
+	input := agents.InputList("a", agents.AssistantMessage("b"), "c")
+
+	_, err := runner.RunInputs(t.Context(), agent, input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc", "b", "c"}, inputItemTexts(t, model.LastTurnArgs.Input))
+}
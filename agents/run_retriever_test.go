@@ -0,0 +1,117 @@
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inputTexts(t *testing.T, input agents.Input) []string {
+	t.Helper()
+	require.IsType(t, agents.InputItems{}, input)
+	var texts []string
+	for _, item := range input.(agents.InputItems) {
+		if item.OfMessage != nil {
+			texts = append(texts, item.OfMessage.Content.OfString.Value)
+		}
+	}
+	return texts
+}
+
+func TestRetriever(t *testing.T) {
+	var retrieverInputs [][]agents.TResponseInputItem
+	retriever := func(_ context.Context, input []agents.TResponseInputItem) ([]agents.TResponseInputItem, error) {
+		retrieverInputs = append(retrieverInputs, input)
+		return []agents.TResponseInputItem{agentstesting.GetTextInputItem("retrieved-doc")}, nil
+	}
+
+	t.Run("non streamed", func(t *testing.T) {
+		retrieverInputs = nil
+		model := agentstesting.NewFakeModel(false, nil)
+		model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+			{Value: []agents.TResponseOutputItem{
+				agentstesting.GetFunctionToolCall("foo", `{}`),
+			}},
+			{Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("done"),
+			}},
+		})
+		agent := agents.New("test").
+			WithModelInstance(model).
+			WithTools(agentstesting.GetFunctionTool("foo", "foo_result"))
+
+		result, err := agents.Runner{Config: agents.RunConfig{
+			Retriever: retriever,
+		}}.Run(t.Context(), agent, "start")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+
+		// Retrieved items are prepended on each turn, without accumulating.
+		assert.Equal(t, []string{"retrieved-doc", "start"}, inputTexts(t, model.LastTurnArgs.Input))
+		assert.Len(t, model.LastTurnArgs.Input, 4)
+		require.Len(t, retrieverInputs, 2)
+		assert.Len(t, retrieverInputs[0], 1)
+		assert.Len(t, retrieverInputs[1], 3)
+
+		// They are not part of the run items.
+		toInputList := agents.InputItems(result.ToInputList())
+		assert.Equal(t, []string{"start"}, inputTexts(t, toInputList))
+		assert.Len(t, toInputList, 4)
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		retrieverInputs = nil
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+		agent := agents.New("test").WithModelInstance(model)
+
+		result, err := agents.Runner{Config: agents.RunConfig{
+			Retriever: retriever,
+		}}.RunStreamed(t.Context(), agent, "start")
+		require.NoError(t, err)
+		err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"retrieved-doc", "start"}, inputTexts(t, model.LastTurnArgs.Input))
+		assert.Len(t, retrieverInputs, 1)
+	})
+
+	t.Run("before input filter", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+		agent := agents.New("test").WithModelInstance(model)
+
+		var filterInput []agents.TResponseInputItem
+		_, err := agents.Runner{Config: agents.RunConfig{
+			Retriever: retriever,
+			CallModelInputFilter: func(_ context.Context, data agents.CallModelData) (*agents.ModelInputData, error) {
+				filterInput = data.ModelData.Input
+				return &data.ModelData, nil
+			},
+		}}.Run(t.Context(), agent, "start")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"retrieved-doc", "start"}, inputTexts(t, agents.InputItems(filterInput)))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		})
+		agent := agents.New("test").WithModelInstance(model)
+
+		retrieverError := errors.New("retriever error")
+		_, err := agents.Runner{Config: agents.RunConfig{
+			Retriever: func(context.Context, []agents.TResponseInputItem) ([]agents.TResponseInputItem, error) {
+				return nil, retrieverError
+			},
+		}}.Run(t.Context(), agent, "start")
+		require.ErrorIs(t, err, retrieverError)
+	})
+}
@@ -529,6 +529,92 @@ func TestComputerToolCallWithComputerToolParsedCorrectly(t *testing.T) {
 	}, result.ComputerActions)
 }
 
+func TestComputerToolCallProducesStructuredAction(t *testing.T) {
+	// Each ResponseComputerToolCall is parsed into a ToolCallItem holding the
+	// structured representation of its action.
+	agent := &Agent{
+		Name:  "test",
+		Tools: []Tool{ComputerTool{Computer: DummyComputer{}}},
+	}
+	testCases := []struct {
+		action responses.ResponseOutputItemUnionAction
+		want   ComputerCallAction
+	}{
+		{
+			action: responses.ResponseOutputItemUnionAction{Type: "click", X: 1, Y: 2, Button: "right"},
+			want:   ComputerCallActionClick{X: 1, Y: 2, Button: computer.ButtonRight},
+		},
+		{
+			action: responses.ResponseOutputItemUnionAction{Type: "double_click", X: 3, Y: 4},
+			want:   ComputerCallActionDoubleClick{X: 3, Y: 4},
+		},
+		{
+			action: responses.ResponseOutputItemUnionAction{
+				Type: "drag",
+				Path: []responses.ResponseComputerToolCallActionDragPath{{X: 1, Y: 2}, {X: 3, Y: 4}},
+			},
+			want: ComputerCallActionDrag{Path: []computer.Position{{X: 1, Y: 2}, {X: 3, Y: 4}}},
+		},
+		{
+			action: responses.ResponseOutputItemUnionAction{Type: "keypress", Keys: []string{"ctrl", "c"}},
+			want:   ComputerCallActionKeypress{Keys: []string{"ctrl", "c"}},
+		},
+		{
+			action: responses.ResponseOutputItemUnionAction{Type: "move", X: 5, Y: 6},
+			want:   ComputerCallActionMove{X: 5, Y: 6},
+		},
+		{
+			action: responses.ResponseOutputItemUnionAction{Type: "screenshot"},
+			want:   ComputerCallActionScreenshot{},
+		},
+		{
+			action: responses.ResponseOutputItemUnionAction{Type: "scroll", X: 7, Y: 8, ScrollX: 0, ScrollY: 100},
+			want:   ComputerCallActionScroll{X: 7, Y: 8, ScrollX: 0, ScrollY: 100},
+		},
+		{
+			action: responses.ResponseOutputItemUnionAction{Type: "type", Text: "hello"},
+			want:   ComputerCallActionType{Text: "hello"},
+		},
+		{
+			action: responses.ResponseOutputItemUnionAction{Type: "wait"},
+			want:   ComputerCallActionWait{},
+		},
+		{
+			action: responses.ResponseOutputItemUnionAction{Type: "unknown"},
+			want:   nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.action.Type, func(t *testing.T) {
+			response := ModelResponse{
+				Output: []TResponseOutputItem{{ // responses.ResponseComputerToolCall
+					ID:     "c1",
+					Type:   "computer_call",
+					Action: tc.action,
+					CallID: "c1",
+					Status: "completed",
+				}},
+				Usage: usage.NewUsage(),
+			}
+			allTools, err := agent.GetAllTools(t.Context())
+			require.NoError(t, err)
+			result, err := RunImpl().ProcessModelResponse(
+				t.Context(),
+				agent,
+				allTools,
+				response,
+				nil,
+			)
+			require.NoError(t, err)
+			require.Len(t, result.NewItems, 1)
+			require.IsType(t, ToolCallItem{}, result.NewItems[0])
+			item := result.NewItems[0].(ToolCallItem)
+			assert.IsType(t, ResponseComputerToolCall{}, item.RawItem)
+			assert.Equal(t, tc.want, item.ComputerCallAction)
+		})
+	}
+}
+
 func TestToolAndHandoffParsedCorrectly(t *testing.T) {
 	agent1 := &Agent{Name: "test_1"}
 	agent2 := &Agent{Name: "test_2"}
@@ -44,3 +44,49 @@ func TestRunAddsUsageToExistingContext(t *testing.T) {
 	assert.Equal(t, uint64(3), tracker.OutputTokens)
 	assert.Equal(t, uint64(8), tracker.TotalTokens)
 }
+
+type namedModelsProvider map[string]agents.Model
+
+func (p namedModelsProvider) GetModel(modelName string) (agents.Model, error) {
+	return p[modelName], nil
+}
+
+func TestRunTracksUsagePerModel(t *testing.T) {
+	modelA := agentstesting.NewFakeModel(false, nil)
+	modelA.SetHardcodedUsage(usage.Usage{InputTokens: 10, OutputTokens: 2, TotalTokens: 12})
+	modelB := agentstesting.NewFakeModel(false, nil)
+	modelB.SetHardcodedUsage(usage.Usage{InputTokens: 20, OutputTokens: 5, TotalTokens: 25})
+
+	agentB := agents.New("agent_b").WithModel("model-b")
+	agentA := agents.New("agent_a").WithModel("model-a").WithAgentHandoffs(agentB)
+
+	modelA.SetNextOutput(agentstesting.FakeModelTurnOutput{Value: []agents.TResponseOutputItem{
+		agentstesting.GetHandoffToolCall(agentB, "", ""),
+	}})
+	modelB.SetNextOutput(agentstesting.FakeModelTurnOutput{Value: []agents.TResponseOutputItem{
+		agentstesting.GetTextMessage("done"),
+	}})
+
+	tracker := usage.NewUsage()
+	ctx := usage.NewContext(t.Context(), tracker)
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		ModelProvider: namedModelsProvider{"model-a": modelA, "model-b": modelB},
+	}}
+	result, err := runner.Run(ctx, agentA, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	assert.Equal(t, uint64(2), tracker.Requests)
+	assert.Equal(t, uint64(30), tracker.InputTokens)
+	assert.Equal(t, uint64(7), tracker.OutputTokens)
+	assert.Equal(t, uint64(37), tracker.TotalTokens)
+
+	assert.Equal(t, []string{"model-a", "model-b"}, tracker.ModelNames())
+	assert.Equal(t, usage.ModelUsage{
+		Requests: 1, InputTokens: 10, OutputTokens: 2, TotalTokens: 12,
+	}, tracker.PerModel["model-a"])
+	assert.Equal(t, usage.ModelUsage{
+		Requests: 1, InputTokens: 20, OutputTokens: 5, TotalTokens: 25,
+	}, tracker.PerModel["model-b"])
+}
@@ -174,7 +174,8 @@ func TestAgentSession(t *testing.T) {
 			})
 
 			t.Run("cannot use both session and list input items", func(t *testing.T) {
-				// Test that passing both a session and list input raises a UserError.
+				// Test that passing both a session and list input with an assistant
+				// message raises a UserError.
 				session, err := memory.NewSQLiteSession(t.Context(), memory.SQLiteSessionParams{
 					SessionID:        "test",
 					DBDataSourceName: filepath.Join(t.TempDir(), "test.db"),
@@ -185,7 +186,8 @@ func TestAgentSession(t *testing.T) {
 				model := agentstesting.NewFakeModel(false, nil)
 				agent := agents.New("test").WithModelInstance(model)
 
-				// Test that providing both a session and a list input raises a UserError
+				// Test that providing both a session and a list input with items other
+				// than new messages raises a UserError
 				model.SetNextOutput(agentstesting.FakeModelTurnOutput{
 					Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("This shouldn't run")},
 				})
@@ -196,6 +198,11 @@ func TestAgentSession(t *testing.T) {
 						Role:    responses.EasyInputMessageRoleUser,
 						Type:    responses.EasyInputMessageTypeMessage,
 					}},
+					{OfMessage: &responses.EasyInputMessageParam{
+						Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt("Test answer")},
+						Role:    responses.EasyInputMessageRoleAssistant,
+						Type:    responses.EasyInputMessageTypeMessage,
+					}},
 				}
 
 				runner := agents.Runner{
@@ -219,6 +226,80 @@ func TestAgentSession(t *testing.T) {
 				assert.ErrorContains(t, finalError, "Cannot provide both a session and a list of input items")
 				assert.ErrorContains(t, finalError, "manually manage conversation history")
 			})
+
+			t.Run("list input of new messages", func(t *testing.T) {
+				// Test that a list input only made of new messages is appended to the
+				// session history.
+				session, err := memory.NewSQLiteSession(t.Context(), memory.SQLiteSessionParams{
+					SessionID:        "test",
+					DBDataSourceName: filepath.Join(t.TempDir(), "test.db"),
+				})
+				require.NoError(t, err)
+				t.Cleanup(func() { assert.NoError(t, session.Close()) })
+
+				model := agentstesting.NewFakeModel(false, nil)
+				agent := agents.New("test").WithModelInstance(model)
+
+				model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+					Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("Hello")},
+				})
+				runAgent(t, streaming, session, agent, "Hi there")
+
+				listInput := agents.InputItems{
+					{OfMessage: &responses.EasyInputMessageParam{
+						Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt("Be brief")},
+						Role:    responses.EasyInputMessageRoleDeveloper,
+						Type:    responses.EasyInputMessageTypeMessage,
+					}},
+					{OfMessage: &responses.EasyInputMessageParam{
+						Content: responses.EasyInputMessageContentUnionParam{OfString: param.NewOpt("Describe this")},
+						Role:    responses.EasyInputMessageRoleUser,
+						Type:    responses.EasyInputMessageTypeMessage,
+					}},
+					{OfInputMessage: &responses.ResponseInputItemMessageParam{
+						Content: responses.ResponseInputMessageContentListParam{{
+							OfInputImage: &responses.ResponseInputImageParam{
+								ImageURL: param.NewOpt("https://example.com/image.png"),
+								Detail:   responses.ResponseInputImageDetailAuto,
+							},
+						}},
+						Role: "user",
+						Type: "message",
+					}},
+				}
+
+				model.SetNextOutput(agentstesting.FakeModelTurnOutput{
+					Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("A cat")},
+				})
+				runner := agents.Runner{
+					Config: agents.RunConfig{
+						Session: session,
+					},
+				}
+				if streaming {
+					result, err := runner.RunInputsStreamed(t.Context(), agent, listInput)
+					require.NoError(t, err)
+					require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+					assert.Equal(t, "A cat", result.FinalOutput())
+				} else {
+					result, err := runner.RunInputs(t.Context(), agent, listInput)
+					require.NoError(t, err)
+					assert.Equal(t, "A cat", result.FinalOutput)
+				}
+
+				// History (2 items) followed by the new messages
+				lastInput := model.LastTurnArgs.Input
+				require.IsType(t, agents.InputItems{}, lastInput)
+				require.Len(t, lastInput.(agents.InputItems), 5)
+				assert.Equal(t, "Hi there", lastInput.(agents.InputItems)[0].OfMessage.Content.OfString.Value)
+				assert.Equal(t, "Be brief", lastInput.(agents.InputItems)[2].OfMessage.Content.OfString.Value)
+				assert.NotNil(t, lastInput.(agents.InputItems)[4].OfInputMessage)
+
+				// The new messages are saved to the session too
+				items, err := session.GetItems(t.Context(), 0)
+				require.NoError(t, err)
+				assert.Len(t, items, 6)
+			})
 		})
 	}
 }
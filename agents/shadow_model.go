@@ -0,0 +1,180 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/tracing"
+)
+
+// ShadowModelResponse is the response of RunConfig.ShadowModel for one turn
+// of the run, recorded for offline comparison with the primary model.
+type ShadowModelResponse struct {
+	// The turn of the run, starting from 1.
+	Turn uint64
+
+	// The name of the agent running the turn.
+	AgentName string
+
+	// The response of the shadow model, nil if Error is set.
+	Response *ModelResponse
+
+	// The error returned by the shadow model, if any. Errors of the shadow
+	// model never make the run fail.
+	Error error
+}
+
+// DefaultShadowModelTimeout is the default value of RunConfig.ShadowModelTimeout.
+const DefaultShadowModelTimeout = 30 * time.Second
+
+var errShadowModelTimeout = errors.New("shadow model call not completed by the end of the run")
+
+// shadowModelCalls tracks the RunConfig.ShadowModel calls of a run. The calls
+// never block the turns: they are only waited for at the end of the run.
+type shadowModelCalls struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu    sync.Mutex
+	calls []*shadowModelCall
+}
+
+type shadowModelCall struct {
+	result ShadowModelResponse
+	done   chan struct{}
+}
+
+type shadowModelCallsKey struct{}
+
+// contextWithShadowModelCalls returns a copy of ctx carrying a new tracker of
+// the shadow model calls of the run. The calls are not canceled along with
+// ctx, but by shadowModelCalls.wait, and they don't share the tracing scope
+// of the run, which keeps changing while they are running.
+func contextWithShadowModelCalls(ctx context.Context) (context.Context, *shadowModelCalls) {
+	callsCtx := tracing.ContextWithClonedOrNewScope(context.WithoutCancel(ctx))
+	callsCtx, cancel := context.WithCancel(callsCtx)
+	calls := &shadowModelCalls{ctx: callsCtx, cancel: cancel}
+	return context.WithValue(ctx, shadowModelCallsKey{}, calls), calls
+}
+
+func shadowModelCallsFromContext(ctx context.Context) *shadowModelCalls {
+	calls, _ := ctx.Value(shadowModelCallsKey{}).(*shadowModelCalls)
+	return calls
+}
+
+// wait waits up to timeout for the pending calls, cancels those still running
+// and returns the responses, in the order the calls were made. Uncompleted
+// calls are reported with an error.
+func (c *shadowModelCalls) wait(timeout time.Duration) []ShadowModelResponse {
+	defer c.cancel()
+
+	c.mu.Lock()
+	calls := slices.Clone(c.calls)
+	c.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	expired := false
+	responses := make([]ShadowModelResponse, len(calls))
+	for i, call := range calls {
+		if !expired {
+			select {
+			case <-call.done:
+			case <-timer.C:
+				expired = true
+			}
+		}
+		select {
+		case <-call.done:
+			responses[i] = call.result
+		default:
+			responses[i] = ShadowModelResponse{
+				Turn:      call.result.Turn,
+				AgentName: call.result.AgentName,
+				Error:     errShadowModelTimeout,
+			}
+		}
+	}
+	return responses
+}
+
+// startShadowModel calls RunConfig.ShadowModel in background with the same
+// params given to the primary model, if a shadow model is configured. The
+// call is tracked by the shadowModelCalls of ctx.
+func (r Runner) startShadowModel(
+	ctx context.Context,
+	agent *Agent,
+	runConfig RunConfig,
+	params ModelResponseParams,
+	turn uint64,
+) {
+	calls := shadowModelCallsFromContext(ctx)
+	if !runConfig.ShadowModel.Valid() || calls == nil {
+		return
+	}
+
+	call := &shadowModelCall{
+		result: ShadowModelResponse{
+			Turn:      turn,
+			AgentName: agent.Name,
+		},
+		done: make(chan struct{}),
+	}
+	calls.mu.Lock()
+	calls.calls = append(calls.calls, call)
+	calls.mu.Unlock()
+
+	// The shadow call is not part of the run, so it is not traced. It is
+	// stateless too, as the previous response is the primary model's one.
+	params.Tracing = ModelTracingDisabled
+	params.PreviousResponseID = ""
+
+	ctx = tracing.ContextWithClonedOrNewScope(calls.ctx)
+	go func() {
+		defer close(call.done)
+
+		result := &call.result
+		model, err := r.getShadowModel(runConfig)
+		if err != nil {
+			result.Error = fmt.Errorf("failed to get shadow model: %w", err)
+		} else {
+			result.Response, result.Error = model.GetResponse(ctx, params)
+		}
+		if result.Error != nil {
+			Logger().Warn("Shadow model error", slog.String("error", result.Error.Error()))
+		}
+	}()
+}
+
+func (r Runner) getShadowModel(runConfig RunConfig) (Model, error) {
+	shadowModel := runConfig.ShadowModel.Value
+	if v, ok := shadowModel.SafeModel(); ok {
+		return v, nil
+	}
+
+	modelProvider := runConfig.ModelProvider
+	if modelProvider == nil {
+		modelProvider = NewMultiProvider(NewMultiProviderParams{})
+	}
+	return modelProvider.GetModel(shadowModel.ModelName())
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncedModel serializes the calls to a FakeModel, as the shadow model calls
+// of different turns may overlap.
+type syncedModel struct {
+	mu sync.Mutex
+	*agentstesting.FakeModel
+}
+
+func (m *syncedModel) GetResponse(ctx context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.FakeModel.GetResponse(ctx, params)
+}
+
+func shadowModelTestSetup() (*agents.Agent, *agentstesting.FakeModel, *syncedModel) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("foo", `{}`),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("primary"),
+		}},
+	})
+
+	shadowModel := agentstesting.NewFakeModel(false, nil)
+	shadowModel.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("shadow 1"),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("shadow 2"),
+		}},
+	})
+
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("foo", "foo_result"))
+	return agent, model, &syncedModel{FakeModel: shadowModel}
+}
+
+func assertShadowResponses(t *testing.T, shadowResponses []agents.ShadowModelResponse) {
+	t.Helper()
+	require.Len(t, shadowResponses, 2)
+	for i, want := range []string{"shadow 1", "shadow 2"} {
+		shadowResponse := shadowResponses[i]
+		assert.Equal(t, uint64(i+1), shadowResponse.Turn)
+		assert.Equal(t, "test", shadowResponse.AgentName)
+		require.NoError(t, shadowResponse.Error)
+		require.NotNil(t, shadowResponse.Response)
+		require.Len(t, shadowResponse.Response.Output, 1)
+		text, ok := agents.ItemHelpers().ExtractLastText(shadowResponse.Response.Output[0])
+		require.True(t, ok)
+		assert.Equal(t, want, text)
+	}
+}
+
+func TestShadowModel(t *testing.T) {
+	agent, model, shadowModel := shadowModelTestSetup()
+
+	result, err := agents.Runner{Config: agents.RunConfig{
+		ShadowModel: param.NewOpt(agents.NewAgentModel(shadowModel)),
+	}}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+
+	// Only the primary model's output is used.
+	assert.Equal(t, "primary", result.FinalOutput)
+	assert.Len(t, result.RawResponses, 2)
+	assertShadowResponses(t, result.ShadowResponses)
+
+	// The shadow model receives the same input as the primary model.
+	assert.Equal(t, model.LastTurnArgs.Input, shadowModel.LastTurnArgs.Input)
+}
+
+func TestShadowModelStreamed(t *testing.T) {
+	agent, model, shadowModel := shadowModelTestSetup()
+
+	result, err := agents.Runner{Config: agents.RunConfig{
+		ShadowModel: param.NewOpt(agents.NewAgentModel(shadowModel)),
+	}}.RunStreamed(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+
+	assert.Equal(t, "primary", result.FinalOutput())
+	assertShadowResponses(t, result.ShadowResponses())
+	assert.Equal(t, model.LastTurnArgs.Input, shadowModel.LastTurnArgs.Input)
+}
+
+func TestShadowModelErrorDoesNotAffectRun(t *testing.T) {
+	agent, _, _ := shadowModelTestSetup()
+
+	shadowErr := errors.New("shadow error")
+	shadowModel := &syncedModel{
+		FakeModel: agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{Error: shadowErr}),
+	}
+
+	result, err := agents.Runner{Config: agents.RunConfig{
+		ShadowModel: param.NewOpt(agents.NewAgentModel(shadowModel)),
+	}}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "primary", result.FinalOutput)
+
+	require.Len(t, result.ShadowResponses, 2)
+	assert.ErrorIs(t, result.ShadowResponses[0].Error, shadowErr)
+	assert.Nil(t, result.ShadowResponses[0].Response)
+	assert.NoError(t, result.ShadowResponses[1].Error)
+}
+
+func TestNoShadowModel(t *testing.T) {
+	agent, _, _ := shadowModelTestSetup()
+
+	result, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "primary", result.FinalOutput)
+	assert.Empty(t, result.ShadowResponses)
+}
+
+func TestShadowModelStateless(t *testing.T) {
+	agent, model, shadowModel := shadowModelTestSetup()
+
+	_, err := agents.Runner{Config: agents.RunConfig{
+		ShadowModel:        param.NewOpt(agents.NewAgentModel(shadowModel)),
+		PreviousResponseID: "resp_primary",
+	}}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, model.LastTurnArgs.PreviousResponseID)
+	assert.Empty(t, shadowModel.LastTurnArgs.PreviousResponseID)
+}
+
+// stuckShadowModel is a Model which only returns once its context is done.
+type stuckShadowModel struct {
+	*agentstesting.FakeModel
+}
+
+func (stuckShadowModel) GetResponse(ctx context.Context, _ agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestShadowModelTimeout(t *testing.T) {
+	agent, _, _ := shadowModelTestSetup()
+
+	// The turns are not blocked by the pending shadow calls, which are only
+	// waited for at the end of the run.
+	result, err := agents.Runner{Config: agents.RunConfig{
+		ShadowModel:        param.NewOpt(agents.NewAgentModel(stuckShadowModel{})),
+		ShadowModelTimeout: 20 * time.Millisecond,
+	}}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "primary", result.FinalOutput)
+
+	require.Len(t, result.ShadowResponses, 2)
+	for i, shadowResponse := range result.ShadowResponses {
+		assert.Equal(t, uint64(i+1), shadowResponse.Turn)
+		assert.Nil(t, shadowResponse.Response)
+		assert.ErrorContains(t, shadowResponse.Error, "not completed")
+	}
+}
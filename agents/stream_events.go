@@ -78,3 +78,79 @@ type AgentUpdatedStreamEvent struct {
 }
 
 func (AgentUpdatedStreamEvent) isStreamEvent() {}
+
+// PartialOutputStreamEvent is an event carrying the partial structured output
+// of the agent, parsed from the text received so far. It is only emitted when
+// RunConfig.EmitPartialStructuredOutput is enabled.
+//
+// The last event of a run carries the final output.
+type PartialOutputStreamEvent struct {
+	// The partial output value, with only the complete fields filled in.
+	Value any
+
+	// Always `partial_output_stream_event`.
+	Type string
+}
+
+func (PartialOutputStreamEvent) isStreamEvent() {}
+
+// RunStoppedEarlyStreamEvent is the last event of a run stopped upon reaching
+// the maximum number of turns, when RunConfig.StopOnMaxTurns is enabled.
+type RunStoppedEarlyStreamEvent struct {
+	// The maximum number of turns which was reached.
+	MaxTurns uint64
+
+	// Always `run_stopped_early_stream_event`.
+	Type string
+}
+
+func (RunStoppedEarlyStreamEvent) isStreamEvent() {}
+
+// ReasoningSummaryStreamEvent is a streaming event carrying a chunk of the
+// summary of the model's reasoning, emitted by reasoning models when a
+// summary is requested (see modelsettings.ModelSettings.Reasoning), e.g. to
+// show the model's thinking in a UI. The complete reasoning is then reported
+// by a RunItemStreamEvent named StreamEventReasoningItemCreated.
+type ReasoningSummaryStreamEvent struct {
+	// The text delta of the reasoning summary.
+	Delta string
+
+	// The ID of the reasoning item the summary belongs to.
+	ItemID string
+
+	// The index of the summary part the delta belongs to, within the
+	// reasoning item.
+	SummaryIndex int64
+
+	// Always `reasoning_summary_stream_event`.
+	Type string
+}
+
+func (ReasoningSummaryStreamEvent) isStreamEvent() {}
+
+// NestedAgentStreamEvent wraps a streaming event of an agent running as a
+// tool (see Agent.AsToolStreamed), forwarded to the stream of the outer run,
+// e.g. to show the progress of nested agents in a UI.
+//
+// Only run item events are forwarded, together with the nested events of
+// deeper agents, so that multiple levels of nesting are preserved.
+type NestedAgentStreamEvent struct {
+	// The name of the tool running the nested agent.
+	ToolName string
+
+	// The ID of the tool call running the nested agent, if known.
+	ToolCallID string
+
+	// The name of the nested agent which generated the event. It differs
+	// from the agent behind the tool after a handoff in the nested run.
+	AgentName string
+
+	// The event of the nested run, either a RunItemStreamEvent or another
+	// NestedAgentStreamEvent.
+	Event StreamEvent
+
+	// Always `nested_agent_stream_event`.
+	Type string
+}
+
+func (NestedAgentStreamEvent) isStreamEvent() {}
@@ -0,0 +1,201 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SSEOptions configures StreamToSSE.
+type SSEOptions struct {
+	// The Runner used to run the agent. If nil, DefaultRunner is used.
+	Runner *Runner
+
+	// Optional function which returns the name of the SSE event for a stream
+	// event. If nil, DefaultSSEEventName is used.
+	EventName func(StreamEvent) string
+}
+
+// SSE event names used by StreamToSSE, in addition to the names of the
+// stream events.
+const (
+	// SSEEventDone is the name of the last event of a successful run. Its
+	// data is a JSON object with the final output of the run:
+	// {"final_output": ...}.
+	SSEEventDone = "done"
+
+	// SSEEventError is the name of the last event of a failed run. Its
+	// data is a JSON object with the error message: {"error": "..."}.
+	SSEEventError = "error"
+)
+
+// DefaultSSEEventName returns the name of the SSE event for a stream event,
+// which is the value of its Type field, e.g. "raw_response_event",
+// "run_item_stream_event" or "agent_updated_stream_event".
+func DefaultSSEEventName(event StreamEvent) string {
+	switch e := event.(type) {
+	case RawResponsesStreamEvent:
+		return e.Type
+	case RunItemStreamEvent:
+		return e.Type
+	case AgentUpdatedStreamEvent:
+		return e.Type
+	case PartialOutputStreamEvent:
+		return e.Type
+	case RunStoppedEarlyStreamEvent:
+		return e.Type
+	case ReasoningSummaryStreamEvent:
+		return e.Type
+	case NestedAgentStreamEvent:
+		return e.Type
+	default:
+		return "message"
+	}
+}
+
+// StreamToSSE runs the agent in streaming mode and writes each stream event
+// to w as a Server-Sent Event, whose name is given by SSEOptions.EventName and
+// whose data is the JSON serialization of the event (see
+// MarshalStreamEventJSON). The response is flushed after each event.
+//
+// The run ends with a SSEEventDone event or, if the run fails after the
+// response has been started, with a SSEEventError event; the error is
+// returned in any case.
+//
+// The run uses the context of r, and it is cancelled as soon as the client
+// disconnects or writing to w fails.
+func StreamToSSE(w http.ResponseWriter, r *http.Request, agent *Agent, input string, opts SSEOptions) error {
+	runner := opts.Runner
+	if runner == nil {
+		runner = &DefaultRunner
+	}
+	eventName := opts.EventName
+	if eventName == nil {
+		eventName = DefaultSSEEventName
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	result, err := runner.RunStreamed(ctx, agent, input)
+	if err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, result.Cancel)
+	defer stop()
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	writeEvent := func(name string, data []byte) error {
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	err = result.StreamEvents(func(event StreamEvent) error {
+		data, err := MarshalStreamEventJSON(event)
+		if err != nil {
+			return err
+		}
+		return writeEvent(eventName(event), data)
+	})
+	if err == nil {
+		err = r.Context().Err()
+	}
+	if err != nil {
+		result.Cancel()
+		if r.Context().Err() == nil {
+			data, _ := json.Marshal(map[string]string{"error": err.Error()})
+			_ = writeEvent(SSEEventError, data)
+		}
+		return err
+	}
+
+	data, err := json.Marshal(map[string]any{"final_output": result.FinalOutput()})
+	if err != nil {
+		return err
+	}
+	return writeEvent(SSEEventDone, data)
+}
+
+// MarshalStreamEventJSON serializes a stream event to JSON, as a JSON object
+// with a "type" field holding the type of the event, and the fields of the
+// event in snake case.
+//
+// Agents are represented by their names, and run items by their
+// representation as input items.
+func MarshalStreamEventJSON(event StreamEvent) ([]byte, error) {
+	payload, err := streamEventPayload(event)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(payload)
+}
+
+func streamEventPayload(event StreamEvent) (any, error) {
+	switch e := event.(type) {
+	case RawResponsesStreamEvent:
+		data := json.RawMessage(e.Data.RawJSON())
+		if len(data) == 0 {
+			var err error
+			if data, err = json.Marshal(e.Data); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]any{"type": e.Type, "data": data}, nil
+	case RunItemStreamEvent:
+		return map[string]any{"type": e.Type, "name": e.Name, "item": e.Item.ToInputItem()}, nil
+	case AgentUpdatedStreamEvent:
+		var agentName string
+		if e.NewAgent != nil {
+			agentName = e.NewAgent.Name
+		}
+		return map[string]any{"type": e.Type, "agent": agentName}, nil
+	case PartialOutputStreamEvent:
+		return map[string]any{"type": e.Type, "value": e.Value}, nil
+	case RunStoppedEarlyStreamEvent:
+		return map[string]any{"type": e.Type, "max_turns": e.MaxTurns}, nil
+	case ReasoningSummaryStreamEvent:
+		return map[string]any{
+			"type":          e.Type,
+			"delta":         e.Delta,
+			"item_id":       e.ItemID,
+			"summary_index": e.SummaryIndex,
+		}, nil
+	case NestedAgentStreamEvent:
+		nested, err := streamEventPayload(e.Event)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"type":         e.Type,
+			"tool_name":    e.ToolName,
+			"tool_call_id": e.ToolCallID,
+			"agent_name":   e.AgentName,
+			"event":        nested,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected StreamEvent type %T", event)
+	}
+}
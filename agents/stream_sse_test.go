@@ -0,0 +1,150 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sseFrame struct {
+	event string
+	data  string
+}
+
+func readSSEFrames(t *testing.T, body string) []sseFrame {
+	t.Helper()
+	var frames []sseFrame
+	var current sseFrame
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			frames = append(frames, current)
+			current = sseFrame{}
+		case strings.HasPrefix(line, "event: "):
+			current.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		default:
+			t.Fatalf("unexpected SSE line %q", line)
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return frames
+}
+
+func TestStreamToSSE(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("hello")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := agents.StreamToSSE(w, r, agent, "hi", agents.SSEOptions{})
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	var body strings.Builder
+	_, err = bufio.NewReader(resp.Body).WriteTo(&body)
+	require.NoError(t, err)
+	frames := readSSEFrames(t, body.String())
+
+	var names []string
+	for _, frame := range frames {
+		names = append(names, frame.event)
+	}
+	assert.Equal(t, "agent_updated_stream_event", names[0])
+	assert.Contains(t, names, "raw_response_event")
+	assert.Contains(t, names, "run_item_stream_event")
+	assert.Equal(t, agents.SSEEventDone, names[len(names)-1])
+
+	assert.JSONEq(t, `{"type":"agent_updated_stream_event","agent":"test"}`, frames[0].data)
+	assert.JSONEq(t, `{"final_output":"hello"}`, frames[len(frames)-1].data)
+
+	for _, frame := range frames {
+		if frame.event == "run_item_stream_event" {
+			assert.Contains(t, frame.data, `"name":"message_output_created"`)
+			assert.Contains(t, frame.data, `"text":"hello"`)
+		}
+	}
+}
+
+func TestStreamToSSECustomEventNames(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("hello")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	eventName := func(event agents.StreamEvent) string {
+		switch e := event.(type) {
+		case agents.RunItemStreamEvent:
+			return string(e.Name)
+		case agents.AgentUpdatedStreamEvent:
+			return "agent"
+		default:
+			return "raw"
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	err := agents.StreamToSSE(w, r, agent, "hi", agents.SSEOptions{EventName: eventName})
+	require.NoError(t, err)
+	assert.True(t, w.Flushed)
+
+	var names []string
+	for _, frame := range readSSEFrames(t, w.Body.String()) {
+		names = append(names, frame.event)
+	}
+	assert.Equal(t, "agent", names[0])
+	assert.Contains(t, names, "message_output_created")
+	assert.Equal(t, agents.SSEEventDone, names[len(names)-1])
+}
+
+func TestStreamToSSEClientDisconnected(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("hello")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	err := agents.StreamToSSE(w, r, agent, "hi", agents.SSEOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotContains(t, w.Body.String(), "event: "+agents.SSEEventDone)
+}
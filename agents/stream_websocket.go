@@ -0,0 +1,219 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
+)
+
+const (
+	DefaultWebSocketPingInterval = 30 * time.Second
+	DefaultWebSocketPongWait     = 60 * time.Second
+	DefaultWebSocketWriteWait    = 10 * time.Second
+)
+
+// WebSocketOptions configures ServeWebSocket.
+type WebSocketOptions struct {
+	// The Runner used to run the agent. If nil, DefaultRunner is used.
+	//
+	// If the Runner has a Session, the conversation history is kept by the
+	// session, otherwise it is kept in memory for the lifetime of the
+	// connection.
+	Runner *Runner
+
+	// How often a ping is sent to the client.
+	// Default: DefaultWebSocketPingInterval.
+	PingInterval time.Duration
+
+	// How long to wait for any message from the client, pongs included,
+	// before considering the connection dead.
+	// Default: DefaultWebSocketPongWait.
+	PongWait time.Duration
+
+	// The time allowed to write a message to the client.
+	// Default: DefaultWebSocketWriteWait.
+	WriteWait time.Duration
+}
+
+// WebSocketUserMessage is a message sent by the client to ServeWebSocket to
+// start a new turn of the conversation.
+type WebSocketUserMessage struct {
+	// Always `user_message`.
+	Type string `json:"type"`
+
+	// The text of the user message.
+	Content string `json:"content"`
+}
+
+// ServeWebSocket runs a conversation with the agent over a WebSocket
+// connection, until the client closes the connection or ctx is done.
+//
+// Each WebSocketUserMessage received from the client starts a streamed run,
+// whose events are sent to the client as JSON text messages (see
+// MarshalStreamEventJSON). Each run ends with a message of type "turn_done",
+// carrying the final output, or a message of type "error", carrying the
+// error message; the conversation continues in both cases, with the last
+// agent of a successful run taking the next turn. Messages received during a
+// run are processed afterward, in order.
+//
+// The connection is kept alive with pings, and the running turn is cancelled
+// as soon as the connection is lost. It returns nil when the client closes
+// the connection normally.
+func ServeWebSocket(ctx context.Context, conn *websocket.Conn, agent *Agent, opts WebSocketOptions) error {
+	runner := opts.Runner
+	if runner == nil {
+		runner = &DefaultRunner
+	}
+	pingInterval := cmp.Or(opts.PingInterval, DefaultWebSocketPingInterval)
+	pongWait := cmp.Or(opts.PongWait, DefaultWebSocketPongWait)
+	writeWait := cmp.Or(opts.WriteWait, DefaultWebSocketWriteWait)
+
+	parentCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// gorilla/websocket supports one concurrent reader and one concurrent
+	// writer, while WriteControl can be called from any goroutine.
+	// The reader stops, and stops the whole connection, as soon as reading
+	// fails or ctx is done.
+	messages := make(chan []byte, 16)
+	var readErr error
+	go func() {
+		defer cancel()
+		defer close(messages)
+
+		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongWait))
+		})
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				readErr = err
+				return
+			}
+			_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+			select {
+			case messages <- message:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				// Unblock the reader.
+				_ = conn.UnderlyingConn().SetReadDeadline(time.Now())
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+					cancel()
+				}
+			}
+		}
+	}()
+
+	writeJSON := func(v any) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+		return conn.WriteJSON(v)
+	}
+
+	var history []TResponseInputItem
+	for message := range messages {
+		if ctx.Err() != nil {
+			continue
+		}
+
+		var userMessage WebSocketUserMessage
+		if err := json.Unmarshal(message, &userMessage); err != nil || userMessage.Type != "user_message" {
+			if err == nil {
+				err = fmt.Errorf("unexpected message type %q", userMessage.Type)
+			}
+			if err = writeJSON(map[string]string{"type": "error", "error": err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		input := []TResponseInputItem{{
+			OfMessage: &responses.EasyInputMessageParam{
+				Content: responses.EasyInputMessageContentUnionParam{
+					OfString: param.NewOpt(userMessage.Content),
+				},
+				Role: responses.EasyInputMessageRoleUser,
+				Type: responses.EasyInputMessageTypeMessage,
+			},
+		}}
+		if runner.Config.Session == nil {
+			input = append(slices.Clone(history), input...)
+		}
+
+		result, err := runner.RunInputsStreamed(ctx, agent, input)
+		if err == nil {
+			err = result.StreamEvents(func(event StreamEvent) error {
+				data, err := MarshalStreamEventJSON(event)
+				if err != nil {
+					return err
+				}
+				_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+				return conn.WriteMessage(websocket.TextMessage, data)
+			})
+		}
+		if ctx.Err() != nil {
+			continue
+		}
+		if err != nil {
+			if err = writeJSON(map[string]string{"type": "error", "error": err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if runner.Config.Session == nil {
+			history = result.ToInputList()
+		}
+		agent = result.LastAgent()
+		if err = writeJSON(map[string]any{"type": "turn_done", "final_output": result.FinalOutput()}); err != nil {
+			return err
+		}
+	}
+
+	if err := parentCtx.Err(); err != nil {
+		_ = conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+			time.Now().Add(writeWait),
+		)
+		return err
+	}
+	if websocket.IsCloseError(readErr, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+		return nil
+	}
+	return fmt.Errorf("error reading websocket message: %w", readErr)
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startWebSocketServer starts an in-process server running ServeWebSocket,
+// and returns a connected client together with a channel receiving the
+// result of ServeWebSocket.
+func startWebSocketServer(t *testing.T, agent *agents.Agent, opts agents.WebSocketOptions) (*websocket.Conn, <-chan error) {
+	t.Helper()
+	serveErr := make(chan error, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			serveErr <- err
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		serveErr <- agents.ServeWebSocket(r.Context(), conn, agent, opts)
+	}))
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, serveErr
+}
+
+// readUntilTurnEnd reads the messages of a turn, up to and including the
+// "turn_done" or "error" message, and returns their types together with the
+// last message.
+func readUntilTurnEnd(t *testing.T, conn *websocket.Conn) ([]string, map[string]any) {
+	t.Helper()
+	var types []string
+	for {
+		var message map[string]any
+		require.NoError(t, conn.ReadJSON(&message))
+		messageType, _ := message["type"].(string)
+		types = append(types, messageType)
+		if messageType == "turn_done" || messageType == "error" {
+			return types, message
+		}
+	}
+}
+
+func closeWebSocket(t *testing.T, conn *websocket.Conn, serveErr <-chan error) {
+	t.Helper()
+	err := conn.WriteMessage(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	require.NoError(t, err)
+	select {
+	case err = <-serveErr:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeWebSocket did not return")
+	}
+}
+
+func TestServeWebSocket(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("first")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("second")}},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	conn, serveErr := startWebSocketServer(t, agent, agents.WebSocketOptions{})
+
+	require.NoError(t, conn.WriteJSON(agents.WebSocketUserMessage{Type: "user_message", Content: "hi"}))
+	types, last := readUntilTurnEnd(t, conn)
+	assert.Equal(t, "agent_updated_stream_event", types[0])
+	assert.Contains(t, types, "raw_response_event")
+	assert.Contains(t, types, "run_item_stream_event")
+	assert.Equal(t, "first", last["final_output"])
+
+	require.NoError(t, conn.WriteJSON(agents.WebSocketUserMessage{Type: "user_message", Content: "again"}))
+	_, last = readUntilTurnEnd(t, conn)
+	assert.Equal(t, "second", last["final_output"])
+
+	// The second turn continues the conversation of the first one.
+	inputItems := model.LastTurnArgs.Input.(agents.InputItems)
+	require.Len(t, inputItems, 3)
+	assert.Equal(t, agentstesting.GetTextInputItem("hi"), inputItems[0])
+	assert.NotNil(t, inputItems[1].OfOutputMessage)
+	assert.Equal(t, agentstesting.GetTextInputItem("again"), inputItems[2])
+
+	closeWebSocket(t, conn, serveErr)
+}
+
+func TestServeWebSocketInvalidMessage(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	conn, serveErr := startWebSocketServer(t, agent, agents.WebSocketOptions{})
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"type": "unknown"}`)))
+	types, last := readUntilTurnEnd(t, conn)
+	assert.Equal(t, []string{"error"}, types)
+	assert.Contains(t, last["error"], "unknown")
+
+	// The connection is still usable.
+	require.NoError(t, conn.WriteJSON(agents.WebSocketUserMessage{Type: "user_message", Content: "hi"}))
+	_, last = readUntilTurnEnd(t, conn)
+	assert.Equal(t, "done", last["final_output"])
+
+	closeWebSocket(t, conn, serveErr)
+}
+
+func TestServeWebSocketPing(t *testing.T) {
+	agent := agents.New("test").WithModelInstance(agentstesting.NewFakeModel(false, nil))
+
+	conn, serveErr := startWebSocketServer(t, agent, agents.WebSocketOptions{
+		PingInterval: 10 * time.Millisecond,
+	})
+
+	pings := make(chan struct{}, 1)
+	conn.SetPingHandler(func(data string) error {
+		select {
+		case pings <- struct{}{}:
+		default:
+		}
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	// Control messages are handled while reading.
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-pings:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no ping received")
+	}
+
+	closeWebSocket(t, conn, serveErr)
+}
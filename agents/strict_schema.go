@@ -25,19 +25,100 @@ func EnsureStrictJSONSchema(schema map[string]any) (map[string]any, error) {
 	if len(schema) == 0 {
 		return newEmptyJSONSchema(), nil
 	}
-	return ensureStrictJSONSchema(schema, nil, schema)
+	return ensureStrictJSONSchema(schema, nil, schema, false)
 }
 
-func ensureStrictJSONSchema(rawJSONSchema any, path []string, root map[string]any) (map[string]any, error) {
+// ValidateStrictJSONSchema checks that the given JSON schema already conforms
+// to the `strict` standard that the OpenAI API expects, applying the same
+// rules as EnsureStrictJSONSchema without modifying the schema: every object
+// sets additionalProperties to false and lists all of its properties as
+// required (in any order), and $refs have no sibling keys.
+// It returns a UserError if the schema is not strict-compatible.
+func ValidateStrictJSONSchema(schema map[string]any) error {
+	if len(schema) == 0 {
+		return NewUserError("JSON schema is not strict-compatible: the schema is empty")
+	}
+	if _, err := ensureStrictJSONSchema(schema, nil, schema, true); err != nil {
+		return UserErrorf("JSON schema is not strict-compatible: %w", err)
+	}
+	return nil
+}
+
+// strictSchemaError reports a strict-mode violation found while validating
+// the schema at the given path.
+func strictSchemaError(path []string, format string, args ...any) error {
+	var b strings.Builder
+	b.WriteString("#")
+	for _, p := range path {
+		b.WriteString("/" + p)
+	}
+	return fmt.Errorf("%s: %s", b.String(), fmt.Sprintf(format, args...))
+}
+
+// isJSONSchemaObjectType reports whether the "type" value of a JSON schema
+// is "object", or a list of types including "object" (e.g. ["object", "null"]).
+func isJSONSchemaObjectType(rawType any) bool {
+	switch v := rawType.(type) {
+	case string:
+		return v == "object"
+	case []string:
+		return slices.Contains(v, "object")
+	case []any:
+		return slices.Contains(v, any("object"))
+	default:
+		return false
+	}
+}
+
+// hasAllRequiredProperties reports whether the "required" value of a JSON
+// schema lists exactly the given properties, in any order.
+func hasAllRequiredProperties(properties map[string]any, rawRequired any) bool {
+	var required []string
+	switch v := rawRequired.(type) {
+	case []string:
+		required = v
+	case []any:
+		required = make([]string, len(v))
+		for i, rawName := range v {
+			name, ok := rawName.(string)
+			if !ok {
+				return false
+			}
+			required[i] = name
+		}
+	default:
+		return len(properties) == 0 && rawRequired == nil
+	}
+
+	if len(required) != len(properties) {
+		return false
+	}
+	seen := make(map[string]bool, len(required))
+	for _, name := range required {
+		if _, ok := properties[name]; !ok || seen[name] {
+			return false
+		}
+		seen[name] = true
+	}
+	return true
+}
+
+// ensureStrictJSONSchema makes the schema strict-compatible, mutating it.
+// If validateOnly is true, the schema is left untouched, and an error is
+// returned instead of each change that would have been needed.
+func ensureStrictJSONSchema(rawJSONSchema any, path []string, root map[string]any, validateOnly bool) (map[string]any, error) {
 	jsonSchema, ok := rawJSONSchema.(map[string]any)
 	if !ok {
+		if validateOnly {
+			return nil, strictSchemaError(path, "expected a JSON schema object, got %T", rawJSONSchema)
+		}
 		return nil, fmt.Errorf("expected %#v to be a map[string]any, path=%+v", rawJSONSchema, path)
 	}
 
 	for _, defKey := range []string{"$defs", "definitions"} {
 		if defs, ok := jsonSchema[defKey].(map[string]any); ok {
-			for defName, defSchema := range defs {
-				_, err := ensureStrictJSONSchema(defSchema, slices.Concat(path, []string{defKey, defName}), root)
+			for _, defName := range slices.Sorted(maps.Keys(defs)) {
+				_, err := ensureStrictJSONSchema(defs[defName], slices.Concat(path, []string{defKey, defName}), root, validateOnly)
 				if err != nil {
 					return nil, err
 				}
@@ -47,10 +128,15 @@ func ensureStrictJSONSchema(rawJSONSchema any, path []string, root map[string]an
 
 	additionalProperties, hasAdditionalProperties := jsonSchema["additionalProperties"]
 
-	if typ, _ := jsonSchema["type"].(string); typ == "object" {
-		if !hasAdditionalProperties {
+	if isJSONSchemaObjectType(jsonSchema["type"]) {
+		allowsAdditionalProperties := hasAdditionalProperties && additionalProperties != false &&
+			!reflect.DeepEqual(additionalProperties, map[string]any{"not": map[string]any{}})
+		switch {
+		case validateOnly && (!hasAdditionalProperties || allowsAdditionalProperties):
+			return nil, strictSchemaError(path, "additionalProperties must be false")
+		case !hasAdditionalProperties:
 			jsonSchema["additionalProperties"] = false
-		} else if additionalProperties != false && !reflect.DeepEqual(additionalProperties, map[string]any{"not": map[string]any{}}) {
+		case allowsAdditionalProperties:
 			return nil, NewUserError(
 				"additionalProperties should not be set for object types. " +
 					"This could be because you configured additional properties to be allowed. " +
@@ -65,32 +151,42 @@ func ensureStrictJSONSchema(rawJSONSchema any, path []string, root map[string]an
 		keys := slices.Collect(maps.Keys(properties))
 		sort.Strings(keys) // Sort for deterministic results, especially in tests
 
-		// For consistency, prefer []any to []string and empty slice over nil
-		required := make([]any, len(keys))
-		for i, k := range keys {
-			required[i] = k
+		if validateOnly {
+			if !hasAllRequiredProperties(properties, jsonSchema["required"]) {
+				return nil, strictSchemaError(path, "all properties must be listed as required")
+			}
+		} else {
+			// For consistency, prefer []any to []string and empty slice over nil
+			required := make([]any, len(keys))
+			for i, k := range keys {
+				required[i] = k
+			}
+			jsonSchema["required"] = required
 		}
-		jsonSchema["required"] = required
 
 		newProperties := make(map[string]any, len(properties))
-		for key, propSchema := range properties {
+		for _, key := range keys {
 			var err error
-			newProperties[key], err = ensureStrictJSONSchema(propSchema, slices.Concat(path, []string{"properties", key}), root)
+			newProperties[key], err = ensureStrictJSONSchema(properties[key], slices.Concat(path, []string{"properties", key}), root, validateOnly)
 			if err != nil {
 				return nil, err
 			}
 		}
-		jsonSchema["properties"] = newProperties
+		if !validateOnly {
+			jsonSchema["properties"] = newProperties
+		}
 	}
 
 	//arrays
 	// { 'type': 'array', 'items': {...} }
 	if items, ok := jsonSchema["items"].(map[string]any); ok {
-		var err error
-		jsonSchema["items"], err = ensureStrictJSONSchema(items, slices.Concat(path, []string{"items"}), root)
+		newItems, err := ensureStrictJSONSchema(items, slices.Concat(path, []string{"items"}), root, validateOnly)
 		if err != nil {
 			return nil, err
 		}
+		if !validateOnly {
+			jsonSchema["items"] = newItems
+		}
 	}
 
 	// unions
@@ -98,18 +194,20 @@ func ensureStrictJSONSchema(rawJSONSchema any, path []string, root map[string]an
 		newAnyOf := make([]any, len(anyOf))
 		for i, variant := range anyOf {
 			var err error
-			newAnyOf[i], err = ensureStrictJSONSchema(variant, slices.Concat(path, []string{"anyOf", strconv.FormatInt(int64(i), 10)}), root)
+			newAnyOf[i], err = ensureStrictJSONSchema(variant, slices.Concat(path, []string{"anyOf", strconv.FormatInt(int64(i), 10)}), root, validateOnly)
 			if err != nil {
 				return nil, err
 			}
 		}
-		jsonSchema["anyOf"] = newAnyOf
+		if !validateOnly {
+			jsonSchema["anyOf"] = newAnyOf
+		}
 	}
 
 	// intersections
 	if allOf, ok := jsonSchema["allOf"].([]any); ok {
-		if len(allOf) == 1 {
-			result, err := ensureStrictJSONSchema(allOf[0], slices.Concat(path, []string{"allOf", "0"}), root)
+		if len(allOf) == 1 && !validateOnly {
+			result, err := ensureStrictJSONSchema(allOf[0], slices.Concat(path, []string{"allOf", "0"}), root, validateOnly)
 			if err != nil {
 				return nil, err
 			}
@@ -119,19 +217,21 @@ func ensureStrictJSONSchema(rawJSONSchema any, path []string, root map[string]an
 			newAllOf := make([]any, len(allOf))
 			for i, variant := range allOf {
 				var err error
-				newAllOf[i], err = ensureStrictJSONSchema(variant, slices.Concat(path, []string{"allOf", strconv.FormatInt(int64(i), 10)}), root)
+				newAllOf[i], err = ensureStrictJSONSchema(variant, slices.Concat(path, []string{"allOf", strconv.FormatInt(int64(i), 10)}), root, validateOnly)
 				if err != nil {
 					return nil, err
 				}
 			}
-			jsonSchema["allOf"] = newAllOf
+			if !validateOnly {
+				jsonSchema["allOf"] = newAllOf
+			}
 		}
 	}
 
 	// strip `nil` defaults as there's no meaningful distinction here
 	// the schema will still be `nullable` and the model will default
 	// to using `nil` anyway
-	if d, ok := jsonSchema["default"]; ok && d == nil {
+	if d, ok := jsonSchema["default"]; ok && d == nil && !validateOnly {
 		delete(jsonSchema, "default")
 	}
 
@@ -140,6 +240,9 @@ func ensureStrictJSONSchema(rawJSONSchema any, path []string, root map[string]an
 	// so we unravel the ref
 	// `{"type": "string", "description": "my description"}`
 	if rawRef, ok := jsonSchema["$ref"]; ok && len(jsonSchema) > 1 {
+		if validateOnly {
+			return nil, strictSchemaError(path, "$ref must not have sibling keys")
+		}
 		ref, ok := rawRef.(string)
 		if !ok {
 			return nil, fmt.Errorf("received non-string $ref: %#v", rawRef)
@@ -158,7 +261,7 @@ func ensureStrictJSONSchema(rawJSONSchema any, path []string, root map[string]an
 		}
 		// Since the schema expanded from `$ref` might not have `additionalProperties: false` applied
 		// we call `ensureStrictJSONSchema` again to fix the inlined schema and ensure it's valid
-		return ensureStrictJSONSchema(jsonSchema, path, root)
+		return ensureStrictJSONSchema(jsonSchema, path, root, validateOnly)
 	}
 
 	return jsonSchema, nil
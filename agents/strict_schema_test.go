@@ -52,6 +52,22 @@ func TestObjectWithoutAdditionalProperties(t *testing.T) {
 	}, result)
 }
 
+func TestNullableObjectWithoutAdditionalProperties(t *testing.T) {
+	type m = map[string]any
+	schema := m{
+		"type":       []any{"object", "null"},
+		"properties": m{"a": m{"type": "string"}},
+	}
+	result, err := agents.EnsureStrictJSONSchema(schema)
+	require.NoError(t, err)
+	assert.Equal(t, m{
+		"type":                 []any{"object", "null"},
+		"additionalProperties": false,
+		"required":             []any{"a"},
+		"properties":           m{"a": m{"type": "string"}},
+	}, result)
+}
+
 func TestObjectWithTrueAdditionalProperties(t *testing.T) {
 	// If additionalProperties is explicitly set to true for an object, a UserError should be raised.
 	type m = map[string]any
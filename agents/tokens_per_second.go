@@ -0,0 +1,57 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/tracing"
+)
+
+// tokensPerSecond computes the output throughput of a model call.
+// It returns zero if there are no tokens, or no measurable duration.
+func tokensPerSecond(outputTokens uint64, elapsed time.Duration) float64 {
+	if outputTokens == 0 || elapsed <= 0 {
+		return 0
+	}
+	return float64(outputTokens) / elapsed.Seconds()
+}
+
+// recordTokensPerSecond adds the tokens per second to the usage of the
+// current span, if it is a generation span (Chat Completions models) or a
+// response span (Responses models).
+func recordTokensPerSecond(ctx context.Context, v float64) {
+	if v == 0 {
+		return
+	}
+	span := tracing.GetCurrentSpan(ctx)
+	if span == nil {
+		return
+	}
+	var spanUsage *map[string]any
+	switch spanData := span.SpanData().(type) {
+	case *tracing.GenerationSpanData:
+		spanUsage = &spanData.Usage
+	case *tracing.ResponseSpanData:
+		spanUsage = &spanData.Usage
+	default:
+		return
+	}
+	if *spanUsage == nil {
+		*spanUsage = make(map[string]any, 1)
+	}
+	(*spanUsage)["tokens_per_second"] = v
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/tracing"
+	"github.com/nlpodyssey/openai-agents-go/tracing/tracingtesting"
+	"github.com/nlpodyssey/openai-agents-go/usage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokensPerSecondStreamed(t *testing.T) {
+	tracingtesting.Setup(t)
+	agents.ClearOpenaiSettings()
+
+	model := delayedStreamingModel{
+		FakeModel: agentstesting.NewFakeModel(true, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		}),
+		delay: 200 * time.Millisecond,
+	}
+	model.SetHardcodedUsage(usage.Usage{
+		Requests:     1,
+		InputTokens:  10,
+		OutputTokens: 100,
+		TotalTokens:  110,
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	result, err := agents.RunStreamed(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	require.NoError(t, err)
+
+	// 100 tokens in (at least) 200ms: at most 500 tokens per second.
+	require.Len(t, result.RawResponses(), 1)
+	tps := result.RawResponses()[0].TokensPerSecond
+	assert.LessOrEqual(t, tps, 500.0)
+	assert.Greater(t, tps, 50.0)
+
+	var generationSpans []tracing.Span
+	for _, span := range tracingtesting.FetchOrderedSpans(false) {
+		if span.SpanData().Type() == "generation" {
+			generationSpans = append(generationSpans, span)
+		}
+	}
+	require.Len(t, generationSpans, 1)
+	spanData := generationSpans[0].SpanData().(*tracing.GenerationSpanData)
+	assert.Equal(t, tps, spanData.Usage["tokens_per_second"])
+}
+
+func TestTokensPerSecondNotStreamed(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	model.SetHardcodedUsage(usage.Usage{Requests: 1, OutputTokens: 100, TotalTokens: 100})
+	agent := agents.New("test").WithModelInstance(model)
+
+	result, err := agents.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	require.Len(t, result.RawResponses, 1)
+	assert.Zero(t, result.RawResponses[0].TokensPerSecond)
+}
+
+func TestTokensPerSecondWithoutUsage(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+	})
+	agent := agents.New("test").WithModelInstance(model)
+
+	result, err := agents.RunStreamed(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	require.NoError(t, err)
+	require.Len(t, result.RawResponses(), 1)
+	assert.Zero(t, result.RawResponses()[0].TokensPerSecond)
+}
+
+// responseSpanStreamingModel is a FakeModel streaming its responses within a
+// response span, like the OpenAI Responses model.
+type responseSpanStreamingModel struct {
+	*agentstesting.FakeModel
+	delay time.Duration
+}
+
+func (m responseSpanStreamingModel) StreamResponse(
+	ctx context.Context,
+	_ agents.ModelResponseParams,
+	yield agents.ModelStreamResponseCallback,
+) error {
+	return tracing.ResponseSpan(ctx, tracing.ResponseSpanParams{}, func(ctx context.Context, _ tracing.Span) error {
+		time.Sleep(m.delay)
+		output := m.GetNextOutput()
+		return yield(ctx, agents.TResponseStreamEvent{ // responses.ResponseCompletedEvent
+			Response: agentstesting.GetResponseObj(output.Value, "", m.HardcodedUsage),
+			Type:     "response.completed",
+		})
+	})
+}
+
+func TestTokensPerSecondResponseSpan(t *testing.T) {
+	tracingtesting.Setup(t)
+	agents.ClearOpenaiSettings()
+
+	model := responseSpanStreamingModel{
+		FakeModel: agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+			Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")},
+		}),
+		delay: 200 * time.Millisecond,
+	}
+	model.SetHardcodedUsage(usage.Usage{Requests: 1, OutputTokens: 100, TotalTokens: 100})
+	agent := agents.New("test").WithModelInstance(model)
+
+	result, err := agents.RunStreamed(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	require.NoError(t, err)
+
+	require.Len(t, result.RawResponses(), 1)
+	tps := result.RawResponses()[0].TokensPerSecond
+	require.NotZero(t, tps)
+
+	var responseSpans []tracing.Span
+	for _, span := range tracingtesting.FetchOrderedSpans(false) {
+		if span.SpanData().Type() == "response" {
+			responseSpans = append(responseSpans, span)
+		}
+	}
+	require.Len(t, responseSpans, 1)
+	spanData := responseSpans[0].SpanData().(*tracing.ResponseSpanData)
+	assert.Equal(t, tps, spanData.Usage["tokens_per_second"])
+	assert.Equal(t, spanData.Usage, spanData.Export()["usage"])
+}
@@ -0,0 +1,362 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/openai/openai-go/v3/shared/constant"
+)
+
+// DefaultToolCallRejectionMessage is the tool output sent to the model when
+// a tool call is rejected and ApprovalDecision.Message is empty.
+const DefaultToolCallRejectionMessage = "The tool call was rejected by the user."
+
+// ApprovalRequiredError can be returned by a FunctionTool to signal that the
+// tool call needs human approval before being executed.
+//
+// Instead of sending the error to the model, the run is suspended: Runner.Run
+// returns a RunResult whose Interruptions describe the pending tool calls,
+// and the run can be continued with Runner.Resume.
+//
+// When the tool is invoked again after being approved,
+// ToolCallApprovedFromContext reports true, and returning this error has no
+// special meaning anymore.
+//
+// Suspending the run is not supported in streaming mode, where such a run
+// fails with a UserError.
+type ApprovalRequiredError struct {
+	// Optional human-readable explanation of why approval is needed.
+	Reason string
+}
+
+func (err ApprovalRequiredError) Error() string {
+	if err.Reason == "" {
+		return "tool call requires approval"
+	}
+	return fmt.Sprintf("tool call requires approval: %s", err.Reason)
+}
+
+// ApprovalRequest describes a tool call which is waiting for approval.
+//
+// It is either a FunctionTool call which returned an ApprovalRequiredError,
+// or a hosted MCP tool call requiring approval (see HostedMCPTool), in which
+// case ServerLabel is set.
+type ApprovalRequest struct {
+	// The name of the tool.
+	ToolName string
+
+	// The ID of the tool call, or of the approval request for hosted MCP tools.
+	CallID string
+
+	// The raw JSON arguments of the tool call.
+	Arguments string
+
+	// The reason reported by the tool's ApprovalRequiredError, if any.
+	Reason string
+
+	// The label of the MCP server, for hosted MCP tool calls.
+	ServerLabel string
+
+	// The IDs of the identical tool calls collapsed into this one (see
+	// Agent.DedupeToolCalls). They share the decision about this request,
+	// and the tool is executed only once for all of them.
+	DuplicateCallIDs []string
+}
+
+// ApprovalDecision is the human decision about an ApprovalRequest.
+type ApprovalDecision struct {
+	// The ID of the tool call this decision refers to.
+	CallID string
+
+	// Whether the tool call is approved. Approved function tool calls are
+	// executed again, this time with ToolCallApprovedFromContext reporting
+	// true. For hosted MCP tools, the decision is sent to the model.
+	Approved bool
+
+	// Optional tool output sent to the model when the tool call is rejected.
+	// Default (when empty): DefaultToolCallRejectionMessage.
+	// For hosted MCP tools, it is the optional rejection reason instead.
+	Message string
+}
+
+type toolCallApprovedKey struct{}
+
+// ContextWithToolCallApproved returns a context marking the tool call as
+// approved (see ToolCallApprovedFromContext).
+func ContextWithToolCallApproved(ctx context.Context) context.Context {
+	return context.WithValue(ctx, toolCallApprovedKey{}, true)
+}
+
+// ToolCallApprovedFromContext reports whether the tool being invoked with the
+// given context has been approved with an ApprovalDecision.
+func ToolCallApprovedFromContext(ctx context.Context) bool {
+	v, _ := ctx.Value(toolCallApprovedKey{}).(bool)
+	return v
+}
+
+// asApprovalRequired reports whether the error returned by a tool is a
+// request for approval, which is only the case if the tool call has not
+// already been approved.
+func asApprovalRequired(ctx context.Context, err error) (ApprovalRequiredError, bool) {
+	var approvalErr ApprovalRequiredError
+	if err == nil || ToolCallApprovedFromContext(ctx) || !errors.As(err, &approvalErr) {
+		return ApprovalRequiredError{}, false
+	}
+	return approvalErr, true
+}
+
+// matchApprovalDecisions returns the decisions sorted as the interruptions
+// they refer to. Each interruption must have exactly one decision.
+func matchApprovalDecisions(interruptions []ApprovalRequest, decisions []ApprovalDecision) ([]ApprovalDecision, error) {
+	byCallID := make(map[string]ApprovalDecision, len(decisions))
+	for _, decision := range decisions {
+		if _, ok := byCallID[decision.CallID]; ok {
+			return nil, UserErrorf("duplicate approval decision for tool call %q", decision.CallID)
+		}
+		byCallID[decision.CallID] = decision
+	}
+
+	matched := make([]ApprovalDecision, len(interruptions))
+	for i, interruption := range interruptions {
+		decision, ok := byCallID[interruption.CallID]
+		if !ok {
+			return nil, UserErrorf("missing approval decision for tool call %q (%s)", interruption.CallID, interruption.ToolName)
+		}
+		delete(byCallID, interruption.CallID)
+		matched[i] = decision
+	}
+	for callID := range byCallID {
+		return nil, UserErrorf("approval decision for unknown tool call %q", callID)
+	}
+	return matched, nil
+}
+
+// ExecuteApprovalDecisions runs the approved tool calls, and produces a
+// rejection output for the other ones. Hosted MCP tool calls get an approval
+// response instead. The returned items are sorted as the interruptions, each
+// followed by the items of its duplicate tool calls, if any.
+func (ri runImpl) ExecuteApprovalDecisions(
+	ctx context.Context,
+	agent *Agent,
+	allTools []Tool,
+	interruptions []ApprovalRequest,
+	decisions []ApprovalDecision,
+	hooks RunHooks,
+	runConfig RunConfig,
+) ([]RunItem, error) {
+	items, _, err := ri.executeApprovalDecisions(ctx, agent, allTools, interruptions, decisions, hooks, runConfig)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(items...), nil
+}
+
+// executeApprovalDecisions implements ExecuteApprovalDecisions, returning the
+// items and the function tool results of each interruption, including its
+// duplicates. Rejected and hosted MCP tool calls have no function tool results.
+func (ri runImpl) executeApprovalDecisions(
+	ctx context.Context,
+	agent *Agent,
+	allTools []Tool,
+	interruptions []ApprovalRequest,
+	decisions []ApprovalDecision,
+	hooks RunHooks,
+	runConfig RunConfig,
+) ([][]RunItem, [][]FunctionToolResult, error) {
+	functionTools := make(map[string]FunctionTool)
+	for _, tool := range allTools {
+		if functionTool, ok := tool.(FunctionTool); ok {
+			functionTools[functionTool.Name] = functionTool
+		}
+	}
+
+	// The items and results of each interruption, including its duplicates.
+	items := make([][]RunItem, len(interruptions))
+	functionResults := make([][]FunctionToolResult, len(interruptions))
+
+	var (
+		approvedRuns    []ToolRunFunction
+		approvedIndices []int
+	)
+	for i, interruption := range interruptions {
+		if interruption.ServerLabel != "" {
+			items[i] = []RunItem{newMCPApprovalResponseItem(agent, interruption.CallID, MCPToolApprovalFunctionResult{
+				Approve: decisions[i].Approved,
+				Reason:  decisions[i].Message,
+			})}
+			continue
+		}
+
+		toolCall := ResponseFunctionToolCall{
+			Arguments: interruption.Arguments,
+			CallID:    interruption.CallID,
+			Name:      interruption.ToolName,
+			Type:      constant.ValueOf[constant.FunctionCall](),
+		}
+
+		if decisions[i].Approved {
+			functionTool, ok := functionTools[interruption.ToolName]
+			if !ok {
+				return nil, nil, UserErrorf("approved tool %q not found on agent %q", interruption.ToolName, agent.Name)
+			}
+			approvedRuns = append(approvedRuns, ToolRunFunction{
+				ToolCall:         toolCall,
+				FunctionTool:     functionTool,
+				DuplicateCallIDs: interruption.DuplicateCallIDs,
+			})
+			approvedIndices = append(approvedIndices, i)
+			continue
+		}
+
+		message := decisions[i].Message
+		if message == "" {
+			message = DefaultToolCallRejectionMessage
+		}
+		for _, callID := range append([]string{interruption.CallID}, interruption.DuplicateCallIDs...) {
+			toolCall.CallID = callID
+			items[i] = append(items[i], ToolCallOutputItem{
+				Agent: agent,
+				RawItem: ResponseInputItemFunctionCallOutputParam(
+					ItemHelpers().ToolCallOutputItem(toolCall, message)),
+				Output: message,
+				Type:   "tool_call_output_item",
+			})
+		}
+	}
+
+	if len(approvedRuns) > 0 {
+		results, err := ri.ExecuteFunctionToolCalls(ContextWithToolCallApproved(ctx), agent, approvedRuns, hooks, runConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Each run has one result per call ID, duplicates included.
+		for i, run := range approvedRuns {
+			n := 1 + len(run.DuplicateCallIDs)
+			index := approvedIndices[i]
+			for _, result := range results[:n] {
+				items[index] = append(items[index], result.RunItem)
+			}
+			functionResults[index] = results[:n]
+			results = results[n:]
+		}
+	}
+
+	return items, functionResults, nil
+}
+
+// interruptedStep is the state of a step suspended because some tool calls
+// need approval, from which the step is completed when the run is resumed.
+type interruptedStep struct {
+	preStepItems      []RunItem
+	newStepItems      []RunItem
+	modelResponse     ModelResponse
+	processedResponse ProcessedResponse
+
+	// The results of the function tool calls, where the ones which need
+	// approval only have an ApprovalRequest.
+	functionResults []FunctionToolResult
+}
+
+// resumeInterruptedStep completes an interrupted step with the approval
+// decisions, the same way ExecuteToolsAndSideEffects does for a step without
+// interruptions: the handoffs are executed, and the tool use behavior of the
+// agent is applied to all the function tool results.
+func (ri runImpl) resumeInterruptedStep(
+	ctx context.Context,
+	agent *Agent,
+	allTools []Tool,
+	originalInput Input,
+	step *interruptedStep,
+	interruptions []ApprovalRequest,
+	decisions []ApprovalDecision,
+	hooks RunHooks,
+	runConfig RunConfig,
+) (*SingleStepResult, error) {
+	items, approvedResults, err := ri.executeApprovalDecisions(
+		ctx, agent, allTools, interruptions, decisions, hooks, runConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace the approval requests with the results of the approved calls,
+	// keeping the results in the order of the tool calls.
+	approvedByCallID := make(map[string][]FunctionToolResult, len(interruptions))
+	for i, interruption := range interruptions {
+		approvedByCallID[interruption.CallID] = approvedResults[i]
+	}
+	var functionResults []FunctionToolResult
+	for _, result := range step.functionResults {
+		if result.ApprovalRequest != nil {
+			functionResults = append(functionResults, approvedByCallID[result.ApprovalRequest.CallID]...)
+		} else {
+			functionResults = append(functionResults, result)
+		}
+	}
+
+	return ri.completeStep(
+		ctx,
+		agent,
+		originalInput,
+		slices.Clone(step.preStepItems),
+		slices.Concat(step.newStepItems, slices.Concat(items...)),
+		step.modelResponse,
+		step.processedResponse,
+		functionResults,
+		agent.OutputType,
+		hooks,
+		runConfig,
+	)
+}
+
+// Resume continues a run which was suspended because some tools returned an
+// ApprovalRequiredError (see RunResult.Interruptions).
+//
+// A decision must be given for each interruption. Approved tool calls are
+// executed again, and rejected ones get a rejection message as output. The
+// interrupted turn is then completed as if no approval had been needed:
+// handoffs from the same model response are executed, and the agent's
+// ToolUseBehavior is applied. Finally, the agent loop continues as in Run.
+//
+// The input guardrails are not run again, and the turns of the prior run
+// count towards RunConfig.MaxTurns.
+func (r Runner) Resume(ctx context.Context, priorResult *RunResult, approvals []ApprovalDecision) (*RunResult, error) {
+	if priorResult == nil || len(priorResult.Interruptions) == 0 {
+		return nil, NewUserError("the prior run result has no interruptions to resume")
+	}
+	if priorResult.LastAgent == nil {
+		return nil, NewUserError("the prior run result has no last agent")
+	}
+	if priorResult.interruptedStep == nil {
+		return nil, NewUserError("the prior run result was not returned by an interrupted run")
+	}
+	decisions, err := matchApprovalDecisions(priorResult.Interruptions, approvals)
+	if err != nil {
+		return nil, err
+	}
+	return r.run(ctx, priorResult.LastAgent, priorResult.Input, &resumedRun{
+		priorResult: priorResult,
+		decisions:   decisions,
+	})
+}
+
+// resumedRun is the state of a run continued with Runner.Resume.
+type resumedRun struct {
+	priorResult *RunResult
+	decisions   []ApprovalDecision
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/modelsettings"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func functionToolCallWithID(name, callID, arguments string) responses.ResponseOutputItemUnion {
+	call := agentstesting.GetFunctionToolCall(name, arguments)
+	call.CallID = callID
+	return call
+}
+
+// sensitiveTool returns a tool which requires approval before running,
+// and records the arguments it was executed with.
+func sensitiveTool(executed *[]string) agents.FunctionTool {
+	tool := agentstesting.GetFunctionTool("send_email", "")
+	tool.OnInvokeTool = func(ctx context.Context, arguments string) (any, error) {
+		if !agents.ToolCallApprovedFromContext(ctx) {
+			return nil, agents.ApprovalRequiredError{Reason: "emails are sensitive"}
+		}
+		*executed = append(*executed, arguments)
+		return "email sent", nil
+	}
+	return tool
+}
+
+func toolOutputs(items []agents.RunItem) map[string]any {
+	outputs := make(map[string]any)
+	for _, item := range items {
+		if outputItem, ok := item.(agents.ToolCallOutputItem); ok {
+			rawItem := outputItem.RawItem.(agents.ResponseInputItemFunctionCallOutputParam)
+			outputs[rawItem.CallID] = outputItem.Output
+		}
+	}
+	return outputs
+}
+
+func interruptedRun(t *testing.T, executed *[]string) (*agents.Agent, *agentstesting.FakeModel, *agents.RunResult) {
+	t.Helper()
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			functionToolCallWithID("send_email", "call_email", `{"to":"bob"}`),
+			functionToolCallWithID("foo", "call_foo", `{}`),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("done"),
+		}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(sensitiveTool(executed), agentstesting.GetFunctionTool("foo", "foo_result"))
+
+	result, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	return agent, model, result
+}
+
+func TestToolApprovalInterruptsRun(t *testing.T) {
+	var executed []string
+	agent, _, result := interruptedRun(t, &executed)
+
+	assert.Equal(t, []agents.ApprovalRequest{{
+		ToolName:  "send_email",
+		CallID:    "call_email",
+		Arguments: `{"to":"bob"}`,
+		Reason:    "emails are sensitive",
+	}}, result.Interruptions)
+	assert.Nil(t, result.FinalOutput)
+	assert.Same(t, agent, result.LastAgent)
+	assert.Len(t, result.RawResponses, 1)
+	assert.Empty(t, executed)
+
+	// The other tools run normally, while the pending call has no output yet.
+	assert.Len(t, result.NewItems, 3)
+	assert.Equal(t, map[string]any{"call_foo": "foo_result"}, toolOutputs(result.NewItems))
+}
+
+func TestToolApprovalResumeApproved(t *testing.T) {
+	var executed []string
+	_, model, interrupted := interruptedRun(t, &executed)
+
+	result, err := agents.Runner{}.Resume(t.Context(), interrupted, []agents.ApprovalDecision{
+		{CallID: "call_email", Approved: true},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`{"to":"bob"}`}, executed)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Empty(t, result.Interruptions)
+	assert.Len(t, result.RawResponses, 2)
+	assert.Equal(t, agents.InputString("user_message"), result.Input)
+	assert.Equal(t, map[string]any{
+		"call_foo":   "foo_result",
+		"call_email": "email sent",
+	}, toolOutputs(result.NewItems))
+
+	// The model received the outputs of both tool calls.
+	input, ok := model.LastTurnArgs.Input.(agents.InputItems)
+	require.True(t, ok)
+	assert.Len(t, input, 5)
+}
+
+func TestToolApprovalResumeRejected(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"default message", "", agents.DefaultToolCallRejectionMessage},
+		{"custom message", "not today", "not today"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var executed []string
+			_, _, interrupted := interruptedRun(t, &executed)
+
+			result, err := agents.Runner{}.Resume(t.Context(), interrupted, []agents.ApprovalDecision{
+				{CallID: "call_email", Approved: false, Message: tc.message},
+			})
+			require.NoError(t, err)
+
+			assert.Empty(t, executed)
+			assert.Equal(t, "done", result.FinalOutput)
+			assert.Equal(t, map[string]any{
+				"call_foo":   "foo_result",
+				"call_email": tc.expected,
+			}, toolOutputs(result.NewItems))
+		})
+	}
+}
+
+func TestToolApprovalResumeWithHandoff(t *testing.T) {
+	var executed []string
+
+	model2 := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done by agent_2")},
+	})
+	agent2 := agents.New("agent_2").WithModelInstance(model2)
+
+	handoffCall := agentstesting.GetHandoffToolCall(agent2, "", "")
+	handoffCall.CallID = "call_handoff"
+	model1 := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{
+			functionToolCallWithID("send_email", "call_email", `{"to":"bob"}`),
+			handoffCall,
+		},
+	})
+	agent1 := agents.New("agent_1").
+		WithModelInstance(model1).
+		WithTools(sensitiveTool(&executed)).
+		WithAgentHandoffs(agent2)
+
+	interrupted, err := agents.Runner{}.Run(t.Context(), agent1, "user_message")
+	require.NoError(t, err)
+	require.Len(t, interrupted.Interruptions, 1)
+	assert.Same(t, agent1, interrupted.LastAgent)
+
+	result, err := agents.Runner{}.Resume(t.Context(), interrupted, []agents.ApprovalDecision{
+		{CallID: "call_email", Approved: true},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`{"to":"bob"}`}, executed)
+	assert.Same(t, agent2, result.LastAgent)
+	assert.Equal(t, "done by agent_2", result.FinalOutput)
+
+	// The new agent received an output for each tool call, the handoff included.
+	input, ok := model2.LastTurnArgs.Input.(agents.InputItems)
+	require.True(t, ok)
+	var outputCallIDs []string
+	for _, item := range input {
+		if item.OfFunctionCallOutput != nil {
+			outputCallIDs = append(outputCallIDs, item.OfFunctionCallOutput.CallID)
+		}
+	}
+	assert.ElementsMatch(t, []string{"call_email", "call_handoff"}, outputCallIDs)
+}
+
+func TestToolApprovalResumeAppliesToolUseBehavior(t *testing.T) {
+	var executed []string
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{
+			functionToolCallWithID("send_email", "call_email", `{"to":"bob"}`),
+			functionToolCallWithID("foo", "call_foo", `{}`),
+		},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(sensitiveTool(&executed), agentstesting.GetFunctionTool("foo", "foo_result")).
+		WithToolUseBehavior(agents.StopOnFirstTool())
+
+	interrupted, err := agents.Runner{}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	require.Len(t, interrupted.Interruptions, 1)
+
+	result, err := agents.Runner{}.Resume(t.Context(), interrupted, []agents.ApprovalDecision{
+		{CallID: "call_email", Approved: true},
+	})
+	require.NoError(t, err)
+
+	// The first tool call is the approved one, as in a run without approvals.
+	assert.Equal(t, "email sent", result.FinalOutput)
+	assert.Len(t, result.RawResponses, 1)
+}
+
+func TestToolApprovalResumeResetsToolChoice(t *testing.T) {
+	var executed []string
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			functionToolCallWithID("send_email", "call_email", `{"to":"bob"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(sensitiveTool(&executed))
+	runner := agents.Runner{Config: agents.RunConfig{
+		ModelSettings: modelsettings.ModelSettings{ToolChoice: modelsettings.ToolChoiceRequired},
+	}}
+
+	interrupted, err := runner.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	require.Len(t, interrupted.Interruptions, 1)
+
+	result, err := runner.Resume(t.Context(), interrupted, []agents.ApprovalDecision{
+		{CallID: "call_email", Approved: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+	assert.Nil(t, model.LastTurnArgs.ModelSettings.ToolChoice)
+}
+
+func TestToolApprovalResumeInvalidDecisions(t *testing.T) {
+	var executed []string
+	_, _, interrupted := interruptedRun(t, &executed)
+
+	for _, tc := range []struct {
+		name      string
+		decisions []agents.ApprovalDecision
+	}{
+		{"missing", nil},
+		{"unknown", []agents.ApprovalDecision{
+			{CallID: "call_email", Approved: true},
+			{CallID: "call_other", Approved: true},
+		}},
+		{"duplicate", []agents.ApprovalDecision{
+			{CallID: "call_email", Approved: true},
+			{CallID: "call_email", Approved: false},
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := agents.Runner{}.Resume(t.Context(), interrupted, tc.decisions)
+			assert.ErrorAs(t, err, &agents.UserError{})
+		})
+	}
+	assert.Empty(t, executed)
+
+	t.Run("no interruptions", func(t *testing.T) {
+		_, err := agents.Runner{}.Resume(t.Context(), &agents.RunResult{}, nil)
+		assert.ErrorAs(t, err, &agents.UserError{})
+	})
+}
+
+func TestToolApprovalStreamedIsUnsupported(t *testing.T) {
+	var executed []string
+	model := agentstesting.NewFakeModel(false, &agentstesting.FakeModelTurnOutput{
+		Value: []agents.TResponseOutputItem{
+			functionToolCallWithID("send_email", "call_email", `{}`),
+		},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(sensitiveTool(&executed))
+
+	result, err := agents.Runner{}.RunStreamed(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+	assert.ErrorAs(t, err, &agents.UserError{})
+}
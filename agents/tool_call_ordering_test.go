@@ -0,0 +1,162 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func authCheckFirst(calls []agents.ToolRunFunction) []agents.ToolRunFunction {
+	ordered := make([]agents.ToolRunFunction, 0, len(calls))
+	for _, call := range calls {
+		if call.FunctionTool.Name == "auth_check" {
+			ordered = append(ordered, call)
+		}
+	}
+	for _, call := range calls {
+		if call.FunctionTool.Name != "auth_check" {
+			ordered = append(ordered, call)
+		}
+	}
+	return ordered
+}
+
+func newToolCallOrderingModel() *agentstesting.FakeModel {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			functionToolCallWithID("foo", "call_foo", `{}`),
+			functionToolCallWithID("bar", "call_bar", `{}`),
+			functionToolCallWithID("auth_check", "call_auth", `{}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	return model
+}
+
+func TestToolCallOrdering(t *testing.T) {
+	model := newToolCallOrderingModel()
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(
+			agentstesting.GetFunctionTool("foo", "foo_result"),
+			agentstesting.GetFunctionTool("bar", "bar_result"),
+			agentstesting.GetFunctionTool("auth_check", "auth_result"),
+		).
+		WithToolCallOrdering(authCheckFirst)
+
+	result, err := agents.Run(t.Context(), agent, "go")
+	require.NoError(t, err)
+
+	type callOutput struct{ callID, output string }
+	var outputs []callOutput
+	for _, item := range result.NewItems {
+		if item, ok := item.(agents.ToolCallOutputItem); ok {
+			raw := item.RawItem.(agents.ResponseInputItemFunctionCallOutputParam)
+			outputs = append(outputs, callOutput{raw.CallID, raw.Output.OfString.Value})
+		}
+	}
+	assert.Equal(t, []callOutput{
+		{"call_auth", "auth_result"},
+		{"call_foo", "foo_result"},
+		{"call_bar", "bar_result"},
+	}, outputs)
+}
+
+func TestToolCallOrderingInvalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ordering agents.ToolCallOrdering
+	}{
+		{"dropped", func(calls []agents.ToolRunFunction) []agents.ToolRunFunction {
+			return calls[1:]
+		}},
+		{"duplicated", func(calls []agents.ToolRunFunction) []agents.ToolRunFunction {
+			return []agents.ToolRunFunction{calls[0], calls[0], calls[1]}
+		}},
+		{"modified arguments", func(calls []agents.ToolRunFunction) []agents.ToolRunFunction {
+			calls[0].ToolCall.Arguments = `{"admin":true}`
+			return calls
+		}},
+		{"swapped tool", func(calls []agents.ToolRunFunction) []agents.ToolRunFunction {
+			calls[0].FunctionTool, calls[1].FunctionTool = calls[1].FunctionTool, calls[0].FunctionTool
+			return calls
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			agent := agents.New("test").
+				WithModelInstance(newToolCallOrderingModel()).
+				WithTools(
+					agentstesting.GetFunctionTool("foo", "foo_result"),
+					agentstesting.GetFunctionTool("bar", "bar_result"),
+					agentstesting.GetFunctionTool("auth_check", "auth_result"),
+				).
+				WithToolCallOrdering(tc.ordering)
+
+			_, err := agents.Run(t.Context(), agent, "go")
+			assert.ErrorAs(t, err, &agents.UserError{})
+			assert.ErrorContains(t, err, "ToolCallOrdering")
+		})
+	}
+}
+
+func TestToolCallOrderingRunsSequentially(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		events  []string
+		running int
+	)
+	recordingTool := func(name string) agents.FunctionTool {
+		return agents.FunctionTool{
+			Name:             name,
+			ParamsJSONSchema: map[string]any{"type": "object"},
+			OnInvokeTool: func(context.Context, string) (any, error) {
+				mu.Lock()
+				running++
+				events = append(events, "start "+name)
+				overlapping := running > 1
+				mu.Unlock()
+				assert.False(t, overlapping, "tool %s ran concurrently", name)
+
+				mu.Lock()
+				running--
+				events = append(events, "end "+name)
+				mu.Unlock()
+				return name + "_result", nil
+			},
+		}
+	}
+
+	agent := agents.New("test").
+		WithModelInstance(newToolCallOrderingModel()).
+		WithTools(recordingTool("foo"), recordingTool("bar"), recordingTool("auth_check")).
+		WithToolCallOrdering(authCheckFirst)
+
+	_, err := agents.Run(t.Context(), agent, "go")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"start auth_check", "end auth_check",
+		"start foo", "end foo",
+		"start bar", "end bar",
+	}, events)
+}
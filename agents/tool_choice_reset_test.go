@@ -89,6 +89,30 @@ func TestShouldResetToolChoiceDirect(t *testing.T) {
 		newSettings := agents.RunImpl().MaybeResetToolChoice(agent, tracker, modelSettings)
 		assert.Nil(t, newSettings.ToolChoice)
 	})
+
+	t.Run(`ToolChoice = "required" should reset to the agent's own tool choice`, func(t *testing.T) {
+		agent := &agents.Agent{
+			Name:          "test_agent",
+			ModelSettings: modelsettings.ModelSettings{ToolChoice: modelsettings.ToolChoiceAuto},
+		}
+		modelSettings := modelsettings.ModelSettings{ToolChoice: modelsettings.ToolChoiceRequired}
+		tracker := agents.NewAgentToolUseTracker()
+		tracker.AddToolUse(agent, []string{"tool1"})
+		newSettings := agents.RunImpl().MaybeResetToolChoice(agent, tracker, modelSettings)
+		assert.Equal(t, modelsettings.ToolChoiceAuto, newSettings.ToolChoice)
+	})
+
+	t.Run(`the agent's own "required" tool choice should be kept`, func(t *testing.T) {
+		agent := &agents.Agent{
+			Name:          "test_agent",
+			ModelSettings: modelsettings.ModelSettings{ToolChoice: modelsettings.ToolChoiceRequired},
+		}
+		modelSettings := modelsettings.ModelSettings{ToolChoice: modelsettings.ToolChoiceRequired}
+		tracker := agents.NewAgentToolUseTracker()
+		tracker.AddToolUse(agent, []string{"tool1"})
+		newSettings := agents.RunImpl().MaybeResetToolChoice(agent, tracker, modelSettings)
+		assert.Equal(t, modelsettings.ToolChoiceRequired, newSettings.ToolChoice)
+	})
 }
 
 func TestRequiredToolChoiceWithMultipleRuns(t *testing.T) {
@@ -255,3 +279,66 @@ func TestDontResetToolChoiceIfNotRequired(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, modelsettings.ToolChoiceRequired, fakeModel.LastTurnArgs.ModelSettings.ToolChoice)
 }
+
+func TestAgentToolChoiceIsKeptAfterToolCall(t *testing.T) {
+	// Test scenario 6: When the agent itself is configured with ToolChoice="required",
+	// ensure the tool choice isn't dropped to auto after a tool call.
+
+	fakeModel := agentstesting.NewFakeModel(false, nil)
+	fakeModel.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("custom_tool", "{}"),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("Final response"),
+		}},
+	})
+
+	customTool := agentstesting.GetFunctionTool("custom_tool", "tool result")
+	agent := &agents.Agent{
+		Name:  "test_agent",
+		Model: param.NewOpt(agents.NewAgentModel(fakeModel)),
+		Tools: []agents.Tool{customTool},
+		ModelSettings: modelsettings.ModelSettings{
+			ToolChoice: modelsettings.ToolChoiceRequired,
+		},
+	}
+
+	_, err := agents.Runner{}.Run(t.Context(), agent, "run test")
+	require.NoError(t, err)
+	assert.Equal(t, modelsettings.ToolChoiceRequired, fakeModel.LastTurnArgs.ModelSettings.ToolChoice)
+}
+
+func TestRunConfigToolChoiceResetsToAgentToolChoice(t *testing.T) {
+	// Test scenario 7: When ToolChoice="required" is forced by the run config, ensure
+	// it is reset to the agent's own tool choice after a tool call.
+
+	fakeModel := agentstesting.NewFakeModel(false, nil)
+	fakeModel.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("custom_tool", "{}"),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("Final response"),
+		}},
+	})
+
+	customTool := agentstesting.GetFunctionTool("custom_tool", "tool result")
+	agent := &agents.Agent{
+		Name:  "test_agent",
+		Model: param.NewOpt(agents.NewAgentModel(fakeModel)),
+		Tools: []agents.Tool{customTool},
+		ModelSettings: modelsettings.ModelSettings{
+			ToolChoice: modelsettings.ToolChoiceAuto,
+		},
+	}
+
+	runner := agents.Runner{Config: agents.RunConfig{
+		ModelSettings: modelsettings.ModelSettings{
+			ToolChoice: modelsettings.ToolChoiceRequired,
+		},
+	}}
+	_, err := runner.Run(t.Context(), agent, "run test")
+	require.NoError(t, err)
+	assert.Equal(t, modelsettings.ToolChoiceAuto, fakeModel.LastTurnArgs.ModelSettings.ToolChoice)
+}
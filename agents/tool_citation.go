@@ -0,0 +1,81 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/openai/openai-go/v3/shared/constant"
+)
+
+// Citation is a source returned by a citation tool (see NewCitationTool).
+type Citation struct {
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Snippet string `json:"snippet"`
+}
+
+// URLCitationAnnotation returns the `url_citation` annotation referencing
+// this citation from the text of an output message, between the given
+// character indices.
+func (c Citation) URLCitationAnnotation(startIndex, endIndex int64) responses.ResponseOutputTextAnnotationUnionParam {
+	return responses.ResponseOutputTextAnnotationUnionParam{
+		OfURLCitation: &responses.ResponseOutputTextAnnotationURLCitationParam{
+			EndIndex:   endIndex,
+			StartIndex: startIndex,
+			Title:      c.Title,
+			URL:        c.URL,
+			Type:       constant.ValueOf[constant.URLCitation](),
+		},
+	}
+}
+
+type citationToolArgs struct {
+	Query string `json:"query"`
+}
+
+const citationToolDescription = "Search for the given query. " +
+	"Returns a list of sources, each with a title, a URL and a snippet: " +
+	"cite the sources supporting your answer by their URL."
+
+// NewCitationTool creates a function tool which searches for a query with
+// the given function, e.g. querying a web search API, and returns the
+// citations found, serialized as a JSON array of objects with "title",
+// "url" and "snippet" fields. See ParseCitations to get them back from the
+// tool output, e.g. to annotate the final output.
+func NewCitationTool(name string, fn func(ctx context.Context, query string) ([]Citation, error)) FunctionTool {
+	return NewFunctionTool(name, citationToolDescription, func(ctx context.Context, args citationToolArgs) ([]Citation, error) {
+		citations, err := fn(ctx, args.Query)
+		if err != nil {
+			return nil, err
+		}
+		if citations == nil {
+			citations = []Citation{}
+		}
+		return citations, nil
+	})
+}
+
+// ParseCitations parses the output of a tool created with NewCitationTool.
+func ParseCitations(toolOutput string) ([]Citation, error) {
+	var citations []Citation
+	if err := json.Unmarshal([]byte(toolOutput), &citations); err != nil {
+		return nil, fmt.Errorf("failed to parse citations: %w", err)
+	}
+	return citations, nil
+}
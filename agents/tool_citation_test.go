@@ -0,0 +1,97 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/openaitypes"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCitationTool(t *testing.T) {
+	citations := []agents.Citation{
+		{Title: "Go", URL: "https://go.dev", Snippet: "The Go programming language"},
+		{Title: "Go spec", URL: "https://go.dev/ref/spec", Snippet: "The Go language specification"},
+	}
+	var gotQuery string
+	tool := agents.NewCitationTool("search", func(_ context.Context, query string) ([]agents.Citation, error) {
+		gotQuery = query
+		return citations, nil
+	})
+	assert.Equal(t, "search", tool.Name)
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("search", `{"query": "golang"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("Go is a language.")}},
+	})
+	agent := agents.New("test").WithModelInstance(model).WithTools(tool)
+
+	result, err := agents.Run(t.Context(), agent, "What is Go?")
+	require.NoError(t, err)
+	assert.Equal(t, "golang", gotQuery)
+
+	var output string
+	for _, item := range result.NewItems {
+		if item, ok := item.(agents.ToolCallOutputItem); ok {
+			output = item.RawItem.(agents.ResponseInputItemFunctionCallOutputParam).Output.OfString.Value
+		}
+	}
+	parsed, err := agents.ParseCitations(output)
+	require.NoError(t, err)
+	require.Equal(t, citations, parsed)
+
+	// The annotations match the url_citation annotations of the output text
+	annotation := parsed[1].URLCitationAnnotation(0, 17)
+	assert.Equal(t, openaitypes.ResponseOutputTextAnnotationUnionToParam(responses.ResponseOutputTextAnnotationUnion{
+		EndIndex:   17,
+		StartIndex: 0,
+		Title:      "Go spec",
+		URL:        "https://go.dev/ref/spec",
+		Type:       "url_citation",
+	}), annotation)
+}
+
+func TestCitationToolNoResults(t *testing.T) {
+	tool := agents.NewCitationTool("search", func(context.Context, string) ([]agents.Citation, error) {
+		return nil, nil
+	})
+	output, err := tool.OnInvokeTool(t.Context(), `{"query": "nothing"}`)
+	require.NoError(t, err)
+	assert.Equal(t, []agents.Citation{}, output)
+}
+
+func TestCitationToolError(t *testing.T) {
+	searchErr := errors.New("search failed")
+	tool := agents.NewCitationTool("search", func(context.Context, string) ([]agents.Citation, error) {
+		return nil, searchErr
+	})
+	_, err := tool.OnInvokeTool(t.Context(), `{"query": "golang"}`)
+	assert.ErrorIs(t, err, searchErr)
+}
+
+func TestParseCitationsInvalid(t *testing.T) {
+	_, err := agents.ParseCitations("not json")
+	assert.ErrorContains(t, err, "failed to parse citations")
+}
@@ -46,3 +46,25 @@ func ToolDataFromContext(ctx context.Context) *ToolContextData {
 	v, _ := ctx.Value(toolContextDataKey{}).(*ToolContextData)
 	return v
 }
+
+// WithToolContextValue returns a copy of ctx carrying the given value, keyed
+// by its type T, which tools can retrieve with ToolContextValue.
+//
+// This is the supported way to pass request-scoped data (e.g. the tenant or
+// the authenticated user) to tools: the context given to Runner.Run (and to
+// the other run methods) is propagated to FunctionTool.OnInvokeTool, only
+// extended with the run data. Define a dedicated type for each value, so that
+// it doesn't conflict with other values of the same type.
+//
+// It is the same as ContextWithDeps: the value is also available to the
+// guardrails created with NewInputGuardrailWithDeps or
+// NewOutputGuardrailWithDeps.
+func WithToolContextValue[T any](ctx context.Context, value T) context.Context {
+	return ContextWithDeps(ctx, value)
+}
+
+// ToolContextValue returns the value of type T set with WithToolContextValue
+// (or ContextWithDeps), if any.
+func ToolContextValue[T any](ctx context.Context) (T, bool) {
+	return DepsFromContext[T](ctx)
+}
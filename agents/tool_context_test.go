@@ -0,0 +1,95 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTenant struct {
+	ID string
+}
+
+func TestToolContextValue(t *testing.T) {
+	ctx := agents.WithToolContextValue(t.Context(), testTenant{ID: "tenant-1"})
+
+	v, ok := agents.ToolContextValue[testTenant](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, testTenant{ID: "tenant-1"}, v)
+
+	// Values are keyed by their type
+	_, ok = agents.ToolContextValue[*testTenant](ctx)
+	assert.False(t, ok)
+	_, ok = agents.ToolContextValue[testTenant](t.Context())
+	assert.False(t, ok)
+}
+
+func TestToolContextValueSharedWithGuardrailDeps(t *testing.T) {
+	ctx := agents.WithToolContextValue(t.Context(), testTenant{ID: "tenant-1"})
+	deps, ok := agents.DepsFromContext[testTenant](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, testTenant{ID: "tenant-1"}, deps)
+
+	ctx = agents.ContextWithDeps(t.Context(), testTenant{ID: "tenant-2"})
+	v, ok := agents.ToolContextValue[testTenant](ctx)
+	assert.True(t, ok)
+	assert.Equal(t, testTenant{ID: "tenant-2"}, v)
+}
+
+func TestToolContextValueVisibleInTools(t *testing.T) {
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming %v", streaming), func(t *testing.T) {
+			var (
+				tenant   testTenant
+				found    bool
+				toolData *agents.ToolContextData
+			)
+			tool := agents.NewFunctionTool("get_tenant", "", func(ctx context.Context, _ struct{}) (string, error) {
+				tenant, found = agents.ToolContextValue[testTenant](ctx)
+				toolData = agents.ToolDataFromContext(ctx)
+				return tenant.ID, nil
+			})
+
+			model := agentstesting.NewFakeModel(false, nil)
+			model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+				{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("get_tenant", `{}`)}},
+				{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+			})
+			agent := agents.New("test").WithModelInstance(model).WithTools(tool)
+
+			ctx := agents.WithToolContextValue(t.Context(), testTenant{ID: "tenant-1"})
+			if streaming {
+				result, err := agents.RunStreamed(ctx, agent, "hi")
+				require.NoError(t, err)
+				require.NoError(t, result.StreamEvents(func(agents.StreamEvent) error { return nil }))
+			} else {
+				_, err := agents.Run(ctx, agent, "hi")
+				require.NoError(t, err)
+			}
+
+			assert.True(t, found)
+			assert.Equal(t, testTenant{ID: "tenant-1"}, tenant)
+			require.NotNil(t, toolData)
+			assert.Equal(t, "get_tenant", toolData.ToolName)
+		})
+	}
+}
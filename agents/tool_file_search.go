@@ -17,21 +17,23 @@ package agents
 import (
 	"github.com/openai/openai-go/v3/packages/param"
 	"github.com/openai/openai-go/v3/responses"
+	"github.com/openai/openai-go/v3/shared/constant"
 )
 
 // FileSearchTool is a hosted tool that lets the LLM search through a vector store.
 // Currently only supported with OpenAI models, using the Responses API.
 type FileSearchTool struct {
-	// The IDs of the vector stores to search.
+	// The IDs of the vector stores to search. At least one is required.
 	VectorStoreIDs []string
 
-	// The maximum number of results to return.
+	// The maximum number of results to return, between 1 and 50.
 	MaxNumResults param.Opt[int64]
 
 	// Whether to include the search results in the output produced by the LLM.
 	IncludeSearchResults bool
 
-	// Optional ranking options for search.
+	// Optional ranking options for search. The ScoreThreshold, if set, must
+	// be between 0 and 1.
 	RankingOptions responses.FileSearchToolRankingOptionsParam
 
 	// Optional filter to apply based on file attributes.
@@ -43,3 +45,47 @@ func (t FileSearchTool) ToolName() string {
 }
 
 func (t FileSearchTool) isTool() {}
+
+// toolParam converts the tool to its Responses API parameter, returning a
+// UserError if the options are invalid.
+func (t FileSearchTool) toolParam() (*responses.FileSearchToolParam, error) {
+	if len(t.VectorStoreIDs) == 0 {
+		return nil, NewUserError("file search tool: at least one vector store ID is required")
+	}
+	for _, id := range t.VectorStoreIDs {
+		if id == "" {
+			return nil, NewUserError("file search tool: vector store IDs must not be empty")
+		}
+	}
+	if n := t.MaxNumResults; n.Valid() && (n.Value < 1 || n.Value > 50) {
+		return nil, UserErrorf("file search tool: max number of results must be between 1 and 50, got %d", n.Value)
+	}
+	if s := t.RankingOptions.ScoreThreshold; s.Valid() && (s.Value < 0 || s.Value > 1) {
+		return nil, UserErrorf("file search tool: score threshold must be between 0 and 1, got %g", s.Value)
+	}
+
+	return &responses.FileSearchToolParam{
+		VectorStoreIDs: t.VectorStoreIDs,
+		MaxNumResults:  t.MaxNumResults,
+		Filters:        t.Filters,
+		RankingOptions: t.RankingOptions,
+		Type:           constant.ValueOf[constant.FileSearch](),
+	}, nil
+}
+
+// FileCitations returns the citations of the files found by the
+// FileSearchTool, annotating the text content of a message, in order.
+func (itemHelpers) FileCitations(message MessageOutputItem) []responses.ResponseOutputTextAnnotationFileCitation {
+	var citations []responses.ResponseOutputTextAnnotationFileCitation
+	for _, content := range message.RawItem.Content {
+		if content.Type != "output_text" {
+			continue
+		}
+		for _, annotation := range content.Annotations {
+			if annotation.Type == "file_citation" {
+				citations = append(citations, annotation.AsFileCitation())
+			}
+		}
+	}
+	return citations
+}
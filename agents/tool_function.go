@@ -117,6 +117,27 @@ func (f FunctionToolEnablerFunc) IsEnabled(ctx context.Context, agent *Agent) (b
 	return f(ctx, agent)
 }
 
+// FunctionToolEnabledAfter returns a FunctionToolEnabler which enables the tool
+// only once the agent has used all the given prerequisite tools during the
+// current run (e.g. expose "checkout" only after "add_to_cart").
+//
+// It relies on the tool use tracker the runner puts in the context (see
+// ToolUseTrackerFromContext): outside a run, the tool is disabled.
+func FunctionToolEnabledAfter(toolNames ...string) FunctionToolEnabler {
+	return FunctionToolEnablerFunc(func(ctx context.Context, agent *Agent) (bool, error) {
+		tracker := ToolUseTrackerFromContext(ctx)
+		if tracker == nil {
+			return false, nil
+		}
+		for _, toolName := range toolNames {
+			if !tracker.HasUsedTool(agent, toolName) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
 // NewFunctionTool creates a FunctionTool tool with automatic JSON schema generation.
 //
 // This helper function simplifies tool creation by automatically generating the
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
 	"github.com/openai/openai-go/v3/packages/param"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -293,3 +294,57 @@ func TestFunctionTool_IsEnabled(t *testing.T) {
 	assert.Equal(t, "another_tool", toolsWithCtx[0].ToolName())
 	assert.Equal(t, "third_tool", toolsWithCtx[1].ToolName())
 }
+
+// toolNamesRecordingModel records the names of the tools available on each turn.
+type toolNamesRecordingModel struct {
+	*agentstesting.FakeModel
+	turnToolNames [][]string
+}
+
+func (m *toolNamesRecordingModel) GetResponse(ctx context.Context, params agents.ModelResponseParams) (*agents.ModelResponse, error) {
+	names := make([]string, len(params.Tools))
+	for i, tool := range params.Tools {
+		names[i] = tool.ToolName()
+	}
+	m.turnToolNames = append(m.turnToolNames, names)
+	return m.FakeModel.GetResponse(ctx, params)
+}
+
+func TestFunctionToolEnabledAfter(t *testing.T) {
+	addToCart := agentstesting.GetFunctionTool("add_to_cart", "added")
+	checkout := agentstesting.GetFunctionTool("checkout", "done")
+	checkout.IsEnabled = agents.FunctionToolEnabledAfter("add_to_cart")
+
+	t.Run("outside a run", func(t *testing.T) {
+		tools, err := agents.New("test").WithTools(addToCart, checkout).GetAllTools(t.Context())
+		require.NoError(t, err)
+		require.Len(t, tools, 1)
+		assert.Equal(t, "add_to_cart", tools[0].ToolName())
+	})
+
+	t.Run("during a run", func(t *testing.T) {
+		model := &toolNamesRecordingModel{FakeModel: agentstesting.NewFakeModel(false, nil)}
+		model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+			{Value: []agents.TResponseOutputItem{
+				agentstesting.GetFunctionToolCall("add_to_cart", ""),
+			}},
+			{Value: []agents.TResponseOutputItem{
+				agentstesting.GetFunctionToolCall("checkout", ""),
+			}},
+			{Value: []agents.TResponseOutputItem{
+				agentstesting.GetTextMessage("done"),
+			}},
+		})
+
+		agent := agents.New("test").WithTools(addToCart, checkout).WithModelInstance(model)
+		result, err := agents.Run(t.Context(), agent, "buy")
+		require.NoError(t, err)
+		assert.Equal(t, "done", result.FinalOutput)
+
+		assert.Equal(t, [][]string{
+			{"add_to_cart"},
+			{"add_to_cart", "checkout"},
+			{"add_to_cart", "checkout"},
+		}, model.turnToolNames)
+	})
+}
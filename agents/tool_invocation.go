@@ -0,0 +1,82 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import "errors"
+
+// ToolInvocation pairs a tool call made during a run with its output.
+type ToolInvocation struct {
+	// The name of the tool.
+	ToolName string
+
+	// The ID of the tool call.
+	CallID string
+
+	// The arguments of the tool call, as a JSON string.
+	ArgumentsJSON string
+
+	// The output of the tool call, as returned by the tool. It is nil if the
+	// tool call has no output, e.g. when it is waiting for approval.
+	Output any
+
+	// The error reported for hosted MCP tool calls which failed.
+	// Function tools report errors to the model as their output instead,
+	// see FunctionTool.FailureErrorFunction.
+	Error error
+}
+
+// toolInvocations pairs the function tool calls (including local MCP tools)
+// and hosted MCP tool calls in the given items with their outputs, in order.
+func toolInvocations(items []RunItem) []ToolInvocation {
+	var invocations []ToolInvocation
+	functionCallIndices := make(map[string]int)
+
+	for _, item := range items {
+		switch item := item.(type) {
+		case ToolCallItem:
+			switch rawItem := item.RawItem.(type) {
+			case ResponseFunctionToolCall:
+				functionCallIndices[rawItem.CallID] = len(invocations)
+				invocations = append(invocations, ToolInvocation{
+					ToolName:      rawItem.Name,
+					CallID:        rawItem.CallID,
+					ArgumentsJSON: rawItem.Arguments,
+				})
+			case ResponseOutputItemMcpCall:
+				invocation := ToolInvocation{
+					ToolName:      rawItem.Name,
+					CallID:        rawItem.ID,
+					ArgumentsJSON: rawItem.Arguments,
+				}
+				if rawItem.Output != "" {
+					invocation.Output = rawItem.Output
+				}
+				if rawItem.Error != "" {
+					invocation.Error = errors.New(rawItem.Error)
+				}
+				invocations = append(invocations, invocation)
+			}
+		case ToolCallOutputItem:
+			rawItem, ok := item.RawItem.(ResponseInputItemFunctionCallOutputParam)
+			if !ok {
+				continue
+			}
+			if i, ok := functionCallIndices[rawItem.CallID]; ok {
+				invocations[i].Output = item.Output
+			}
+		}
+	}
+	return invocations
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolInvocations(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	agent1 := agents.New("agent_1").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("bar", "bar_result"))
+	agent2 := agents.New("agent_2").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("foo", "foo_result")).
+		WithAgentHandoffs(agent1)
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		// First turn: a tool call
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("foo", `{"a": "b"}`),
+		}},
+		// Second turn: a message and a handoff
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("a_message"),
+			agentstesting.GetHandoffToolCall(agent1, "", ""),
+		}},
+		// Third turn: another tool call
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("bar", `{"c": "d"}`),
+		}},
+		// Fourth turn: text message
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("done"),
+		}},
+	})
+
+	t.Run("non streamed", func(t *testing.T) {
+		result, err := agents.Run(t.Context(), agent2, "user_message")
+		require.NoError(t, err)
+		assert.Equal(t, []agents.ToolInvocation{
+			{ToolName: "foo", CallID: "2", ArgumentsJSON: `{"a": "b"}`, Output: "foo_result"},
+			{ToolName: "bar", CallID: "2", ArgumentsJSON: `{"c": "d"}`, Output: "bar_result"},
+		}, result.ToolInvocations())
+	})
+
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("foo", `{"a": "b"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("done"),
+		}},
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		result, err := agents.RunStreamed(t.Context(), agent2, "user_message")
+		require.NoError(t, err)
+		err = result.StreamEvents(func(agents.StreamEvent) error { return nil })
+		require.NoError(t, err)
+		assert.Equal(t, []agents.ToolInvocation{
+			{ToolName: "foo", CallID: "2", ArgumentsJSON: `{"a": "b"}`, Output: "foo_result"},
+		}, result.ToolInvocations())
+	})
+}
+
+func TestToolInvocationsWithHostedMCPCalls(t *testing.T) {
+	agent := agents.New("test")
+	result := agents.RunResult{
+		NewItems: []agents.RunItem{
+			agents.ToolCallItem{
+				Agent: agent,
+				RawItem: agents.ResponseOutputItemMcpCall(responses.ResponseOutputItemMcpCall{
+					ID:          "mcp1",
+					Arguments:   `{"x": 1}`,
+					Name:        "search",
+					ServerLabel: "server",
+					Output:      "found",
+				}),
+				Type: "tool_call_item",
+			},
+			agents.ToolCallItem{
+				Agent: agent,
+				RawItem: agents.ResponseOutputItemMcpCall(responses.ResponseOutputItemMcpCall{
+					ID:          "mcp2",
+					Arguments:   `{}`,
+					Name:        "fetch",
+					ServerLabel: "server",
+					Error:       "not found",
+				}),
+				Type: "tool_call_item",
+			},
+			agents.ToolCallItem{
+				Agent: agent,
+				RawItem: agents.ResponseFunctionToolCall(responses.ResponseFunctionToolCall{
+					CallID:    "call1",
+					Name:      "pending",
+					Arguments: `{}`,
+				}),
+				Type: "tool_call_item",
+			},
+		},
+	}
+
+	invocations := result.ToolInvocations()
+	require.Len(t, invocations, 3)
+	assert.Equal(t, agents.ToolInvocation{
+		ToolName: "search", CallID: "mcp1", ArgumentsJSON: `{"x": 1}`, Output: "found",
+	}, invocations[0])
+	assert.Equal(t, "fetch", invocations[1].ToolName)
+	assert.Nil(t, invocations[1].Output)
+	assert.EqualError(t, invocations[1].Error, "not found")
+	assert.Equal(t, agents.ToolInvocation{
+		ToolName: "pending", CallID: "call1", ArgumentsJSON: `{}`,
+	}, invocations[2], "a tool call without output")
+}
@@ -15,23 +15,40 @@
 package agents
 
 import (
+	"bytes"
 	"context"
-
-	"github.com/openai/openai-go/v3/responses"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 )
 
-// LocalShellCommandRequest is a request to execute a command on a shell.
-type LocalShellCommandRequest struct {
-	// The data from the local shell tool call.
-	Data responses.ResponseOutputItemLocalShellCall
-}
-
 // LocalShellExecutor is a function that executes a command on a shell.
-type LocalShellExecutor = func(context.Context, LocalShellCommandRequest) (string, error)
+//
+// It receives the command and its arguments, additional environment variables
+// in "KEY=VALUE" form, the working directory (empty for the current one) and
+// a timeout in milliseconds (zero for no timeout).
+// A non-zero exit code is not an error: it is reported back to the model
+// together with the captured output.
+// A LocalShellCommandRejectedError is reported back to the model as well,
+// while any other error aborts the run.
+type LocalShellExecutor = func(
+	ctx context.Context,
+	cmd []string,
+	env []string,
+	cwd string,
+	timeoutMs int,
+) (stdout, stderr string, exitCode int, err error)
 
 // LocalShellTool is a tool that allows the LLM to execute commands on a shell.
 type LocalShellTool struct {
 	// A function that executes a command on a shell.
+	// If nil, every command is rejected, as if the executor returned by
+	// NewAllowlistLocalShellExecutor was used with an empty allowlist.
 	Executor LocalShellExecutor
 }
 
@@ -40,3 +57,144 @@ func (t LocalShellTool) ToolName() string {
 }
 
 func (t LocalShellTool) isTool() {}
+
+func (t LocalShellTool) executor() LocalShellExecutor {
+	if t.Executor != nil {
+		return t.Executor
+	}
+	return NewAllowlistLocalShellExecutor(AllowlistLocalShellExecutorParams{})
+}
+
+// LocalShellCommandRejectedError can be returned by a LocalShellExecutor
+// which refuses to execute a command.
+// Instead of aborting the run, the rejection is sent to the model as the
+// output of the local shell call, so that the model can try something else.
+type LocalShellCommandRejectedError struct {
+	// Human-readable explanation of why the command was rejected.
+	Reason string
+}
+
+func (err LocalShellCommandRejectedError) Error() string {
+	return fmt.Sprintf("local shell command rejected: %s", err.Reason)
+}
+
+// AllowlistLocalShellExecutorParams configures the executor returned by
+// NewAllowlistLocalShellExecutor.
+type AllowlistLocalShellExecutorParams struct {
+	// The program names (the first element of the command) which can be
+	// executed. Any other command is rejected.
+	AllowedCommands []string
+
+	// The names of the environment variables which the model can set.
+	// A command setting any other variable is rejected: variables such as
+	// PATH, LD_PRELOAD or BASH_ENV would allow running arbitrary code.
+	AllowedEnv []string
+
+	// The directory within which commands are executed. The working
+	// directory requested by the model, if relative, is resolved against
+	// it, and a command whose working directory is outside of it (even
+	// through symbolic links) is rejected.
+	// If empty, the current working directory of the process is used.
+	RootDir string
+}
+
+// NewAllowlistLocalShellExecutor returns a LocalShellExecutor which runs
+// commands as local processes, without going through a shell, only if the
+// program name (the first element of the command) is one of the allowed
+// commands, the environment variables are allowed, and the working directory
+// is within the root directory (see AllowlistLocalShellExecutorParams).
+// Any other command is rejected with a LocalShellCommandRejectedError.
+func NewAllowlistLocalShellExecutor(params AllowlistLocalShellExecutorParams) LocalShellExecutor {
+	allowedCommands := slices.Clone(params.AllowedCommands)
+	allowedEnv := slices.Clone(params.AllowedEnv)
+	rootDir := params.RootDir
+	return func(
+		ctx context.Context,
+		cmd []string,
+		env []string,
+		cwd string,
+		timeoutMs int,
+	) (string, string, int, error) {
+		if len(cmd) == 0 {
+			return "", "", 0, LocalShellCommandRejectedError{Reason: "the command is empty"}
+		}
+		if !slices.Contains(allowedCommands, cmd[0]) {
+			return "", "", 0, LocalShellCommandRejectedError{
+				Reason: fmt.Sprintf("command %q is not allowed", cmd[0]),
+			}
+		}
+		for _, kv := range env {
+			name, _, _ := strings.Cut(kv, "=")
+			if !slices.Contains(allowedEnv, name) {
+				return "", "", 0, LocalShellCommandRejectedError{
+					Reason: fmt.Sprintf("environment variable %q is not allowed", name),
+				}
+			}
+		}
+		dir, err := confineLocalShellDir(rootDir, cwd)
+		if err != nil {
+			return "", "", 0, err
+		}
+
+		if timeoutMs > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+			defer cancel()
+		}
+
+		var stdout, stderr bytes.Buffer
+		c := exec.CommandContext(ctx, cmd[0], cmd[1:]...)
+		c.Env = append(os.Environ(), env...)
+		c.Dir = dir
+		c.Stdout = &stdout
+		c.Stderr = &stderr
+
+		err = c.Run()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return stdout.String(), stderr.String(), exitErr.ExitCode(), nil
+		}
+		if err != nil {
+			return "", "", 0, err
+		}
+		return stdout.String(), stderr.String(), 0, nil
+	}
+}
+
+// confineLocalShellDir resolves the working directory requested for a local
+// shell command against the root directory, returning a
+// LocalShellCommandRejectedError if it is outside of it.
+func confineLocalShellDir(rootDir, cwd string) (string, error) {
+	if rootDir == "" {
+		var err error
+		if rootDir, err = os.Getwd(); err != nil {
+			return "", err
+		}
+	}
+	root, err := filepath.EvalSymlinks(rootDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve local shell root directory: %w", err)
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve local shell root directory: %w", err)
+	}
+
+	dir := cwd
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", LocalShellCommandRejectedError{
+			Reason: fmt.Sprintf("working directory %q can't be resolved", cwd),
+		}
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", LocalShellCommandRejectedError{
+			Reason: fmt.Sprintf("working directory %q is outside of the allowed directory", cwd),
+		}
+	}
+	return dir, nil
+}
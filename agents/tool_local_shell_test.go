@@ -0,0 +1,190 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getLocalShellCall(command ...string) agents.TResponseOutputItem {
+	return responses.ResponseOutputItemUnion{
+		ID:     "1",
+		CallID: "2",
+		Type:   "local_shell_call",
+		Status: "completed",
+		Action: responses.ResponseOutputItemUnionAction{
+			Type:             "exec",
+			Command:          command,
+			Env:              map[string]string{"B": "2", "A": "1"},
+			TimeoutMs:        500,
+			WorkingDirectory: "/tmp",
+		},
+	}
+}
+
+func TestLocalShellCallIsExecuted(t *testing.T) {
+	type executorCall struct {
+		cmd       []string
+		env       []string
+		cwd       string
+		timeoutMs int
+	}
+	var calls []executorCall
+	executor := func(_ context.Context, cmd, env []string, cwd string, timeoutMs int) (string, string, int, error) {
+		calls = append(calls, executorCall{cmd: cmd, env: env, cwd: cwd, timeoutMs: timeoutMs})
+		return "hello\n", "", 0, nil
+	}
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{getLocalShellCall("echo", "hello")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agents.LocalShellTool{Executor: executor})
+
+	result, err := agents.Run(t.Context(), agent, "say hello")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	assert.Equal(t, []executorCall{{
+		cmd:       []string{"echo", "hello"},
+		env:       []string{"A=1", "B=2"},
+		cwd:       "/tmp",
+		timeoutMs: 500,
+	}}, calls)
+
+	require.Len(t, result.NewItems, 3)
+	outputItem, ok := result.NewItems[1].(agents.ToolCallOutputItem)
+	require.True(t, ok)
+	assert.Equal(t, "hello\n", outputItem.Output)
+	assert.Equal(t, agents.ResponseInputItemLocalShellCallOutputParam{
+		ID:     "2",
+		Output: "hello\n",
+		Status: "completed",
+		Type:   "local_shell_call_output",
+	}, outputItem.RawItem)
+
+	inputItems := model.LastTurnArgs.Input.(agents.InputItems)
+	require.Len(t, inputItems, 3)
+	require.NotNil(t, inputItems[2].OfLocalShellCallOutput)
+	assert.Equal(t, "hello\n", inputItems[2].OfLocalShellCallOutput.Output)
+}
+
+func TestLocalShellCallOutputIncludesStderrAndExitCode(t *testing.T) {
+	executor := func(context.Context, []string, []string, string, int) (string, string, int, error) {
+		return "out", "boom\n", 2, nil
+	}
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{getLocalShellCall("false")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agents.LocalShellTool{Executor: executor})
+
+	result, err := agents.Run(t.Context(), agent, "fail")
+	require.NoError(t, err)
+
+	outputItem, ok := result.NewItems[1].(agents.ToolCallOutputItem)
+	require.True(t, ok)
+	assert.Equal(t, "out\nboom\nexit code: 2", outputItem.Output)
+}
+
+func TestLocalShellToolRejectsCommandsByDefault(t *testing.T) {
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{getLocalShellCall("rm", "-rf", "/")}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("sorry")}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agents.LocalShellTool{})
+
+	result, err := agents.Run(t.Context(), agent, "delete everything")
+	require.NoError(t, err)
+	assert.Equal(t, "sorry", result.FinalOutput)
+
+	outputItem, ok := result.NewItems[1].(agents.ToolCallOutputItem)
+	require.True(t, ok)
+	assert.Equal(t, `local shell command rejected: command "rm" is not allowed`, outputItem.Output)
+}
+
+func TestAllowlistLocalShellExecutor(t *testing.T) {
+	rootDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(rootDir, "sub"), 0o755))
+	outsideDir := t.TempDir()
+	require.NoError(t, os.Symlink(outsideDir, filepath.Join(rootDir, "link")))
+
+	executor := agents.NewAllowlistLocalShellExecutor(agents.AllowlistLocalShellExecutorParams{
+		AllowedCommands: []string{"sh"},
+		AllowedEnv:      []string{"GREETING"},
+		RootDir:         rootDir,
+	})
+
+	t.Run("allowed command", func(t *testing.T) {
+		stdout, stderr, exitCode, err := executor(
+			t.Context(),
+			[]string{"sh", "-c", `echo "$GREETING"; echo oops >&2; exit 3`},
+			[]string{"GREETING=hi"},
+			"",
+			0,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "hi\n", stdout)
+		assert.Equal(t, "oops\n", stderr)
+		assert.Equal(t, 3, exitCode)
+	})
+
+	t.Run("working directory within the root", func(t *testing.T) {
+		stdout, _, _, err := executor(t.Context(), []string{"sh", "-c", "pwd -P"}, nil, "sub", 0)
+		require.NoError(t, err)
+		wantDir, err := filepath.EvalSymlinks(filepath.Join(rootDir, "sub"))
+		require.NoError(t, err)
+		assert.Equal(t, wantDir+"\n", stdout)
+	})
+
+	rejected := []struct {
+		name string
+		cmd  []string
+		env  []string
+		cwd  string
+	}{
+		{name: "command not in allowlist", cmd: []string{"echo", "hi"}},
+		{name: "empty command", cmd: nil},
+		{name: "environment variable not in allowlist", cmd: []string{"sh", "-c", "true"}, env: []string{"LD_PRELOAD=/tmp/x.so"}},
+		{name: "working directory outside of the root", cmd: []string{"sh", "-c", "true"}, cwd: outsideDir},
+		{name: "relative working directory outside of the root", cmd: []string{"sh", "-c", "true"}, cwd: ".."},
+		{name: "working directory linked outside of the root", cmd: []string{"sh", "-c", "true"}, cwd: "link"},
+	}
+	for _, tc := range rejected {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, err := executor(t.Context(), tc.cmd, tc.env, tc.cwd, 0)
+			assert.ErrorAs(t, err, &agents.LocalShellCommandRejectedError{})
+		})
+	}
+}
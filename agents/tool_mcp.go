@@ -42,8 +42,11 @@ type HostedMCPTool struct {
 	ToolConfig responses.ToolMcpParam
 
 	// An optional function that will be called if approval is requested for an MCP tool.
-	// If not provided, you will need to manually add approvals/rejections to the input and call
-	// `Run(...)` again.
+	// If not provided, the Agent.MCPApprovalPolicy is used, if any; otherwise, the run is
+	// suspended, listing the approval request in RunResult.Interruptions, and you can
+	// approve or reject it with Runner.Resume.
+	// Suspending the run is not supported in streaming mode: streamed runs
+	// need this function or an Agent.MCPApprovalPolicy to handle approvals.
 	OnApprovalRequest MCPToolApprovalFunction
 }
 
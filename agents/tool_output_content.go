@@ -0,0 +1,122 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"strings"
+
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/openai/openai-go/v3/shared/constant"
+)
+
+// ToolOutputContent is rich content which can be returned by
+// FunctionTool.OnInvokeTool instead of plain text, e.g. by tools producing
+// images. It is sent to the model as a function call output made of multiple
+// content parts: the text, if any, followed by the images.
+//
+// The Chat Completions API only supports text tool outputs: there, the
+// images are replaced by a text placeholder.
+type ToolOutputContent struct {
+	// Optional text content.
+	Text string
+
+	// Optional images.
+	Images []ToolOutputImage
+}
+
+// ToolOutputImage is an image returned by a tool, within a ToolOutputContent.
+// Either the URL or the base64-encoded data must be provided.
+type ToolOutputImage struct {
+	// The URL of the image.
+	URL string
+
+	// The base64-encoded image data, used when URL is empty.
+	Base64Data string
+
+	// The media type of the base64-encoded data. Default: "image/png".
+	MediaType string
+
+	// Optional level of detail of the image sent to the model
+	// (see responses.ResponseInputImageContentDetail). Default: "auto".
+	Detail responses.ResponseInputImageContentDetail
+}
+
+// ImageURL returns the URL of the image, or a data URL of the base64-encoded
+// data if the URL is empty.
+func (img ToolOutputImage) ImageURL() string {
+	if img.URL != "" {
+		return img.URL
+	}
+	mediaType := img.MediaType
+	if mediaType == "" {
+		mediaType = "image/png"
+	}
+	return "data:" + mediaType + ";base64," + img.Base64Data
+}
+
+// outputItems converts the content to the content parts of a function call
+// output.
+func (c ToolOutputContent) outputItems() responses.ResponseFunctionCallOutputItemListParam {
+	items := make(responses.ResponseFunctionCallOutputItemListParam, 0, len(c.Images)+1)
+	if c.Text != "" {
+		items = append(items, responses.ResponseFunctionCallOutputItemUnionParam{
+			OfInputText: &responses.ResponseInputTextContentParam{
+				Text: c.Text,
+				Type: constant.ValueOf[constant.InputText](),
+			},
+		})
+	}
+	for _, img := range c.Images {
+		detail := img.Detail
+		if detail == "" {
+			detail = responses.ResponseInputImageContentDetailAuto
+		}
+		items = append(items, responses.ResponseFunctionCallOutputItemUnionParam{
+			OfInputImage: &responses.ResponseInputImageContentParam{
+				ImageURL: param.NewOpt(img.ImageURL()),
+				Detail:   detail,
+				Type:     constant.ValueOf[constant.InputImage](),
+			},
+		})
+	}
+	return items
+}
+
+// functionCallOutputItemsToText describes the content parts of a function
+// call output as text, for models which only support text tool outputs.
+// Images and files are replaced by placeholders.
+func functionCallOutputItemsToText(items responses.ResponseFunctionCallOutputItemListParam) string {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		switch {
+		case item.OfInputText != nil:
+			parts = append(parts, item.OfInputText.Text)
+		case item.OfInputImage != nil:
+			if url := item.OfInputImage.ImageURL.Or(""); url != "" && !strings.HasPrefix(url, "data:") {
+				parts = append(parts, "[image: "+url+"]")
+			} else {
+				parts = append(parts, "[image]")
+			}
+		case item.OfInputFile != nil:
+			if filename := item.OfInputFile.Filename.Or(""); filename != "" {
+				parts = append(parts, "[file: "+filename+"]")
+			} else {
+				parts = append(parts, "[file]")
+			}
+		}
+	}
+	return strings.Join(parts, "\n")
+}
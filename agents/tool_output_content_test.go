@@ -0,0 +1,106 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func chartToolOutputContent() agents.ToolOutputContent {
+	return agents.ToolOutputContent{
+		Text: "chart",
+		Images: []agents.ToolOutputImage{
+			{URL: "https://example.com/a.png"},
+			{Base64Data: "AAAA", MediaType: "image/jpeg", Detail: responses.ResponseInputImageContentDetailHigh},
+		},
+	}
+}
+
+func TestToolOutputContentWithImages(t *testing.T) {
+	tool := agents.NewFunctionTool("chart", "", func(context.Context, struct{}) (agents.ToolOutputContent, error) {
+		return chartToolOutputContent(), nil
+	})
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("chart", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").WithModelInstance(model).WithTools(tool)
+
+	result, err := agents.Run(t.Context(), agent, "draw")
+	require.NoError(t, err)
+
+	var rawItem agents.ResponseInputItemFunctionCallOutputParam
+	for _, item := range result.NewItems {
+		if item, ok := item.(agents.ToolCallOutputItem); ok {
+			rawItem = item.RawItem.(agents.ResponseInputItemFunctionCallOutputParam)
+			assert.Equal(t, chartToolOutputContent(), item.Output)
+		}
+	}
+
+	parts := rawItem.Output.OfResponseFunctionCallOutputItemArray
+	require.Len(t, parts, 3)
+	require.NotNil(t, parts[0].OfInputText)
+	assert.Equal(t, "chart", parts[0].OfInputText.Text)
+	require.NotNil(t, parts[1].OfInputImage)
+	assert.Equal(t, "https://example.com/a.png", parts[1].OfInputImage.ImageURL.Value)
+	assert.Equal(t, responses.ResponseInputImageContentDetailAuto, parts[1].OfInputImage.Detail)
+	require.NotNil(t, parts[2].OfInputImage)
+	assert.Equal(t, "data:image/jpeg;base64,AAAA", parts[2].OfInputImage.ImageURL.Value)
+	assert.Equal(t, responses.ResponseInputImageContentDetailHigh, parts[2].OfInputImage.Detail)
+
+	// Chat Completions only supports text tool outputs
+	messages, err := agents.ChatCmplConverter().ItemsToMessages(agents.InputItems(result.ToInputList()))
+	require.NoError(t, err)
+	var toolMessages []string
+	for _, message := range messages {
+		if message.OfTool != nil {
+			toolMessages = append(toolMessages, message.OfTool.Content.OfString.Value)
+		}
+	}
+	assert.Equal(t, []string{"chart\n[image: https://example.com/a.png]\n[image]"}, toolMessages)
+}
+
+func TestToolOutputContentTextOnly(t *testing.T) {
+	tool := agents.NewFunctionTool("text", "", func(context.Context, struct{}) (*agents.ToolOutputContent, error) {
+		return &agents.ToolOutputContent{Text: "just text"}, nil
+	})
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{agentstesting.GetFunctionToolCall("text", `{}`)}},
+		{Value: []agents.TResponseOutputItem{agentstesting.GetTextMessage("done")}},
+	})
+	agent := agents.New("test").WithModelInstance(model).WithTools(tool)
+
+	result, err := agents.Run(t.Context(), agent, "go")
+	require.NoError(t, err)
+
+	var output string
+	for _, item := range result.NewItems {
+		if item, ok := item.(agents.ToolCallOutputItem); ok {
+			output = item.RawItem.(agents.ResponseInputItemFunctionCallOutputParam).Output.OfString.Value
+		}
+	}
+	assert.Equal(t, "just text", output)
+}
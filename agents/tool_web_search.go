@@ -15,16 +15,22 @@
 package agents
 
 import (
+	"strings"
+
 	"github.com/openai/openai-go/v3/responses"
 )
 
 // WebSearchTool is a hosted tool that lets the LLM search the web.
 // Currently only supported with OpenAI models, using the Responses API.
 type WebSearchTool struct {
-	// Optional filters to apply to the search.
+	// Optional filters to apply to the search. Filters.AllowedDomains must
+	// list bare domain names (e.g. "pubmed.ncbi.nlm.nih.gov"), not URLs.
+	// The API doesn't support blocking domains.
 	Filters responses.WebSearchToolFiltersParam
 
 	// Optional location for the search. Lets you customize results to be relevant to a location.
+	// The location Type can be omitted: it defaults to "approximate", the
+	// only supported value.
 	UserLocation responses.WebSearchToolUserLocationParam
 
 	// Optional amount of context to use for the search. Default: "medium".
@@ -35,4 +41,45 @@ func (t WebSearchTool) ToolName() string {
 	return "web_search"
 }
 
+// toolParam converts the tool to its Responses API parameter, returning a
+// UserError if the options are invalid.
+func (t WebSearchTool) toolParam() (*responses.WebSearchToolParam, error) {
+	switch t.SearchContextSize {
+	case "",
+		responses.WebSearchToolSearchContextSizeLow,
+		responses.WebSearchToolSearchContextSizeMedium,
+		responses.WebSearchToolSearchContextSizeHigh:
+	default:
+		return nil, UserErrorf("web search tool: invalid search context size %q", t.SearchContextSize)
+	}
+
+	userLocation := t.UserLocation
+	switch userLocation.Type {
+	case "approximate":
+	case "":
+		if userLocation.City.Valid() || userLocation.Country.Valid() ||
+			userLocation.Region.Valid() || userLocation.Timezone.Valid() {
+			userLocation.Type = "approximate"
+		}
+	default:
+		return nil, UserErrorf("web search tool: invalid user location type %q", userLocation.Type)
+	}
+	if country := userLocation.Country; country.Valid() && len(country.Value) != 2 {
+		return nil, UserErrorf("web search tool: user location country must be a two-letter ISO code, got %q", country.Value)
+	}
+
+	for _, domain := range t.Filters.AllowedDomains {
+		if domain == "" || strings.ContainsAny(domain, "/:") {
+			return nil, UserErrorf("web search tool: allowed domains must be bare domain names, got %q", domain)
+		}
+	}
+
+	return &responses.WebSearchToolParam{
+		Type:              responses.WebSearchToolTypeWebSearch,
+		Filters:           t.Filters,
+		UserLocation:      userLocation,
+		SearchContextSize: t.SearchContextSize,
+	}, nil
+}
+
 func (t WebSearchTool) isTool() {}
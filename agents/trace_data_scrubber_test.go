@@ -0,0 +1,147 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nlpodyssey/openai-agents-go/agents"
+	"github.com/nlpodyssey/openai-agents-go/agentstesting"
+	"github.com/nlpodyssey/openai-agents-go/tracing"
+	"github.com/nlpodyssey/openai-agents-go/tracing/tracingtesting"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexpDataScrubber(t *testing.T) {
+	scrub := tracing.NewRegexpDataScrubber()
+	assert.Equal(t,
+		"mail [REDACTED] with key [REDACTED], auth [REDACTED]",
+		scrub("mail jane.doe@example.com with key sk-abcdefghijklmnop1234, auth Bearer abc.def-123"),
+	)
+	assert.Equal(t, "nothing to hide", scrub("nothing to hide"))
+}
+
+func TestRunConfigTraceDataScrubber(t *testing.T) {
+	tracingtesting.Setup(t)
+
+	model := agentstesting.NewFakeModel(false, nil)
+	model.AddMultipleTurnOutputs([]agentstesting.FakeModelTurnOutput{
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetFunctionToolCall("lookup", `{"email":"jane.doe@example.com"}`),
+		}},
+		{Value: []agents.TResponseOutputItem{
+			agentstesting.GetTextMessage("done"),
+		}},
+	})
+	agent := agents.New("test").
+		WithModelInstance(model).
+		WithTools(agentstesting.GetFunctionTool("lookup", "found john@example.org"))
+
+	result, err := agents.Runner{Config: agents.RunConfig{
+		TraceIncludeSensitiveData: param.NewOpt(true),
+		TraceDataScrubber:         tracing.NewRegexpDataScrubber(),
+	}}.Run(t.Context(), agent, "user_message")
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.FinalOutput)
+
+	var functionSpanData *tracing.FunctionSpanData
+	for _, span := range tracingtesting.FetchOrderedSpans(false) {
+		if v, ok := span.SpanData().(*tracing.FunctionSpanData); ok {
+			functionSpanData = v
+		}
+	}
+	require.NotNil(t, functionSpanData)
+	assert.Equal(t, `{"email":"[REDACTED]"}`, functionSpanData.Input)
+	assert.Equal(t, "found [REDACTED]", functionSpanData.Output)
+
+	// The data seen by the run is not affected.
+	toolOutput := result.NewItems[1].(agents.ToolCallOutputItem)
+	assert.Equal(t, "found john@example.org", toolOutput.Output)
+}
+
+func TestScrubResponseSpanData(t *testing.T) {
+	scrub := tracing.NewRegexpDataScrubber()
+
+	t.Run("string input", func(t *testing.T) {
+		sd := &tracing.ResponseSpanData{Input: agents.InputString("I am jane.doe@example.com")}
+		tracing.ScrubSpanData(sd, scrub)
+		assert.Equal(t, agents.InputString("I am [REDACTED]"), sd.Input)
+	})
+
+	t.Run("input items", func(t *testing.T) {
+		input := agents.InputItems{
+			agentstesting.GetTextInputItem("my key is sk-abcdefghijklmnop1234"),
+			{
+				OfFunctionCallOutput: &responses.ResponseInputItemFunctionCallOutputParam{
+					CallID: "call_1",
+					Output: responses.ResponseInputItemFunctionCallOutputOutputUnionParam{
+						OfString: param.NewOpt("found john@example.org"),
+					},
+				},
+			},
+		}
+		sd := &tracing.ResponseSpanData{Input: input}
+		tracing.ScrubSpanData(sd, scrub)
+
+		scrubbed, ok := sd.Input.(agents.InputItems)
+		require.True(t, ok)
+		require.Len(t, scrubbed, 2)
+		assert.Equal(t, "my key is [REDACTED]", scrubbed[0].OfMessage.Content.OfString.Value)
+		assert.Equal(t, "found [REDACTED]", scrubbed[1].OfFunctionCallOutput.Output.OfString.Value)
+		assert.Equal(t, "call_1", scrubbed[1].OfFunctionCallOutput.CallID)
+
+		// The original input is not modified.
+		assert.Equal(t, "my key is sk-abcdefghijklmnop1234", input[0].OfMessage.Content.OfString.Value)
+	})
+
+	t.Run("response", func(t *testing.T) {
+		var response responses.Response
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"id": "resp_1",
+			"object": "response",
+			"output": [{
+				"type": "message",
+				"id": "msg_1",
+				"role": "assistant",
+				"status": "completed",
+				"content": [{"type": "output_text", "text": "write to jane.doe@example.com", "annotations": []}]
+			}]
+		}`), &response))
+
+		sd := &tracing.ResponseSpanData{Response: &response}
+		tracing.ScrubSpanData(sd, scrub)
+
+		require.NotNil(t, sd.Response)
+		assert.Equal(t, "resp_1", sd.Response.ID)
+		assert.Equal(t, "write to [REDACTED]", sd.Response.OutputText())
+		assert.NotContains(t, sd.Response.RawJSON(), "jane.doe@example.com")
+
+		// The original response is not modified.
+		assert.Equal(t, "write to jane.doe@example.com", response.OutputText())
+	})
+}
+
+func TestScrubFunctionSpanMCPData(t *testing.T) {
+	sd := &tracing.FunctionSpanData{
+		Name:    "lookup",
+		MCPData: map[string]any{"server": "mail", "args": []any{"jane.doe@example.com"}},
+	}
+	tracing.ScrubSpanData(sd, tracing.NewRegexpDataScrubber())
+	assert.Equal(t, map[string]any{"server": "mail", "args": []any{"[REDACTED]"}}, sd.MCPData)
+}
@@ -67,6 +67,87 @@ func (d AudioDataInt16) Int() []int {
 	return result
 }
 
+// Resample converts the audio data from fromHz to toHz sample rate, using
+// linear interpolation. It returns an error if a sample rate is not positive.
+//
+// Linear interpolation is cheap and works well for speech, especially when
+// upsampling (e.g. from 16kHz to 24kHz). No low-pass filter is applied, so
+// downsampling may introduce aliasing of frequencies above the new Nyquist
+// frequency: prefer capturing audio at the target rate if quality matters.
+func (d AudioDataInt16) Resample(fromHz, toHz int) (AudioDataInt16, error) {
+	if fromHz <= 0 || toHz <= 0 {
+		return nil, fmt.Errorf("invalid resampling rates: from %d Hz to %d Hz", fromHz, toHz)
+	}
+	if fromHz == toHz || len(d) == 0 {
+		return d, nil
+	}
+	r := newAudioResampler(fromHz, toHz)
+	return append(r.resample(d), r.flush()...), nil
+}
+
+// audioResampler resamples a stream of audio chunks with linear
+// interpolation. It keeps the interpolation phase and the last sample across
+// chunks, so that resampling a stream chunk by chunk yields the same samples
+// as resampling it at once, without discontinuities at chunk boundaries.
+type audioResampler struct {
+	fromHz, toHz int64
+	inputs       int64 // Number of input samples received so far.
+	outputs      int64 // Number of output samples produced so far.
+	last         int16 // Last input sample of the previous chunk.
+}
+
+// newAudioResampler returns a resampler from fromHz to toHz.
+// Both sample rates must be positive.
+func newAudioResampler(fromHz, toHz int) *audioResampler {
+	return &audioResampler{fromHz: int64(fromHz), toHz: int64(toHz)}
+}
+
+// resample returns the output samples which can be interpolated from the
+// input received so far. The samples falling after the last input sample are
+// produced with the next chunk, or by flush.
+func (r *audioResampler) resample(d AudioDataInt16) AudioDataInt16 {
+	offset := r.inputs
+	r.inputs += int64(len(d))
+	sample := func(i int64) float64 {
+		if i < offset {
+			return float64(r.last)
+		}
+		return float64(d[i-offset])
+	}
+
+	result := make(AudioDataInt16, 0, max(0, r.inputs*r.toHz/r.fromHz-r.outputs))
+	for {
+		pos := r.outputs * r.fromHz
+		j := pos / r.toHz
+		if j+1 >= r.inputs {
+			break
+		}
+		frac := float64(pos%r.toHz) / float64(r.toHz)
+		result = append(result, int16(math.Round(sample(j)*(1-frac)+sample(j+1)*frac)))
+		r.outputs++
+	}
+
+	if len(d) > 0 {
+		r.last = d[len(d)-1]
+	}
+	return result
+}
+
+// flush returns the output samples falling after the last input sample,
+// repeating it, so that the output length matches the input duration.
+func (r *audioResampler) flush() AudioDataInt16 {
+	n := r.inputs*r.toHz/r.fromHz - r.outputs
+	if n <= 0 {
+		return nil
+	}
+	result := make(AudioDataInt16, n)
+	for i := range result {
+		result[i] = r.last
+	}
+	r.outputs += n
+	return result
+}
+
 type AudioDataFloat32 []float32
 
 func (d AudioDataFloat32) Len() int { return len(d) }
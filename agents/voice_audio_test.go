@@ -15,9 +15,12 @@
 package agents
 
 import (
+	"math"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAudioDataInt16(t *testing.T) {
@@ -49,3 +52,78 @@ func TestAudioDataFloat32(t *testing.T) {
 	assert.Equal(t, AudioDataInt16{-32767, -16383, 0, 16383, 32767}, data.Int16())
 	assert.Equal(t, []int{-32767, -16383, 0, 16383, 32767}, data.Int())
 }
+
+func TestAudioDataInt16Resample(t *testing.T) {
+	sineWave := func(freq float64, sampleRate int) AudioDataInt16 {
+		data := make(AudioDataInt16, sampleRate)
+		for i := range data {
+			data[i] = int16(math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)) * 32767)
+		}
+		return data
+	}
+
+	// estimateFrequency counts the sign changes over one second of audio.
+	estimateFrequency := func(data AudioDataInt16) float64 {
+		crossings := 0
+		for i := 1; i < len(data); i++ {
+			if (data[i-1] < 0) != (data[i] < 0) {
+				crossings++
+			}
+		}
+		return float64(crossings) / 2
+	}
+
+	t.Run("upsampling", func(t *testing.T) {
+		resampled, err := sineWave(440, 16000).Resample(16000, 24000)
+		require.NoError(t, err)
+		assert.Len(t, resampled, 24000)
+		assert.InDelta(t, 440, estimateFrequency(resampled), 2)
+	})
+
+	t.Run("downsampling", func(t *testing.T) {
+		resampled, err := sineWave(440, 48000).Resample(48000, 24000)
+		require.NoError(t, err)
+		assert.Len(t, resampled, 24000)
+		assert.InDelta(t, 440, estimateFrequency(resampled), 2)
+	})
+
+	t.Run("same rate", func(t *testing.T) {
+		data := AudioDataInt16{1, 2, 3}
+		resampled, err := data.Resample(24000, 24000)
+		require.NoError(t, err)
+		assert.Equal(t, data, resampled)
+	})
+
+	t.Run("interpolation", func(t *testing.T) {
+		resampled, err := AudioDataInt16{0, 100, 200}.Resample(1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, AudioDataInt16{0, 50, 100, 150, 200, 200}, resampled)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		resampled, err := AudioDataInt16{}.Resample(16000, 24000)
+		require.NoError(t, err)
+		assert.Empty(t, resampled)
+	})
+
+	t.Run("invalid rates", func(t *testing.T) {
+		_, err := AudioDataInt16{1}.Resample(0, 24000)
+		assert.EqualError(t, err, "invalid resampling rates: from 0 Hz to 24000 Hz")
+		_, err = AudioDataInt16{1}.Resample(16000, -1)
+		assert.Error(t, err)
+	})
+
+	t.Run("chunked", func(t *testing.T) {
+		data := sineWave(440, 16000)
+		expected, err := data.Resample(16000, 24000)
+		require.NoError(t, err)
+
+		r := newAudioResampler(16000, 24000)
+		var resampled AudioDataInt16
+		for chunk := range slices.Chunk(data, 333) {
+			resampled = append(resampled, r.resample(chunk)...)
+		}
+		resampled = append(resampled, r.flush()...)
+		assert.Equal(t, expected, resampled)
+	})
+}
@@ -33,6 +33,7 @@ const (
 	VoiceStreamEventLifecycleEventTurnStarted  VoiceStreamEventLifecycleEvent = "turn_started"
 	VoiceStreamEventLifecycleEventTurnEnded    VoiceStreamEventLifecycleEvent = "turn_ended"
 	VoiceStreamEventLifecycleEventSessionEnded VoiceStreamEventLifecycleEvent = "session_ended"
+	VoiceStreamEventLifecycleEventInterrupted  VoiceStreamEventLifecycleEvent = "interrupted"
 )
 
 // VoiceStreamEventLifecycle is a streaming event from the VoicePipeline.
@@ -15,8 +15,13 @@
 package agents
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"math"
+	"sync"
 
 	"github.com/go-audio/audio"
 	"github.com/go-audio/wav"
@@ -87,6 +92,7 @@ type AudioInput struct {
 	Buffer AudioData
 
 	// Optional sample rate of the audio data. Defaults to DefaultAudioSampleRate.
+	// The audio is sent to the STT model as a WAV file with this sample rate.
 	SampleRate int
 
 	// Optional sample width of the audio data. Defaults to DefaultAudioSampleWidth.
@@ -96,6 +102,79 @@ type AudioInput struct {
 	Channels int
 }
 
+// NewAudioInputFromWAV reads a WAV file and returns its content as an AudioInput.
+//
+// The audio must have DefaultAudioSampleRate sample rate and DefaultAudioChannels
+// channels, which is what the STT models expect. 16-bit PCM samples are loaded
+// as AudioDataInt16, and 32-bit IEEE float samples as AudioDataFloat32.
+func NewAudioInputFromWAV(r io.Reader) (AudioInput, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return AudioInput{}, fmt.Errorf("error reading WAV file: %w", err)
+	}
+
+	dec := wav.NewDecoder(bytes.NewReader(content))
+	if !dec.IsValidFile() {
+		return AudioInput{}, UserErrorf("invalid WAV file")
+	}
+	if dec.SampleRate != DefaultAudioSampleRate {
+		return AudioInput{}, UserErrorf(
+			"unsupported WAV sample rate %d Hz: expected %d Hz, please resample the audio",
+			dec.SampleRate, DefaultAudioSampleRate,
+		)
+	}
+	if dec.NumChans != DefaultAudioChannels {
+		return AudioInput{}, UserErrorf(
+			"unsupported WAV channel count %d: expected %d",
+			dec.NumChans, DefaultAudioChannels,
+		)
+	}
+
+	if err = dec.FwdToPCM(); err != nil {
+		return AudioInput{}, fmt.Errorf("error reading WAV PCM data: %w", err)
+	}
+	data := make([]byte, dec.PCMLen())
+	if _, err = io.ReadFull(dec.PCMChunk, data); err != nil {
+		return AudioInput{}, fmt.Errorf("error reading WAV PCM data: %w", err)
+	}
+
+	const (
+		wavFormatPCM       = 1
+		wavFormatIEEEFloat = 3
+	)
+
+	switch {
+	case dec.WavAudioFormat == wavFormatPCM && dec.BitDepth == 16:
+		buffer := make(AudioDataInt16, len(data)/2)
+		for i := range buffer {
+			buffer[i] = int16(binary.LittleEndian.Uint16(data[i*2:]))
+		}
+		return AudioInput{
+			Buffer:      buffer,
+			SampleRate:  int(dec.SampleRate),
+			SampleWidth: 2,
+			Channels:    int(dec.NumChans),
+		}, nil
+	case dec.WavAudioFormat == wavFormatIEEEFloat && dec.BitDepth == 32:
+		buffer := make(AudioDataFloat32, len(data)/4)
+		for i := range buffer {
+			buffer[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+		}
+		return AudioInput{
+			// The audio is converted to 16-bit PCM when sent to the STT model.
+			Buffer:      buffer,
+			SampleRate:  int(dec.SampleRate),
+			SampleWidth: 2,
+			Channels:    int(dec.NumChans),
+		}, nil
+	default:
+		return AudioInput{}, UserErrorf(
+			"unsupported WAV encoding (format %d, %d bits per sample): expected 16-bit PCM or 32-bit IEEE float",
+			dec.WavAudioFormat, dec.BitDepth,
+		)
+	}
+}
+
 func (ai AudioInput) ToAudioFile() (*AudioFile, error) {
 	return bufferToAudioFile(ai.Buffer, ai.SampleRate, ai.SampleWidth, ai.Channels)
 }
@@ -110,15 +189,46 @@ func (ai AudioInput) ToBase64() string {
 // queue using the AddAudio method.
 type StreamedAudioInput struct {
 	Queue *asyncqueue.Queue[AudioData]
+
+	// Optional sample rate of the audio data added with AddAudio.
+	// Defaults to DefaultAudioSampleRate, which is what the STT session
+	// expects. Audio with a different sample rate is resampled with linear
+	// interpolation (see AudioDataInt16.Resample) before being queued.
+	SampleRate int
+
+	resampling *streamedAudioResampling
+}
+
+// streamedAudioResampling holds the resampler state shared by the copies of
+// a StreamedAudioInput, so that consecutive chunks are resampled seamlessly.
+type streamedAudioResampling struct {
+	mu        sync.Mutex
+	resampler *audioResampler
 }
 
 func NewStreamedAudioInput() StreamedAudioInput {
 	return StreamedAudioInput{
-		Queue: asyncqueue.New[AudioData](),
+		Queue:      asyncqueue.New[AudioData](),
+		resampling: new(streamedAudioResampling),
 	}
 }
 
 // AddAudio adds more audio data to the stream.
 func (s StreamedAudioInput) AddAudio(audio AudioData) {
+	if s.SampleRate > 0 && s.SampleRate != DefaultAudioSampleRate {
+		audio = s.resampling.resample(audio.Int16(), s.SampleRate)
+		if audio.Len() == 0 {
+			return
+		}
+	}
 	s.Queue.Put(audio)
 }
+
+func (r *streamedAudioResampling) resample(audio AudioDataInt16, fromHz int) AudioDataInt16 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.resampler == nil || r.resampler.fromHz != int64(fromHz) {
+		r.resampler = newAudioResampler(fromHz, DefaultAudioSampleRate)
+	}
+	return r.resampler.resample(audio)
+}
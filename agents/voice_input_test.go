@@ -16,6 +16,8 @@ package agents
 
 import (
 	"bytes"
+	_ "embed"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"testing"
@@ -102,6 +104,83 @@ func TestAudioInput_ToAudioFile(t *testing.T) {
 	assert.Equal(t, buffer.Len(), intBuf.NumFrames())
 }
 
+//go:embed testdata/sine_24khz_mono_s16.wav
+var sineWAVFixture []byte
+
+// float32WAV builds a mono WAV file with 32-bit IEEE float samples.
+func float32WAV(sampleRate uint32, samples []float32) []byte {
+	var b bytes.Buffer
+	le := binary.LittleEndian
+	b.WriteString("RIFF")
+	_ = binary.Write(&b, le, uint32(36+4*len(samples)))
+	b.WriteString("WAVEfmt ")
+	_ = binary.Write(&b, le, uint32(16))
+	_ = binary.Write(&b, le, uint16(3)) // IEEE float
+	_ = binary.Write(&b, le, uint16(1))
+	_ = binary.Write(&b, le, sampleRate)
+	_ = binary.Write(&b, le, sampleRate*4)
+	_ = binary.Write(&b, le, uint16(4))
+	_ = binary.Write(&b, le, uint16(32))
+	b.WriteString("data")
+	_ = binary.Write(&b, le, uint32(4*len(samples)))
+	_ = binary.Write(&b, le, samples)
+	return b.Bytes()
+}
+
+func TestNewAudioInputFromWAV(t *testing.T) {
+	t.Run("16-bit PCM fixture", func(t *testing.T) {
+		audioInput, err := NewAudioInputFromWAV(bytes.NewReader(sineWAVFixture))
+		require.NoError(t, err)
+
+		buffer, ok := audioInput.Buffer.(AudioDataInt16)
+		require.True(t, ok)
+		assert.Len(t, buffer, 480)
+		assert.Equal(t, int16(0), buffer[0])
+		assert.Equal(t, int16(math.Sin(2*math.Pi*440*10/24000)*16000), buffer[10])
+		assert.Equal(t, DefaultAudioSampleRate, audioInput.SampleRate)
+		assert.Equal(t, 2, audioInput.SampleWidth)
+		assert.Equal(t, 1, audioInput.Channels)
+	})
+
+	t.Run("32-bit float", func(t *testing.T) {
+		samples := []float32{0, 0.5, -0.25, 1}
+		audioInput, err := NewAudioInputFromWAV(bytes.NewReader(float32WAV(DefaultAudioSampleRate, samples)))
+		require.NoError(t, err)
+		assert.Equal(t, AudioDataFloat32(samples), audioInput.Buffer)
+	})
+
+	t.Run("mismatched sample rate", func(t *testing.T) {
+		audioFile, err := bufferToAudioFile(sineWaveInt16(440), 16000, 2, 1)
+		require.NoError(t, err)
+
+		_, err = NewAudioInputFromWAV(bytes.NewReader(audioFile.Content))
+		assert.ErrorAs(t, err, &UserError{})
+		assert.ErrorContains(t, err, "sample rate 16000 Hz")
+	})
+
+	t.Run("mismatched channel count", func(t *testing.T) {
+		audioFile, err := bufferToAudioFile(sineWaveInt16(440), DefaultAudioSampleRate, 2, 2)
+		require.NoError(t, err)
+
+		_, err = NewAudioInputFromWAV(bytes.NewReader(audioFile.Content))
+		assert.ErrorAs(t, err, &UserError{})
+		assert.ErrorContains(t, err, "channel count 2")
+	})
+
+	t.Run("unsupported bit depth", func(t *testing.T) {
+		audioFile, err := bufferToAudioFile(sineWaveInt16(440), DefaultAudioSampleRate, 1, 1)
+		require.NoError(t, err)
+
+		_, err = NewAudioInputFromWAV(bytes.NewReader(audioFile.Content))
+		assert.ErrorAs(t, err, &UserError{})
+	})
+
+	t.Run("not a WAV file", func(t *testing.T) {
+		_, err := NewAudioInputFromWAV(bytes.NewReader([]byte("hello")))
+		assert.ErrorAs(t, err, &UserError{})
+	})
+}
+
 func TestNewStreamedAudioInput(t *testing.T) {
 	streamedInput := NewStreamedAudioInput()
 
@@ -123,3 +202,28 @@ func TestNewStreamedAudioInput(t *testing.T) {
 
 	assert.True(t, streamedInput.Queue.IsEmpty())
 }
+
+func TestStreamedAudioInputAddAudioResamples(t *testing.T) {
+	streamedInput := NewStreamedAudioInput()
+	streamedInput.SampleRate = 16000
+
+	data := make(AudioDataInt16, 1600)
+	for i := range data {
+		data[i] = int16(i)
+	}
+	expected, err := data.Resample(16000, DefaultAudioSampleRate)
+	require.NoError(t, err)
+
+	// The chunks are resampled seamlessly, up to the last input sample.
+	streamedInput.AddAudio(data[:800])
+	streamedInput.AddAudio(data[800:])
+
+	var resampled AudioDataInt16
+	for !streamedInput.Queue.IsEmpty() {
+		v, ok := streamedInput.Queue.GetNoWait()
+		require.True(t, ok)
+		resampled = append(resampled, v.Int16()...)
+	}
+	require.Equal(t, 2399, len(resampled))
+	assert.Equal(t, expected[:len(resampled)], resampled)
+}
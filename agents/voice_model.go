@@ -17,6 +17,7 @@ package agents
 import (
 	"context"
 	"iter"
+	"slices"
 
 	"github.com/openai/openai-go/v3/packages/param"
 )
@@ -35,8 +36,18 @@ const (
 	TTSVoiceNova    TTSVoice = "nova"
 	TTSVoiceSage    TTSVoice = "sage"
 	TTSVoiceShimmer TTSVoice = "shimmer"
+	TTSVoiceBallad  TTSVoice = "ballad"
+	TTSVoiceVerse   TTSVoice = "verse"
+	TTSVoiceMarin   TTSVoice = "marin"
+	TTSVoiceCedar   TTSVoice = "cedar"
 )
 
+var knownTTSVoices = []TTSVoice{
+	TTSVoiceAlloy, TTSVoiceAsh, TTSVoiceCoral, TTSVoiceEcho, TTSVoiceFable,
+	TTSVoiceOnyx, TTSVoiceNova, TTSVoiceSage, TTSVoiceShimmer, TTSVoiceBallad,
+	TTSVoiceVerse, TTSVoiceMarin, TTSVoiceCedar,
+}
+
 // TTSModelSettings provides settings for a TTS model.
 type TTSModelSettings struct {
 	// Optional voice to use for the TTS model.
@@ -69,6 +80,18 @@ type TTSModelSettings struct {
 	Speed param.Opt[float64]
 }
 
+// Validate reports an error if the settings contain an unknown voice, or a
+// speed outside the supported range.
+func (s TTSModelSettings) Validate() error {
+	if s.Voice != "" && !slices.Contains(knownTTSVoices, s.Voice) {
+		return UserErrorf("unknown TTS voice %q", s.Voice)
+	}
+	if s.Speed.Valid() && (s.Speed.Value < 0.25 || s.Speed.Value > 4.0) {
+		return UserErrorf("TTS speed must be between 0.25 and 4.0, got %v", s.Speed.Value)
+	}
+	return nil
+}
+
 // TTSTextSplitterFunc is a function to split the text into chunks.
 // This is useful if you want to split the text into chunks before sending it
 // to the TTS model rather than waiting for the whole text to be processed.
@@ -108,6 +131,77 @@ type StreamedTranscriptionSessionTranscribeTurns interface {
 	Error() error
 }
 
+// Transcription is a transcribed turn, together with optional metadata
+// provided by the speech-to-text model.
+type Transcription struct {
+	// The transcribed text.
+	Text string
+
+	// Optional language detected for the turn.
+	Language string
+
+	// Optional average log probability of the transcribed tokens.
+	// It is closer to zero when the model is more confident.
+	AvgLogprob param.Opt[float64]
+}
+
+// StreamedTranscriptionSessionWithMetadata can be implemented by a
+// StreamedTranscriptionSession which is able to provide transcription
+// metadata, such as the detected language, along with the text.
+type StreamedTranscriptionSessionWithMetadata interface {
+	StreamedTranscriptionSession
+
+	// TranscribeTurnsWithMetadata is like TranscribeTurns, but yields
+	// each turn as a Transcription.
+	TranscribeTurnsWithMetadata(ctx context.Context) StreamedTranscriptionSessionTranscribeTurnsWithMetadata
+}
+
+type StreamedTranscriptionSessionTranscribeTurnsWithMetadata interface {
+	Seq() iter.Seq[Transcription]
+	Error() error
+}
+
+// TranscriptionEvent is either a final Transcription of a turn, or a partial
+// TranscriptionDelta of the turn being transcribed.
+type TranscriptionEvent interface {
+	isTranscriptionEvent()
+}
+
+func (Transcription) isTranscriptionEvent() {}
+
+// TranscriptionDelta is a partial transcription of the turn being transcribed.
+// It is followed by further deltas, and eventually by the final Transcription
+// of the turn.
+type TranscriptionDelta struct {
+	// The ID of the transcribed conversation item.
+	ItemID string
+
+	// The newly transcribed text.
+	Delta string
+
+	// The text transcribed so far for the item, including Delta.
+	Text string
+}
+
+func (TranscriptionDelta) isTranscriptionEvent() {}
+
+// StreamedTranscriptionSessionWithPartials can be implemented by a
+// StreamedTranscriptionSession which is able to provide partial
+// transcriptions while a turn is being transcribed, e.g. for live captioning.
+type StreamedTranscriptionSessionWithPartials interface {
+	StreamedTranscriptionSession
+
+	// TranscribeTurnsWithPartials is like TranscribeTurnsWithMetadata, but
+	// also yields a TranscriptionDelta for each partial transcription,
+	// before the final Transcription of the turn.
+	TranscribeTurnsWithPartials(ctx context.Context) StreamedTranscriptionSessionTranscribeTurnsWithPartials
+}
+
+type StreamedTranscriptionSessionTranscribeTurnsWithPartials interface {
+	Seq() iter.Seq[TranscriptionEvent]
+	Error() error
+}
+
 // STTModelSettings provides settings for a speech-to-text model.
 type STTModelSettings struct {
 	// Optional instructions for the model to follow.
@@ -121,6 +215,11 @@ type STTModelSettings struct {
 
 	// Optional turn detection settings for the model when using streamed audio input.
 	TurnDetection map[string]any
+
+	// Whether to request the log probabilities of the transcribed tokens when
+	// using streamed audio input, to provide Transcription.AvgLogprob.
+	// Defaults to false.
+	IncludeLogprobs param.Opt[bool]
 }
 
 // STTModel interface is implemented by a speech-to-text model that can
@@ -12,6 +12,7 @@ import (
 	"log/slog"
 	"net/http"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -19,6 +20,7 @@ import (
 	"github.com/nlpodyssey/openai-agents-go/asynctask"
 	"github.com/nlpodyssey/openai-agents-go/tracing"
 	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/packages/param"
 )
 
 const (
@@ -102,8 +104,10 @@ type OpenAISTTTranscriptionSession struct {
 	turnAudioBuffer []AudioData
 	tracingSpan     tracing.Span
 
-	// tasks
+	// tasks, guarded by tasksMu since they are created by the connection task
+	// while the transcription loop may check them
 
+	tasksMu           sync.Mutex
 	listenerTask      *asynctask.TaskNoValue
 	processEventsTask *asynctask.TaskNoValue
 	streamAudioTask   *asynctask.TaskNoValue
@@ -115,9 +119,14 @@ type openAISTTTranscriptionSessionOutputQueueValue interface {
 	isOpenAISTTTranscriptionSessionOutputQueueValue()
 }
 
-type openAISTTTranscriptionSessionOutputQueueValueString string
+type openAISTTTranscriptionSessionOutputQueueValueTranscription Transcription
 
-func (openAISTTTranscriptionSessionOutputQueueValueString) isOpenAISTTTranscriptionSessionOutputQueueValue() {
+func (openAISTTTranscriptionSessionOutputQueueValueTranscription) isOpenAISTTTranscriptionSessionOutputQueueValue() {
+}
+
+type openAISTTTranscriptionSessionOutputQueueValueDelta TranscriptionDelta
+
+func (openAISTTTranscriptionSessionOutputQueueValueDelta) isOpenAISTTTranscriptionSessionOutputQueueValue() {
 }
 func (voiceModelsOpenAIErrorSentinel) isOpenAISTTTranscriptionSessionOutputQueueValue()           {}
 func (voiceModelsOpenAISessionCompleteSentinel) isOpenAISTTTranscriptionSessionOutputQueueValue() {}
@@ -272,19 +281,26 @@ func (s *OpenAISTTTranscriptionSession) configureSession() error {
 	if s.websocket == nil {
 		return fmt.Errorf("websocket not initialized")
 	}
+	session := map[string]any{
+		"input_audio_format":        "pcm16",
+		"input_audio_transcription": map[string]any{"model": s.model},
+		"turn_detection":            s.turnDetection,
+	}
+	if s.settings.IncludeLogprobs.Or(false) {
+		session["include"] = []string{"item.input_audio_transcription.logprobs"}
+	}
 	return s.websocket.WriteJSON(map[string]any{
-		"type": "transcription_session.update",
-		"session": map[string]any{
-			"input_audio_format":        "pcm16",
-			"input_audio_transcription": map[string]any{"model": s.model},
-			"turn_detection":            s.turnDetection,
-		},
+		"type":    "transcription_session.update",
+		"session": session,
 	})
 }
 
 func (s *OpenAISTTTranscriptionSession) setupConnection(ctx context.Context, c *websocket.Conn) (err error) {
 	s.websocket = c
-	s.listenerTask = asynctask.CreateTaskNoValue(ctx, s.eventListener)
+	listenerTask := asynctask.CreateTaskNoValue(ctx, s.eventListener)
+	s.tasksMu.Lock()
+	s.listenerTask = listenerTask
+	s.tasksMu.Unlock()
 
 	defer func() {
 		if err != nil {
@@ -334,6 +350,8 @@ func (s *OpenAISTTTranscriptionSession) handleEvents(ctx context.Context) (err e
 		}
 	}()
 
+	partialTexts := make(map[string]string) // by item ID
+
 loop:
 	for {
 		event, ok := s.eventQueue.GetTimeout(VoiceModelsOpenAIEventInactivityTimeout)
@@ -348,16 +366,31 @@ loop:
 			break loop
 		case openAISTTTranscriptionSessionEventQueueValueMap:
 			eventType, _ := event["type"].(string)
-			if eventType == "conversation.item.input_audio_transcription.completed" {
-				transcript, _ := event["transcript"].(string)
-				if transcript != "" {
-					if err = s.endTurn(ctx, transcript); err != nil {
+			switch eventType {
+			case "conversation.item.input_audio_transcription.delta":
+				itemID, _ := event["item_id"].(string)
+				delta, _ := event["delta"].(string)
+				if delta != "" {
+					partialTexts[itemID] += delta
+					s.outputQueue.Put(openAISTTTranscriptionSessionOutputQueueValueDelta{
+						ItemID: itemID,
+						Delta:  delta,
+						Text:   partialTexts[itemID],
+					})
+				}
+			case "conversation.item.input_audio_transcription.completed":
+				itemID, _ := event["item_id"].(string)
+				delete(partialTexts, itemID)
+
+				transcription := openAISTTTranscriptionFromEvent(event)
+				if transcription.Text != "" {
+					if err = s.endTurn(ctx, transcription.Text); err != nil {
 						return err
 					}
 					if err = s.startTurn(ctx); err != nil {
 						return err
 					}
-					s.outputQueue.Put(openAISTTTranscriptionSessionOutputQueueValueString(transcript))
+					s.outputQueue.Put(openAISTTTranscriptionSessionOutputQueueValueTranscription(transcription))
 				}
 			}
 		default:
@@ -432,29 +465,38 @@ func (s *OpenAISTTTranscriptionSession) processWebsocketConnection(ctx context.C
 		return err
 	}
 
+	s.tasksMu.Lock()
 	s.processEventsTask = asynctask.CreateTaskNoValue(ctx, s.handleEvents)
 	s.streamAudioTask = asynctask.CreateTaskNoValue(ctx, func(ctx context.Context) error {
 		return s.streamAudio(ctx, s.inputQueue)
 	})
+	listenerTask := s.listenerTask
+	s.tasksMu.Unlock()
 	s.connected = true
 
-	if s.listenerTask == nil {
+	if listenerTask == nil {
 		Logger().Error("Listener task not initialized")
 		return NewAgentsError("listener task not initialized")
 	}
 
-	s.listenerTask.Await()
+	listenerTask.Await()
 	return nil
 }
 
-func (s *OpenAISTTTranscriptionSession) checkErrors() {
-	tasks := []*asynctask.TaskNoValue{
+// tasks returns the tasks of the session created so far.
+func (s *OpenAISTTTranscriptionSession) tasks() []*asynctask.TaskNoValue {
+	s.tasksMu.Lock()
+	defer s.tasksMu.Unlock()
+	return []*asynctask.TaskNoValue{
 		s.connectionTask,
 		s.processEventsTask,
 		s.streamAudioTask,
 		s.listenerTask,
 	}
-	for _, t := range tasks {
+}
+
+func (s *OpenAISTTTranscriptionSession) checkErrors() {
+	for _, t := range s.tasks() {
 		if t != nil && t.IsDone() {
 			if err := t.Await().Error; err != nil {
 				s.storedError = err
@@ -464,13 +506,7 @@ func (s *OpenAISTTTranscriptionSession) checkErrors() {
 }
 
 func (s *OpenAISTTTranscriptionSession) cleanupTasks() {
-	tasks := []*asynctask.TaskNoValue{
-		s.connectionTask,
-		s.processEventsTask,
-		s.streamAudioTask,
-		s.listenerTask,
-	}
-	for _, t := range tasks {
+	for _, t := range s.tasks() {
 		if t != nil && !t.IsDone() {
 			t.Cancel()
 		}
@@ -481,6 +517,22 @@ func (s *OpenAISTTTranscriptionSession) TranscribeTurns(ctx context.Context) Str
 	return &openAISTTTranscriptionSessionTranscribeTurns{ctx: ctx, s: s}
 }
 
+var _ StreamedTranscriptionSessionWithMetadata = (*OpenAISTTTranscriptionSession)(nil)
+
+func (s *OpenAISTTTranscriptionSession) TranscribeTurnsWithMetadata(ctx context.Context) StreamedTranscriptionSessionTranscribeTurnsWithMetadata {
+	return openAISTTTranscriptionSessionTranscribeTurnsWithMetadata{
+		openAISTTTranscriptionSessionTranscribeTurns: &openAISTTTranscriptionSessionTranscribeTurns{ctx: ctx, s: s},
+	}
+}
+
+var _ StreamedTranscriptionSessionWithPartials = (*OpenAISTTTranscriptionSession)(nil)
+
+func (s *OpenAISTTTranscriptionSession) TranscribeTurnsWithPartials(ctx context.Context) StreamedTranscriptionSessionTranscribeTurnsWithPartials {
+	return openAISTTTranscriptionSessionTranscribeTurnsWithPartials{
+		openAISTTTranscriptionSessionTranscribeTurns: &openAISTTTranscriptionSessionTranscribeTurns{ctx: ctx, s: s},
+	}
+}
+
 func (s *OpenAISTTTranscriptionSession) Close(context.Context) (err error) {
 	if s.websocket != nil {
 		if err = s.websocket.Close(); err != nil {
@@ -499,21 +551,47 @@ type openAISTTTranscriptionSessionTranscribeTurns struct {
 }
 
 func (o *openAISTTTranscriptionSessionTranscribeTurns) Seq() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for transcription := range o.transcriptions() {
+			if !yield(transcription.Text) {
+				return
+			}
+		}
+	}
+}
+
+func (o *openAISTTTranscriptionSessionTranscribeTurns) transcriptions() iter.Seq[Transcription] {
+	return func(yield func(Transcription) bool) {
+		for event := range o.events() {
+			if t, ok := event.(Transcription); ok && !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+func (o *openAISTTTranscriptionSessionTranscribeTurns) events() iter.Seq[TranscriptionEvent] {
 	ctx := o.ctx
 	s := o.s
-	return func(yield func(string) bool) {
+	return func(yield func(TranscriptionEvent) bool) {
 		canYield := true // once yield returns false, stop yielding, but finish consuming the queue
 
+		s.tasksMu.Lock()
 		s.connectionTask = asynctask.CreateTaskNoValue(ctx, s.processWebsocketConnection)
+		s.tasksMu.Unlock()
 
 	loop:
 		for {
 			turn := s.outputQueue.Get()
 
 			switch t := turn.(type) {
-			case openAISTTTranscriptionSessionOutputQueueValueString:
+			case openAISTTTranscriptionSessionOutputQueueValueTranscription:
+				if canYield {
+					canYield = yield(Transcription(t))
+				}
+			case openAISTTTranscriptionSessionOutputQueueValueDelta:
 				if canYield {
-					canYield = yield(string(t))
+					canYield = yield(TranscriptionDelta(t))
 				}
 
 			case voiceModelsOpenAIErrorSentinel, voiceModelsOpenAISessionCompleteSentinel:
@@ -544,6 +622,49 @@ func (o *openAISTTTranscriptionSessionTranscribeTurns) Seq() iter.Seq[string] {
 
 func (o *openAISTTTranscriptionSessionTranscribeTurns) Error() error { return o.err }
 
+type openAISTTTranscriptionSessionTranscribeTurnsWithMetadata struct {
+	*openAISTTTranscriptionSessionTranscribeTurns
+}
+
+func (o openAISTTTranscriptionSessionTranscribeTurnsWithMetadata) Seq() iter.Seq[Transcription] {
+	return o.transcriptions()
+}
+
+type openAISTTTranscriptionSessionTranscribeTurnsWithPartials struct {
+	*openAISTTTranscriptionSessionTranscribeTurns
+}
+
+func (o openAISTTTranscriptionSessionTranscribeTurnsWithPartials) Seq() iter.Seq[TranscriptionEvent] {
+	return o.events()
+}
+
+// openAISTTTranscriptionFromEvent extracts the transcription from a
+// "conversation.item.input_audio_transcription.completed" event.
+func openAISTTTranscriptionFromEvent(event map[string]any) Transcription {
+	transcript, _ := event["transcript"].(string)
+	language, _ := event["language"].(string)
+	transcription := Transcription{
+		Text:     transcript,
+		Language: language,
+	}
+
+	logprobs, _ := event["logprobs"].([]any)
+	var sum float64
+	var count int
+	for _, item := range logprobs {
+		m, _ := item.(map[string]any)
+		if logprob, ok := m["logprob"].(float64); ok {
+			sum += logprob
+			count++
+		}
+	}
+	if count > 0 {
+		transcription.AvgLogprob = param.NewOpt(sum / float64(count))
+	}
+
+	return transcription
+}
+
 // OpenAISTTModel is a speech-to-text model for OpenAI.
 type OpenAISTTModel struct {
 	model  string
@@ -0,0 +1,161 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_openAISTTTranscriptionFromEvent(t *testing.T) {
+	t.Run("with metadata", func(t *testing.T) {
+		event := map[string]any{
+			"type":       "conversation.item.input_audio_transcription.completed",
+			"transcript": "Bonjour",
+			"language":   "fr",
+			"logprobs": []any{
+				map[string]any{"token": "Bon", "logprob": -0.5},
+				map[string]any{"token": "jour", "logprob": -0.25},
+			},
+		}
+		assert.Equal(t, Transcription{
+			Text:       "Bonjour",
+			Language:   "fr",
+			AvgLogprob: param.NewOpt(-0.375),
+		}, openAISTTTranscriptionFromEvent(event))
+	})
+
+	t.Run("without metadata", func(t *testing.T) {
+		event := map[string]any{
+			"type":       "conversation.item.input_audio_transcription.completed",
+			"transcript": "Hello",
+		}
+		assert.Equal(t, Transcription{Text: "Hello"}, openAISTTTranscriptionFromEvent(event))
+	})
+}
+
+// newFakeSTTWebsocketServer starts a fake realtime transcription server which,
+// once the session is configured and some audio is received, sends the given
+// events and closes the connection.
+func newFakeSTTWebsocketServer(t *testing.T, events []map[string]any) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer func() { _ = c.Close() }()
+
+		send := func(event map[string]any) bool {
+			return assert.NoError(t, c.WriteJSON(event))
+		}
+		receive := func(eventType string) bool {
+			for {
+				var event map[string]any
+				if err := c.ReadJSON(&event); !assert.NoError(t, err) {
+					return false
+				}
+				if event["type"] == eventType {
+					return true
+				}
+			}
+		}
+
+		if !send(map[string]any{"type": "transcription_session.created"}) ||
+			!receive("transcription_session.update") ||
+			!send(map[string]any{"type": "transcription_session.updated"}) ||
+			!receive("input_audio_buffer.append") {
+			return
+		}
+		for _, event := range events {
+			if !send(event) {
+				return
+			}
+		}
+		_ = c.WriteMessage(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newFakeSTTTranscriptionSession(t *testing.T, events []map[string]any) *OpenAISTTTranscriptionSession {
+	t.Helper()
+	server := newFakeSTTWebsocketServer(t, events)
+
+	input := NewStreamedAudioInput()
+	input.AddAudio(AudioDataInt16{1, 2, 3})
+	input.AddAudio(AudioDataInt16{})
+
+	session := NewOpenAISTTTranscriptionSession(OpenAISTTTranscriptionSessionParams{
+		Input:        input,
+		Model:        "gpt-4o-transcribe",
+		WebsocketURL: "ws" + strings.TrimPrefix(server.URL, "http"),
+	})
+	t.Cleanup(func() { _ = session.Close(t.Context()) })
+	return session
+}
+
+func TestOpenAISTTTranscriptionSession_TranscribeTurnsWithPartials(t *testing.T) {
+	events := []map[string]any{
+		{"type": "conversation.item.input_audio_transcription.delta", "item_id": "item_1", "delta": "Hel"},
+		{"type": "conversation.item.input_audio_transcription.delta", "item_id": "item_1", "delta": "lo"},
+		{"type": "conversation.item.input_audio_transcription.completed", "item_id": "item_1", "transcript": "Hello"},
+		{"type": "conversation.item.input_audio_transcription.delta", "item_id": "item_2", "delta": "World"},
+		{"type": "conversation.item.input_audio_transcription.completed", "item_id": "item_2", "transcript": "World!"},
+	}
+
+	t.Run("with partials", func(t *testing.T) {
+		session := newFakeSTTTranscriptionSession(t, events)
+
+		tt := session.TranscribeTurnsWithPartials(t.Context())
+		var got []TranscriptionEvent
+		for event := range tt.Seq() {
+			got = append(got, event)
+		}
+		require.NoError(t, tt.Error())
+
+		assert.Equal(t, []TranscriptionEvent{
+			TranscriptionDelta{ItemID: "item_1", Delta: "Hel", Text: "Hel"},
+			TranscriptionDelta{ItemID: "item_1", Delta: "lo", Text: "Hello"},
+			Transcription{Text: "Hello"},
+			TranscriptionDelta{ItemID: "item_2", Delta: "World", Text: "World"},
+			Transcription{Text: "World!"},
+		}, got)
+	})
+
+	t.Run("finals only", func(t *testing.T) {
+		session := newFakeSTTTranscriptionSession(t, events)
+
+		tt := session.TranscribeTurns(t.Context())
+		var got []string
+		for text := range tt.Seq() {
+			got = append(got, text)
+		}
+		require.NoError(t, tt.Error())
+		assert.Equal(t, []string{"Hello", "World!"}, got)
+	})
+}
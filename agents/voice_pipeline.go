@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"log/slog"
 
 	"github.com/nlpodyssey/openai-agents-go/tracing"
@@ -65,7 +66,13 @@ type VoicePipelineParams struct {
 }
 
 // NewVoicePipeline creates a new voice pipeline.
+//
+// It returns a UserError if the configured TTS settings are invalid.
 func NewVoicePipeline(params VoicePipelineParams) (*VoicePipeline, error) {
+	if err := params.Config.TTSSettings.Validate(); err != nil {
+		return nil, err
+	}
+
 	modelProvider := params.Config.ModelProvider
 	if modelProvider == nil {
 		modelProvider = NewDefaultOpenAIVoiceModelProvider()
@@ -159,7 +166,7 @@ func (p *VoicePipeline) runSingleTurn(ctx context.Context, audioInput AudioInput
 						output.addError(err)
 					}
 				}()
-				runResult := p.workflow.Run(ctx, inputText)
+				runResult := p.runWorkflow(ctx, Transcription{Text: inputText})
 				for textEvent := range runResult.Seq() {
 					if err = output.addText(ctx, textEvent); err != nil {
 						return fmt.Errorf("error adding text to output: %w", err)
@@ -228,10 +235,10 @@ func (p *VoicePipeline) runMultiTurn(ctx context.Context, audioInput StreamedAud
 					output.done()
 				}()
 
-				tt := transcriptionSession.TranscribeTurns(ctx)
+				turns, turnsError := p.transcribeTurns(ctx, transcriptionSession)
 
-				for inputText := range tt.Seq() {
-					result := p.workflow.Run(ctx, inputText)
+				for transcription := range turns {
+					result := p.runWorkflow(ctx, transcription)
 					for textEvent := range result.Seq() {
 						if err = output.addText(ctx, textEvent); err != nil {
 							return fmt.Errorf("error adding text to output: %w", err)
@@ -243,7 +250,7 @@ func (p *VoicePipeline) runMultiTurn(ctx context.Context, audioInput StreamedAud
 
 					output.turnDone(ctx)
 				}
-				if err = tt.Error(); err != nil {
+				if err = turnsError(); err != nil {
 					return fmt.Errorf("error transcribing turns: %w", err)
 				}
 
@@ -257,3 +264,32 @@ func (p *VoicePipeline) runMultiTurn(ctx context.Context, audioInput StreamedAud
 	}
 	return output, nil
 }
+
+// transcribeTurns yields the transcribed turns of the session, including
+// metadata if the session supports it.
+func (p *VoicePipeline) transcribeTurns(
+	ctx context.Context,
+	session StreamedTranscriptionSession,
+) (iter.Seq[Transcription], func() error) {
+	if s, ok := session.(StreamedTranscriptionSessionWithMetadata); ok {
+		tt := s.TranscribeTurnsWithMetadata(ctx)
+		return tt.Seq(), tt.Error
+	}
+
+	tt := session.TranscribeTurns(ctx)
+	seq := func(yield func(Transcription) bool) {
+		for text := range tt.Seq() {
+			if !yield(Transcription{Text: text}) {
+				return
+			}
+		}
+	}
+	return seq, tt.Error
+}
+
+func (p *VoicePipeline) runWorkflow(ctx context.Context, transcription Transcription) VoiceWorkflowBaseRunResult {
+	if w, ok := p.workflow.(VoiceWorkflowTranscriptionRunner); ok {
+		return w.RunTranscription(ctx, transcription)
+	}
+	return p.workflow.Run(ctx, transcription.Text)
+}
@@ -0,0 +1,401 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agents
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTTSModel struct {
+	mu       sync.Mutex
+	settings []TTSModelSettings
+}
+
+func (m *fakeTTSModel) ModelName() string { return "fake-tts" }
+
+func (m *fakeTTSModel) Run(_ context.Context, _ string, settings TTSModelSettings) TTSModelRunResult {
+	m.mu.Lock()
+	m.settings = append(m.settings, settings)
+	m.mu.Unlock()
+	return fakeTTSModelRunResult{}
+}
+
+func (m *fakeTTSModel) receivedSettings() []TTSModelSettings {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.settings)
+}
+
+type fakeTTSModelRunResult struct{}
+
+func (fakeTTSModelRunResult) Seq() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		yield([]byte{1, 0, 2, 0})
+	}
+}
+
+func (fakeTTSModelRunResult) Error() error { return nil }
+
+type fakeSTTModel struct {
+	transcription string
+}
+
+func (m fakeSTTModel) ModelName() string { return "fake-stt" }
+
+func (m fakeSTTModel) Transcribe(context.Context, STTModelTranscribeParams) (string, error) {
+	return m.transcription, nil
+}
+
+func (m fakeSTTModel) CreateSession(context.Context, STTModelCreateSessionParams) (StreamedTranscriptionSession, error) {
+	panic("not implemented")
+}
+
+type fakeVoiceWorkflow struct {
+	outputs []string
+}
+
+func (w fakeVoiceWorkflow) Run(context.Context, string) VoiceWorkflowBaseRunResult {
+	return fakeVoiceWorkflowRunResult{outputs: w.outputs}
+}
+
+func (w fakeVoiceWorkflow) OnStart(context.Context) VoiceWorkflowBaseOnStartResult {
+	return NoOpVoiceWorkflowBaseOnStartResult{}
+}
+
+type fakeVoiceWorkflowRunResult struct {
+	outputs []string
+}
+
+func (r fakeVoiceWorkflowRunResult) Seq() iter.Seq[string] { return slices.Values(r.outputs) }
+func (r fakeVoiceWorkflowRunResult) Error() error          { return nil }
+
+func TestVoicePipelineTTSSettingsReachSynthesis(t *testing.T) {
+	ttsModel := &fakeTTSModel{}
+	pipeline, err := NewVoicePipeline(VoicePipelineParams{
+		Workflow: fakeVoiceWorkflow{outputs: []string{"Hello there."}},
+		STTModel: fakeSTTModel{transcription: "hi"},
+		TTSModel: ttsModel,
+		Config: VoicePipelineConfig{
+			TracingDisabled: true,
+			TTSSettings: TTSModelSettings{
+				Voice: TTSVoiceCoral,
+				Speed: param.NewOpt(1.5),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := pipeline.Run(t.Context(), AudioInput{Buffer: AudioDataInt16{0, 0}})
+	require.NoError(t, err)
+
+	stream := result.Stream(t.Context())
+	for range stream.Seq() {
+	}
+	require.NoError(t, stream.Error())
+
+	settings := ttsModel.receivedSettings()
+	require.NotEmpty(t, settings)
+	for _, s := range settings {
+		assert.Equal(t, TTSVoiceCoral, s.Voice)
+		assert.Equal(t, param.NewOpt(1.5), s.Speed)
+	}
+}
+
+func TestNewVoicePipelineInvalidTTSSettings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings TTSModelSettings
+	}{
+		{"unknown voice", TTSModelSettings{Voice: "robot"}},
+		{"speed too low", TTSModelSettings{Speed: param.NewOpt(0.1)}},
+		{"speed too high", TTSModelSettings{Speed: param.NewOpt(4.5)}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewVoicePipeline(VoicePipelineParams{
+				Workflow: fakeVoiceWorkflow{},
+				STTModel: fakeSTTModel{},
+				TTSModel: &fakeTTSModel{},
+				Config:   VoicePipelineConfig{TTSSettings: tc.settings},
+			})
+			assert.ErrorAs(t, err, &UserError{})
+		})
+	}
+}
+
+// blockingTTSModel streams a first chunk of audio, then blocks until the
+// context is canceled for any text starting with "Long".
+type blockingTTSModel struct{}
+
+func (blockingTTSModel) ModelName() string { return "blocking-tts" }
+
+func (blockingTTSModel) Run(ctx context.Context, text string, _ TTSModelSettings) TTSModelRunResult {
+	return &blockingTTSModelRunResult{ctx: ctx, block: strings.HasPrefix(text, "Long")}
+}
+
+type blockingTTSModelRunResult struct {
+	ctx   context.Context
+	block bool
+	err   error
+}
+
+func (r *blockingTTSModelRunResult) Seq() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		if !yield([]byte{1, 0, 2, 0}) || !r.block {
+			return
+		}
+		<-r.ctx.Done()
+		r.err = r.ctx.Err()
+	}
+}
+
+func (r *blockingTTSModelRunResult) Error() error { return r.err }
+
+type channelSTTModel struct {
+	turns <-chan string
+}
+
+func (m channelSTTModel) ModelName() string { return "channel-stt" }
+
+func (m channelSTTModel) Transcribe(context.Context, STTModelTranscribeParams) (string, error) {
+	panic("not implemented")
+}
+
+func (m channelSTTModel) CreateSession(context.Context, STTModelCreateSessionParams) (StreamedTranscriptionSession, error) {
+	return channelTranscriptionSession{turns: m.turns}, nil
+}
+
+type channelTranscriptionSession struct {
+	turns <-chan string
+}
+
+func (s channelTranscriptionSession) TranscribeTurns(context.Context) StreamedTranscriptionSessionTranscribeTurns {
+	return s
+}
+
+func (s channelTranscriptionSession) Seq() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for turn := range s.turns {
+			if !yield(turn) {
+				return
+			}
+		}
+	}
+}
+
+func (s channelTranscriptionSession) Error() error                { return nil }
+func (s channelTranscriptionSession) Close(context.Context) error { return nil }
+
+// transcriptionVoiceWorkflow yields the transcription itself as output.
+type transcriptionVoiceWorkflow struct{}
+
+func (transcriptionVoiceWorkflow) Run(_ context.Context, transcription string) VoiceWorkflowBaseRunResult {
+	return fakeVoiceWorkflowRunResult{outputs: []string{transcription}}
+}
+
+func (transcriptionVoiceWorkflow) OnStart(context.Context) VoiceWorkflowBaseOnStartResult {
+	return NoOpVoiceWorkflowBaseOnStartResult{}
+}
+
+func TestStreamedAudioResultInterrupt(t *testing.T) {
+	baseGoroutines := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	turns := make(chan string)
+	pipeline, err := NewVoicePipeline(VoicePipelineParams{
+		Workflow: transcriptionVoiceWorkflow{},
+		STTModel: channelSTTModel{turns: turns},
+		TTSModel: blockingTTSModel{},
+		Config: VoicePipelineConfig{
+			TracingDisabled: true,
+			TTSSettings:     TTSModelSettings{BufferSize: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := pipeline.Run(ctx, NewStreamedAudioInput())
+	require.NoError(t, err)
+
+	const interruptions = 5
+	interrupted := make(chan struct{})
+	go func() {
+		for range interruptions {
+			turns <- "Long answer which is going to be interrupted."
+			<-interrupted
+		}
+		turns <- "Short answer."
+		close(turns)
+	}()
+
+	var lifecycleEvents []VoiceStreamEventLifecycleEvent
+	stream := result.Stream(ctx)
+	for event := range stream.Seq() {
+		switch e := event.(type) {
+		case VoiceStreamEventAudio:
+			if len(lifecycleEvents) < 2*interruptions {
+				require.NoError(t, result.Interrupt(ctx))
+			}
+		case VoiceStreamEventLifecycle:
+			lifecycleEvents = append(lifecycleEvents, e.Event)
+			if e.Event == VoiceStreamEventLifecycleEventInterrupted {
+				interrupted <- struct{}{}
+			}
+		}
+	}
+	require.NoError(t, stream.Error())
+
+	var expected []VoiceStreamEventLifecycleEvent
+	for range interruptions {
+		expected = append(expected,
+			VoiceStreamEventLifecycleEventTurnStarted,
+			VoiceStreamEventLifecycleEventInterrupted,
+		)
+	}
+	expected = append(expected,
+		VoiceStreamEventLifecycleEventTurnStarted,
+		VoiceStreamEventLifecycleEventTurnEnded,
+		VoiceStreamEventLifecycleEventSessionEnded,
+	)
+	assert.Equal(t, expected, lifecycleEvents)
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		// The condition itself runs in a separate goroutine.
+		return runtime.NumGoroutine() <= baseGoroutines+1
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestStreamedAudioResultInterruptWithoutTurn(t *testing.T) {
+	result := NewStreamedAudioResult(&fakeTTSModel{}, TTSModelSettings{}, VoicePipelineConfig{})
+	require.NoError(t, result.Interrupt(t.Context()))
+	assert.True(t, result.queue.IsEmpty())
+}
+
+type metadataTranscriptionSession struct {
+	transcriptions []Transcription
+}
+
+func (s metadataTranscriptionSession) TranscribeTurns(context.Context) StreamedTranscriptionSessionTranscribeTurns {
+	panic("not implemented")
+}
+
+func (s metadataTranscriptionSession) TranscribeTurnsWithMetadata(context.Context) StreamedTranscriptionSessionTranscribeTurnsWithMetadata {
+	return s
+}
+
+func (s metadataTranscriptionSession) Seq() iter.Seq[Transcription] {
+	return slices.Values(s.transcriptions)
+}
+func (s metadataTranscriptionSession) Error() error                { return nil }
+func (s metadataTranscriptionSession) Close(context.Context) error { return nil }
+
+type metadataSTTModel struct {
+	session metadataTranscriptionSession
+}
+
+func (m metadataSTTModel) ModelName() string { return "metadata-stt" }
+
+func (m metadataSTTModel) Transcribe(context.Context, STTModelTranscribeParams) (string, error) {
+	panic("not implemented")
+}
+
+func (m metadataSTTModel) CreateSession(context.Context, STTModelCreateSessionParams) (StreamedTranscriptionSession, error) {
+	return m.session, nil
+}
+
+type recordingTranscriptionWorkflow struct {
+	mu             sync.Mutex
+	transcriptions []Transcription
+}
+
+func (w *recordingTranscriptionWorkflow) Run(context.Context, string) VoiceWorkflowBaseRunResult {
+	panic("not implemented")
+}
+
+func (w *recordingTranscriptionWorkflow) RunTranscription(_ context.Context, transcription Transcription) VoiceWorkflowBaseRunResult {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.transcriptions = append(w.transcriptions, transcription)
+	return fakeVoiceWorkflowRunResult{outputs: []string{"Hello there."}}
+}
+
+func (w *recordingTranscriptionWorkflow) OnStart(context.Context) VoiceWorkflowBaseOnStartResult {
+	return NoOpVoiceWorkflowBaseOnStartResult{}
+}
+
+func TestVoicePipelineTranscriptionMetadataReachesWorkflow(t *testing.T) {
+	transcriptions := []Transcription{
+		{Text: "Hello", Language: "en", AvgLogprob: param.NewOpt(-0.1)},
+		{Text: "Bonjour", Language: "fr"},
+	}
+	workflow := &recordingTranscriptionWorkflow{}
+
+	pipeline, err := NewVoicePipeline(VoicePipelineParams{
+		Workflow: workflow,
+		STTModel: metadataSTTModel{session: metadataTranscriptionSession{transcriptions: transcriptions}},
+		TTSModel: &fakeTTSModel{},
+		Config:   VoicePipelineConfig{TracingDisabled: true},
+	})
+	require.NoError(t, err)
+
+	result, err := pipeline.Run(t.Context(), NewStreamedAudioInput())
+	require.NoError(t, err)
+
+	stream := result.Stream(t.Context())
+	for range stream.Seq() {
+	}
+	require.NoError(t, stream.Error())
+
+	assert.Equal(t, transcriptions, workflow.transcriptions)
+}
+
+type transcriptionCallbacks struct {
+	transcriptions []Transcription
+}
+
+func (c *transcriptionCallbacks) OnRun(context.Context, *SingleAgentVoiceWorkflow, string) error {
+	panic("not implemented")
+}
+
+func (c *transcriptionCallbacks) OnRunTranscription(_ context.Context, _ *SingleAgentVoiceWorkflow, transcription Transcription) error {
+	c.transcriptions = append(c.transcriptions, transcription)
+	return errors.New("stop")
+}
+
+func TestSingleAgentVoiceWorkflowTranscriptionCallbacks(t *testing.T) {
+	callbacks := &transcriptionCallbacks{}
+	workflow := NewSingleAgentVoiceWorkflow(New("test"), callbacks)
+
+	transcription := Transcription{Text: "Bonjour", Language: "fr"}
+	result := workflow.RunTranscription(t.Context(), transcription)
+	for range result.Seq() {
+	}
+	assert.EqualError(t, result.Error(), "stop")
+	assert.Equal(t, []Transcription{transcription}, callbacks.transcriptions)
+}
@@ -61,10 +61,23 @@ type StreamedAudioResult struct {
 	queue               *asyncqueue.Queue[VoiceStreamEvent]
 	tasks               []*asynctask.TaskNoValue
 	tasksMu             sync.RWMutex
-	orderedTasks        []*asyncqueue.Queue[VoiceStreamEvent] // List to hold local queues for each text segment
+	orderedTasks        []*audioSegment // List to hold local queues for each text segment
 	orderedTasksMu      sync.RWMutex
 	dispatcherTask      *atomic.Pointer[asynctask.TaskNoValue] // Task to dispatch audio chunks in order
 
+	// interruptMu guards the interruption state below, and serializes
+	// Interrupt with the creation of audio segments and the dispatching of
+	// audio chunks.
+	interruptMu sync.Mutex
+	// Incremented on each interruption. Segments created with an older
+	// generation are stale: their audio is discarded.
+	generation uint64
+	// Whether the workflow is still producing text for the current turn.
+	textTurnActive bool
+	// Whether the remaining text of the current turn must be discarded,
+	// because the turn was interrupted.
+	discardTurnText bool
+
 	doneProcessing        *atomic.Bool
 	bufferSize            int
 	startedProcessingTurn *atomic.Bool
@@ -158,12 +171,43 @@ func (r *StreamedAudioResult) getTasks() []*asynctask.TaskNoValue {
 	return v
 }
 
-func (r *StreamedAudioResult) appendToOrderedTasks(q *asyncqueue.Queue[VoiceStreamEvent]) {
+func (r *StreamedAudioResult) takeTasks() []*asynctask.TaskNoValue {
+	r.tasksMu.Lock()
+	v := r.tasks
+	r.tasks = nil
+	r.tasksMu.Unlock()
+	return v
+}
+
+// audioSegment holds the audio events of a text segment.
+type audioSegment struct {
+	queue      *asyncqueue.Queue[VoiceStreamEvent]
+	generation uint64
+}
+
+// newAudioSegment creates a new segment and appends it to the ordered tasks.
+// It must be called with interruptMu held.
+func (r *StreamedAudioResult) newAudioSegment() *audioSegment {
+	seg := &audioSegment{
+		queue:      asyncqueue.New[VoiceStreamEvent](),
+		generation: r.generation,
+	}
+	r.appendToOrderedTasks(seg)
+	return seg
+}
+
+func (r *StreamedAudioResult) isStale(seg *audioSegment) bool {
+	r.interruptMu.Lock()
+	defer r.interruptMu.Unlock()
+	return seg.generation != r.generation
+}
+
+func (r *StreamedAudioResult) appendToOrderedTasks(seg *audioSegment) {
 	r.orderedTasksMu.Lock()
-	r.orderedTasks = append(r.orderedTasks, q)
+	r.orderedTasks = append(r.orderedTasks, seg)
 	r.orderedTasksMu.Unlock()
 }
-func (r *StreamedAudioResult) popFromOrderedTasks() *asyncqueue.Queue[VoiceStreamEvent] {
+func (r *StreamedAudioResult) popFromOrderedTasks() *audioSegment {
 	r.orderedTasksMu.Lock()
 	defer r.orderedTasksMu.Unlock()
 
@@ -246,9 +290,11 @@ func (r *StreamedAudioResult) transformAudioBuffer(buffer [][]byte, outputDataTy
 func (r *StreamedAudioResult) streamAudio(
 	ctx context.Context,
 	text string,
-	localQueue *asyncqueue.Queue[VoiceStreamEvent],
+	seg *audioSegment,
 	finishTurn bool,
 ) error {
+	localQueue := seg.queue
+
 	var spanInput string
 	if r.voicePipelineConfig.TraceIncludeSensitiveData.Or(true) {
 		spanInput = text
@@ -269,6 +315,12 @@ func (r *StreamedAudioResult) streamAudio(
 		},
 		func(ctx context.Context, ttsSpan tracing.Span) (err error) {
 			defer func() {
+				if err != nil && r.isStale(seg) {
+					// The turn was interrupted: just signal completion for this segment
+					err = nil
+					localQueue.Put(nil)
+					return
+				}
 				if err != nil {
 					var errorText string
 					if r.voicePipelineConfig.TraceIncludeSensitiveData.Or(true) {
@@ -347,6 +399,14 @@ func (r *StreamedAudioResult) streamAudio(
 }
 
 func (r *StreamedAudioResult) addText(ctx context.Context, text string) error {
+	r.interruptMu.Lock()
+	defer r.interruptMu.Unlock()
+
+	if r.discardTurnText {
+		return nil
+	}
+	r.textTurnActive = true
+
 	err := r.startTurn(ctx)
 	if err != nil {
 		return err
@@ -368,11 +428,9 @@ func (r *StreamedAudioResult) addText(ctx context.Context, text string) error {
 
 	r.setTextBuffer(remainingText)
 	if len(combinedSentences) >= 20 {
-		localQueue := asyncqueue.New[VoiceStreamEvent]()
-		r.appendToOrderedTasks(localQueue)
-
+		seg := r.newAudioSegment()
 		r.appendToTasks(asynctask.CreateTaskNoValue(ctx, func(ctx context.Context) error {
-			return r.streamAudio(ctx, combinedSentences, localQueue, false)
+			return r.streamAudio(ctx, combinedSentences, seg, false)
 		}))
 		if r.getDispatcherTask() == nil {
 			r.createDispatcherTask(ctx, func(ctx context.Context) error {
@@ -385,14 +443,20 @@ func (r *StreamedAudioResult) addText(ctx context.Context, text string) error {
 }
 
 func (r *StreamedAudioResult) turnDone(ctx context.Context) {
-	if textBuffer := r.getTextBuffer(); textBuffer != "" {
-		localQueue := asyncqueue.New[VoiceStreamEvent]()
-		r.appendToOrderedTasks(localQueue) // Append the local queue for the final segment
+	r.interruptMu.Lock()
+	if r.discardTurnText {
+		r.setTextBuffer("")
+	} else if textBuffer := r.getTextBuffer(); textBuffer != "" {
+		seg := r.newAudioSegment() // Append the local queue for the final segment
 		r.appendToTasks(asynctask.CreateTaskNoValue(ctx, func(ctx context.Context) error {
-			return r.streamAudio(ctx, textBuffer, localQueue, true)
+			return r.streamAudio(ctx, textBuffer, seg, true)
 		}))
 		r.setTextBuffer("")
 	}
+	r.textTurnActive = false
+	r.discardTurnText = false
+	r.interruptMu.Unlock()
+
 	r.doneProcessing.Store(true)
 	if r.getDispatcherTask() == nil {
 		r.createDispatcherTask(ctx, r.dispatchAudio)
@@ -430,9 +494,12 @@ func (r *StreamedAudioResult) done() {
 // Dispatch audio chunks from each segment in the order they were added.
 func (r *StreamedAudioResult) dispatchAudio(ctx context.Context) error {
 	for {
-		localQueue := r.popFromOrderedTasks()
-		if localQueue == nil {
-			if r.completedSession.Load() {
+		// Segments are all appended before the session is completed: check
+		// for completion before popping, not to miss the last segments.
+		completedSession := r.completedSession.Load()
+		seg := r.popFromOrderedTasks()
+		if seg == nil {
+			if completedSession {
 				break
 			}
 			time.Sleep(1 * time.Nanosecond)
@@ -440,16 +507,15 @@ func (r *StreamedAudioResult) dispatchAudio(ctx context.Context) error {
 		}
 
 		for {
-			chunk := localQueue.Get()
+			chunk := seg.queue.Get()
 			if chunk == nil {
 				break
 			}
-			r.queue.Put(chunk)
-			if e, ok := chunk.(VoiceStreamEventLifecycle); ok && e.Event == VoiceStreamEventLifecycleEventTurnEnded {
-				err := r.finishTurn(ctx)
-				if err != nil {
-					return err
-				}
+			segmentDone, err := r.dispatchChunk(ctx, seg, chunk)
+			if err != nil {
+				return err
+			}
+			if segmentDone {
 				break
 			}
 		}
@@ -460,6 +526,90 @@ func (r *StreamedAudioResult) dispatchAudio(ctx context.Context) error {
 	return nil
 }
 
+// dispatchChunk forwards a chunk of a segment to the output queue, unless
+// the segment is stale. It reports whether the segment is done.
+func (r *StreamedAudioResult) dispatchChunk(ctx context.Context, seg *audioSegment, chunk VoiceStreamEvent) (bool, error) {
+	r.interruptMu.Lock()
+	defer r.interruptMu.Unlock()
+
+	e, isLifecycle := chunk.(VoiceStreamEventLifecycle)
+	turnEnded := isLifecycle && e.Event == VoiceStreamEventLifecycleEventTurnEnded
+	if seg.generation != r.generation {
+		return turnEnded || (isLifecycle && e.Event == VoiceStreamEventLifecycleEventSessionEnded), nil
+	}
+
+	r.queue.Put(chunk)
+	if turnEnded {
+		if err := r.finishTurn(ctx); err != nil {
+			return true, err
+		}
+	}
+	return turnEnded, nil
+}
+
+// Interrupt stops the audio of the current turn.
+//
+// It cancels any in-progress text-to-speech synthesis, discards the audio
+// which has not been streamed yet, together with any text the workflow
+// still produces for the current turn, and emits a
+// VoiceStreamEventLifecycleEventInterrupted event.
+// When using StreamedAudioInput, the transcription session stays alive,
+// and the pipeline keeps processing the next turns.
+//
+// Calling Interrupt while no turn is in progress has no effect.
+func (r *StreamedAudioResult) Interrupt(ctx context.Context) error {
+	r.interruptMu.Lock()
+	if !r.startedProcessingTurn.Load() {
+		r.interruptMu.Unlock()
+		return nil
+	}
+	r.generation++
+	if r.textTurnActive {
+		r.discardTurnText = true
+	}
+	r.setTextBuffer("")
+
+	// Stop the in-progress synthesis. Tasks are taken and canceled with
+	// interruptMu held, so that no segment of a new turn can be among them.
+	// Segments of the previous generation always terminate their queue,
+	// so the dispatcher can't get stuck.
+	var canceled []*asynctask.TaskNoValue
+	for _, task := range r.takeTasks() {
+		if task.IsDone() {
+			r.appendToTasks(task) // keep it for error checking
+			continue
+		}
+		task.Cancel()
+		canceled = append(canceled, task)
+	}
+	r.interruptMu.Unlock()
+
+	// The canceled tasks check whether they are stale, which requires
+	// interruptMu: wait for them only after releasing it.
+	for _, task := range canceled {
+		task.Await()
+	}
+
+	r.interruptMu.Lock()
+	defer r.interruptMu.Unlock()
+
+	// Flush the audio that was already dispatched, but not consumed yet
+	var pending []VoiceStreamEvent
+	for !r.queue.IsEmpty() {
+		if event, ok := r.queue.GetNoWait(); ok {
+			if _, isAudio := event.(VoiceStreamEventAudio); !isAudio {
+				pending = append(pending, event)
+			}
+		}
+	}
+	for _, event := range pending {
+		r.queue.Put(event)
+	}
+
+	r.queue.Put(VoiceStreamEventLifecycle{Event: VoiceStreamEventLifecycleEventInterrupted})
+	return r.finishTurn(ctx)
+}
+
 func (r *StreamedAudioResult) waitForCompletion() {
 	for _, task := range r.getTasks() {
 		task.Await()
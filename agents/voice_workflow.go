@@ -43,6 +43,14 @@ type VoiceWorkflowBase interface {
 	OnStart(context.Context) VoiceWorkflowBaseOnStartResult
 }
 
+// VoiceWorkflowTranscriptionRunner can be implemented by a VoiceWorkflowBase
+// to receive the transcription metadata (such as the detected language)
+// along with the text. When implemented, the VoicePipeline calls
+// RunTranscription instead of Run.
+type VoiceWorkflowTranscriptionRunner interface {
+	RunTranscription(ctx context.Context, transcription Transcription) VoiceWorkflowBaseRunResult
+}
+
 type VoiceWorkflowBaseRunResult interface {
 	Seq() iter.Seq[string]
 	Error() error
@@ -101,6 +109,14 @@ type SingleAgentWorkflowCallbacks interface {
 	OnRun(ctx context.Context, workflow *SingleAgentVoiceWorkflow, transcription string) error
 }
 
+// SingleAgentWorkflowTranscriptionCallbacks can be implemented by
+// SingleAgentWorkflowCallbacks to receive the transcription metadata.
+// When implemented, OnRunTranscription is called instead of OnRun.
+type SingleAgentWorkflowTranscriptionCallbacks interface {
+	// OnRunTranscription is called when the workflow is run.
+	OnRunTranscription(ctx context.Context, workflow *SingleAgentVoiceWorkflow, transcription Transcription) error
+}
+
 // SingleAgentVoiceWorkflow is a simple voice workflow that runs a single agent.
 // Each transcription and result is added to the input history.
 // For more complex workflows (e.g. multiple runner calls, custom message history, custom logic,
@@ -121,6 +137,10 @@ func NewSingleAgentVoiceWorkflow(agent *Agent, callbacks SingleAgentWorkflowCall
 }
 
 func (w *SingleAgentVoiceWorkflow) Run(ctx context.Context, transcription string) VoiceWorkflowBaseRunResult {
+	return w.RunTranscription(ctx, Transcription{Text: transcription})
+}
+
+func (w *SingleAgentVoiceWorkflow) RunTranscription(ctx context.Context, transcription Transcription) VoiceWorkflowBaseRunResult {
 	return &singleAgentVoiceWorkflowRunResult{
 		ctx:           ctx,
 		workflow:      w,
@@ -136,24 +156,26 @@ func (w *SingleAgentVoiceWorkflow) OnStart(context.Context) VoiceWorkflowBaseOnS
 type singleAgentVoiceWorkflowRunResult struct {
 	ctx           context.Context
 	workflow      *SingleAgentVoiceWorkflow
-	transcription string
+	transcription Transcription
 	err           error
 }
 
 func (r *singleAgentVoiceWorkflowRunResult) Seq() iter.Seq[string] {
 	return func(yield func(string) bool) {
-		if r.workflow.callbacks != nil {
-			r.err = r.workflow.callbacks.OnRun(r.ctx, r.workflow, r.transcription)
-			if r.err != nil {
-				return
-			}
+		if callbacks, ok := r.workflow.callbacks.(SingleAgentWorkflowTranscriptionCallbacks); ok {
+			r.err = callbacks.OnRunTranscription(r.ctx, r.workflow, r.transcription)
+		} else if r.workflow.callbacks != nil {
+			r.err = r.workflow.callbacks.OnRun(r.ctx, r.workflow, r.transcription.Text)
+		}
+		if r.err != nil {
+			return
 		}
 
 		// Add the transcription to the input history
 		r.workflow.inputHistory = append(r.workflow.inputHistory, TResponseInputItem{
 			OfMessage: &responses.EasyInputMessageParam{
 				Content: responses.EasyInputMessageContentUnionParam{
-					OfString: param.NewOpt(r.transcription),
+					OfString: param.NewOpt(r.transcription.Text),
 				},
 				Role: responses.EasyInputMessageRoleUser,
 				Type: responses.EasyInputMessageTypeMessage,
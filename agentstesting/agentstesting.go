@@ -91,6 +91,18 @@ func GetFunctionToolCall(name string, arguments string) responses.ResponseOutput
 	}
 }
 
+// GetComputerToolCall returns a computer tool call performing the given
+// action, e.g. {Type: "screenshot"}.
+func GetComputerToolCall(action responses.ResponseOutputItemUnionAction) responses.ResponseOutputItemUnion {
+	return responses.ResponseOutputItemUnion{ // responses.ResponseComputerToolCall
+		ID:     "1",
+		CallID: "2",
+		Type:   "computer_call",
+		Action: action,
+		Status: "completed",
+	}
+}
+
 func GetHandoffToolCall(
 	toAgent *agents.Agent,
 	overrideName string,
@@ -0,0 +1,105 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstesting
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nlpodyssey/openai-agents-go/computer"
+)
+
+// FakeComputer is a computer.Computer test driver which records the actions
+// it performs, and returns the same screenshot every time.
+// It is safe for concurrent use.
+type FakeComputer struct {
+	// The base64-encoded PNG image returned by Screenshot.
+	ScreenshotData string
+
+	mu    sync.Mutex
+	calls [][]any
+}
+
+func NewFakeComputer(screenshotData string) *FakeComputer {
+	return &FakeComputer{ScreenshotData: screenshotData}
+}
+
+// Calls returns the actions performed so far, in order, each one as its
+// method name followed by its arguments, e.g. {"Click", int64(1), int64(2),
+// computer.ButtonLeft}.
+func (c *FakeComputer) Calls() [][]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][]any(nil), c.calls...)
+}
+
+func (c *FakeComputer) record(call ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+func (c *FakeComputer) Environment(context.Context) (computer.Environment, error) {
+	return computer.EnvironmentLinux, nil
+}
+
+func (c *FakeComputer) Dimensions(context.Context) (computer.Dimensions, error) {
+	return computer.Dimensions{Width: 800, Height: 600}, nil
+}
+
+func (c *FakeComputer) Screenshot(context.Context) (string, error) {
+	c.record("Screenshot")
+	return c.ScreenshotData, nil
+}
+
+func (c *FakeComputer) Click(_ context.Context, x, y int64, button computer.Button) error {
+	c.record("Click", x, y, button)
+	return nil
+}
+
+func (c *FakeComputer) DoubleClick(_ context.Context, x, y int64) error {
+	c.record("DoubleClick", x, y)
+	return nil
+}
+
+func (c *FakeComputer) Scroll(_ context.Context, x, y int64, scrollX, scrollY int64) error {
+	c.record("Scroll", x, y, scrollX, scrollY)
+	return nil
+}
+
+func (c *FakeComputer) Type(_ context.Context, text string) error {
+	c.record("Type", text)
+	return nil
+}
+
+func (c *FakeComputer) Wait(context.Context) error {
+	c.record("Wait")
+	return nil
+}
+
+func (c *FakeComputer) Move(_ context.Context, x, y int64) error {
+	c.record("Move", x, y)
+	return nil
+}
+
+func (c *FakeComputer) Keypress(_ context.Context, keys []string) error {
+	c.record("Keypress", keys)
+	return nil
+}
+
+func (c *FakeComputer) Drag(_ context.Context, path []computer.Position) error {
+	c.record("Drag", path)
+	return nil
+}
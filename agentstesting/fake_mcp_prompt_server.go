@@ -100,3 +100,11 @@ func (s *FakeMCPPromptServer) GetPrompt(_ context.Context, name string, argument
 		Messages:    []*mcp.PromptMessage{message},
 	}, nil
 }
+
+func (s *FakeMCPPromptServer) ListResources(context.Context) (*mcp.ListResourcesResult, error) {
+	return &mcp.ListResourcesResult{}, nil
+}
+
+func (s *FakeMCPPromptServer) ReadResource(_ context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	return nil, fmt.Errorf("resource %q not found", uri)
+}
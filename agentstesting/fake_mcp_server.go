@@ -31,6 +31,9 @@ type FakeMCPServer struct {
 	ToolCalls   []string
 	ToolResults []string
 	ToolFilter  agents.MCPToolFilter
+	Resources   []*mcp.Resource
+	// Text contents of the resources, by URI.
+	ResourceContents map[string]string
 }
 
 func NewFakeMCPServer(
@@ -52,6 +55,19 @@ func (s *FakeMCPServer) AddTool(name string, inputSchema *jsonschema.Schema) {
 	})
 }
 
+// AddResource adds a text resource to the fake server.
+func (s *FakeMCPServer) AddResource(uri, name, text string) {
+	s.Resources = append(s.Resources, &mcp.Resource{
+		URI:      uri,
+		Name:     name,
+		MIMEType: "text/plain",
+	})
+	if s.ResourceContents == nil {
+		s.ResourceContents = make(map[string]string)
+	}
+	s.ResourceContents[uri] = text
+}
+
 func (s *FakeMCPServer) Connect(context.Context) error { return nil }
 func (s *FakeMCPServer) Cleanup(context.Context) error { return nil }
 func (s *FakeMCPServer) Name() string                  { return s.name }
@@ -99,3 +115,19 @@ func (s *FakeMCPServer) GetPrompt(_ context.Context, name string, _ map[string]s
 		Messages:    []*mcp.PromptMessage{message},
 	}, nil
 }
+
+// ListResources returns the resources added to the fake server.
+func (s *FakeMCPServer) ListResources(context.Context) (*mcp.ListResourcesResult, error) {
+	return &mcp.ListResourcesResult{Resources: s.Resources}, nil
+}
+
+// ReadResource returns the text contents of a resource added to the fake server.
+func (s *FakeMCPServer) ReadResource(_ context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	text, ok := s.ResourceContents[uri]
+	if !ok {
+		return nil, fmt.Errorf("resource %q not found", uri)
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: "text/plain", Text: text}},
+	}, nil
+}
@@ -109,10 +109,9 @@ func (m *FakeModel) GetResponse(ctx context.Context, params agents.ModelResponse
 				return err
 			}
 
-			u := m.HardcodedUsage
-			if u == nil {
-				u = usage.NewUsage()
-			}
+			// Each response gets its own copy, since the runner updates it
+			u := usage.NewUsage()
+			u.Add(m.HardcodedUsage)
 
 			modelResponse = &agents.ModelResponse{
 				Output:     output.Value,
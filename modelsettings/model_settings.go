@@ -15,7 +15,10 @@
 package modelsettings
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"maps"
 	"reflect"
 
@@ -45,6 +48,22 @@ type ModelSettings struct {
 	// The presence penalty to use when calling the model.
 	PresencePenalty param.Opt[float64] `json:"presence_penalty"`
 
+	// Optional map from token IDs to bias values (from -100 to 100), to
+	// modify the likelihood of the specified tokens appearing in the output.
+	// Only available for Chat Completions API: it is ignored by the Responses
+	// API, and by models that don't support it.
+	LogitBias map[string]int64 `json:"logit_bias"`
+
+	// Optional sequences (up to 4) where the model will stop generating
+	// further tokens. Only available for Chat Completions API.
+	Stop []string `json:"stop"`
+
+	// Optional seed for deterministic sampling: repeated requests with the
+	// same seed and parameters should return the same result, on a best
+	// effort basis (see ModelResponse.SystemFingerprint in the agents package
+	// to detect backend changes). Only available for Chat Completions API.
+	Seed param.Opt[int64] `json:"seed"`
+
 	// Optional tool choice to use when calling the model.
 	ToolChoice ToolChoice `json:"tool_choice"`
 
@@ -53,7 +72,12 @@ type ModelSettings struct {
 	// For most current providers (e.g., OpenAI), this typically means parallel tool calls
 	// are enabled (true).
 	// Set to true to explicitly enable parallel tool calls, or false to restrict the
-	// model to at most one tool call per turn.
+	// model to at most one tool call per turn, e.g. for models which behave better
+	// calling tools sequentially.
+	// It is sent to both the Responses and Chat Completions APIs; a true value is
+	// only sent when the agent has tools or handoffs. Providers exposing an
+	// OpenAI-compatible Chat Completions API may ignore it, or reject it: in the
+	// latter case, leave it unset.
 	ParallelToolCalls param.Opt[bool] `json:"parallel_tool_calls"`
 
 	// The truncation strategy to use when calling the model.
@@ -71,6 +95,11 @@ type ModelSettings struct {
 	// Constrains the verbosity of the model's response.
 	Verbosity param.Opt[Verbosity] `json:"verbosity"`
 
+	// Optional format of the model's response, without a JSON schema, e.g.
+	// ResponseFormatJSONObject to get any valid JSON object. The schema
+	// derived from the agent's OutputType takes precedence over this setting.
+	ResponseFormat param.Opt[ResponseFormat] `json:"response_format"`
+
 	// Optional metadata to include with the model response call.
 	Metadata map[string]string `json:"metadata"`
 
@@ -79,6 +108,12 @@ type ModelSettings struct {
 	// For Chat Completions API: disabled when not specified.
 	Store param.Opt[bool] `json:"store"`
 
+	// Whether to run the model response in the background.
+	// Only available for Responses API: the model polls the background
+	// response until it is no longer queued or in progress. It can't be
+	// combined with Store set to false.
+	Background param.Opt[bool] `json:"background"`
+
 	// Whether to include usage chunk.
 	//Only available for Chat Completions API.
 	IncludeUsage param.Opt[bool] `json:"include_usage"`
@@ -120,6 +155,18 @@ const (
 	VerbosityHigh   Verbosity = "high"
 )
 
+type ResponseFormat string
+
+const (
+	// ResponseFormatText is the default format of plain text responses.
+	ResponseFormatText ResponseFormat = "text"
+	// ResponseFormatJSONObject is the JSON mode, which ensures the model
+	// generates a valid JSON object, without constraining it to a schema.
+	// Note that the model must be instructed to produce JSON, e.g. in the
+	// system prompt.
+	ResponseFormatJSONObject ResponseFormat = "json_object"
+)
+
 type ToolChoice interface {
 	isToolChoice()
 }
@@ -149,6 +196,57 @@ const (
 	TruncationDisabled Truncation = "disabled"
 )
 
+// UnmarshalJSON implements json.Unmarshaler, decoding ToolChoice either as
+// a ToolChoiceString or as a ToolChoiceMCP.
+func (ms *ModelSettings) UnmarshalJSON(data []byte) error {
+	type alias ModelSettings
+	aux := struct {
+		*alias
+		ToolChoice json.RawMessage `json:"tool_choice"`
+	}{alias: (*alias)(ms)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	toolChoice, err := unmarshalToolChoice(aux.ToolChoice)
+	if err != nil {
+		return err
+	}
+	ms.ToolChoice = toolChoice
+
+	// JSON null values are decoded as explicit nulls: reset them to omitted
+	// values, as if they were never set.
+	v := reflect.ValueOf(ms).Elem()
+	for i := range v.NumField() {
+		if opt, ok := v.Field(i).Interface().(interface{ Valid() bool }); ok && !opt.Valid() {
+			v.Field(i).SetZero()
+		}
+	}
+	return nil
+}
+
+func unmarshalToolChoice(data json.RawMessage) (ToolChoice, error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		return nil, nil
+	case data[0] == '"':
+		var s ToolChoiceString
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case data[0] == '{':
+		var mcp ToolChoiceMCP
+		if err := json.Unmarshal(data, &mcp); err != nil {
+			return nil, err
+		}
+		return mcp, nil
+	default:
+		return nil, fmt.Errorf("invalid tool_choice JSON value %s", data)
+	}
+}
+
 // Resolve produces a new ModelSettings by overlaying any present values from
 // the override on top of this instance.
 func (ms ModelSettings) Resolve(override ModelSettings) ModelSettings {
@@ -157,14 +255,19 @@ func (ms ModelSettings) Resolve(override ModelSettings) ModelSettings {
 	resolveOpt(&newSettings.TopP, override.TopP)
 	resolveOpt(&newSettings.FrequencyPenalty, override.FrequencyPenalty)
 	resolveOpt(&newSettings.PresencePenalty, override.PresencePenalty)
+	resolveMap(&newSettings.LogitBias, override.LogitBias)
+	resolveAny(&newSettings.Stop, override.Stop)
+	resolveOpt(&newSettings.Seed, override.Seed)
 	resolveAny(&newSettings.ToolChoice, override.ToolChoice)
 	resolveOpt(&newSettings.ParallelToolCalls, override.ParallelToolCalls)
 	resolveOpt(&newSettings.Truncation, override.Truncation)
 	resolveOpt(&newSettings.MaxTokens, override.MaxTokens)
 	resolveAny(&newSettings.Reasoning, override.Reasoning)
 	resolveOpt(&newSettings.Verbosity, override.Verbosity)
+	resolveOpt(&newSettings.ResponseFormat, override.ResponseFormat)
 	resolveMap(&newSettings.Metadata, override.Metadata)
 	resolveOpt(&newSettings.Store, override.Store)
+	resolveOpt(&newSettings.Background, override.Background)
 	resolveOpt(&newSettings.IncludeUsage, override.IncludeUsage)
 	resolveAny(&newSettings.ResponseInclude, override.ResponseInclude)
 	resolveOpt(&newSettings.TopLogprobs, override.TopLogprobs)
@@ -47,14 +47,19 @@ func TestModelSettings_BasicSerialization(t *testing.T) {
 		"top_p":               json.Number("0.9"),
 		"frequency_penalty":   nil,
 		"presence_penalty":    nil,
+		"logit_bias":          nil,
+		"stop":                nil,
+		"seed":                nil,
 		"tool_choice":         nil,
 		"parallel_tool_calls": nil,
 		"truncation":          nil,
 		"max_tokens":          json.Number("100"),
 		"reasoning":           map[string]any{},
 		"verbosity":           nil,
+		"response_format":     nil,
 		"metadata":            nil,
 		"store":               nil,
+		"background":          nil,
 		"include_usage":       nil,
 		"response_include":    nil,
 		"top_logprobs":        nil,
@@ -71,14 +76,19 @@ func TestModelSettings_AllFieldsSerialization(t *testing.T) {
 		TopP:              param.NewOpt(0.9),
 		FrequencyPenalty:  param.NewOpt(0.0),
 		PresencePenalty:   param.NewOpt(0.0),
+		LogitBias:         map[string]int64{"42": -100},
+		Stop:              []string{"END"},
+		Seed:              param.NewOpt[int64](42),
 		ToolChoice:        ToolChoiceAuto,
 		ParallelToolCalls: param.NewOpt(true),
 		Truncation:        param.NewOpt(TruncationAuto),
 		MaxTokens:         param.NewOpt[int64](100),
 		Reasoning:         openai.ReasoningParam{},
 		Verbosity:         param.NewOpt(VerbosityMedium),
+		ResponseFormat:    param.NewOpt(ResponseFormatJSONObject),
 		Metadata:          map[string]string{"foo": "bar"},
 		Store:             param.NewOpt(false),
+		Background:        param.NewOpt(true),
 		IncludeUsage:      param.NewOpt(false),
 		ResponseInclude:   []responses.ResponseIncludable{responses.ResponseIncludableFileSearchCallResults},
 		TopLogprobs:       param.NewOpt(int64(1)),
@@ -97,14 +107,19 @@ func TestModelSettings_AllFieldsSerialization(t *testing.T) {
 		"top_p":               json.Number("0.9"),
 		"frequency_penalty":   json.Number("0"),
 		"presence_penalty":    json.Number("0"),
+		"logit_bias":          map[string]any{"42": json.Number("-100")},
+		"stop":                []any{"END"},
+		"seed":                json.Number("42"),
 		"tool_choice":         "auto",
 		"parallel_tool_calls": true,
 		"truncation":          "auto",
 		"max_tokens":          json.Number("100"),
 		"reasoning":           map[string]any{},
 		"verbosity":           "medium",
+		"response_format":     "json_object",
 		"metadata":            map[string]any{"foo": "bar"},
 		"store":               false,
+		"background":          true,
 		"include_usage":       false,
 		"response_include":    []any{"file_search_call.results"},
 		"top_logprobs":        json.Number("1"),
@@ -134,6 +149,9 @@ func TestModelSettings_ToolChoiceMCPSerialization(t *testing.T) {
 		"top_p":             nil,
 		"frequency_penalty": nil,
 		"presence_penalty":  nil,
+		"logit_bias":        nil,
+		"stop":              nil,
+		"seed":              nil,
 		"tool_choice": map[string]any{
 			"server_label": "mcp",
 			"name":         "mcp_tool",
@@ -143,8 +161,10 @@ func TestModelSettings_ToolChoiceMCPSerialization(t *testing.T) {
 		"max_tokens":          nil,
 		"reasoning":           map[string]any{},
 		"verbosity":           nil,
+		"response_format":     nil,
 		"metadata":            nil,
 		"store":               nil,
+		"background":          nil,
 		"include_usage":       nil,
 		"response_include":    nil,
 		"top_logprobs":        nil,
@@ -154,6 +174,35 @@ func TestModelSettings_ToolChoiceMCPSerialization(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+// Tests whether ModelSettings survive a JSON round trip, including ToolChoice.
+func TestModelSettings_Deserialization(t *testing.T) {
+	for _, toolChoice := range []ToolChoice{
+		nil,
+		ToolChoiceRequired,
+		ToolChoiceString("my_tool"),
+		ToolChoiceMCP{ServerLabel: "mcp", Name: "mcp_tool"},
+	} {
+		modelSettings := ModelSettings{
+			Temperature: param.NewOpt(0.5),
+			MaxTokens:   param.NewOpt[int64](100),
+			ToolChoice:  toolChoice,
+			Reasoning:   openai.ReasoningParam{Effort: openai.ReasoningEffortLow},
+			Metadata:    map[string]string{"foo": "bar"},
+		}
+		res, err := json.Marshal(modelSettings)
+		require.NoError(t, err)
+
+		var got ModelSettings
+		require.NoError(t, json.Unmarshal(res, &got))
+		assert.Equal(t, modelSettings, got)
+	}
+
+	t.Run("invalid tool choice", func(t *testing.T) {
+		var got ModelSettings
+		assert.Error(t, json.Unmarshal([]byte(`{"tool_choice":42}`), &got))
+	})
+}
+
 func unmarshal(data []byte, v any) error {
 	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
@@ -167,6 +216,9 @@ func TestModelSettings_Resolve(t *testing.T) {
 		TopP:              param.NewOpt(0.9),
 		FrequencyPenalty:  param.NewOpt(0.0),
 		PresencePenalty:   param.NewOpt[float64](0.0),
+		LogitBias:         map[string]int64{"42": -100},
+		Stop:              []string{"END"},
+		Seed:              param.NewOpt[int64](42),
 		ToolChoice:        ToolChoiceAuto,
 		ParallelToolCalls: param.NewOpt(true),
 		Truncation:        param.NewOpt(TruncationAuto),
@@ -176,8 +228,10 @@ func TestModelSettings_Resolve(t *testing.T) {
 			Summary: openai.ReasoningSummaryConcise,
 		},
 		Verbosity:                       param.NewOpt(VerbosityMedium),
+		ResponseFormat:                  param.NewOpt(ResponseFormatText),
 		Metadata:                        map[string]string{"foo": "bar"},
 		Store:                           param.NewOpt(false),
+		Background:                      param.NewOpt(false),
 		IncludeUsage:                    param.NewOpt(false),
 		ResponseInclude:                 []responses.ResponseIncludable{responses.ResponseIncludableFileSearchCallResults},
 		TopLogprobs:                     param.NewOpt(int64(1)),
@@ -192,14 +246,18 @@ func TestModelSettings_Resolve(t *testing.T) {
 			Temperature:      param.NewOpt(0.4),
 			FrequencyPenalty: param.NewOpt(0.1),
 			ToolChoice:       ToolChoiceRequired,
+			LogitBias:        map[string]int64{"7": 5},
+			Seed:             param.NewOpt[int64](7),
 			Truncation:       param.NewOpt(TruncationDisabled),
 			Reasoning: openai.ReasoningParam{
 				Effort:  openai.ReasoningEffortMedium,
 				Summary: openai.ReasoningSummaryDetailed,
 			},
-			Verbosity:  param.NewOpt(VerbosityHigh),
-			Store:      param.NewOpt(true),
-			ExtraQuery: map[string]string{"a": "b"},
+			Verbosity:      param.NewOpt(VerbosityHigh),
+			ResponseFormat: param.NewOpt(ResponseFormatJSONObject),
+			Store:          param.NewOpt(true),
+			Background:     param.NewOpt(true),
+			ExtraQuery:     map[string]string{"a": "b"},
 			CustomizeResponsesRequest: func(context.Context, *responses.ResponseNewParams, []option.RequestOption) (*responses.ResponseNewParams, []option.RequestOption, error) {
 				return nil, nil, nil
 			},
@@ -211,6 +269,9 @@ func TestModelSettings_Resolve(t *testing.T) {
 		assert.Equal(t, param.NewOpt(0.9), resolved.TopP)
 		assert.Equal(t, param.NewOpt(0.1), resolved.FrequencyPenalty)
 		assert.Equal(t, param.NewOpt(0.0), resolved.PresencePenalty)
+		assert.Equal(t, map[string]int64{"7": 5}, resolved.LogitBias)
+		assert.Equal(t, param.NewOpt[int64](7), resolved.Seed)
+		assert.Equal(t, []string{"END"}, resolved.Stop)
 		assert.Equal(t, ToolChoiceRequired, resolved.ToolChoice)
 		assert.Equal(t, param.NewOpt(true), resolved.ParallelToolCalls)
 		assert.Equal(t, param.NewOpt(TruncationDisabled), resolved.Truncation)
@@ -220,8 +281,10 @@ func TestModelSettings_Resolve(t *testing.T) {
 			Summary: openai.ReasoningSummaryDetailed,
 		}, resolved.Reasoning)
 		assert.Equal(t, param.NewOpt(VerbosityHigh), resolved.Verbosity)
+		assert.Equal(t, param.NewOpt(ResponseFormatJSONObject), resolved.ResponseFormat)
 		assert.Equal(t, map[string]string{"foo": "bar"}, resolved.Metadata)
 		assert.Equal(t, param.NewOpt(true), resolved.Store)
+		assert.Equal(t, param.NewOpt(true), resolved.Background)
 		assert.Equal(t, param.NewOpt(false), resolved.IncludeUsage)
 		assert.Equal(t, []responses.ResponseIncludable{responses.ResponseIncludableFileSearchCallResults}, resolved.ResponseInclude)
 		assert.Equal(t, param.NewOpt(int64(1)), resolved.TopLogprobs)
@@ -235,6 +298,7 @@ func TestModelSettings_Resolve(t *testing.T) {
 		override := ModelSettings{
 			TopP:              param.NewOpt(0.8),
 			PresencePenalty:   param.NewOpt(0.2),
+			Stop:              []string{"STOP", "DONE"},
 			ParallelToolCalls: param.NewOpt(false),
 			MaxTokens:         param.NewOpt[int64](42),
 			Metadata:          map[string]string{"a": "b"},
@@ -253,6 +317,9 @@ func TestModelSettings_Resolve(t *testing.T) {
 		assert.Equal(t, param.NewOpt(0.8), resolved.TopP)
 		assert.Equal(t, param.NewOpt(0.0), resolved.FrequencyPenalty)
 		assert.Equal(t, param.NewOpt(0.2), resolved.PresencePenalty)
+		assert.Equal(t, map[string]int64{"42": -100}, resolved.LogitBias)
+		assert.Equal(t, param.NewOpt[int64](42), resolved.Seed)
+		assert.Equal(t, []string{"STOP", "DONE"}, resolved.Stop)
 		assert.Equal(t, ToolChoiceAuto, resolved.ToolChoice)
 		assert.Equal(t, param.NewOpt(false), resolved.ParallelToolCalls)
 		assert.Equal(t, param.NewOpt(TruncationAuto), resolved.Truncation)
@@ -262,8 +329,10 @@ func TestModelSettings_Resolve(t *testing.T) {
 			Summary: openai.ReasoningSummaryConcise,
 		}, resolved.Reasoning)
 		assert.Equal(t, param.NewOpt(VerbosityMedium), resolved.Verbosity)
+		assert.Equal(t, param.NewOpt(ResponseFormatText), resolved.ResponseFormat)
 		assert.Equal(t, map[string]string{"a": "b"}, resolved.Metadata)
 		assert.Equal(t, param.NewOpt(false), resolved.Store)
+		assert.Equal(t, param.NewOpt(false), resolved.Background)
 		assert.Equal(t, param.NewOpt(true), resolved.IncludeUsage)
 		assert.Equal(t, []responses.ResponseIncludable{responses.ResponseIncludableMessageInputImageImageURL}, resolved.ResponseInclude)
 		assert.Equal(t, param.NewOpt(int64(2)), resolved.TopLogprobs)
@@ -122,6 +122,11 @@ func ResponseOutputTextAnnotationUnionToParam(
 		return responses.ResponseOutputTextAnnotationUnionParam{
 			OfURLCitation: &v,
 		}
+	case "container_file_citation":
+		v := ResponseOutputTextAnnotationContainerFileCitationParamFromResponseOutputTextAnnotationUnion(input)
+		return responses.ResponseOutputTextAnnotationUnionParam{
+			OfContainerFileCitation: &v,
+		}
 	case "file_path":
 		v := ResponseOutputTextAnnotationFilePathParamFromResponseOutputTextAnnotationUnion(input)
 		return responses.ResponseOutputTextAnnotationUnionParam{
@@ -136,9 +141,23 @@ func ResponseOutputTextAnnotationFileCitationParamFromResponseOutputTextAnnotati
 	input responses.ResponseOutputTextAnnotationUnion,
 ) responses.ResponseOutputTextAnnotationFileCitationParam {
 	return responses.ResponseOutputTextAnnotationFileCitationParam{
-		FileID: input.FileID,
-		Index:  input.Index,
-		Type:   constant.ValueOf[constant.FileCitation](),
+		FileID:   input.FileID,
+		Filename: input.Filename,
+		Index:    input.Index,
+		Type:     constant.ValueOf[constant.FileCitation](),
+	}
+}
+
+func ResponseOutputTextAnnotationContainerFileCitationParamFromResponseOutputTextAnnotationUnion(
+	input responses.ResponseOutputTextAnnotationUnion,
+) responses.ResponseOutputTextAnnotationContainerFileCitationParam {
+	return responses.ResponseOutputTextAnnotationContainerFileCitationParam{
+		ContainerID: input.ContainerID,
+		EndIndex:    input.EndIndex,
+		FileID:      input.FileID,
+		Filename:    input.Filename,
+		StartIndex:  input.StartIndex,
+		Type:        constant.ValueOf[constant.ContainerFileCitation](),
 	}
 }
 
@@ -226,15 +245,52 @@ func ResponseReasoningItemToParam(
 	if input.EncryptedContent != "" {
 		encryptedContent = param.NewOpt(input.EncryptedContent)
 	}
+	summary := ResponseReasoningItemSummarySliceToParams(input.Summary)
+	if summary == nil {
+		// The summary is required, even if empty.
+		summary = []responses.ResponseReasoningItemSummaryParam{}
+	}
 	return responses.ResponseReasoningItemParam{
 		ID:               input.ID,
-		Summary:          ResponseReasoningItemSummarySliceToParams(input.Summary),
+		Summary:          summary,
+		Content:          ResponseReasoningItemContentSliceToParams(input.Content),
 		Status:           input.Status,
 		EncryptedContent: encryptedContent,
 		Type:             constant.ValueOf[constant.Reasoning](),
 	}
 }
 
+func ResponseReasoningItemContentSliceToParams(
+	input []responses.ResponseReasoningItemContent,
+) []responses.ResponseReasoningItemContentParam {
+	if input == nil {
+		return nil
+	}
+	out := make([]responses.ResponseReasoningItemContentParam, len(input))
+	for i, item := range input {
+		out[i] = responses.ResponseReasoningItemContentParam{
+			Text: item.Text,
+			Type: constant.ValueOf[constant.ReasoningText](),
+		}
+	}
+	return out
+}
+
+func ResponseReasoningItemContentSliceFromResponseOutputMessageContentUnions(
+	input []responses.ResponseOutputMessageContentUnion,
+) []responses.ResponseReasoningItemContent {
+	var out []responses.ResponseReasoningItemContent
+	for _, item := range input {
+		if item.Type == "reasoning_text" {
+			out = append(out, responses.ResponseReasoningItemContent{
+				Text: item.Text,
+				Type: constant.ValueOf[constant.ReasoningText](),
+			})
+		}
+	}
+	return out
+}
+
 func ResponseReasoningItemSummarySliceToParams(
 	input []responses.ResponseReasoningItemSummary,
 ) []responses.ResponseReasoningItemSummaryParam {
@@ -316,10 +372,12 @@ func ResponseInputItemUnionParamFromResponseOutputItemUnion(
 		})
 	case "reasoning":
 		return ResponseInputItemUnionParamFromResponseReasoningItem(responses.ResponseReasoningItem{
-			ID:      input.ID,
-			Summary: input.Summary,
-			Type:    constant.ValueOf[constant.Reasoning](),
-			Status:  responses.ResponseReasoningItemStatus(input.Status),
+			ID:               input.ID,
+			Summary:          input.Summary,
+			Type:             constant.ValueOf[constant.Reasoning](),
+			Content:          ResponseReasoningItemContentSliceFromResponseOutputMessageContentUnions(input.Content),
+			EncryptedContent: input.EncryptedContent,
+			Status:           responses.ResponseReasoningItemStatus(input.Status),
 		})
 	default:
 		panic(fmt.Errorf("unexpected ResponseOutputItemUnion type %q", input.Type))
@@ -0,0 +1,215 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/openai/openai-go/v3/responses"
+)
+
+// DataScrubber redacts sensitive data from a string included in span data.
+type DataScrubber func(string) string
+
+// RedactedPlaceholder replaces the sensitive data removed by the
+// DataScrubber returned by NewRegexpDataScrubber.
+const RedactedPlaceholder = "[REDACTED]"
+
+// DefaultSensitiveDataPatterns match commonly sensitive data: email
+// addresses, OpenAI-style and AWS access keys, and bearer tokens.
+var DefaultSensitiveDataPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}`),
+	regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]+=*`),
+}
+
+// NewRegexpDataScrubber returns a DataScrubber replacing every match of the
+// given patterns with RedactedPlaceholder. Without patterns,
+// DefaultSensitiveDataPatterns are used.
+func NewRegexpDataScrubber(patterns ...*regexp.Regexp) DataScrubber {
+	if len(patterns) == 0 {
+		patterns = DefaultSensitiveDataPatterns
+	}
+	return func(s string) string {
+		for _, pattern := range patterns {
+			s = pattern.ReplaceAllString(s, RedactedPlaceholder)
+		}
+		return s
+	}
+}
+
+type dataScrubberContextKey struct{}
+
+// ContextWithDataScrubber returns a context carrying the given DataScrubber,
+// which is applied to the data of the spans finished with this context (or
+// any derived one), before they are passed to the processors.
+func ContextWithDataScrubber(ctx context.Context, scrubber DataScrubber) context.Context {
+	return context.WithValue(ctx, dataScrubberContextKey{}, scrubber)
+}
+
+// DataScrubberFromContext returns the DataScrubber set on the context, if any.
+func DataScrubberFromContext(ctx context.Context) DataScrubber {
+	scrubber, _ := ctx.Value(dataScrubberContextKey{}).(DataScrubber)
+	return scrubber
+}
+
+// ScrubSpanData applies the scrubber to the inputs and outputs held by the
+// given span data, which must be a pointer to be modified. Audio data is
+// left untouched.
+func ScrubSpanData(data SpanData, scrub DataScrubber) {
+	switch sd := data.(type) {
+	case *AgentSpanData, *HandoffSpanData, *GuardrailSpanData, *MCPListToolsSpanData:
+		// No inputs or outputs.
+	case *FunctionSpanData:
+		sd.Input = scrub(sd.Input)
+		if sd.Output != nil {
+			sd.Output = scrub(fmt.Sprintf("%+v", sd.Output))
+		}
+		sd.MCPData = scrubMap(sd.MCPData, scrub)
+	case *GenerationSpanData:
+		sd.Input = scrubMaps(sd.Input, scrub)
+		sd.Output = scrubMaps(sd.Output, scrub)
+	case *ResponseSpanData:
+		sd.Input = scrubValue(sd.Input, scrub)
+		sd.Response = scrubResponse(sd.Response, scrub)
+	case *CustomSpanData:
+		sd.Data = scrubMap(sd.Data, scrub)
+	case *TranscriptionSpanData:
+		sd.Output = scrub(sd.Output)
+	case *SpeechSpanData:
+		sd.Input = scrub(sd.Input)
+	case *SpeechGroupSpanData:
+		sd.Input = scrub(sd.Input)
+	}
+}
+
+func scrubMap(m map[string]any, scrub DataScrubber) map[string]any {
+	return scrubValue(m, scrub).(map[string]any)
+}
+
+func scrubMaps(maps []map[string]any, scrub DataScrubber) []map[string]any {
+	return scrubValue(maps, scrub).([]map[string]any)
+}
+
+// scrubResponse returns a copy of the response with scrubbed strings.
+// The response goes through JSON, so that the raw JSON it was decoded from,
+// available via RawJSON, is scrubbed too. If that fails, nil is returned
+// rather than leaking unscrubbed data.
+func scrubResponse(response *responses.Response, scrub DataScrubber) *responses.Response {
+	if response == nil {
+		return nil
+	}
+	raw := []byte(response.RawJSON())
+	if len(raw) == 0 {
+		var err error
+		if raw, err = json.Marshal(response); err != nil {
+			return nil
+		}
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	scrubbedJSON, err := json.Marshal(scrubValue(v, scrub))
+	if err != nil {
+		return nil
+	}
+	scrubbed := new(responses.Response)
+	if err = scrubbed.UnmarshalJSON(scrubbedJSON); err != nil {
+		return nil
+	}
+	return scrubbed
+}
+
+// maxScrubDepth limits the nesting of the values visited by scrubValue,
+// guarding against cyclic data. Deeper values are replaced by zero values.
+const maxScrubDepth = 64
+
+// scrubValue returns a deep copy of v in which all the strings, including
+// the ones of named string types, held by exported struct fields, pointers,
+// interfaces, slices, arrays and maps are scrubbed. Byte slices and
+// unexported struct fields are copied unchanged.
+func scrubValue(v any, scrub DataScrubber) any {
+	if v == nil {
+		return nil
+	}
+	return scrubReflectValue(reflect.ValueOf(v), scrub, 0).Interface()
+}
+
+func scrubReflectValue(v reflect.Value, scrub DataScrubber, depth int) reflect.Value {
+	if depth > maxScrubDepth {
+		return reflect.Zero(v.Type())
+	}
+	depth++
+
+	switch v.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(scrub(v.String())).Convert(v.Type())
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type().Elem())
+		result.Elem().Set(scrubReflectValue(v.Elem(), scrub, depth))
+		return result
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.Set(scrubReflectValue(v.Elem(), scrub, depth))
+		return result
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			result.Index(i).Set(scrubReflectValue(v.Index(i), scrub, depth))
+		}
+		return result
+	case reflect.Array:
+		result := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			result.Index(i).Set(scrubReflectValue(v.Index(i), scrub, depth))
+		}
+		return result
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			result.SetMapIndex(iter.Key(), scrubReflectValue(iter.Value(), scrub, depth))
+		}
+		return result
+	case reflect.Struct:
+		result := reflect.New(v.Type()).Elem()
+		result.Set(v)
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				result.Field(i).Set(scrubReflectValue(v.Field(i), scrub, depth))
+			}
+		}
+		return result
+	default:
+		return v
+	}
+}
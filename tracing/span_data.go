@@ -133,6 +133,10 @@ type ResponseSpanData struct {
 	// This is not used by the OpenAI trace processors, but is useful for
 	// other tracing processor implementations.
 	Input any
+
+	// Optional usage metrics measured by the runner, such as the tokens per
+	// second. The token counts are reported by Response.
+	Usage map[string]any
 }
 
 func (ResponseSpanData) Type() string { return "response" }
@@ -142,10 +146,14 @@ func (sd ResponseSpanData) Export() map[string]any {
 	if sd.Response != nil {
 		responseID = sd.Response.ID
 	}
-	return map[string]any{
+	m := map[string]any{
 		"type":        sd.Type(),
 		"response_id": responseID,
 	}
+	if len(sd.Usage) > 0 {
+		m["usage"] = sd.Usage
+	}
+	return m
 }
 
 // HandoffSpanData represents a Handoff Span in the trace.
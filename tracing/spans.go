@@ -195,6 +195,9 @@ func (s *SpanImpl) Finish(ctx context.Context, resetCurrent bool) error {
 	}
 
 	s.endedAt = time.Now()
+	if scrub := DataScrubberFromContext(ctx); scrub != nil {
+		ScrubSpanData(s.spanData, scrub)
+	}
 	err := s.processor.OnSpanEnd(ctx, s)
 	if err != nil {
 		return err
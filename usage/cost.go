@@ -0,0 +1,127 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"maps"
+	"strings"
+)
+
+// ModelPricing holds the prices of a model, in dollars per 1M tokens.
+type ModelPricing struct {
+	// Price of 1M input tokens.
+	InputPerMillion float64
+
+	// Optional price of 1M cached input tokens.
+	// If zero, cached tokens are charged at the InputPerMillion price.
+	CachedInputPerMillion float64
+
+	// Price of 1M output tokens (reasoning tokens included).
+	OutputPerMillion float64
+}
+
+// Cost returns the approximate cost, in dollars, of the given model usage.
+func (p ModelPricing) Cost(m ModelUsage) float64 {
+	inputTokens := float64(m.InputTokens)
+	cost := 0.0
+
+	if p.CachedInputPerMillion != 0 {
+		cachedTokens := min(float64(max(m.InputTokensDetails.CachedTokens, 0)), inputTokens)
+		inputTokens -= cachedTokens
+		cost += cachedTokens * p.CachedInputPerMillion
+	}
+
+	cost += inputTokens * p.InputPerMillion
+	cost += float64(m.OutputTokens) * p.OutputPerMillion
+	return cost / 1_000_000
+}
+
+// CostTable maps model names to their prices.
+type CostTable map[string]ModelPricing
+
+// Pricing returns the prices of the named model. A model name with the
+// "openai/" prefix (see agents.MultiProvider) is also looked up without it.
+func (t CostTable) Pricing(modelName string) (ModelPricing, bool) {
+	if p, ok := t[modelName]; ok {
+		return p, true
+	}
+	if name, ok := strings.CutPrefix(modelName, "openai/"); ok {
+		p, ok := t[name]
+		return p, ok
+	}
+	return ModelPricing{}, false
+}
+
+// DefaultCostTable holds the standard prices of some common OpenAI models.
+//
+// Prices change over time and don't account for discounts (e.g. batch or
+// flex processing): the table is meant for rough estimates only. It can be
+// overridden, or extended, to match your own prices.
+var DefaultCostTable = CostTable{
+	"gpt-4o":       {InputPerMillion: 2.50, CachedInputPerMillion: 1.25, OutputPerMillion: 10.00},
+	"gpt-4o-mini":  {InputPerMillion: 0.15, CachedInputPerMillion: 0.075, OutputPerMillion: 0.60},
+	"gpt-4.1":      {InputPerMillion: 2.00, CachedInputPerMillion: 0.50, OutputPerMillion: 8.00},
+	"gpt-4.1-mini": {InputPerMillion: 0.40, CachedInputPerMillion: 0.10, OutputPerMillion: 1.60},
+	"gpt-4.1-nano": {InputPerMillion: 0.10, CachedInputPerMillion: 0.025, OutputPerMillion: 0.40},
+	"gpt-5":        {InputPerMillion: 1.25, CachedInputPerMillion: 0.125, OutputPerMillion: 10.00},
+	"gpt-5-mini":   {InputPerMillion: 0.25, CachedInputPerMillion: 0.025, OutputPerMillion: 2.00},
+	"gpt-5-nano":   {InputPerMillion: 0.05, CachedInputPerMillion: 0.005, OutputPerMillion: 0.40},
+	"o3":           {InputPerMillion: 2.00, CachedInputPerMillion: 0.50, OutputPerMillion: 8.00},
+	"o4-mini":      {InputPerMillion: 1.10, CachedInputPerMillion: 0.275, OutputPerMillion: 4.40},
+}
+
+// EstimateCost returns the approximate cost, in dollars, of the usage,
+// according to its per-model breakdown (see PerModel).
+//
+// It reports false if the usage has no per-model breakdown, or if any of
+// its models is missing from the table.
+func (u *Usage) EstimateCost(table CostTable) (float64, bool) {
+	return u.EstimateCostWithDefault(table, "")
+}
+
+// EstimateCostWithDefault is like EstimateCost, but if the usage has no
+// per-model breakdown, its aggregate values are priced as if they were all
+// consumed by defaultModel.
+func (u *Usage) EstimateCostWithDefault(table CostTable, defaultModel string) (float64, bool) {
+	if u == nil {
+		return 0, false
+	}
+
+	mu := u.perModelLock()
+	mu.Lock()
+	perModel := maps.Clone(u.PerModel)
+	mu.Unlock()
+
+	if len(perModel) == 0 {
+		if defaultModel == "" {
+			return 0, false
+		}
+		pricing, ok := table.Pricing(defaultModel)
+		if !ok {
+			return 0, false
+		}
+		return pricing.Cost(u.aggregateModelUsage()), true
+	}
+
+	var total float64
+	for name, modelUsage := range perModel {
+		pricing, ok := table.Pricing(name)
+		if !ok {
+			return 0, false
+		}
+		total += pricing.Cost(modelUsage)
+	}
+	return total, true
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The NLP Odyssey Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"testing"
+
+	"github.com/openai/openai-go/v3/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCostTable = CostTable{
+	"model-a": {InputPerMillion: 2, OutputPerMillion: 8},
+	"model-b": {InputPerMillion: 1, CachedInputPerMillion: 0.5, OutputPerMillion: 4},
+}
+
+func TestModelPricing_Cost(t *testing.T) {
+	pricing := ModelPricing{InputPerMillion: 1, CachedInputPerMillion: 0.5, OutputPerMillion: 4}
+	cost := pricing.Cost(ModelUsage{
+		InputTokens: 1_000_000,
+		InputTokensDetails: responses.ResponseUsageInputTokensDetails{
+			CachedTokens: 400_000,
+		},
+		OutputTokens: 250_000,
+	})
+	// 600k * $1/M + 400k * $0.5/M + 250k * $4/M
+	assert.InDelta(t, 0.6+0.2+1.0, cost, 1e-9)
+
+	// Without a cached price, cached tokens are charged as regular input.
+	pricing.CachedInputPerMillion = 0
+	cost = pricing.Cost(ModelUsage{
+		InputTokens: 1_000_000,
+		InputTokensDetails: responses.ResponseUsageInputTokensDetails{
+			CachedTokens: 400_000,
+		},
+	})
+	assert.InDelta(t, 1.0, cost, 1e-9)
+}
+
+func TestUsage_EstimateCost(t *testing.T) {
+	u := NewUsage()
+	u.AddForModel("model-a", &Usage{Requests: 1, InputTokens: 500_000, OutputTokens: 100_000})
+	u.AddForModel("openai/model-b", &Usage{Requests: 1, InputTokens: 2_000_000, OutputTokens: 1_000_000})
+
+	cost, ok := u.EstimateCost(testCostTable)
+	assert.True(t, ok)
+	// model-a: 0.5 * 2 + 0.1 * 8 = 1.8
+	// model-b: 2 * 1 + 1 * 4 = 6
+	assert.InDelta(t, 7.8, cost, 1e-9)
+}
+
+func TestUsage_EstimateCostUnknownModel(t *testing.T) {
+	u := NewUsage()
+	u.AddForModel("model-a", &Usage{Requests: 1, InputTokens: 100})
+	u.AddForModel("unknown", &Usage{Requests: 1, InputTokens: 100})
+
+	_, ok := u.EstimateCost(testCostTable)
+	assert.False(t, ok)
+
+	_, ok = u.EstimateCostWithDefault(testCostTable, "model-a")
+	assert.False(t, ok, "the default model must not be used when there is a breakdown")
+}
+
+func TestUsage_EstimateCostWithDefault(t *testing.T) {
+	u := &Usage{Requests: 2, InputTokens: 3_000_000, OutputTokens: 500_000}
+
+	_, ok := u.EstimateCost(testCostTable)
+	assert.False(t, ok, "no per-model breakdown")
+
+	cost, ok := u.EstimateCostWithDefault(testCostTable, "model-a")
+	assert.True(t, ok)
+	assert.InDelta(t, 3*2+0.5*8, cost, 1e-9)
+
+	_, ok = u.EstimateCostWithDefault(testCostTable, "unknown")
+	assert.False(t, ok)
+}
+
+func TestDefaultCostTable(t *testing.T) {
+	u := NewUsage()
+	u.AddForModel("gpt-4o", &Usage{Requests: 1, InputTokens: 1_000_000, OutputTokens: 1_000_000})
+
+	cost, ok := u.EstimateCost(DefaultCostTable)
+	assert.True(t, ok)
+	assert.InDelta(t, 12.5, cost, 1e-9)
+}
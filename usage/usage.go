@@ -16,6 +16,9 @@ package usage
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"sync"
 	"sync/atomic"
 
 	"github.com/openai/openai-go/v3/responses"
@@ -39,6 +42,57 @@ type Usage struct {
 
 	// Total tokens sent and received, across all requests.
 	TotalTokens uint64
+
+	// Breakdown of the usage by model name. It is only populated by usage
+	// updates which know the model (see AddForModel), so the aggregate
+	// fields above can account for more than the sum of its entries.
+	// Access it concurrently only through Add, AddForModel and ModelNames.
+	PerModel map[string]ModelUsage
+
+	// The *sync.Mutex guarding PerModel, created on first use. It is held
+	// in an atomic.Value to keep Usage a plain value type: copies of a Usage
+	// share the lock, as they share the PerModel map.
+	perModelMu atomic.Value
+}
+
+// perModelLock returns the lock guarding u.PerModel.
+func (u *Usage) perModelLock() *sync.Mutex {
+	if mu, ok := u.perModelMu.Load().(*sync.Mutex); ok {
+		return mu
+	}
+	u.perModelMu.CompareAndSwap(nil, new(sync.Mutex))
+	return u.perModelMu.Load().(*sync.Mutex)
+}
+
+// ModelUsage is the usage consumed by a single model.
+type ModelUsage struct {
+	// Requests made to the LLM API with this model.
+	Requests uint64
+
+	// Input tokens sent to this model.
+	InputTokens uint64
+
+	// Details about the input tokens, matching responses API usage details.
+	InputTokensDetails responses.ResponseUsageInputTokensDetails
+
+	// Output tokens received from this model.
+	OutputTokens uint64
+
+	// Details about the output tokens, matching responses API usage details.
+	OutputTokensDetails responses.ResponseUsageOutputTokensDetails
+
+	// Tokens sent to and received from this model.
+	TotalTokens uint64
+}
+
+func (m ModelUsage) add(other ModelUsage) ModelUsage {
+	m.Requests += other.Requests
+	m.InputTokens += other.InputTokens
+	m.InputTokensDetails.CachedTokens += other.InputTokensDetails.CachedTokens
+	m.OutputTokens += other.OutputTokens
+	m.OutputTokensDetails.ReasoningTokens += other.OutputTokensDetails.ReasoningTokens
+	m.TotalTokens += other.TotalTokens
+	return m
 }
 
 func NewUsage() *Usage {
@@ -56,6 +110,85 @@ func (u *Usage) Add(other *Usage) {
 	atomic.AddUint64(&u.TotalTokens, other.TotalTokens)
 	atomic.AddInt64(&u.InputTokensDetails.CachedTokens, other.InputTokensDetails.CachedTokens)
 	atomic.AddInt64(&u.OutputTokensDetails.ReasoningTokens, other.OutputTokensDetails.ReasoningTokens)
+
+	u.mergePerModel(other)
+}
+
+// AddForModel adds other to u, like Add, and also accounts it to the
+// PerModel entry of the given model name, unless other has its own
+// per-model breakdown, which is merged instead.
+func (u *Usage) AddForModel(modelName string, other *Usage) {
+	if u == nil || other == nil {
+		return
+	}
+
+	atomic.AddUint64(&u.Requests, other.Requests)
+	atomic.AddUint64(&u.InputTokens, other.InputTokens)
+	atomic.AddUint64(&u.OutputTokens, other.OutputTokens)
+	atomic.AddUint64(&u.TotalTokens, other.TotalTokens)
+	atomic.AddInt64(&u.InputTokensDetails.CachedTokens, other.InputTokensDetails.CachedTokens)
+	atomic.AddInt64(&u.OutputTokensDetails.ReasoningTokens, other.OutputTokensDetails.ReasoningTokens)
+
+	if u.mergePerModel(other) {
+		return
+	}
+
+	modelUsage := other.aggregateModelUsage()
+
+	mu := u.perModelLock()
+	mu.Lock()
+	defer mu.Unlock()
+	u.addModelUsageLocked(modelName, modelUsage)
+}
+
+// mergePerModel adds the PerModel entries of other to u, reporting whether
+// there were any.
+func (u *Usage) mergePerModel(other *Usage) bool {
+	// Clone first, not to hold both locks, which may be the same one
+	otherMu := other.perModelLock()
+	otherMu.Lock()
+	perModel := maps.Clone(other.PerModel)
+	otherMu.Unlock()
+	if len(perModel) == 0 {
+		return false
+	}
+
+	mu := u.perModelLock()
+	mu.Lock()
+	defer mu.Unlock()
+	for name, modelUsage := range perModel {
+		u.addModelUsageLocked(name, modelUsage)
+	}
+	return true
+}
+
+func (u *Usage) aggregateModelUsage() ModelUsage {
+	return ModelUsage{
+		Requests:            u.Requests,
+		InputTokens:         u.InputTokens,
+		InputTokensDetails:  u.InputTokensDetails,
+		OutputTokens:        u.OutputTokens,
+		OutputTokensDetails: u.OutputTokensDetails,
+		TotalTokens:         u.TotalTokens,
+	}
+}
+
+func (u *Usage) addModelUsageLocked(modelName string, modelUsage ModelUsage) {
+	if u.PerModel == nil {
+		u.PerModel = make(map[string]ModelUsage)
+	}
+	u.PerModel[modelName] = u.PerModel[modelName].add(modelUsage)
+}
+
+// ModelNames returns the sorted names of the models in the PerModel breakdown.
+func (u *Usage) ModelNames() []string {
+	if u == nil {
+		return nil
+	}
+	mu := u.perModelLock()
+	mu.Lock()
+	defer mu.Unlock()
+	return slices.Sorted(maps.Keys(u.PerModel))
 }
 
 // usageContextKey is the key type for Usage values in Contexts.
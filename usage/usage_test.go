@@ -108,3 +108,117 @@ func TestUsage_AddConcurrent(t *testing.T) {
 
 	assert.Equal(t, expected, u)
 }
+
+func TestUsage_AddForModel(t *testing.T) {
+	u := NewUsage()
+	u.AddForModel("model-a", &Usage{Requests: 1, InputTokens: 2, OutputTokens: 3, TotalTokens: 5})
+	u.AddForModel("model-b", &Usage{Requests: 1, InputTokens: 10, OutputTokens: 20, TotalTokens: 30})
+	u.AddForModel("model-a", &Usage{
+		Requests:    1,
+		InputTokens: 4,
+		InputTokensDetails: responses.ResponseUsageInputTokensDetails{
+			CachedTokens: 1,
+		},
+		OutputTokens: 6,
+		OutputTokensDetails: responses.ResponseUsageOutputTokensDetails{
+			ReasoningTokens: 2,
+		},
+		TotalTokens: 10,
+	})
+
+	assert.Equal(t, uint64(3), u.Requests)
+	assert.Equal(t, uint64(16), u.InputTokens)
+	assert.Equal(t, uint64(29), u.OutputTokens)
+	assert.Equal(t, uint64(45), u.TotalTokens)
+
+	assert.Equal(t, []string{"model-a", "model-b"}, u.ModelNames())
+	assert.Equal(t, map[string]ModelUsage{
+		"model-a": {
+			Requests:    2,
+			InputTokens: 6,
+			InputTokensDetails: responses.ResponseUsageInputTokensDetails{
+				CachedTokens: 1,
+			},
+			OutputTokens: 9,
+			OutputTokensDetails: responses.ResponseUsageOutputTokensDetails{
+				ReasoningTokens: 2,
+			},
+			TotalTokens: 15,
+		},
+		"model-b": {Requests: 1, InputTokens: 10, OutputTokens: 20, TotalTokens: 30},
+	}, u.PerModel)
+}
+
+func TestUsage_AddMergesPerModel(t *testing.T) {
+	u := NewUsage()
+	u.AddForModel("model-a", &Usage{Requests: 1, TotalTokens: 5})
+
+	other := NewUsage()
+	other.AddForModel("model-a", &Usage{Requests: 1, TotalTokens: 7})
+	other.AddForModel("model-b", &Usage{Requests: 1, TotalTokens: 11})
+
+	u.Add(other)
+
+	assert.Equal(t, uint64(3), u.Requests)
+	assert.Equal(t, uint64(23), u.TotalTokens)
+	assert.Equal(t, map[string]ModelUsage{
+		"model-a": {Requests: 2, TotalTokens: 12},
+		"model-b": {Requests: 1, TotalTokens: 11},
+	}, u.PerModel)
+
+	// other must be left untouched
+	assert.Equal(t, map[string]ModelUsage{
+		"model-a": {Requests: 1, TotalTokens: 7},
+		"model-b": {Requests: 1, TotalTokens: 11},
+	}, other.PerModel)
+}
+
+func TestUsage_AddForModelMergesPerModelOnce(t *testing.T) {
+	other := NewUsage()
+	other.AddForModel("model-b", &Usage{Requests: 1, TotalTokens: 7})
+
+	u := NewUsage()
+	u.AddForModel("model-a", other)
+
+	assert.Equal(t, uint64(1), u.Requests)
+	assert.Equal(t, uint64(7), u.TotalTokens)
+	assert.Equal(t, map[string]ModelUsage{
+		"model-b": {Requests: 1, TotalTokens: 7},
+	}, u.PerModel)
+}
+
+func TestUsage_AddForModelConcurrent(t *testing.T) {
+	const goroutines = 128
+
+	u := NewUsage()
+	other := NewUsage()
+	other.AddForModel("model-b", &Usage{Requests: 1, TotalTokens: 2})
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := range goroutines {
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				u.AddForModel("model-a", &Usage{Requests: 1, TotalTokens: 3})
+			} else {
+				u.Add(other)
+			}
+			_ = u.ModelNames()
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, uint64(goroutines), u.Requests)
+	assert.Equal(t, map[string]ModelUsage{
+		"model-a": {Requests: goroutines / 2, TotalTokens: 3 * goroutines / 2},
+		"model-b": {Requests: goroutines / 2, TotalTokens: 2 * goroutines / 2},
+	}, u.PerModel)
+}
+
+func TestUsage_ModelNamesEmpty(t *testing.T) {
+	assert.Empty(t, NewUsage().ModelNames())
+	assert.Nil(t, (*Usage)(nil).ModelNames())
+}